@@ -0,0 +1,180 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hinkers/Phorge/internal/config"
+)
+
+// subcommands lists the CLI subcommands offered as the first argument.
+var subcommands = config.Subcommands
+
+// launchFlags lists the flags accepted when launching the TUI.
+var launchFlags = []string{"--ssh", "--sftp", "--db", "--version", "--high-contrast", "--no-color", "--ascii"}
+
+// completionScripts holds the shell glue for `phorge completion <shell>`.
+// Each script calls back into `phorge __complete` with the words typed so
+// far, the last being the (possibly empty) word under the cursor.
+var completionScripts = map[string]string{
+	"bash": `# bash completion for phorge
+# Add to ~/.bashrc:  source <(phorge completion bash)
+_phorge() {
+    local IFS=$'\n'
+    COMPREPLY=($(phorge __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _phorge phorge
+`,
+	"zsh": `# zsh completion for phorge
+# Add to ~/.zshrc (after compinit):  source <(phorge completion zsh)
+_phorge() {
+    local -a candidates
+    candidates=(${(f)"$(phorge __complete "${(@)words[2,CURRENT]}" 2>/dev/null)"})
+    compadd -a candidates
+}
+compdef _phorge phorge
+`,
+	"fish": `# fish completion for phorge
+# Save to ~/.config/fish/completions/phorge.fish:
+#   phorge completion fish > ~/.config/fish/completions/phorge.fish
+function __phorge_complete
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    phorge __complete $tokens[2..-1] "$current" 2>/dev/null
+end
+complete -c phorge -f -a '(__phorge_complete)'
+`,
+}
+
+// runCompletion implements `phorge completion bash|zsh|fish`: it prints the
+// completion script for the given shell.
+func runCompletion(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: phorge completion bash|zsh|fish")
+	}
+	script, ok := completionScripts[args[0]]
+	if !ok {
+		return fmt.Errorf("unsupported shell %q (want bash, zsh or fish)", args[0])
+	}
+	fmt.Print(script)
+	return nil
+}
+
+// runComplete implements the hidden `phorge __complete <words...>` command
+// the completion scripts call. It prints one candidate per line. Server
+// and site names come from the name cache, so no API request is made.
+func runComplete(args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		return
+	}
+	for _, c := range completeArgs(cfg, config.LoadNameCache(), config.LoadProjectConfig(), args) {
+		fmt.Println(c)
+	}
+}
+
+// completeArgs returns the candidates for the last of args, the words
+// typed after `phorge`.
+func completeArgs(cfg *config.Config, names *config.NameCache, project config.ProjectConfig, args []string) []string {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	current := args[len(args)-1]
+	previous := ""
+	if len(args) > 1 {
+		previous = args[len(args)-2]
+	}
+
+	// targets lists everything that names a site: nicknames and site names.
+	targets := func() []string {
+		var out []string
+		for nick := range cfg.Nicknames {
+			out = append(out, nick)
+		}
+		slices.Sort(out)
+		return append(out, names.SiteNames()...)
+	}
+
+	var candidates []string
+	switch {
+	case len(args) == 1:
+		candidates = append(slices.Clone(subcommands), targets()...)
+		if strings.HasPrefix(current, "-") {
+			candidates = launchFlags
+		}
+	case args[0] == "deploy":
+		switch {
+		case previous == "--env":
+			candidates = project.EnvironmentNames()
+		case strings.HasPrefix(current, "-"):
+			candidates = []string{"--env", "--wait", "--timeout"}
+		default:
+			candidates = targets()
+		}
+	case args[0] == "servers" || args[0] == "sites":
+		switch {
+		case previous == "--format":
+			candidates = []string{"table", "json"}
+		case strings.HasPrefix(current, "-"):
+			candidates = []string{"--format"}
+		case args[0] == "sites":
+			candidates = names.ServerNames()
+		}
+	case args[0] == "regions":
+		switch {
+		case previous == "--format":
+			candidates = []string{"table", "json"}
+		case strings.HasPrefix(current, "-"):
+			candidates = []string{"--refresh", "--format"}
+		case len(args) == 2:
+			candidates = config.LoadCatalogue().Providers()
+		case len(args) == 3:
+			for _, r := range config.LoadCatalogue().Regions[args[1]] {
+				candidates = append(candidates, r.ID)
+			}
+		}
+	case args[0] == "export":
+		switch {
+		case previous == "--format":
+			candidates = []string{"json", "prometheus"}
+		case previous == "--interval":
+			return nil
+		case strings.HasPrefix(current, "-"):
+			candidates = []string{"--format", "--interval"}
+		default:
+			return nil // let the shell complete file names
+		}
+	case args[0] == "open":
+		if !strings.HasPrefix(current, "-") {
+			return nil // let the shell complete directory names
+		}
+		candidates = []string{"--attach"}
+	case args[0] == "ssh-config":
+		if previous == "--output" {
+			return nil // let the shell complete file names
+		}
+		candidates = []string{"--print", "--output"}
+	case args[0] == "completion":
+		if len(args) == 2 {
+			candidates = []string{"bash", "zsh", "fish"}
+		}
+	case args[0] == "state":
+		if len(args) == 2 {
+			candidates = []string{"reset"}
+		}
+	case args[0] == "update":
+		return nil
+	default:
+		// phorge <target> [flag]
+		candidates = launchFlags
+	}
+
+	var out []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, current) && !slices.Contains(out, c) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
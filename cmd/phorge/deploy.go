@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/health"
+)
+
+// deployPollInterval is how often `phorge deploy --wait` checks on the
+// deployment.
+const deployPollInterval = 3 * time.Second
+
+// runDeploy implements `phorge deploy [site|nickname] [--env name] [--wait]
+// [--override reason]`: it triggers a deployment without starting the TUI
+// and, with --wait, streams its output, probes the site's health check if
+// one is configured, and fails if either fails. A failed health check rolls
+// the site back when auto_rollback is enabled for it. Outside the
+// maintenance windows of the site's environment it refuses to deploy
+// unless --override gives a reason, which is written to the audit log.
+func runDeploy(args []string) error {
+	fs := flag.NewFlagSet("deploy", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	envName := fs.String("env", "", "deploy the named environment from .phorge")
+	wait := fs.Bool("wait", false, "wait for the deployment to finish, streaming its output")
+	timeout := fs.Duration("timeout", 15*time.Minute, "how long --wait waits before giving up")
+	override := fs.String("override", "", "deploy outside the environment's maintenance windows, giving the reason for the audit log")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: phorge deploy [site|nickname] [--env name] [--wait] [--timeout 15m] [--override reason]")
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(positional) > 1 {
+		fs.Usage()
+		return fmt.Errorf("too many arguments")
+	}
+	var arg string
+	if len(positional) == 1 {
+		arg = positional[0]
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.ResolveAPIKey(); err != nil {
+		return err
+	}
+	if cfg.Forge.APIKey == "" {
+		return fmt.Errorf("no API key configured; run phorge once to set one up")
+	}
+
+	project := config.LoadProjectConfig()
+	serverName, siteName, err := resolveTarget(cfg, project, *envName, arg)
+	if err != nil {
+		return err
+	}
+
+	// Leave room for the deployment and, if its health check fails, a
+	// rollback deployment.
+	limit := time.Minute
+	if *wait {
+		limit += 2 * *timeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), limit)
+	defer cancel()
+
+	client := forge.NewClient(cfg.Forge.APIKey).WithFallbackToken(cfg.Forge.FallbackAPIKey).WithSchemaLog(cfg.Forge.SchemaLog)
+	srv, site, err := findSite(ctx, client, serverName, siteName)
+	if err != nil {
+		return err
+	}
+	if err := checkMaintenanceWindow(project, srv, site, strings.TrimSpace(*override)); err != nil {
+		return err
+	}
+
+	// Note the newest deployment so the one triggered below can be told
+	// apart from it.
+	var prevID int64
+	if *wait {
+		deployments, err := client.Deployments.List(ctx, srv.ID, site.ID)
+		if err != nil {
+			return fmt.Errorf("listing deployments: %w", err)
+		}
+		for _, d := range deployments {
+			prevID = max(prevID, d.ID)
+		}
+	}
+
+	if err := client.Deployments.Deploy(ctx, srv.ID, site.ID); err != nil {
+		return fmt.Errorf("deploying %s: %w", site.Name, err)
+	}
+	fmt.Printf("Deployment started for %s on %s\n", site.Name, srv.Name)
+	if !*wait {
+		return nil
+	}
+	deployID, err := waitForDeploy(ctx, client, srv, site, prevID, *timeout)
+	if err != nil {
+		return err
+	}
+
+	url := cfg.HealthCheckURL(site.Name)
+	if url == "" && project.IsProjectSite(site.Name) {
+		url = project.HealthCheck
+	}
+	if url == "" {
+		return nil
+	}
+	result := health.Probe(ctx, url)
+	fmt.Printf("Health check %s: %s\n", url, result)
+	if result.OK() {
+		return nil
+	}
+	if !cfg.AutoRollbackEnabled(site.Name) && !(project.AutoRollback && project.IsProjectSite(site.Name)) {
+		return fmt.Errorf("health check for %s failed", site.Name)
+	}
+
+	target, err := client.Deployments.Rollback(ctx, srv.ID, site.ID, site.DeploymentURL, deployID)
+	if err != nil {
+		return fmt.Errorf("health check for %s failed and rolling back failed: %w", site.Name, err)
+	}
+	fmt.Printf("Rolling %s back to %s from deployment #%d\n", site.Name, target.CommitHash, target.ID)
+	if _, err := waitForDeploy(ctx, client, srv, site, deployID, *timeout); err != nil {
+		return fmt.Errorf("health check for %s failed and the rollback did not finish: %w", site.Name, err)
+	}
+	return fmt.Errorf("health check for %s failed; rolled back to %s", site.Name, target.CommitHash)
+}
+
+// checkMaintenanceWindow refuses a deploy outside the maintenance windows
+// of the site's .phorge environment unless an override reason is given, in
+// which case the override is recorded in the audit log first.
+func checkMaintenanceWindow(project config.ProjectConfig, srv *forge.Server, site *forge.Site, reason string) error {
+	check, err := project.CheckMaintenanceWindow(srv.Name, site.Name, time.Now())
+	if err != nil {
+		return err
+	}
+	if !check.Outside {
+		return nil
+	}
+	if reason == "" {
+		return fmt.Errorf("%s is outside the %s maintenance window (%s); pass --override \"reason\" to deploy anyway",
+			site.Name, check.Environment, check.Describe())
+	}
+	err = config.AppendAudit(config.AuditEntry{
+		Action:      "deploy outside maintenance window",
+		Server:      srv.Name,
+		Site:        site.Name,
+		Environment: check.Environment,
+		Reason:      reason,
+		Via:         "cli",
+	})
+	if err != nil {
+		return fmt.Errorf("recording the override: %w", err)
+	}
+	fmt.Printf("Deploying %s outside the %s maintenance window: %s\n", site.Name, check.Environment, reason)
+	return nil
+}
+
+// waitForDeploy polls the first deployment newer than prevID until it
+// finishes, copying its log to stdout as it grows, and returns its ID. It
+// returns an error if the deployment fails or is still running after
+// timeout.
+func waitForDeploy(ctx context.Context, client *forge.Client, srv *forge.Server, site *forge.Site, prevID int64, timeout time.Duration) (int64, error) {
+	deadline := time.Now().Add(timeout)
+	var printed string
+	for {
+		status, id, err := newDeploymentStatus(ctx, client, srv.ID, site.ID, prevID)
+		if err != nil {
+			return 0, err
+		}
+
+		finished := id != 0 && !forge.DeploymentRunning(status)
+		// The live log is the site's latest, so it is only read once this
+		// deployment has left the queue.
+		var log string
+		fetched := true
+		switch {
+		case finished:
+			log, err = client.Deployments.GetOutput(ctx, srv.ID, site.ID, id)
+		case status == "deploying":
+			log, err = client.Deployments.GetLogSince(ctx, srv.ID, site.ID, printed)
+		default:
+			fetched = false
+		}
+		if err != nil {
+			return 0, fmt.Errorf("fetching deployment output: %w", err)
+		}
+		if fetched {
+			printed = printNewOutput(os.Stdout, printed, log)
+		}
+
+		if finished {
+			if printed != "" && !strings.HasSuffix(printed, "\n") {
+				fmt.Println()
+			}
+			if status != "finished" {
+				return id, fmt.Errorf("deployment of %s %s", site.Name, status)
+			}
+			fmt.Printf("Deployment of %s finished\n", site.Name)
+			return id, nil
+		}
+
+		if time.Now().After(deadline) {
+			return 0, fmt.Errorf("timed out after %s waiting for %s to deploy", timeout, site.Name)
+		}
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("waiting for %s to deploy: %w", site.Name, ctx.Err())
+		case <-time.After(deployPollInterval):
+		}
+	}
+}
+
+// printNewOutput prints the part of log that follows printed, the output
+// of the deployment printed so far, and returns log. The live log and the
+// archived output normally share a prefix; when they don't, log is printed
+// in full after a notice that it replaces what was printed.
+func printNewOutput(w io.Writer, printed, log string) string {
+	if strings.HasPrefix(log, printed) {
+		fmt.Fprint(w, log[len(printed):])
+		return log
+	}
+	if !strings.HasSuffix(printed, "\n") {
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w, "--- the deployment output was replaced; printing it in full ---")
+	fmt.Fprint(w, log)
+	return log
+}
+
+// newDeploymentStatus returns the ID and status of the oldest deployment
+// newer than prevID, or a zero ID when it has not appeared yet.
+func newDeploymentStatus(ctx context.Context, client *forge.Client, serverID, siteID, prevID int64) (status string, id int64, err error) {
+	deployments, err := client.Deployments.List(ctx, serverID, siteID)
+	if err != nil {
+		return "", 0, fmt.Errorf("checking deployment status: %w", err)
+	}
+	for _, d := range deployments {
+		if d.ID > prevID && (id == 0 || d.ID < id) {
+			status, id = d.Status, d.ID
+		}
+	}
+	return status, id, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPrintNewOutput(t *testing.T) {
+	tests := []struct {
+		name         string
+		printed, log string
+		want         string
+		wantReplaced bool
+	}{
+		{"first output", "", "Cloning\n", "Cloning\n", false},
+		{"log grew", "Cloning\n", "Cloning\nInstalling\n", "Installing\n", false},
+		{"nothing new", "Cloning\n", "Cloning\n", "", false},
+		{"archived output differs", "Cloning\nInstall", "$ git pull\nCloning\nInstalling\n", "$ git pull\nCloning\nInstalling\n", true},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if got := printNewOutput(&b, tt.printed, tt.log); got != tt.log {
+			t.Errorf("%s: returned %q, want the log %q", tt.name, got, tt.log)
+		}
+		out := b.String()
+		if replaced := strings.Contains(out, "replaced"); replaced != tt.wantReplaced {
+			t.Errorf("%s: output %q, want a replacement notice %v", tt.name, out, tt.wantReplaced)
+		}
+		if !strings.HasSuffix(out, tt.want) {
+			t.Errorf("%s: output %q, want it to end with %q", tt.name, out, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/monitor"
+)
+
+// runExport implements `phorge export [--format f] [--interval d] [path]`:
+// it collects deployment results, certificate expiries and daemon and
+// worker statuses across the account, evaluates the configured alert
+// rules against them and writes the lot as JSON or a Prometheus textfile.
+// With --interval it keeps running and rewrites the file on every tick.
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	format := fs.String("format", monitor.FormatJSON, "output format: json or prometheus")
+	interval := fs.Duration("interval", 0, "collect again on this interval, e.g. 5m, instead of once")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: phorge export [--format json|prometheus] [--interval d] [path]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return fmt.Errorf("unexpected argument %q", fs.Arg(1))
+	}
+	path := fs.Arg(0)
+	if *format != monitor.FormatJSON && *format != monitor.FormatPrometheus {
+		return fmt.Errorf("unknown format %q (want json or prometheus)", *format)
+	}
+	if *interval > 0 && (path == "" || path == "-") {
+		return fmt.Errorf("--interval needs a file to write")
+	}
+	if *interval > 0 && *interval < time.Minute {
+		*interval = time.Minute
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.ResolveAPIKey(); err != nil {
+		return err
+	}
+	if cfg.Forge.APIKey == "" {
+		return fmt.Errorf("no API key configured; run phorge once to set one up")
+	}
+	client := forge.NewClient(cfg.Forge.APIKey).WithFallbackToken(cfg.Forge.FallbackAPIKey).WithSchemaLog(cfg.Forge.SchemaLog)
+
+	var rules []monitor.Rule
+	for _, text := range cfg.Alerts.Rules {
+		r, err := monitor.Parse(text)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: alert rule %q: %v\n", text, err)
+			continue
+		}
+		rules = append(rules, r)
+	}
+
+	for {
+		err := exportOnce(client, cfg, rules, *format, path)
+		if *interval == 0 {
+			return err
+		}
+		// Keep going on failure: the next tick may well succeed, and a
+		// stale file is what a scraper should see in the meantime.
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", time.Now().Format(time.DateTime), err)
+		}
+		time.Sleep(*interval)
+	}
+}
+
+// exportOnce collects a snapshot of the servers not hidden in cfg and
+// writes it to path, or to stdout when path is "" or "-".
+func exportOnce(client *forge.Client, cfg *config.Config, rules []monitor.Rule, format, path string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	servers, err := client.Servers.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing servers: %w", err)
+	}
+	var targets []forge.Server
+	for _, srv := range servers {
+		if !cfg.ServerHidden(srv.Name, srv.ID, srv.TagNames()) {
+			targets = append(targets, srv)
+		}
+	}
+
+	snap := monitor.Collect(ctx, client, targets, monitor.All)
+	alerts := monitor.Evaluate(rules, snap, snap.Time)
+	for _, err := range snap.Errors {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	if path == "" || path == "-" {
+		return monitor.Write(os.Stdout, format, snap, alerts)
+	}
+	if err := monitor.WriteFile(path, format, snap, alerts); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
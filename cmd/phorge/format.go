@@ -0,0 +1,70 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// formatUsage describes the --format flag shared by the list commands.
+const formatUsage = "output format: table, json, or a Go template such as '{{.Name}}'"
+
+// column is one column of a list command's table output.
+type column[T any] struct {
+	header string
+	value  func(T) string
+}
+
+// printList writes items to w in the given format: "table" (the default)
+// renders cols as aligned columns, "json" writes the items as a JSON array,
+// and anything else is parsed as a text/template executed once per item,
+// like `docker ps --format`. Templates can use {{json .}} to embed a value
+// as JSON.
+func printList[T any](w io.Writer, format string, items []T, cols []column[T]) error {
+	switch format {
+	case "", "table":
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		headers := make([]string, len(cols))
+		for i, c := range cols {
+			headers[i] = c.header
+		}
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+		for _, item := range items {
+			values := make([]string, len(cols))
+			for i, c := range cols {
+				values[i] = c.value(item)
+			}
+			fmt.Fprintln(tw, strings.Join(values, "\t"))
+		}
+		return tw.Flush()
+
+	case "json":
+		if items == nil {
+			items = []T{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+
+	default:
+		tmpl, err := template.New("format").Funcs(template.FuncMap{
+			"json": func(v any) (string, error) {
+				data, err := json.Marshal(v)
+				return string(data), err
+			},
+		}).Parse(format)
+		if err != nil {
+			return fmt.Errorf("parsing --format template: %w", err)
+		}
+		for _, item := range items {
+			if err := tmpl.Execute(w, item); err != nil {
+				return fmt.Errorf("executing --format template: %w", err)
+			}
+			fmt.Fprintln(w)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+var (
+	testServers = []forge.Server{
+		{ID: 1, Name: "web-1", IPAddress: "10.0.0.1", Region: "syd1", PHPVersion: "php84", IsReady: true},
+		{ID: 22, Name: "db", IPAddress: "10.0.0.2", Region: "syd1"},
+	}
+	testSites = []siteRow{
+		{Site: forge.Site{ID: 7, Name: "example.com", RepositoryBranch: "main", QuickDeploy: true}, Server: "web-1"},
+		{Site: forge.Site{ID: 8, Name: "api.example.com", Aliases: []string{"www.api.example.com"}}, Server: "web-1"},
+	}
+)
+
+func TestPrintListTable(t *testing.T) {
+	var b strings.Builder
+	if err := printList(&b, "table", testServers, serverColumns); err != nil {
+		t.Fatal(err)
+	}
+	want := "" +
+		"ID  NAME   IP        REGION  PHP    READY\n" +
+		"1   web-1  10.0.0.1  syd1    php84  true\n" +
+		"22  db     10.0.0.2  syd1           false\n"
+	if b.String() != want {
+		t.Errorf("servers table =\n%s\nwant\n%s", b.String(), want)
+	}
+
+	b.Reset()
+	if err := printList(&b, "", testSites, siteColumns); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "ID  SERVER  NAME") || !strings.Contains(lines[1], "example.com") || !strings.HasSuffix(lines[1], "true") {
+		t.Errorf("sites table (the default format) =\n%s", b.String())
+	}
+}
+
+func TestPrintListJSON(t *testing.T) {
+	var b strings.Builder
+	if err := printList(&b, "json", testSites, siteColumns); err != nil {
+		t.Fatal(err)
+	}
+	var got []map[string]any
+	if err := json.Unmarshal([]byte(b.String()), &got); err != nil {
+		t.Fatalf("output isn't a JSON array: %v\n%s", err, b.String())
+	}
+	if len(got) != 2 || got[0]["name"] != "example.com" || got[0]["server"] != "web-1" || got[1]["id"] != float64(8) {
+		t.Errorf("sites json = %v", got)
+	}
+
+	// An empty list is an empty array, not null.
+	b.Reset()
+	if err := printList(&b, "json", []forge.Server(nil), serverColumns); err != nil {
+		t.Fatal(err)
+	}
+	if strings.TrimSpace(b.String()) != "[]" {
+		t.Errorf("empty list = %q, want []", b.String())
+	}
+}
+
+func TestPrintListTemplate(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"{{.Name}}", "web-1\ndb\n"},
+		{"{{.ID}} {{.IPAddress}}", "1 10.0.0.1\n22 10.0.0.2\n"},
+		{`{{if .IsReady}}{{.Name}} is ready{{end}}`, "web-1 is ready\n\n"},
+		{"{{json .Region}}", "\"syd1\"\n\"syd1\"\n"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		if err := printList(&b, tt.format, testServers, serverColumns); err != nil {
+			t.Errorf("%s: %v", tt.format, err)
+			continue
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s: output = %q, want %q", tt.format, b.String(), tt.want)
+		}
+	}
+
+	var b strings.Builder
+	if err := printList(&b, "{{.Server}}/{{.Name}}", testSites, siteColumns); err != nil {
+		t.Fatal(err)
+	}
+	if want := "web-1/example.com\nweb-1/api.example.com\n"; b.String() != want {
+		t.Errorf("sites template output = %q, want %q", b.String(), want)
+	}
+}
+
+func TestPrintListBadTemplate(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"{{.Name", "parsing --format template"},
+		{"{{.NoSuchField}}", "executing --format template"},
+		{"{{index .Aliases 3}}", "executing --format template"},
+		{"{{nosuchfunc .}}", "parsing --format template"},
+	}
+	for _, tt := range tests {
+		var b strings.Builder
+		err := printList(&b, tt.format, testSites, siteColumns)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error = %v, want one containing %q", tt.format, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// siteRow is a site together with the name of its server, as listed by
+// `phorge sites`.
+type siteRow struct {
+	forge.Site
+	Server string `json:"server"`
+}
+
+// runServers implements `phorge servers [--format f]`: it lists every
+// server on the account.
+func runServers(args []string) error {
+	fs := flag.NewFlagSet("servers", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	format := fs.String("format", "table", formatUsage)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: phorge servers [--format table|json|template]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	client, err := cliClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	servers, err := client.Servers.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing servers: %w", err)
+	}
+
+	names := config.LoadNameCache()
+	serverNames := make([]string, len(servers))
+	for i, srv := range servers {
+		serverNames[i] = srv.Name
+	}
+	names.SetServers(serverNames)
+	_ = names.Save() // best effort; the cache only feeds completion
+
+	return printList(os.Stdout, *format, servers, serverColumns)
+}
+
+// serverColumns are the columns of `phorge servers` table output.
+var serverColumns = []column[forge.Server]{
+	{"ID", func(s forge.Server) string { return strconv.FormatInt(s.ID, 10) }},
+	{"NAME", func(s forge.Server) string { return s.Name }},
+	{"IP", func(s forge.Server) string { return s.IPAddress }},
+	{"REGION", func(s forge.Server) string { return s.Region }},
+	{"PHP", func(s forge.Server) string { return s.PHPVersion }},
+	{"READY", func(s forge.Server) string { return strconv.FormatBool(s.IsReady) }},
+}
+
+// runSites implements `phorge sites [server] [--format f]`: it lists the
+// sites on one server, or on every server when none is named.
+func runSites(args []string) error {
+	fs := flag.NewFlagSet("sites", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	format := fs.String("format", "table", formatUsage)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: phorge sites [server] [--format table|json|template]")
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(positional) > 1 {
+		fs.Usage()
+		return fmt.Errorf("too many arguments")
+	}
+	var serverName string
+	if len(positional) == 1 {
+		serverName = positional[0]
+	}
+
+	client, err := cliClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	servers, err := client.Servers.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing servers: %w", err)
+	}
+
+	names := config.LoadNameCache()
+	defer names.Save() // best effort; the cache only feeds completion
+
+	var rows []siteRow
+	found := false
+	for _, srv := range servers {
+		if serverName != "" && !strings.EqualFold(srv.Name, serverName) {
+			continue
+		}
+		found = true
+		sites, err := client.Sites.List(ctx, srv.ID)
+		if err != nil {
+			return fmt.Errorf("listing sites on %s: %w", srv.Name, err)
+		}
+		siteNames := make([]string, len(sites))
+		for i, site := range sites {
+			siteNames[i] = site.Name
+			rows = append(rows, siteRow{Site: site, Server: srv.Name})
+		}
+		names.SetSites(srv.Name, siteNames)
+	}
+	if serverName != "" && !found {
+		return fmt.Errorf("server %q not found", serverName)
+	}
+
+	return printList(os.Stdout, *format, rows, siteColumns)
+}
+
+// siteColumns are the columns of `phorge sites` table output.
+var siteColumns = []column[siteRow]{
+	{"ID", func(s siteRow) string { return strconv.FormatInt(s.ID, 10) }},
+	{"SERVER", func(s siteRow) string { return s.Server }},
+	{"NAME", func(s siteRow) string { return s.Name }},
+	{"BRANCH", func(s siteRow) string { return s.RepositoryBranch }},
+	{"PHP", func(s siteRow) string { return s.PHPVersion }},
+	{"QUICK DEPLOY", func(s siteRow) string { return strconv.FormatBool(s.QuickDeploy) }},
+}
+
+// cliClient loads the config and returns an API client for it, failing
+// when no API key has been set up yet.
+func cliClient() (*forge.Client, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.ResolveAPIKey(); err != nil {
+		return nil, err
+	}
+	if cfg.Forge.APIKey == "" {
+		return nil, fmt.Errorf("no API key configured; run phorge once to set one up")
+	}
+	return forge.NewClient(cfg.Forge.APIKey).WithFallbackToken(cfg.Forge.FallbackAPIKey).WithSchemaLog(cfg.Forge.SchemaLog), nil
+}
@@ -13,11 +13,96 @@ import (
 var version = "dev"
 
 func main() {
+	// Subcommands: phorge update | phorge deploy [target] [--env name] |
+	// phorge ssh-config [--print] [--output path] | phorge completion <shell> |
+	// phorge servers [--format f] | phorge sites [server] [--format f] |
+	// phorge state reset | phorge export [--format f] [--interval d] [path] |
+	// phorge regions [provider [region]] [--refresh] [--format f] |
+	// phorge open [--attach] [dir]
+	// These are checked before nicknames and site names; keep
+	// config.Subcommands in step with them.
+	args := os.Args[1:]
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "update":
+			if err := runUpdate(); err != nil {
+				fmt.Fprintf(os.Stderr, "Update failed: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "deploy":
+			if err := runDeploy(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Deploy failed: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "completion":
+			if err := runCompletion(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "__complete":
+			runComplete(os.Args[2:])
+			return
+		case "servers":
+			if err := runServers(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Listing servers failed: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "sites":
+			if err := runSites(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Listing sites failed: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "state":
+			if err := runState(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "regions":
+			if err := runRegions(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Listing regions failed: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "export":
+			if err := runExport(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Export failed: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "open":
+			launch, err := runOpen(os.Args[2:])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Open failed: %v\n", err)
+				os.Exit(1)
+			}
+			if !launch {
+				return
+			}
+			// Nothing is running to focus: start the TUI in the project,
+			// which selects its site on startup.
+			args = nil
+		case "ssh-config":
+			if err := runSSHConfig(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "ssh-config failed: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
 	// Parse arguments: phorge [nickname] [--ssh|--sftp|--db] [--version|-v]
+	// [--high-contrast] [--no-color] [--ascii]
 	var jumpTarget string
 	var action tui.LaunchAction
+	var highContrast, noColor, ascii bool
 
-	for _, arg := range os.Args[1:] {
+	for _, arg := range args {
 		switch arg {
 		case "--version", "-v":
 			fmt.Printf("phorge %s\n", version)
@@ -28,6 +113,12 @@ func main() {
 			action = tui.LaunchSFTP
 		case "--db", "-d":
 			action = tui.LaunchDB
+		case "--high-contrast":
+			highContrast = true
+		case "--no-color":
+			noColor = true
+		case "--ascii":
+			ascii = true
 		default:
 			jumpTarget = arg
 		}
@@ -38,10 +129,26 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 		os.Exit(1)
 	}
+	for _, w := range cfg.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	if err := cfg.ResolveAPIKey(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading API key: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Display flags override the config for this run only.
+	ui := cfg.UI
+	if highContrast {
+		ui.Theme = config.ThemeHighContrast
+	}
+	ui.NoColor = ui.NoColor || noColor
+	ui.ASCII = ui.ASCII || ascii
+	opts := tui.ApplyDisplay(ui)
 
 	if cfg.Forge.APIKey == "" {
 		// Run the first-run setup flow to collect the API key.
-		setupProgram := tea.NewProgram(tui.NewSetup(cfg))
+		setupProgram := tea.NewProgram(tui.NewSetup(cfg), opts...)
 		if _, err := setupProgram.Run(); err != nil {
 			fmt.Fprintf(os.Stderr, "Setup error: %v\n", err)
 			os.Exit(1)
@@ -60,7 +167,8 @@ func main() {
 		}
 	}
 
-	p := tea.NewProgram(tui.NewApp(cfg, jumpTarget, action))
+	tui.Version = version
+	p := tea.NewProgram(tui.NewApp(cfg, jumpTarget, action), opts...)
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/socket"
+)
+
+// openTimeout is how long `phorge open` waits for the running Phorge to
+// find the site, which may mean searching every server's sites.
+const openTimeout = 30 * time.Second
+
+// runOpen implements `phorge open [--attach] [dir]`, for editor plugins: it
+// asks the Phorge listening on the event socket to select the site of the
+// project checked out in dir (the working directory by default). When none
+// is running it reports launch, and the caller starts the TUI in dir, which
+// selects the same site on startup; --attach makes that an error instead.
+func runOpen(args []string) (launch bool, err error) {
+	fs := flag.NewFlagSet("open", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	attach := fs.Bool("attach", false, "only focus a running phorge; fail if none is")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: phorge open [--attach] [dir]")
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return false, err
+	}
+	if len(positional) > 1 {
+		fs.Usage()
+		return false, errors.New("too many arguments")
+	}
+	dir := "."
+	if len(positional) == 1 {
+		dir = positional[0]
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return false, err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return false, fmt.Errorf("loading config: %w", err)
+	}
+	err = socket.Send(cfg.Socket.SocketPath(), socket.Command{Name: socket.CommandOpen, Dir: dir}, openTimeout)
+	if errors.Is(err, socket.ErrNotRunning) && !*attach {
+		return true, os.Chdir(dir)
+	}
+	return false, err
+}
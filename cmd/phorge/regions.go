@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// regionRow is a region together with its provider, as listed by
+// `phorge regions`.
+type regionRow struct {
+	Provider string `json:"provider"`
+	forge.Region
+}
+
+// runRegions implements `phorge regions [provider [region]] [--refresh]
+// [--format f]`: it lists the regions Forge can create servers in, or the
+// server sizes of one region. The list is cached in phorge.db for a week;
+// --refresh fetches it again.
+func runRegions(args []string) error {
+	fs := flag.NewFlagSet("regions", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	format := fs.String("format", "table", formatUsage)
+	refresh := fs.Bool("refresh", false, "fetch the list again instead of using the cache")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: phorge regions [provider [region]] [--refresh] [--format table|json|template]")
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if len(positional) > 2 {
+		fs.Usage()
+		return fmt.Errorf("too many arguments")
+	}
+
+	client, err := cliClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	cat, err := config.CachedCatalogue(ctx, client, *refresh)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 2 {
+		provider, region := positional[0], positional[1]
+		if err := cat.Validate(provider, region, ""); err != nil {
+			return err
+		}
+		r, _ := cat.Region(provider, region)
+		return printList(os.Stdout, *format, r.Sizes, []column[forge.Size]{
+			{"ID", func(s forge.Size) string { return s.ID }},
+			{"SIZE", func(s forge.Size) string { return s.Size }},
+			{"NAME", func(s forge.Size) string { return s.Name }},
+		})
+	}
+
+	providers := cat.Providers()
+	if len(positional) == 1 {
+		if _, ok := cat.Regions[positional[0]]; !ok {
+			return cat.Validate(positional[0], "", "")
+		}
+		providers = positional[:1]
+	}
+	var rows []regionRow
+	for _, p := range providers {
+		for _, r := range cat.Regions[p] {
+			rows = append(rows, regionRow{Provider: p, Region: r})
+		}
+	}
+	return printList(os.Stdout, *format, rows, []column[regionRow]{
+		{"PROVIDER", func(r regionRow) string { return r.Provider }},
+		{"ID", func(r regionRow) string { return r.ID }},
+		{"NAME", func(r regionRow) string { return r.Name }},
+		{"SIZES", func(r regionRow) string { return strconv.Itoa(len(r.Sizes)) }},
+	})
+}
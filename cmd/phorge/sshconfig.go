@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// runSSHConfig implements `phorge ssh-config [--print] [--output path]`: it
+// writes an OpenSSH include file with a Host entry for every Forge server,
+// so `ssh <server-name>` works outside the TUI. Re-run it to pick up new or
+// renamed servers. The entries sit between marker lines, and anything else
+// in the file is left as it is.
+func runSSHConfig(args []string) error {
+	fs := flag.NewFlagSet("ssh-config", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	output := fs.String("output", defaultSSHConfigPath(), "file to write")
+	toStdout := fs.Bool("print", false, "print the entries instead of writing them")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: phorge ssh-config [--print] [--output path]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("loading config: %w", err)
+	}
+	if err := cfg.ResolveAPIKey(); err != nil {
+		return err
+	}
+	if cfg.Forge.APIKey == "" {
+		return fmt.Errorf("no API key configured; run phorge once to set one up")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	servers, err := forge.NewClient(cfg.Forge.APIKey).WithFallbackToken(cfg.Forge.FallbackAPIKey).WithSchemaLog(cfg.Forge.SchemaLog).Servers.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing servers: %w", err)
+	}
+
+	if *toStdout {
+		_, err := writeSSHConfig(os.Stdout, cfg, servers)
+		return err
+	}
+
+	var block strings.Builder
+	n, err := writeSSHConfig(&block, cfg, servers)
+	if err != nil {
+		return err
+	}
+	existing, err := os.ReadFile(*output)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("reading %s: %w", *output, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*output), 0o700); err != nil {
+		return fmt.Errorf("creating %s: %w", filepath.Dir(*output), err)
+	}
+	// Write to a temporary file and rename it into place so ssh never
+	// reads a half-written config.
+	tmp, err := os.CreateTemp(filepath.Dir(*output), ".phorge-ssh-*")
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", *output, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.WriteString(tmp, replaceSSHConfigBlock(string(existing), block.String())); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing %s: %w", *output, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", *output, err)
+	}
+	if err := os.Rename(tmp.Name(), *output); err != nil {
+		return fmt.Errorf("writing %s: %w", *output, err)
+	}
+
+	fmt.Printf("Wrote %d hosts to %s\n", n, *output)
+	if userConfig := filepath.Join(filepath.Dir(filepath.Dir(*output)), "config"); !sshConfigIncludes(userConfig, *output) {
+		fmt.Printf("Add this line near the top of %s to use them:\n\n    Include %s\n", userConfig, *output)
+	}
+	return nil
+}
+
+// Marker lines around the entries phorge manages in the ssh config file.
+const (
+	sshConfigBegin = "# BEGIN phorge ssh-config"
+	sshConfigEnd   = "# END phorge ssh-config"
+)
+
+// writeSSHConfig writes one Host block per server to w, sorted by name,
+// between the sshConfigBegin and sshConfigEnd markers. Servers without a
+// public IP are skipped, and aliases that collide after sanitising get the
+// server ID appended. It returns the number of hosts written.
+func writeSSHConfig(w io.Writer, cfg *config.Config, servers []forge.Server) (int, error) {
+	sorted := make([]forge.Server, len(servers))
+	copy(sorted, servers)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name)
+	})
+
+	var b strings.Builder
+	b.WriteString(sshConfigBegin + "\n")
+	b.WriteString("# Generated by phorge ssh-config. Edits inside this block are replaced when it is re-run.\n")
+	seen := make(map[string]bool)
+	n := 0
+	for _, srv := range sorted {
+		if srv.IPAddress == "" {
+			continue
+		}
+		alias := sshHostAlias(srv.Name)
+		if alias == "" || seen[alias] {
+			alias = fmt.Sprintf("%s-%d", alias, srv.ID)
+			alias = strings.TrimPrefix(alias, "-")
+		}
+		seen[alias] = true
+		n++
+
+		fmt.Fprintf(&b, "\nHost %s\n", alias)
+		fmt.Fprintf(&b, "    HostName %s\n", srv.IPAddress)
+		fmt.Fprintf(&b, "    User %s\n", cfg.SSHUserFor(srv.Name))
+		if srv.SSHPort != 0 && srv.SSHPort != 22 {
+			fmt.Fprintf(&b, "    Port %d\n", srv.SSHPort)
+		}
+	}
+	b.WriteString(sshConfigEnd + "\n")
+	_, err := io.WriteString(w, b.String())
+	return n, err
+}
+
+// replaceSSHConfigBlock returns existing with its phorge block replaced by
+// block, or with block appended when it has none. Everything outside the
+// markers is kept.
+func replaceSSHConfigBlock(existing, block string) string {
+	// The block ends at the first end marker and starts at the last begin
+	// marker before it, so a stray begin marker above it is kept.
+	if ends := markerLines(existing, sshConfigEnd); len(ends) > 0 {
+		if begins := markerLines(existing[:ends[0]], sshConfigBegin); len(begins) > 0 {
+			start := begins[len(begins)-1]
+			// Drop the end marker's line, keeping what follows it.
+			rest := existing[ends[0]:]
+			if i := strings.IndexByte(rest, '\n'); i >= 0 {
+				rest = rest[i+1:]
+			} else {
+				rest = ""
+			}
+			return existing[:start] + block + rest
+		}
+	}
+	if existing == "" {
+		return block
+	}
+	if !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	return existing + "\n" + block
+}
+
+// markerLines returns the offsets in s of the start of each line that is
+// marker.
+func markerLines(s, marker string) []int {
+	var offsets []int
+	off := 0
+	for line := range strings.SplitSeq(s, "\n") {
+		if strings.TrimSpace(line) == marker {
+			offsets = append(offsets, off)
+		}
+		off += len(line) + 1
+	}
+	return offsets
+}
+
+// sshHostAlias turns a server name into a Host pattern: whitespace becomes
+// a dash, and the pattern characters ssh treats specially are dropped.
+func sshHostAlias(name string) string {
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case r == ' ' || r == '\t':
+			b.WriteRune('-')
+		case r == '*' || r == '?' || r == '!' || r == ',' || r == '#' || r == '"':
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// sshConfigIncludes reports whether the ssh config at path has an Include
+// line naming target, directly or via a config.d/* glob.
+func sshConfigIncludes(path, target string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	base := filepath.Base(target)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || !strings.EqualFold(fields[0], "include") {
+			continue
+		}
+		for _, pattern := range fields[1:] {
+			if ok, _ := filepath.Match(filepath.Base(pattern), base); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// defaultSSHConfigPath returns ~/.ssh/config.d/phorge.
+func defaultSSHConfigPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".ssh", "config.d", "phorge")
+}
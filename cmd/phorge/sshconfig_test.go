@@ -0,0 +1,112 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+func TestSSHHostAlias(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"prod-api", "prod-api"},
+		{"  prod api  ", "prod-api"},
+		{"web*1?", "web1"},
+		{`"quoted", #1`, "quoted-1"},
+		{"***", ""},
+	}
+	for _, tt := range tests {
+		if got := sshHostAlias(tt.name); got != tt.want {
+			t.Errorf("sshHostAlias(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWriteSSHConfigAliasCollisions(t *testing.T) {
+	servers := []forge.Server{
+		{ID: 2, Name: "web", IPAddress: "10.0.0.2"},
+		{ID: 1, Name: "web", IPAddress: "10.0.0.1", SSHPort: 2222},
+		{ID: 3, Name: "web*", IPAddress: "10.0.0.3"},
+		{ID: 4, Name: "***", IPAddress: "10.0.0.4"},
+		{ID: 5, Name: "no-ip"},
+	}
+	var b strings.Builder
+	n, err := writeSSHConfig(&b, config.Default(), servers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 4 {
+		t.Errorf("wrote %d hosts, want 4", n)
+	}
+
+	out := b.String()
+	var hosts []string
+	for line := range strings.SplitSeq(out, "\n") {
+		if alias, ok := strings.CutPrefix(line, "Host "); ok {
+			hosts = append(hosts, alias)
+		}
+	}
+	want := []string{"4", "web", "web-1", "web-3"}
+	if strings.Join(hosts, " ") != strings.Join(want, " ") {
+		t.Errorf("hosts = %v, want %v", hosts, want)
+	}
+	if !strings.Contains(out, "Host web-1\n    HostName 10.0.0.1\n    User forge\n    Port 2222\n") {
+		t.Errorf("output has no entry for the second web server:\n%s", out)
+	}
+	if !strings.HasPrefix(out, sshConfigBegin+"\n") || !strings.HasSuffix(out, sshConfigEnd+"\n") {
+		t.Errorf("output isn't wrapped in the block markers:\n%s", out)
+	}
+}
+
+func TestReplaceSSHConfigBlock(t *testing.T) {
+	block := func(hosts ...string) string {
+		var b strings.Builder
+		b.WriteString(sshConfigBegin + "\n")
+		for _, h := range hosts {
+			b.WriteString("Host " + h + "\n")
+		}
+		b.WriteString(sshConfigEnd + "\n")
+		return b.String()
+	}
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{"new file", "", block("web")},
+		{
+			"appended after user content",
+			"Host mine\n    HostName 192.0.2.1",
+			"Host mine\n    HostName 192.0.2.1\n\n" + block("web"),
+		},
+		{
+			"replaces the block in place",
+			"Host before\n\n" + block("old-1", "old-2") + "\nHost after\n",
+			"Host before\n\n" + block("web") + "\nHost after\n",
+		},
+		{
+			"block without a trailing newline",
+			"Host before\n" + strings.TrimSuffix(block("old"), "\n"),
+			"Host before\n" + block("web"),
+		},
+		{
+			"stray begin marker is kept",
+			sshConfigBegin + "\nHost old\n",
+			sshConfigBegin + "\nHost old\n\n" + block("web"),
+		},
+	}
+	for _, tt := range tests {
+		got := replaceSSHConfigBlock(tt.existing, block("web"))
+		if got != tt.want {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.name, got, tt.want)
+		}
+		// Running again must not add a second block.
+		if again := replaceSSHConfigBlock(got, block("web")); again != got {
+			t.Errorf("%s: re-running changed the file:\ngot  %q\nwant %q", tt.name, again, got)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/store"
+)
+
+// runState implements `phorge state reset`: it deletes the local database
+// of session state and caches. Configuration is left alone.
+func runState(args []string) error {
+	if len(args) != 1 || args[0] != "reset" {
+		return fmt.Errorf("usage: phorge state reset")
+	}
+	path := config.StorePath()
+	if err := store.Reset(path); err != nil {
+		return fmt.Errorf("removing %s: %w", path, err)
+	}
+	fmt.Printf("Removed %s; it will be rebuilt on the next run\n", path)
+	return nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// parseInterspersed parses fs from args while allowing flags to appear after
+// positional arguments (e.g. `phorge deploy prod --env staging`), which the
+// standard flag package otherwise stops at. It returns the positional args.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// resolveTarget turns CLI input into a server and site name. envName selects
+// a .phorge environment; otherwise arg is tried as a nickname and then as a
+// bare site name; with neither, the .phorge default is used. An empty server
+// name means the site should be searched for on every server.
+func resolveTarget(cfg *config.Config, project config.ProjectConfig, envName, arg string) (server, site string, err error) {
+	switch {
+	case envName != "":
+		env, ok := project.Environment(envName)
+		if !ok {
+			names := project.EnvironmentNames()
+			if len(names) == 0 {
+				return "", "", fmt.Errorf("environment %q not found: no environments declared in .phorge", envName)
+			}
+			return "", "", fmt.Errorf("environment %q not found (available: %s)", envName, strings.Join(names, ", "))
+		}
+		server, site = env.Server, env.Site
+	case arg != "":
+		if entry, ok := cfg.LookupNickname(arg); ok {
+			server, site = entry.Server, entry.Site
+		} else {
+			site = arg
+		}
+	default:
+		server, site = project.Server, project.Site
+	}
+
+	if site == "" {
+		return "", "", fmt.Errorf("no site specified: pass a site name, nickname or --env, or set a default in .phorge")
+	}
+	return server, site, nil
+}
+
+// findSite looks up a site by name via the API, restricted to the named
+// server when serverName is non-empty. The names it sees on the way are
+// recorded in the name cache used for shell completion.
+func findSite(ctx context.Context, client *forge.Client, serverName, siteName string) (*forge.Server, *forge.Site, error) {
+	servers, err := client.Servers.List(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing servers: %w", err)
+	}
+
+	names := config.LoadNameCache()
+	defer names.Save() // best effort; the cache only feeds completion
+	serverNames := make([]string, len(servers))
+	for i, srv := range servers {
+		serverNames[i] = srv.Name
+	}
+	names.SetServers(serverNames)
+
+	for i := range servers {
+		srv := &servers[i]
+		if serverName != "" && !strings.EqualFold(srv.Name, serverName) {
+			continue
+		}
+		sites, err := client.Sites.List(ctx, srv.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("listing sites on %s: %w", srv.Name, err)
+		}
+		siteNames := make([]string, len(sites))
+		for j, site := range sites {
+			siteNames[j] = site.Name
+		}
+		names.SetSites(srv.Name, siteNames)
+		for j := range sites {
+			if strings.EqualFold(sites[j].Name, siteName) {
+				return srv, &sites[j], nil
+			}
+		}
+	}
+
+	if serverName != "" {
+		return nil, nil, fmt.Errorf("site %q not found on server %q", siteName, serverName)
+	}
+	return nil, nil, fmt.Errorf("site %q not found on any server", siteName)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/update"
+)
+
+// runUpdate implements `phorge update`: it checks GitHub for a newer
+// release and, if one exists, replaces the running binary with it.
+func runUpdate() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	u := update.NewUpdater()
+	fmt.Println("Checking for updates...")
+	rel, err := u.Latest(ctx)
+	if err != nil {
+		return fmt.Errorf("checking latest release: %w", err)
+	}
+
+	if !update.IsNewer(version, rel.Version()) {
+		fmt.Printf("phorge %s is already up to date\n", version)
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating executable: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	fmt.Printf("Updating phorge %s -> %s...\n", version, rel.Version())
+	if err := u.Apply(ctx, rel, exe); err != nil {
+		return err
+	}
+	fmt.Printf("Updated %s to %s\n", exe, rel.Version())
+	return nil
+}
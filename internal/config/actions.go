@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Action is a user-defined command run from the TUI, for workflows Phorge
+// doesn't cover itself. It is declared as an [[actions]] table:
+//
+//	[[actions]]
+//	name = "Tail Horizon log"
+//	key = "ctrl+t"
+//	command = "ssh forge@{server_ip} tail -f {site_dir}/storage/logs/horizon.log"
+type Action struct {
+	// Name is how the action is listed in the action palette and footer.
+	Name string `toml:"name"`
+
+	// Key optionally runs the action directly, e.g. "ctrl+t" or "f5".
+	// The TUI's global keys take precedence.
+	Key string `toml:"key,omitempty"`
+
+	// Command is run with sh -c once its placeholders (see
+	// ActionPlaceholders) are replaced with the selection's values.
+	Command string `toml:"command"`
+}
+
+// ActionPlaceholders are the placeholders an action's command may use.
+var ActionPlaceholders = []string{"server_name", "server_ip", "ssh_user", "site_name", "site_dir"}
+
+var placeholderRE = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// NeedsSite reports whether the command uses a site placeholder, so can
+// only run with a site selected.
+func (a Action) NeedsSite() bool {
+	return strings.Contains(a.Command, "{site_")
+}
+
+// Expand returns the command with each placeholder replaced by its value
+// in vars. Values are inserted as they are, without shell quoting.
+func (a Action) Expand(vars map[string]string) string {
+	return placeholderRE.ReplaceAllStringFunc(a.Command, func(m string) string {
+		name := m[1 : len(m)-1]
+		if v, ok := vars[name]; ok && isActionPlaceholder(name) {
+			return v
+		}
+		return m
+	})
+}
+
+// actionWarnings describes actions without a name or command, with an
+// unknown placeholder or with a key another action already uses.
+func actionWarnings(file string, actions []Action) []string {
+	var warnings []string
+	keys := make(map[string]string)
+	for i, a := range actions {
+		label := a.Name
+		if label == "" {
+			label = fmt.Sprintf("#%d", i+1)
+		}
+		if a.Name == "" || strings.TrimSpace(a.Command) == "" {
+			warnings = append(warnings, fmt.Sprintf("%s: action %s needs both a name and a command", file, label))
+			continue
+		}
+		for _, m := range placeholderRE.FindAllStringSubmatch(a.Command, -1) {
+			if !isActionPlaceholder(m[1]) {
+				warnings = append(warnings, fmt.Sprintf("%s: action %s uses unknown placeholder {%s} (known: %s)",
+					file, label, m[1], strings.Join(ActionPlaceholders, ", ")))
+			}
+		}
+		if a.Key == "" {
+			continue
+		}
+		if other, ok := keys[a.Key]; ok {
+			warnings = append(warnings, fmt.Sprintf("%s: actions %s and %s both use key %q", file, other, label, a.Key))
+		}
+		keys[a.Key] = label
+	}
+	return warnings
+}
+
+func isActionPlaceholder(name string) bool {
+	return slices.Contains(ActionPlaceholders, name)
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestActionExpand(t *testing.T) {
+	a := Action{Name: "tail", Command: "ssh {ssh_user}@{server_ip} tail -f {site_dir}/storage/logs/laravel.log # {site_name} {unknown}"}
+	got := a.Expand(map[string]string{
+		"ssh_user":  "forge",
+		"server_ip": "10.0.0.1",
+		"site_dir":  "/home/forge/example.com",
+		"site_name": "example.com",
+		"unknown":   "x",
+	})
+	want := "ssh forge@10.0.0.1 tail -f /home/forge/example.com/storage/logs/laravel.log # example.com {unknown}"
+	if got != want {
+		t.Errorf("Expand = %q, want %q", got, want)
+	}
+	if !a.NeedsSite() {
+		t.Error("NeedsSite = false for a command using {site_dir}")
+	}
+	if (Action{Command: "ping {server_ip}"}).NeedsSite() {
+		t.Error("NeedsSite = true for a server-only command")
+	}
+}
+
+func TestLoadFromActionWarnings(t *testing.T) {
+	content := `
+[[actions]]
+name = "Tail"
+key = "ctrl+t"
+command = "ssh {server_ip} tail -f {site_path}"
+
+[[actions]]
+name = "Top"
+key = "ctrl+t"
+command = "ssh {server_ip} htop"
+
+[[actions]]
+name = "Empty"
+`
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if len(cfg.Actions) != 3 {
+		t.Fatalf("loaded %d actions, want 3", len(cfg.Actions))
+	}
+	want := []string{"unknown placeholder {site_path}", `both use key "ctrl+t"`, "Empty needs both a name and a command"}
+	if len(cfg.Warnings) != len(want) {
+		t.Fatalf("Warnings = %q, want %d", cfg.Warnings, len(want))
+	}
+	for i, w := range want {
+		if !strings.Contains(cfg.Warnings[i], w) {
+			t.Errorf("Warnings[%d] = %q, want it to mention %q", i, cfg.Warnings[i], w)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ResolveAPIKey runs Forge.APIKeyCmd, if set, and uses the first line of
+// its output as the API key, e.g. with api_key_cmd = "op read
+// op://Private/Forge/credential". A key obtained this way is never written
+// back to config.toml by SaveTo.
+func (c *Config) ResolveAPIKey() error {
+	if c.Forge.APIKeyCmd == "" {
+		return nil
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("sh", "-c", c.Forge.APIKeyCmd)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n"); msg != "" {
+			return fmt.Errorf("api_key_cmd: %w: %s", err, msg)
+		}
+		return fmt.Errorf("api_key_cmd: %w", err)
+	}
+
+	key, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return fmt.Errorf("api_key_cmd: command printed no token")
+	}
+	c.Forge.APIKey = key
+	return nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+)
+
+// AuditFileName is the name of the audit log in the config directory.
+const AuditFileName = "audit.log"
+
+// AuditEntry is one line of the audit log: an action that needed an
+// override, who took it and why.
+type AuditEntry struct {
+	Time        time.Time `json:"time"`
+	User        string    `json:"user,omitempty"`
+	Action      string    `json:"action"`
+	Server      string    `json:"server,omitempty"`
+	Site        string    `json:"site,omitempty"`
+	Environment string    `json:"environment,omitempty"`
+	Reason      string    `json:"reason"`
+	Via         string    `json:"via"` // "tui" or "cli"
+}
+
+// AuditPath returns the path of the audit log, next to config.toml.
+func AuditPath() string {
+	return filepath.Join(filepath.Dir(DefaultPath()), AuditFileName)
+}
+
+// AppendAudit adds e to the default audit log, filling in the time and
+// user when unset.
+func AppendAudit(e AuditEntry) error {
+	return AppendAuditTo(AuditPath(), e)
+}
+
+// AppendAuditTo adds e as a JSON line to the audit log at path. Unlike the
+// store, the log is only ever appended to and is not removed by a reset.
+func AppendAuditTo(path string, e AuditEntry) error {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.User == "" {
+		if u, err := user.Current(); err == nil {
+			e.User = u.Username
+		}
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating audit log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("writing audit log: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/hinkers/Phorge/internal/store"
+)
+
+// NameCache remembers the server and site names last seen from the API so
+// shell completion can offer them without a network round trip.
+type NameCache struct {
+	// Servers maps each server name to the names of its sites. A server
+	// whose sites have not been loaded yet maps to nil.
+	Servers map[string][]string `json:"servers,omitempty"`
+}
+
+// LoadNameCache reads the name cache from the default store. A missing or
+// unreadable store yields an empty cache.
+func LoadNameCache() *NameCache {
+	c, err := LoadNameCacheFrom(StorePath())
+	if err != nil {
+		return &NameCache{}
+	}
+	return c
+}
+
+// LoadNameCacheFrom reads the name cache from the store at the given path.
+// If no cache has been saved, it returns an empty cache (no error).
+func LoadNameCacheFrom(path string) (*NameCache, error) {
+	c := &NameCache{}
+	if _, err := store.Load(path, store.BucketCache, "names", c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetServers replaces the known servers, keeping the cached sites of those
+// still present and dropping the rest.
+func (c *NameCache) SetServers(names []string) {
+	servers := make(map[string][]string, len(names))
+	for _, name := range names {
+		servers[name] = c.Servers[name]
+	}
+	c.Servers = servers
+}
+
+// SetSites records the site names of a server.
+func (c *NameCache) SetSites(server string, sites []string) {
+	if c.Servers == nil {
+		c.Servers = make(map[string][]string)
+	}
+	c.Servers[server] = slices.Clone(sites)
+}
+
+// ServerNames returns the cached server names, sorted.
+func (c *NameCache) ServerNames() []string {
+	names := make([]string, 0, len(c.Servers))
+	for name := range c.Servers {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return names
+}
+
+// SiteNames returns the cached site names across all servers, sorted and
+// without duplicates.
+func (c *NameCache) SiteNames() []string {
+	var names []string
+	for _, sites := range c.Servers {
+		names = append(names, sites...)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// Save writes the name cache to the default store.
+func (c *NameCache) Save() error {
+	return c.SaveTo(StorePath())
+}
+
+// SaveTo writes the name cache to the store at the given path, creating it
+// and its parent directories if needed.
+func (c *NameCache) SaveTo(path string) error {
+	return store.Save(path, store.BucketCache, "names", c)
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestNameCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "phorge.db")
+
+	orig := &NameCache{}
+	orig.SetSites("web-1", []string{"b.example.com", "a.example.com"})
+	orig.SetSites("web-2", []string{"a.example.com"})
+	if err := orig.SaveTo(path); err != nil {
+		t.Fatalf("SaveTo: %v", err)
+	}
+
+	got, err := LoadNameCacheFrom(path)
+	if err != nil {
+		t.Fatalf("LoadNameCacheFrom: %v", err)
+	}
+	if want := []string{"web-1", "web-2"}; !slices.Equal(got.ServerNames(), want) {
+		t.Errorf("ServerNames = %v, want %v", got.ServerNames(), want)
+	}
+	if want := []string{"a.example.com", "b.example.com"}; !slices.Equal(got.SiteNames(), want) {
+		t.Errorf("SiteNames = %v, want %v", got.SiteNames(), want)
+	}
+}
+
+func TestLoadNameCacheFromMissingFile(t *testing.T) {
+	c, err := LoadNameCacheFrom(filepath.Join(t.TempDir(), "phorge.db"))
+	if err != nil {
+		t.Fatalf("LoadNameCacheFrom missing file: %v", err)
+	}
+	if len(c.ServerNames()) != 0 {
+		t.Errorf("ServerNames = %v, want empty", c.ServerNames())
+	}
+}
+
+func TestNameCacheSetServers(t *testing.T) {
+	c := &NameCache{}
+	c.SetSites("old", []string{"gone.example.com"})
+	c.SetSites("kept", []string{"kept.example.com"})
+
+	c.SetServers([]string{"kept", "new"})
+
+	if want := []string{"kept", "new"}; !slices.Equal(c.ServerNames(), want) {
+		t.Errorf("ServerNames = %v, want %v", c.ServerNames(), want)
+	}
+	if want := []string{"kept.example.com"}; !slices.Equal(c.SiteNames(), want) {
+		t.Errorf("SiteNames = %v, want %v", c.SiteNames(), want)
+	}
+}
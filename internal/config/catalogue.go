@@ -0,0 +1,135 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/store"
+)
+
+// CatalogueMaxAge is how long a fetched catalogue is used before it is
+// fetched again. Providers rarely add regions or sizes.
+const CatalogueMaxAge = 7 * 24 * time.Hour
+
+// Catalogue caches the regions and server sizes of each provider, as
+// listed by Forge, for choosing and checking them without a request.
+type Catalogue struct {
+	// Regions maps a credential type, e.g. "ocean2", to its regions.
+	Regions map[string][]forge.Region `json:"regions,omitempty"`
+
+	// FetchedAt is when Regions was fetched from the API.
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// LoadCatalogue reads the catalogue from the default store. A missing or
+// unreadable store yields an empty catalogue.
+func LoadCatalogue() *Catalogue {
+	c, err := LoadCatalogueFrom(StorePath())
+	if err != nil {
+		return &Catalogue{}
+	}
+	return c
+}
+
+// LoadCatalogueFrom reads the catalogue from the store at the given path.
+// If none has been saved, it returns an empty catalogue (no error).
+func LoadCatalogueFrom(path string) (*Catalogue, error) {
+	c := &Catalogue{}
+	if _, err := store.Load(path, store.BucketCache, "regions", c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// CachedCatalogue returns the catalogue from the default store, fetching
+// it with client first when it is stale or refresh is set. Saving the
+// fetched catalogue is best effort.
+func CachedCatalogue(ctx context.Context, client *forge.Client, refresh bool) (*Catalogue, error) {
+	c := LoadCatalogue()
+	if !refresh && !c.Stale(time.Now()) {
+		return c, nil
+	}
+	regions, err := client.Credentials.Regions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching regions: %w", err)
+	}
+	c.Set(regions, time.Now())
+	_ = c.Save()
+	return c, nil
+}
+
+// Save writes the catalogue to the default store.
+func (c *Catalogue) Save() error {
+	return c.SaveTo(StorePath())
+}
+
+// SaveTo writes the catalogue to the store at the given path.
+func (c *Catalogue) SaveTo(path string) error {
+	return store.Save(path, store.BucketCache, "regions", c)
+}
+
+// Stale reports whether the catalogue is empty or older than
+// CatalogueMaxAge at now.
+func (c *Catalogue) Stale(now time.Time) bool {
+	return len(c.Regions) == 0 || now.Sub(c.FetchedAt) > CatalogueMaxAge
+}
+
+// Set replaces the cached regions, fetched at now.
+func (c *Catalogue) Set(regions map[string][]forge.Region, now time.Time) {
+	c.Regions = regions
+	c.FetchedAt = now
+}
+
+// Providers returns the credential types in the catalogue, sorted.
+func (c *Catalogue) Providers() []string {
+	providers := make([]string, 0, len(c.Regions))
+	for p := range c.Regions {
+		providers = append(providers, p)
+	}
+	slices.Sort(providers)
+	return providers
+}
+
+// Region returns the region of provider with the given ID, matched
+// case-insensitively.
+func (c *Catalogue) Region(provider, id string) (forge.Region, bool) {
+	for _, r := range c.Regions[provider] {
+		if strings.EqualFold(r.ID, id) {
+			return r, true
+		}
+	}
+	return forge.Region{}, false
+}
+
+// Validate checks that provider offers size in region. size may be given
+// as the size's ID or its provider name, e.g. "s-1vcpu-1gb". The error
+// lists what is available.
+func (c *Catalogue) Validate(provider, region, size string) error {
+	regions, ok := c.Regions[provider]
+	if !ok {
+		return fmt.Errorf("unknown provider %q (known: %s)", provider, strings.Join(c.Providers(), ", "))
+	}
+	r, ok := c.Region(provider, region)
+	if !ok {
+		ids := make([]string, len(regions))
+		for i, r := range regions {
+			ids[i] = r.ID
+		}
+		return fmt.Errorf("%s has no region %q (available: %s)", provider, region, strings.Join(ids, ", "))
+	}
+	if size == "" {
+		return nil
+	}
+	sizes := make([]string, len(r.Sizes))
+	for i, s := range r.Sizes {
+		if strings.EqualFold(s.ID, size) || strings.EqualFold(s.Size, size) {
+			return nil
+		}
+		sizes[i] = s.Size
+	}
+	return fmt.Errorf("region %s of %s has no size %q (available: %s)", r.ID, provider, size, strings.Join(sizes, ", "))
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+func testCatalogue(now time.Time) *Catalogue {
+	c := &Catalogue{}
+	c.Set(map[string][]forge.Region{
+		"ocean2": {
+			{ID: "ams3", Name: "Amsterdam 3", Sizes: []forge.Size{{ID: "01", Size: "s-1vcpu-1gb", Name: "1GB RAM - 1 CPU Core"}}},
+			{ID: "nyc1", Name: "New York 1"},
+		},
+		"hetzner": {{ID: "fsn1", Name: "Falkenstein"}},
+	}, now)
+	return c
+}
+
+func TestCatalogueRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phorge.db")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := testCatalogue(now).SaveTo(path); err != nil {
+		t.Fatalf("SaveTo: %v", err)
+	}
+	got, err := LoadCatalogueFrom(path)
+	if err != nil {
+		t.Fatalf("LoadCatalogueFrom: %v", err)
+	}
+	if providers := got.Providers(); len(providers) != 2 || providers[0] != "hetzner" {
+		t.Errorf("Providers = %v, want [hetzner ocean2]", providers)
+	}
+	if !got.FetchedAt.Equal(now) {
+		t.Errorf("FetchedAt = %v, want %v", got.FetchedAt, now)
+	}
+}
+
+func TestCatalogueStale(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if !(&Catalogue{}).Stale(now) {
+		t.Error("empty catalogue isn't stale")
+	}
+	c := testCatalogue(now)
+	if c.Stale(now.Add(time.Hour)) {
+		t.Error("fresh catalogue is stale")
+	}
+	if !c.Stale(now.Add(CatalogueMaxAge + time.Hour)) {
+		t.Error("old catalogue isn't stale")
+	}
+}
+
+func TestCatalogueValidate(t *testing.T) {
+	c := testCatalogue(time.Now())
+	tests := []struct {
+		provider, region, size string
+		wantErr                string // "" for valid
+	}{
+		{"ocean2", "ams3", "s-1vcpu-1gb", ""},
+		{"ocean2", "AMS3", "01", ""},
+		{"ocean2", "nyc1", "", ""},
+		{"linode", "us-east", "", "unknown provider"},
+		{"ocean2", "lon1", "", "available: ams3, nyc1"},
+		{"ocean2", "ams3", "huge", "available: s-1vcpu-1gb"},
+	}
+	for _, tt := range tests {
+		err := c.Validate(tt.provider, tt.region, tt.size)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("Validate(%q, %q, %q) = %v, want nil", tt.provider, tt.region, tt.size, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("Validate(%q, %q, %q) = %v, want an error containing %q", tt.provider, tt.region, tt.size, err, tt.wantErr)
+		}
+	}
+}
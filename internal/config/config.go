@@ -6,10 +6,14 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	toml "github.com/pelletier/go-toml/v2"
 )
@@ -24,8 +28,54 @@ type NicknameEntry struct {
 type Config struct {
 	Forge       ForgeConfig            `toml:"forge"`
 	Editor      EditorConfig           `toml:"editor"`
+	UI          UIConfig               `toml:"ui"`
 	ServerUsers map[string]string      `toml:"server_users,omitempty"`
 	Nicknames   map[string]NicknameEntry `toml:"nicknames,omitempty"`
+
+	// FirewallSets are named groups of ports (e.g. web = [80, 443]) that
+	// can be applied to a server's firewall in one step.
+	FirewallSets map[string][]int `toml:"firewall_sets,omitempty"`
+
+	// RestartDaemons lists, per site name, the daemons to restart after a
+	// successful deploy from the TUI. Entries match a daemon ID or part of
+	// its command.
+	RestartDaemons map[string][]string `toml:"restart_daemons,omitempty"`
+
+	// HealthChecks maps a site name to a URL that is requested after each
+	// deploy of the site finishes, to catch broken releases.
+	HealthChecks map[string]string `toml:"health_checks,omitempty"`
+
+	// AutoRollback lists, per site name, whether a failed post-deploy
+	// health check redeploys the previous successful commit.
+	AutoRollback map[string]bool `toml:"auto_rollback,omitempty"`
+
+	// HiddenServers lists servers left out of the tree unless hidden
+	// servers are shown: by name, by ID, or "tag:<name>" for every server
+	// with a tag. See ServerHidden.
+	HiddenServers []string `toml:"hidden_servers,omitempty"`
+
+	// Workspaces are named sets of targets the tree can be narrowed to:
+	// "server" for a whole server or "server/site" for one site on it.
+	Workspaces map[string][]string `toml:"workspaces,omitempty"`
+
+	// Alerts are rules checked in the background while the TUI runs.
+	Alerts AlertsConfig `toml:"alerts,omitempty"`
+
+	// Actions are user-defined commands listed in the TUI's action
+	// palette and run against the selected server or site.
+	Actions []Action `toml:"actions,omitempty"`
+
+	// Hooks are user scripts run when events such as a finished deploy
+	// happen in the TUI.
+	Hooks []Hook `toml:"hooks,omitempty"`
+
+	// Socket is the optional local socket external tools use to follow
+	// and drive the TUI.
+	Socket SocketConfig `toml:"socket,omitempty"`
+
+	// Warnings lists problems found while loading the file that did not
+	// prevent it from loading, such as unrecognised keys.
+	Warnings []string `toml:"-"`
 }
 
 // ForgeConfig holds Laravel Forge API settings.
@@ -33,6 +83,20 @@ type ForgeConfig struct {
 	APIKey        string `toml:"api_key"`
 	SSHUser       string `toml:"ssh_user"`
 	DefaultSSHKey string `toml:"default_ssh_key,omitempty"`
+
+	// APIKeyCmd is a shell command whose output is the API token, such
+	// as a password manager's CLI, used instead of storing api_key here.
+	// See ResolveAPIKey.
+	APIKeyCmd string `toml:"api_key_cmd,omitempty"`
+
+	// FallbackAPIKey is a second token, such as a read-only organisation
+	// token, that reads are retried with when api_key is rate limited.
+	FallbackAPIKey string `toml:"fallback_api_key,omitempty"`
+
+	// SchemaLog is a file that fields and types in API responses the
+	// client doesn't know about are logged to, for debugging. Empty
+	// leaves strict decoding off.
+	SchemaLog string `toml:"schema_log,omitempty"`
 }
 
 // EditorConfig holds external editor settings.
@@ -40,6 +104,103 @@ type EditorConfig struct {
 	Command string `toml:"command"`
 }
 
+// UIConfig holds terminal UI preferences.
+type UIConfig struct {
+	// TourSeen is set once the first-run onboarding tour has been
+	// completed or skipped, so it is only shown automatically once.
+	TourSeen bool `toml:"tour_seen,omitempty"`
+
+	// Reachability enables a background TCP check of each server's SSH
+	// port, shown as an online/offline dot in the tree. Off by default.
+	Reachability bool `toml:"reachability,omitempty"`
+
+	// Author is the user's name as it appears as the commit author of
+	// deployments, for the deployments panel's "only mine" filter.
+	Author string `toml:"author,omitempty"`
+
+	// Theme is the colour theme: ThemeDefault or ThemeHighContrast.
+	Theme string `toml:"theme,omitempty"`
+
+	// Timezone is the IANA timezone, e.g. "Europe/London", that absolute
+	// times are shown in. Empty means the local timezone.
+	Timezone string `toml:"timezone,omitempty"`
+
+	// NoColor drops all colour, as does the NO_COLOR environment variable.
+	NoColor bool `toml:"no_color,omitempty"`
+
+	// ASCII draws borders, tree lines and status icons in plain ASCII,
+	// for screen readers and terminals without Unicode support.
+	ASCII bool `toml:"ascii,omitempty"`
+
+	// ReducedMotion turns off spinners and polls live output less often,
+	// for slow SSH sessions where constant redraws get in the way.
+	ReducedMotion bool `toml:"reduced_motion,omitempty"`
+
+	// DisabledTabs lists detail tabs, by their TabIDs, that are left out
+	// of the TUI, e.g. ["firewall", "sshkeys"] to take risky features out
+	// of the hands of a wider team.
+	DisabledTabs []string `toml:"disabled_tabs,omitempty"`
+}
+
+// TabIDs are the detail tabs UIConfig.DisabledTabs can name. databases
+// and ssl cover both a site's tab and the server's.
+var TabIDs = []string{
+	"deployments", "env", "databases", "ssl", "workers", "commands", "logs", "git", "domains",
+	"events", "nginx", "circles", "daemons", "firewall", "jobs", "sshkeys", "backups",
+	"recipes",
+}
+
+// SocketConfig controls the TUI's local event socket.
+type SocketConfig struct {
+	// Enabled opens the socket while the TUI runs.
+	Enabled bool `toml:"enabled"`
+
+	// Path overrides where the socket is created (an absolute path); see
+	// SocketPath.
+	Path string `toml:"path,omitempty"`
+}
+
+// SocketPath returns where the event socket is created: Path, or
+// phorge.sock next to config.toml.
+func (c SocketConfig) SocketPath() string {
+	if c.Path != "" {
+		return c.Path
+	}
+	return filepath.Join(filepath.Dir(DefaultPath()), "phorge.sock")
+}
+
+// AlertsConfig holds the alert rules and how often they are checked.
+type AlertsConfig struct {
+	// Rules are alert rules such as "deployment failed on
+	// tag:production", "cert expires <7d" or "daemon status != running".
+	Rules []string `toml:"rules,omitempty"`
+
+	// Interval is how often the rules are checked, e.g. "10m". See
+	// CheckInterval.
+	Interval string `toml:"interval,omitempty"`
+}
+
+// DefaultAlertInterval is how often alert rules are checked unless
+// configured otherwise.
+const DefaultAlertInterval = 5 * time.Minute
+
+// CheckInterval returns the configured alert interval, or
+// DefaultAlertInterval when it is unset or invalid. Intervals under a
+// minute are raised to one, to spare the API.
+func (a AlertsConfig) CheckInterval() time.Duration {
+	d, err := time.ParseDuration(a.Interval)
+	if err != nil || d <= 0 {
+		return DefaultAlertInterval
+	}
+	return max(d, time.Minute)
+}
+
+// Colour themes for UIConfig.Theme.
+const (
+	ThemeDefault      = "default"
+	ThemeHighContrast = "high-contrast"
+)
+
 // Default returns a Config populated with sensible defaults.
 func Default() *Config {
 	return &Config{
@@ -85,7 +246,24 @@ func LoadFrom(path string) (*Config, error) {
 
 	cfg := Default()
 	if err := toml.Unmarshal(data, cfg); err != nil {
-		return nil, err
+		return nil, describeDecodeError(path, err)
+	}
+	cfg.Warnings = unknownKeyWarnings(path, data)
+	if t := cfg.UI.Theme; t != "" && t != ThemeDefault && t != ThemeHighContrast {
+		cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("%s: unknown ui.theme %q (want %q or %q)", filepath.Base(path), t, ThemeDefault, ThemeHighContrast))
+	}
+	if tz := cfg.UI.Timezone; tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("%s: unknown ui.timezone %q (want an IANA name such as \"Europe/London\")", filepath.Base(path), tz))
+		}
+	}
+	cfg.Warnings = append(cfg.Warnings, actionWarnings(filepath.Base(path), cfg.Actions)...)
+	cfg.Warnings = append(cfg.Warnings, hookWarnings(filepath.Base(path), cfg.Hooks)...)
+	cfg.Warnings = append(cfg.Warnings, reservedNicknameWarnings(filepath.Base(path), cfg.Nicknames)...)
+	for _, tab := range cfg.UI.DisabledTabs {
+		if !slices.Contains(TabIDs, strings.ToLower(strings.TrimSpace(tab))) {
+			cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("%s: unknown tab %q in ui.disabled_tabs (known: %s)", filepath.Base(path), tab, strings.Join(TabIDs, ", ")))
+		}
 	}
 
 	// Ensure maps are never nil after unmarshalling.
@@ -112,7 +290,13 @@ func (c *Config) SaveTo(path string) error {
 		return err
 	}
 
-	data, err := toml.Marshal(c)
+	// Keep a token fetched by api_key_cmd out of the file.
+	out := *c
+	if out.Forge.APIKeyCmd != "" {
+		out.Forge.APIKey = ""
+	}
+
+	data, err := toml.Marshal(&out)
 	if err != nil {
 		return err
 	}
@@ -130,6 +314,34 @@ func (c *Config) SSHUserFor(serverName string) string {
 	return c.Forge.SSHUser
 }
 
+// Subcommands are the first arguments the phorge CLI runs as subcommands.
+// They are matched before nicknames and site names, so they can't be used
+// as nicknames.
+var Subcommands = []string{"completion", "deploy", "export", "open", "regions", "servers", "sites", "ssh-config", "state", "update"}
+
+// ReservedNickname reports whether name is taken by a subcommand (or the
+// hidden __complete one shell completion calls), so `phorge <name>` would
+// never open the nickname.
+func ReservedNickname(name string) bool {
+	return slices.Contains(Subcommands, name) || name == "__complete"
+}
+
+// reservedNicknameWarnings describes nicknames that are subcommands.
+func reservedNicknameWarnings(file string, nicknames map[string]NicknameEntry) []string {
+	var names []string
+	for name := range nicknames {
+		if ReservedNickname(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	var warnings []string
+	for _, name := range names {
+		warnings = append(warnings, fmt.Sprintf("%s: nickname %q is a phorge subcommand, so `phorge %s` runs the subcommand instead; rename it", file, name, name))
+	}
+	return warnings
+}
+
 // LookupNickname returns the entry for the given nickname, or false if not found.
 func (c *Config) LookupNickname(name string) (NicknameEntry, bool) {
 	entry, ok := c.Nicknames[name]
@@ -159,17 +371,188 @@ func (c *Config) FindNicknameFor(server, site string) string {
 	return ""
 }
 
-// ProjectConfig is a per-directory config stored in .phorge in the working
-// directory. It lets users pin a default server and/or site for a project.
+// FirewallSet returns the ports of the named firewall set
+// (case-insensitive), or false if it is not declared.
+func (c *Config) FirewallSet(name string) ([]int, bool) {
+	if ports, ok := c.FirewallSets[name]; ok {
+		return ports, true
+	}
+	for n, ports := range c.FirewallSets {
+		if strings.EqualFold(n, name) {
+			return ports, true
+		}
+	}
+	return nil, false
+}
+
+// FirewallSetNames returns the declared firewall set names in sorted order.
+func (c *Config) FirewallSetNames() []string {
+	names := make([]string, 0, len(c.FirewallSets))
+	for n := range c.FirewallSets {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DaemonsToRestart returns the daemons configured to restart after site is
+// deployed (case-insensitive), or nil.
+func (c *Config) DaemonsToRestart(site string) []string {
+	if daemons, ok := c.RestartDaemons[site]; ok {
+		return daemons
+	}
+	for n, daemons := range c.RestartDaemons {
+		if strings.EqualFold(n, site) {
+			return daemons
+		}
+	}
+	return nil
+}
+
+// HealthCheckURL returns the health-check URL configured for site
+// (case-insensitive), or "".
+func (c *Config) HealthCheckURL(site string) string {
+	if url, ok := c.HealthChecks[site]; ok {
+		return url
+	}
+	for n, url := range c.HealthChecks {
+		if strings.EqualFold(n, site) {
+			return url
+		}
+	}
+	return ""
+}
+
+// AutoRollbackEnabled reports whether site opts in to rolling back when its
+// post-deploy health check fails (case-insensitive).
+func (c *Config) AutoRollbackEnabled(site string) bool {
+	if on, ok := c.AutoRollback[site]; ok {
+		return on
+	}
+	for n, on := range c.AutoRollback {
+		if strings.EqualFold(n, site) {
+			return on
+		}
+	}
+	return false
+}
+
+// ProjectConfigName is the file name of the per-project config.
+const ProjectConfigName = ".phorge"
+
+// ProjectConfig is a per-directory config stored in .phorge in the project
+// directory. It lets users pin a default server and/or site for a project,
+// plus any number of named environments (e.g. production, staging).
 type ProjectConfig struct {
-	Server string `toml:"server,omitempty"`
+	Server       string                        `toml:"server,omitempty"`
+	Site         string                        `toml:"site,omitempty"`
+	Environments map[string]ProjectEnvironment `toml:"environments,omitempty"`
+
+	// RestartDaemons are restarted after a successful TUI deploy of the
+	// project's site (or any of its environments' sites).
+	RestartDaemons []string `toml:"restart_daemons,omitempty"`
+
+	// HealthCheck is requested after each deploy of the project's site
+	// (or any of its environments' sites) finishes.
+	HealthCheck string `toml:"health_check,omitempty"`
+
+	// AutoRollback redeploys the previous successful commit of the
+	// project's site when its health check fails after a deploy.
+	AutoRollback bool `toml:"auto_rollback,omitempty"`
+}
+
+// IsProjectSite reports whether site is the project's site or the site of
+// one of its environments (case-insensitive).
+func (p ProjectConfig) IsProjectSite(site string) bool {
+	if site == "" {
+		return false
+	}
+	if strings.EqualFold(p.Site, site) {
+		return true
+	}
+	for _, env := range p.Environments {
+		if strings.EqualFold(env.Site, site) {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectEnvironment is a named deploy target declared in .phorge.
+type ProjectEnvironment struct {
+	Server string `toml:"server"`
 	Site   string `toml:"site,omitempty"`
+
+	// Maintenance lists the windows the environment may be deployed in,
+	// e.g. "mon-fri 22:00-06:00" (local time). Outside them a deploy needs
+	// an override with a reason, which is written to the audit log. See
+	// ParseMaintenanceWindow.
+	Maintenance []string `toml:"maintenance_windows,omitempty"`
 }
 
-// LoadProjectConfig reads the .phorge file from the current directory.
-// If the file does not exist, it returns an empty ProjectConfig (no error).
+// Environment returns the named environment (case-insensitive), or false
+// if it is not declared.
+func (p ProjectConfig) Environment(name string) (ProjectEnvironment, bool) {
+	if env, ok := p.Environments[name]; ok {
+		return env, true
+	}
+	for n, env := range p.Environments {
+		if strings.EqualFold(n, name) {
+			return env, true
+		}
+	}
+	return ProjectEnvironment{}, false
+}
+
+// EnvironmentNames returns the declared environment names in sorted order.
+func (p ProjectConfig) EnvironmentNames() []string {
+	names := make([]string, 0, len(p.Environments))
+	for n := range p.Environments {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// FindProjectConfig walks up from dir (like git does for .git) and returns
+// the path of the nearest .phorge file, or "" if none exists.
+func FindProjectConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		path := filepath.Join(dir, ProjectConfigName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// projectConfigPath returns the .phorge file to read and write: the nearest
+// one found from the current directory upwards, or ./.phorge if none exists.
+func projectConfigPath() string {
+	if path := FindProjectConfig("."); path != "" {
+		return path
+	}
+	return filepath.Join(".", ProjectConfigName)
+}
+
+// LoadProjectConfig reads the nearest .phorge file, searching the current
+// directory and then its parents.
+// If no file exists, it returns an empty ProjectConfig (no error).
 func LoadProjectConfig() ProjectConfig {
-	path := filepath.Join(".", ".phorge")
+	return LoadProjectConfigFrom(projectConfigPath())
+}
+
+// LoadProjectConfigFrom reads a .phorge file from the given path.
+// If the file does not exist, it returns an empty ProjectConfig (no error).
+func LoadProjectConfigFrom(path string) ProjectConfig {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return ProjectConfig{}
@@ -181,11 +564,12 @@ func LoadProjectConfig() ProjectConfig {
 	return cfg
 }
 
-// SaveProjectConfig writes the .phorge file in the current directory.
-// If both server and site are empty, it deletes the file.
+// SaveProjectConfig writes the nearest .phorge file (see LoadProjectConfig),
+// creating one in the current directory if none exists.
+// If server, site and environments are all empty, it deletes the file.
 func SaveProjectConfig(cfg ProjectConfig) error {
-	path := filepath.Join(".", ".phorge")
-	if cfg.Server == "" && cfg.Site == "" {
+	path := projectConfigPath()
+	if cfg.Server == "" && cfg.Site == "" && len(cfg.Environments) == 0 {
 		// Remove the file when clearing all defaults.
 		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
 			return err
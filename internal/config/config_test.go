@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 )
 
@@ -21,6 +23,9 @@ func TestDefaultValues(t *testing.T) {
 	if cfg.ServerUsers == nil {
 		t.Error("Default ServerUsers is nil, want initialized map")
 	}
+	if cfg.UI.Reachability {
+		t.Error("Default reachability = true, want the check off until enabled")
+	}
 }
 
 func TestDefaultPathNotEmpty(t *testing.T) {
@@ -121,6 +126,78 @@ api_key = "partial-key"
 	}
 }
 
+func TestLoadFromFirewallSets(t *testing.T) {
+	content := `
+[firewall_sets]
+web = [80, 443]
+mail = [25, 587]
+`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if len(cfg.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %v", cfg.Warnings)
+	}
+
+	if got := cfg.FirewallSetNames(); len(got) != 2 || got[0] != "mail" || got[1] != "web" {
+		t.Errorf("FirewallSetNames = %v, want [mail web]", got)
+	}
+	ports, ok := cfg.FirewallSet("WEB")
+	if !ok {
+		t.Fatal("FirewallSet(WEB) not found")
+	}
+	if len(ports) != 2 || ports[0] != 80 || ports[1] != 443 {
+		t.Errorf("web ports = %v, want [80 443]", ports)
+	}
+	if _, ok := cfg.FirewallSet("dns"); ok {
+		t.Error("FirewallSet(dns) found, want missing")
+	}
+}
+
+func TestDaemonsToRestart(t *testing.T) {
+	cfg := Default()
+	cfg.RestartDaemons = map[string][]string{"MyApp.com": {"horizon", "42"}}
+
+	got := cfg.DaemonsToRestart("myapp.com")
+	if len(got) != 2 || got[0] != "horizon" || got[1] != "42" {
+		t.Errorf("DaemonsToRestart = %v, want [horizon 42]", got)
+	}
+	if got := cfg.DaemonsToRestart("other.com"); got != nil {
+		t.Errorf("DaemonsToRestart(other.com) = %v, want nil", got)
+	}
+}
+
+func TestHealthCheckURL(t *testing.T) {
+	cfg := Default()
+	cfg.HealthChecks = map[string]string{"MyApp.com": "https://myapp.com/up"}
+
+	if got := cfg.HealthCheckURL("myapp.com"); got != "https://myapp.com/up" {
+		t.Errorf("HealthCheckURL = %q, want https://myapp.com/up", got)
+	}
+	if got := cfg.HealthCheckURL("other.com"); got != "" {
+		t.Errorf("HealthCheckURL(other.com) = %q, want empty", got)
+	}
+}
+
+func TestAutoRollbackEnabled(t *testing.T) {
+	cfg := Default()
+	cfg.AutoRollback = map[string]bool{"MyApp.com": true, "off.com": false}
+
+	if !cfg.AutoRollbackEnabled("myapp.com") {
+		t.Error("AutoRollbackEnabled(myapp.com) = false, want true")
+	}
+	if cfg.AutoRollbackEnabled("off.com") || cfg.AutoRollbackEnabled("other.com") {
+		t.Error("AutoRollbackEnabled on an unconfigured or disabled site = true")
+	}
+}
+
 func TestSaveAndReload(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "subdir", "config.toml")
@@ -240,3 +317,341 @@ func TestLoadFromInvalidTOML(t *testing.T) {
 		t.Fatal("Expected error for invalid TOML, got nil")
 	}
 }
+
+func TestLoadFromUnknownKeys(t *testing.T) {
+	content := `
+[forge]
+api_key = "k"
+ssh_usr = "ubuntu"
+
+[editr]
+command = "nano"
+
+[nicknames.prod]
+server = "production"
+sit = "example.com"
+`
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+
+	want := []string{
+		`config.toml:4: unknown key "forge.ssh_usr" (did you mean "ssh_user"?)`,
+		`config.toml:6: unknown key "editr" (did you mean "editor"?)`,
+		`config.toml:11: unknown key "nicknames.prod.sit" (did you mean "site"?)`,
+	}
+	if len(cfg.Warnings) != len(want) {
+		t.Fatalf("got %d warnings %q, want %d", len(cfg.Warnings), cfg.Warnings, len(want))
+	}
+	for i := range want {
+		if cfg.Warnings[i] != want[i] {
+			t.Errorf("Warnings[%d] = %q, want %q", i, cfg.Warnings[i], want[i])
+		}
+	}
+}
+
+func TestLoadFromWrongType(t *testing.T) {
+	content := `
+[forge]
+ssh_user = 42
+`
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadFrom(path)
+	if err == nil {
+		t.Fatal("LoadFrom: expected error for wrong type, got nil")
+	}
+	if !strings.HasPrefix(err.Error(), "config.toml:3:") {
+		t.Errorf("error = %q, want position prefix config.toml:3:", err)
+	}
+}
+
+func TestFindProjectConfigWalksUp(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "app", "src", "http")
+	if err := os.MkdirAll(nested, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	want := filepath.Join(root, ".phorge")
+	if err := os.WriteFile(want, []byte("server = \"prod\"\nsite = \"example.com\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	got := FindProjectConfig(nested)
+	if got != want {
+		t.Fatalf("FindProjectConfig = %q, want %q", got, want)
+	}
+
+	cfg := LoadProjectConfigFrom(got)
+	if cfg.Server != "prod" || cfg.Site != "example.com" {
+		t.Errorf("LoadProjectConfigFrom = %+v, want prod/example.com", cfg)
+	}
+}
+
+func TestFindProjectConfigNearestWins(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "api")
+	if err := os.MkdirAll(nested, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range []string{root, nested} {
+		if err := os.WriteFile(filepath.Join(dir, ".phorge"), []byte("server = \"x\"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, want := FindProjectConfig(nested), filepath.Join(nested, ".phorge"); got != want {
+		t.Errorf("FindProjectConfig = %q, want %q", got, want)
+	}
+}
+
+func TestProjectConfigEnvironments(t *testing.T) {
+	content := `
+server = "prod-1"
+site = "example.com"
+
+[environments.production]
+server = "prod-1"
+site = "example.com"
+
+[environments.Staging]
+server = "staging-1"
+site = "staging.example.com"
+`
+	path := filepath.Join(t.TempDir(), ".phorge")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := LoadProjectConfigFrom(path)
+	if cfg.Server != "prod-1" {
+		t.Errorf("Server = %q, want %q", cfg.Server, "prod-1")
+	}
+
+	names := cfg.EnvironmentNames()
+	if len(names) != 2 || names[0] != "Staging" || names[1] != "production" {
+		t.Errorf("EnvironmentNames() = %v, want [Staging production]", names)
+	}
+
+	env, ok := cfg.Environment("staging")
+	if !ok {
+		t.Fatal("Environment(staging) not found")
+	}
+	if env.Server != "staging-1" || env.Site != "staging.example.com" {
+		t.Errorf("Environment(staging) = %+v, want staging-1/staging.example.com", env)
+	}
+
+	if _, ok := cfg.Environment("qa"); ok {
+		t.Error("Environment(qa) found, want not found")
+	}
+}
+
+func TestResolveAPIKey(t *testing.T) {
+	cfg := Default()
+	cfg.Forge.APIKeyCmd = "printf 'from-cmd\\nignored\\n'"
+	if err := cfg.ResolveAPIKey(); err != nil {
+		t.Fatalf("ResolveAPIKey: %v", err)
+	}
+	if cfg.Forge.APIKey != "from-cmd" {
+		t.Errorf("APIKey = %q, want %q", cfg.Forge.APIKey, "from-cmd")
+	}
+
+	// The fetched token is not written back to the file.
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := cfg.SaveTo(path); err != nil {
+		t.Fatalf("SaveTo: %v", err)
+	}
+	saved, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if saved.Forge.APIKey != "" {
+		t.Errorf("saved api_key = %q, want empty", saved.Forge.APIKey)
+	}
+	if saved.Forge.APIKeyCmd != cfg.Forge.APIKeyCmd {
+		t.Errorf("saved api_key_cmd = %q, want %q", saved.Forge.APIKeyCmd, cfg.Forge.APIKeyCmd)
+	}
+	if cfg.Forge.APIKey != "from-cmd" {
+		t.Error("SaveTo cleared the in-memory APIKey")
+	}
+}
+
+func TestResolveAPIKeyErrors(t *testing.T) {
+	for _, cmd := range []string{"echo locked >&2; exit 1", "true"} {
+		cfg := Default()
+		cfg.Forge.APIKey = "unchanged"
+		cfg.Forge.APIKeyCmd = cmd
+		if err := cfg.ResolveAPIKey(); err == nil {
+			t.Errorf("ResolveAPIKey(%q): expected error", cmd)
+		}
+		if cfg.Forge.APIKey != "unchanged" {
+			t.Errorf("ResolveAPIKey(%q) set APIKey to %q", cmd, cfg.Forge.APIKey)
+		}
+	}
+}
+
+func TestLoadFromUnknownTheme(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("[ui]\ntheme = \"neon\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], `unknown ui.theme "neon"`) {
+		t.Errorf("Warnings = %q, want one about ui.theme", cfg.Warnings)
+	}
+}
+
+func TestLoadFromUnknownTimezone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("[ui]\ntimezone = \"Mars/Olympus\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], `unknown ui.timezone "Mars/Olympus"`) {
+		t.Errorf("Warnings = %q, want one about ui.timezone", cfg.Warnings)
+	}
+}
+
+func TestLoadFromUnknownDisabledTab(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("[ui]\ndisabled_tabs = [\"Firewall\", \"shell\"]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if len(cfg.Warnings) != 1 || !strings.Contains(cfg.Warnings[0], `unknown tab "shell"`) {
+		t.Errorf("Warnings = %q, want one about the shell tab", cfg.Warnings)
+	}
+}
+
+func TestServerHidden(t *testing.T) {
+	cfg := Default()
+	cfg.HiddenServers = []string{"Old-Web", "42", "tag:decommissioned"}
+	tests := []struct {
+		name string
+		id   int64
+		tags []string
+		want bool
+	}{
+		{"old-web", 1, nil, true},
+		{"db", 42, nil, true},
+		{"worker", 7, []string{"Decommissioned"}, true},
+		{"web", 1, []string{"production"}, false},
+		{"decommissioned", 2, nil, false},
+	}
+	for _, tt := range tests {
+		if got := cfg.ServerHidden(tt.name, tt.id, tt.tags); got != tt.want {
+			t.Errorf("ServerHidden(%q, %d, %q) = %v, want %v", tt.name, tt.id, tt.tags, got, tt.want)
+		}
+	}
+}
+
+func TestHideUnhideServer(t *testing.T) {
+	cfg := Default()
+	cfg.HiddenServers = []string{"7", "tag:old"}
+	cfg.HideServer("web")
+	if !cfg.ServerHidden("web", 1, nil) {
+		t.Fatal("HideServer: server not hidden")
+	}
+	if !cfg.UnhideServer("web", 7) {
+		t.Fatal("UnhideServer reported nothing removed")
+	}
+	if want := []string{"tag:old"}; !slices.Equal(cfg.HiddenServers, want) {
+		t.Errorf("HiddenServers = %q, want %q", cfg.HiddenServers, want)
+	}
+	if cfg.UnhideServer("web", 7) {
+		t.Error("second UnhideServer reported a removal")
+	}
+	if !cfg.ServerHidden("web", 7, []string{"old"}) {
+		t.Error("UnhideServer removed a tag entry")
+	}
+}
+
+func TestWorkspace(t *testing.T) {
+	cfg := Default()
+	cfg.Workspaces = map[string][]string{
+		"API": {"production-1/api.example.com", " staging-1 / staging.api.example.com ", "worker-1", ""},
+	}
+	targets, ok := cfg.Workspace("api")
+	if !ok {
+		t.Fatal("Workspace(api) not found")
+	}
+	want := []WorkspaceTarget{
+		{"production-1", "api.example.com"},
+		{"staging-1", "staging.api.example.com"},
+		{"worker-1", ""},
+	}
+	if !slices.Equal(targets, want) {
+		t.Fatalf("Workspace(api) = %+v, want %+v", targets, want)
+	}
+	if _, ok := cfg.Workspace("web"); ok {
+		t.Error("Workspace(web) found")
+	}
+
+	tests := []struct {
+		server, site string
+		want         bool
+	}{
+		{"production-1", "", true},
+		{"PRODUCTION-1", "api.example.com", true},
+		{"production-1", "blog.example.com", false},
+		{"worker-1", "anything.example.com", true},
+		{"db-1", "", false},
+	}
+	for _, tt := range tests {
+		if got := Includes(targets, tt.server, tt.site); got != tt.want {
+			t.Errorf("Includes(%q, %q) = %v, want %v", tt.server, tt.site, got, tt.want)
+		}
+	}
+}
+
+func TestReservedNicknames(t *testing.T) {
+	content := `
+[nicknames.deploy]
+server = "production"
+
+[nicknames.prod]
+server = "production"
+site = "example.com"
+
+[nicknames.open]
+server = "staging"
+`
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if len(cfg.Warnings) != 2 || !strings.Contains(cfg.Warnings[0], `nickname "deploy"`) || !strings.Contains(cfg.Warnings[1], `nickname "open"`) {
+		t.Errorf("Warnings = %q, want one for each of deploy and open", cfg.Warnings)
+	}
+
+	for _, name := range []string{"deploy", "ssh-config", "__complete"} {
+		if !ReservedNickname(name) {
+			t.Errorf("ReservedNickname(%q) = false", name)
+		}
+	}
+	if ReservedNickname("prod") {
+		t.Error(`ReservedNickname("prod") = true`)
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeployLog is a deployment's output saved to disk, since Forge truncates
+// and eventually expires old outputs.
+type DeployLog struct {
+	Site    string
+	ID      int64
+	Path    string
+	ModTime time.Time
+}
+
+// DeployLogsDir returns the directory deployment outputs are saved under,
+// ~/.local/share/phorge/deploys unless XDG_DATA_HOME says otherwise.
+func DeployLogsDir() string {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "phorge", "deploys")
+}
+
+// SaveDeployLog writes the output of deployment id of site to
+// <dir>/<site>/<id>.log, replacing an earlier save, and returns its path.
+func SaveDeployLog(dir, site string, id int64, output string) (string, error) {
+	siteDir := filepath.Join(dir, deployLogSiteDir(site))
+	if err := os.MkdirAll(siteDir, 0o700); err != nil {
+		return "", fmt.Errorf("creating deploy log directory: %w", err)
+	}
+	path := filepath.Join(siteDir, strconv.FormatInt(id, 10)+".log")
+	if err := os.WriteFile(path, []byte(output), 0o600); err != nil {
+		return "", fmt.Errorf("writing deploy log: %w", err)
+	}
+	return path, nil
+}
+
+// ListDeployLogs returns the logs saved under dir for site, newest
+// deployment first. A site that has none yields an empty list.
+func ListDeployLogs(dir, site string) ([]DeployLog, error) {
+	siteDir := filepath.Join(dir, deployLogSiteDir(site))
+	entries, err := os.ReadDir(siteDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var logs []DeployLog
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".log")
+		id, err := strconv.ParseInt(name, 10, 64)
+		if !ok || err != nil || e.IsDir() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		logs = append(logs, DeployLog{
+			Site:    site,
+			ID:      id,
+			Path:    filepath.Join(siteDir, e.Name()),
+			ModTime: info.ModTime(),
+		})
+	}
+	slices.SortFunc(logs, func(a, b DeployLog) int { return cmp.Compare(b.ID, a.ID) })
+	return logs, nil
+}
+
+// deployLogSiteDir turns a site name into a directory name, in case it
+// holds a path separator.
+func deployLogSiteDir(site string) string {
+	return strings.NewReplacer("/", "_", `\`, "_").Replace(site)
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDeployLogs(t *testing.T) {
+	dir := t.TempDir()
+	if logs, err := ListDeployLogs(dir, "example.com"); err != nil || len(logs) != 0 {
+		t.Fatalf("ListDeployLogs before any save = %v, %v; want none", logs, err)
+	}
+
+	for _, id := range []int64{9, 120, 11} {
+		if _, err := SaveDeployLog(dir, "example.com", id, "deploy output"); err != nil {
+			t.Fatalf("SaveDeployLog: %v", err)
+		}
+	}
+	path, err := SaveDeployLog(dir, "example.com", 11, "full output")
+	if err != nil {
+		t.Fatalf("SaveDeployLog: %v", err)
+	}
+	if want := filepath.Join(dir, "example.com", "11.log"); path != want {
+		t.Errorf("path = %s, want %s", path, want)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "full output" {
+		t.Errorf("saved output = %q, want it replaced", data)
+	}
+	// Other files in the directory are ignored.
+	os.WriteFile(filepath.Join(dir, "example.com", "notes.txt"), nil, 0o600)
+
+	logs, err := ListDeployLogs(dir, "example.com")
+	if err != nil {
+		t.Fatalf("ListDeployLogs: %v", err)
+	}
+	var ids []int64
+	for _, l := range logs {
+		ids = append(ids, l.ID)
+	}
+	if len(ids) != 3 || ids[0] != 120 || ids[1] != 11 || ids[2] != 9 {
+		t.Errorf("ids = %v, want [120 11 9]", ids)
+	}
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ServerHidden reports whether an entry in HiddenServers matches the
+// server with the given name, ID and tags. Names and tags match
+// case-insensitively.
+func (c *Config) ServerHidden(name string, id int64, tags []string) bool {
+	for _, entry := range c.HiddenServers {
+		entry = strings.TrimSpace(entry)
+		if tag, ok := strings.CutPrefix(entry, "tag:"); ok {
+			tag = strings.TrimSpace(tag)
+			if slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+				return true
+			}
+			continue
+		}
+		if hidesServer(entry, name, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// HideServer adds the server's name to HiddenServers.
+func (c *Config) HideServer(name string) {
+	c.HiddenServers = append(c.HiddenServers, name)
+}
+
+// UnhideServer removes the HiddenServers entries naming the server by name
+// or ID, reporting whether there were any. Tag entries are left alone, so
+// the server may still be hidden by one of them.
+func (c *Config) UnhideServer(name string, id int64) bool {
+	n := len(c.HiddenServers)
+	c.HiddenServers = slices.DeleteFunc(c.HiddenServers, func(entry string) bool {
+		return hidesServer(strings.TrimSpace(entry), name, id)
+	})
+	return len(c.HiddenServers) < n
+}
+
+// hidesServer reports whether a name or ID entry matches the server.
+func hidesServer(entry, name string, id int64) bool {
+	if strings.EqualFold(entry, name) {
+		return true
+	}
+	n, err := strconv.ParseInt(entry, 10, 64)
+	return err == nil && n == id
+}
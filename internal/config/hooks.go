@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Hook events a [[hooks]] entry can subscribe to.
+const (
+	// HookDeployFinished fires when a deployment started from the TUI
+	// finishes or fails.
+	HookDeployFinished = "deploy_finished"
+
+	// HookPanelLoaded fires when a detail tab is opened.
+	HookPanelLoaded = "panel_loaded"
+)
+
+// HookEvents are the events a hook can subscribe to.
+var HookEvents = []string{HookDeployFinished, HookPanelLoaded}
+
+// Hook is a user script run when something happens in the TUI, for
+// extending Phorge without forking it. It is declared as a [[hooks]] table
+// with either a command or a Starlark script:
+//
+//	[[hooks]]
+//	event = "deploy_finished"
+//	command = "~/.config/phorge/hooks/notify-slack"
+//
+//	[[hooks]]
+//	event = "deploy_finished"
+//	script = "~/.config/phorge/hooks/retry.star"
+//
+// The command is run with sh -c and gets the event as JSON on stdin; the
+// script is run by Phorge's embedded interpreter. See the hooks package
+// for what either may send back.
+type Hook struct {
+	// Event is one of HookEvents.
+	Event string `toml:"event"`
+
+	// Command is run with sh -c each time the event fires.
+	Command string `toml:"command,omitempty"`
+
+	// Script is the path of a Starlark file run each time the event
+	// fires, instead of a command.
+	Script string `toml:"script,omitempty"`
+}
+
+// HooksFor returns the hooks subscribed to event that have a command or a
+// script.
+func (c *Config) HooksFor(event string) []Hook {
+	var hooks []Hook
+	for _, h := range c.Hooks {
+		if h.Event == event && (strings.TrimSpace(h.Command) != "" || strings.TrimSpace(h.Script) != "") {
+			hooks = append(hooks, h)
+		}
+	}
+	return hooks
+}
+
+// hookWarnings describes hooks without a command or script, with both, or
+// with an unknown event.
+func hookWarnings(file string, hooks []Hook) []string {
+	var warnings []string
+	for i, h := range hooks {
+		hasCommand, hasScript := strings.TrimSpace(h.Command) != "", strings.TrimSpace(h.Script) != ""
+		switch {
+		case !hasCommand && !hasScript:
+			warnings = append(warnings, fmt.Sprintf("%s: hook #%d has no command or script", file, i+1))
+		case hasCommand && hasScript:
+			warnings = append(warnings, fmt.Sprintf("%s: hook #%d has both a command and a script; only the script is run", file, i+1))
+		}
+		if !slices.Contains(HookEvents, h.Event) {
+			warnings = append(warnings, fmt.Sprintf("%s: hook #%d has unknown event %q (known: %s)",
+				file, i+1, h.Event, strings.Join(HookEvents, ", ")))
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadFromHooks(t *testing.T) {
+	content := `
+[[hooks]]
+event = "deploy_finished"
+command = "notify-slack"
+
+[[hooks]]
+event = "deploy_failed"
+command = "page-someone"
+
+[[hooks]]
+event = "panel_loaded"
+
+[[hooks]]
+event = "deploy_finished"
+script = "retry.star"
+`
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if got := cfg.HooksFor(HookDeployFinished); len(got) != 2 || got[0].Command != "notify-slack" || got[1].Script != "retry.star" {
+		t.Errorf("HooksFor(deploy_finished) = %q", got)
+	}
+	if got := cfg.HooksFor(HookPanelLoaded); len(got) != 0 {
+		t.Errorf("HooksFor(panel_loaded) = %q, want none for a hook without a command", got)
+	}
+	want := []string{`unknown event "deploy_failed"`, "hook #3 has no command or script"}
+	if len(cfg.Warnings) != len(want) {
+		t.Fatalf("Warnings = %q, want %d", cfg.Warnings, len(want))
+	}
+	for i, w := range want {
+		if !strings.Contains(cfg.Warnings[i], w) {
+			t.Errorf("Warnings[%d] = %q, want it to mention %q", i, cfg.Warnings[i], w)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaintenanceWindow is a weekly period in which an environment may be
+// deployed without an override, such as "mon-fri 22:00-06:00". A window
+// that ends at or before its start runs past midnight, into the day after
+// each of its days.
+type MaintenanceWindow struct {
+	Days       [7]bool // indexed by time.Weekday
+	Start, End int     // minutes after midnight
+	text       string
+}
+
+// String returns the window as it was written.
+func (w MaintenanceWindow) String() string {
+	return w.text
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseMaintenanceWindow parses a window written as an optional list of
+// days and day ranges followed by a time range, e.g. "22:00-02:00" (every
+// day), "sat,sun 00:00-23:59" or "mon-fri 22:00-06:00".
+func ParseMaintenanceWindow(text string) (MaintenanceWindow, error) {
+	w := MaintenanceWindow{text: strings.TrimSpace(text)}
+	fields := strings.Fields(strings.ToLower(text))
+	var days, times string
+	switch len(fields) {
+	case 1:
+		days, times = "mon-sun", fields[0]
+	case 2:
+		days, times = fields[0], fields[1]
+	default:
+		return w, fmt.Errorf("maintenance window %q: expected [days] HH:MM-HH:MM, e.g. \"mon-fri 22:00-06:00\"", text)
+	}
+
+	for _, part := range strings.Split(days, ",") {
+		from, to, isRange := strings.Cut(part, "-")
+		first, ok1 := weekdays[from]
+		last, ok2 := weekdays[to]
+		if !isRange {
+			last, ok2 = first, ok1
+		}
+		if !ok1 || !ok2 {
+			return w, fmt.Errorf("maintenance window %q: unknown day %q (use mon, tue, ... sun)", text, part)
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			w.Days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+
+	start, end, ok := strings.Cut(times, "-")
+	var err error
+	if w.Start, err = parseMinutes(start); ok && err == nil {
+		w.End, err = parseMinutes(end)
+	}
+	if !ok || err != nil {
+		return w, fmt.Errorf("maintenance window %q: expected a time range like 22:00-06:00", text)
+	}
+	return w, nil
+}
+
+// parseMinutes parses "HH:MM" as minutes after midnight.
+func parseMinutes(s string) (int, error) {
+	hh, mm, ok := strings.Cut(s, ":")
+	h, herr := strconv.Atoi(hh)
+	m, merr := strconv.Atoi(mm)
+	if !ok || herr != nil || merr != nil || h < 0 || h > 23 || m < 0 || m > 59 || len(mm) != 2 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// Contains reports whether t, in its own location, falls in the window.
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	now := t.Hour()*60 + t.Minute()
+	day := t.Weekday()
+	if w.End > w.Start {
+		return w.Days[day] && now >= w.Start && now < w.End
+	}
+	// Past midnight: either the evening part of one of its days or the
+	// morning part of the day after one.
+	return (w.Days[day] && now >= w.Start) || (w.Days[(day+6)%7] && now < w.End)
+}
+
+// MaintenanceWindows parses the environment's maintenance windows.
+func (e ProjectEnvironment) MaintenanceWindows() ([]MaintenanceWindow, error) {
+	windows := make([]MaintenanceWindow, 0, len(e.Maintenance))
+	for _, text := range e.Maintenance {
+		w, err := ParseMaintenanceWindow(text)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, w)
+	}
+	return windows, nil
+}
+
+// WindowCheck is the outcome of checking a deploy against the maintenance
+// windows of the environment it targets.
+type WindowCheck struct {
+	// Environment is the name of the environment the site belongs to, or
+	// "" when it belongs to none with maintenance windows.
+	Environment string
+
+	// Windows are the environment's windows.
+	Windows []MaintenanceWindow
+
+	// Outside is set when the deploy falls outside every window and so
+	// needs an override.
+	Outside bool
+}
+
+// Describe lists the windows, e.g. "mon-fri 22:00-06:00, sat 10:00-12:00".
+func (c WindowCheck) Describe() string {
+	texts := make([]string, len(c.Windows))
+	for i, w := range c.Windows {
+		texts[i] = w.String()
+	}
+	return strings.Join(texts, ", ")
+}
+
+// CheckMaintenanceWindow checks a deploy of site on server at t against
+// the maintenance windows of the .phorge environment that targets it
+// (names compare case-insensitively; an environment without a server
+// matches any). Sites outside every environment with windows may always be
+// deployed. An unparsable window is reported as an error rather than
+// treated as open.
+func (p ProjectConfig) CheckMaintenanceWindow(server, site string, t time.Time) (WindowCheck, error) {
+	for _, name := range p.EnvironmentNames() {
+		env := p.Environments[name]
+		if len(env.Maintenance) == 0 || !strings.EqualFold(env.Site, site) {
+			continue
+		}
+		if env.Server != "" && server != "" && !strings.EqualFold(env.Server, server) {
+			continue
+		}
+		windows, err := env.MaintenanceWindows()
+		if err != nil {
+			return WindowCheck{Environment: name}, fmt.Errorf("environment %s: %w", name, err)
+		}
+		c := WindowCheck{Environment: name, Windows: windows, Outside: true}
+		for _, w := range windows {
+			if w.Contains(t) {
+				c.Outside = false
+				break
+			}
+		}
+		return c, nil
+	}
+	return WindowCheck{}, nil
+}
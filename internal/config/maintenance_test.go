@@ -0,0 +1,100 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaintenanceWindowContains(t *testing.T) {
+	// 2026-03-13 is a Friday.
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 3, day, hour, min, 0, 0, time.UTC) }
+	tests := []struct {
+		window string
+		t      time.Time
+		want   bool
+	}{
+		{"22:00-23:00", at(13, 22, 30), true},
+		{"22:00-23:00", at(13, 23, 0), false},
+		{"mon-fri 09:00-17:00", at(13, 9, 0), true},
+		{"mon-fri 09:00-17:00", at(14, 10, 0), false}, // Saturday
+		{"sat,sun 00:00-23:59", at(15, 12, 0), true},
+		{"fri 22:00-02:00", at(13, 23, 0), true},
+		{"fri 22:00-02:00", at(14, 1, 59), true}, // Saturday morning
+		{"fri 22:00-02:00", at(14, 2, 0), false},
+		{"fri 22:00-02:00", at(13, 1, 0), false},      // Friday morning belongs to Thursday
+		{"sat-mon 10:00-11:00", at(16, 10, 30), true}, // wraps past Sunday
+	}
+	for _, tt := range tests {
+		w, err := ParseMaintenanceWindow(tt.window)
+		if err != nil {
+			t.Fatalf("ParseMaintenanceWindow(%q): %v", tt.window, err)
+		}
+		if got := w.Contains(tt.t); got != tt.want {
+			t.Errorf("%q.Contains(%s) = %v, want %v", tt.window, tt.t.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestParseMaintenanceWindowErrors(t *testing.T) {
+	for _, text := range []string{"", "22:00", "funday 22:00-23:00", "mon 25:00-26:00", "mon fri 22:00-23:00"} {
+		if _, err := ParseMaintenanceWindow(text); err == nil {
+			t.Errorf("ParseMaintenanceWindow(%q) = nil error, want one", text)
+		}
+	}
+}
+
+func TestCheckMaintenanceWindow(t *testing.T) {
+	p := ProjectConfig{Environments: map[string]ProjectEnvironment{
+		"production": {Server: "prod-1", Site: "example.com", Maintenance: []string{"mon-fri 22:00-06:00"}},
+		"staging":    {Server: "staging-1", Site: "staging.example.com"},
+	}}
+	friday := time.Date(2026, 3, 13, 12, 0, 0, 0, time.UTC)
+
+	c, err := p.CheckMaintenanceWindow("Prod-1", "Example.com", friday)
+	if err != nil || c.Environment != "production" || !c.Outside {
+		t.Errorf("production at noon = %+v, %v; want outside its window", c, err)
+	}
+	c, _ = p.CheckMaintenanceWindow("prod-1", "example.com", friday.Add(11*time.Hour))
+	if c.Outside {
+		t.Error("production at 23:00 is outside its window, want inside")
+	}
+	if c, _ := p.CheckMaintenanceWindow("staging-1", "staging.example.com", friday); c.Outside || c.Environment != "" {
+		t.Errorf("staging = %+v, want no window", c)
+	}
+	if c, _ := p.CheckMaintenanceWindow("other", "example.com", friday); c.Outside {
+		t.Error("example.com on another server needs an override, want none")
+	}
+
+	p.Environments["production"] = ProjectEnvironment{Site: "example.com", Maintenance: []string{"whenever"}}
+	if _, err := p.CheckMaintenanceWindow("prod-1", "example.com", friday); err == nil {
+		t.Error("an invalid window gave no error")
+	}
+}
+
+func TestAppendAudit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phorge", AuditFileName)
+	for _, reason := range []string{"hotfix for checkout", "second"} {
+		if err := AppendAuditTo(path, AuditEntry{Action: "deploy", Site: "example.com", Reason: reason, Via: "tui"}); err != nil {
+			t.Fatalf("AppendAuditTo: %v", err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("audit log has %d lines, want 2", len(lines))
+	}
+	var e AuditEntry
+	if err := json.Unmarshal([]byte(lines[0]), &e); err != nil {
+		t.Fatal(err)
+	}
+	if e.Reason != "hotfix for checkout" || e.Time.IsZero() {
+		t.Errorf("first entry = %+v, want the reason and a time", e)
+	}
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hinkers/Phorge/internal/store"
+)
+
+// State holds UI session state that is remembered between runs but is not
+// user configuration, such as which tree nodes were expanded.
+type State struct {
+	// Expanded lists the IDs of servers expanded in the tree.
+	Expanded []int64 `json:"expanded,omitempty"`
+
+	// Recent lists recently visited sites, most recent first.
+	Recent []RecentSite `json:"recent,omitempty"`
+
+	// TreeFilter is the filter applied to the server tree.
+	TreeFilter string `json:"tree_filter,omitempty"`
+
+	// TreeByApp is set while the tree groups sites by application.
+	TreeByApp bool `json:"tree_by_app,omitempty"`
+
+	// Workspace is the workspace the tree is narrowed to.
+	Workspace string `json:"workspace,omitempty"`
+
+	// Usage counts how often each action has been used, keyed by its
+	// description, for the help modal's most used section. Like the rest
+	// of the state it only ever lives on this machine.
+	Usage map[string]ActionUse `json:"usage,omitempty"`
+
+	// InputHistory holds the values last submitted to input dialogs that
+	// remember them, by dialog ID, oldest first, for recall with up/down.
+	InputHistory map[string][]string `json:"input_history,omitempty"`
+}
+
+// ActionUse is how often an action has been used and the key that runs it.
+type ActionUse struct {
+	Action string `json:"-"` // filled in by MostUsed
+	Key    string `json:"key"`
+	Count  int    `json:"count"`
+}
+
+// RecentSite identifies a recently visited site.
+type RecentSite struct {
+	ServerID int64 `json:"server_id"`
+	SiteID   int64 `json:"site_id"`
+}
+
+// MaxRecent is the number of sites kept in State.Recent.
+const MaxRecent = 5
+
+// AddRecent moves the given site to the front of the recent list,
+// dropping the oldest entry once the list exceeds MaxRecent.
+func (s *State) AddRecent(serverID, siteID int64) {
+	recent := []RecentSite{{ServerID: serverID, SiteID: siteID}}
+	for _, r := range s.Recent {
+		if r.SiteID == siteID {
+			continue
+		}
+		if len(recent) == MaxRecent {
+			break
+		}
+		recent = append(recent, r)
+	}
+	s.Recent = recent
+}
+
+// RecordUse counts one use of action, run by pressing key.
+func (s *State) RecordUse(action, key string) {
+	if s.Usage == nil {
+		s.Usage = make(map[string]ActionUse)
+	}
+	u := s.Usage[action]
+	u.Key = key
+	u.Count++
+	s.Usage[action] = u
+}
+
+// MostUsed returns up to n of the actions used most, most used first.
+func (s *State) MostUsed(n int) []ActionUse {
+	uses := make([]ActionUse, 0, len(s.Usage))
+	for action, u := range s.Usage {
+		u.Action = action
+		uses = append(uses, u)
+	}
+	slices.SortFunc(uses, func(a, b ActionUse) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Action, b.Action)
+	})
+	if len(uses) > n {
+		uses = uses[:n]
+	}
+	return uses
+}
+
+// StorePath returns the path to the local database holding state and
+// caches, next to config.toml.
+func StorePath() string {
+	return filepath.Join(filepath.Dir(DefaultPath()), store.FileName)
+}
+
+// LoadState reads the state from the default store. A missing or
+// unreadable store yields an empty State — losing session state is never
+// fatal.
+func LoadState() *State {
+	s, err := LoadStateFrom(StorePath())
+	if err != nil {
+		return &State{}
+	}
+	return s
+}
+
+// LoadStateFrom reads the state from the store at the given path.
+// If no state has been saved, it returns an empty State (no error).
+func LoadStateFrom(path string) (*State, error) {
+	s := &State{}
+	if _, err := store.Load(path, store.BucketState, "session", s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save writes the state to the default store.
+func (s *State) Save() error {
+	return s.SaveTo(StorePath())
+}
+
+// SaveTo writes the state to the store at the given path, creating it and
+// its parent directories if needed.
+func (s *State) SaveTo(path string) error {
+	return store.Save(path, store.BucketState, "session", s)
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestLoadStateFromMissingFile(t *testing.T) {
+	s, err := LoadStateFrom(filepath.Join(t.TempDir(), "phorge.db"))
+	if err != nil {
+		t.Fatalf("LoadStateFrom missing file: %v", err)
+	}
+	if len(s.Expanded) != 0 {
+		t.Errorf("Expanded = %v, want empty", s.Expanded)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "phorge.db")
+
+	orig := &State{Expanded: []int64{3, 17}}
+	if err := orig.SaveTo(path); err != nil {
+		t.Fatalf("SaveTo: %v", err)
+	}
+
+	got, err := LoadStateFrom(path)
+	if err != nil {
+		t.Fatalf("LoadStateFrom: %v", err)
+	}
+	if !slices.Equal(got.Expanded, orig.Expanded) {
+		t.Errorf("Expanded = %v, want %v", got.Expanded, orig.Expanded)
+	}
+}
+
+func TestStateAddRecent(t *testing.T) {
+	s := &State{}
+	for id := int64(1); id <= MaxRecent+2; id++ {
+		s.AddRecent(100, id)
+	}
+	if len(s.Recent) != MaxRecent {
+		t.Fatalf("len(Recent) = %d, want %d", len(s.Recent), MaxRecent)
+	}
+	if s.Recent[0].SiteID != MaxRecent+2 {
+		t.Errorf("Recent[0].SiteID = %d, want %d", s.Recent[0].SiteID, MaxRecent+2)
+	}
+
+	// Revisiting moves an entry to the front without duplicating it.
+	s.AddRecent(100, 5)
+	if s.Recent[0].SiteID != 5 || len(s.Recent) != MaxRecent {
+		t.Errorf("after revisit Recent = %v", s.Recent)
+	}
+	for _, r := range s.Recent[1:] {
+		if r.SiteID == 5 {
+			t.Errorf("site 5 duplicated in %v", s.Recent)
+		}
+	}
+}
+
+func TestLoadStateFromCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phorge.db")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadStateFrom(path); err == nil {
+		t.Error("LoadStateFrom corrupt file: expected error")
+	}
+}
+
+func TestStateMostUsed(t *testing.T) {
+	s := &State{}
+	for range 3 {
+		s.RecordUse("Deploy: deploy", "d")
+	}
+	s.RecordUse("ssh", "ctrl+s")
+	s.RecordUse("refresh", "ctrl+r")
+	s.RecordUse("refresh", "ctrl+r")
+	s.RecordUse("about", "A")
+
+	got := s.MostUsed(3)
+	want := []ActionUse{
+		{Action: "Deploy: deploy", Key: "d", Count: 3},
+		{Action: "refresh", Key: "ctrl+r", Count: 2},
+		{Action: "about", Key: "A", Count: 1},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("MostUsed(3) = %+v, want %+v", got, want)
+	}
+	if got := (&State{}).MostUsed(3); len(got) != 0 {
+		t.Errorf("MostUsed on empty state = %+v, want none", got)
+	}
+}
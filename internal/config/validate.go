@@ -0,0 +1,143 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// unknownKeyWarnings decodes data strictly and returns one human-readable
+// warning per key that does not map to a Config field, with a "did you
+// mean" suggestion when a known key is a close match.
+func unknownKeyWarnings(path string, data []byte) []string {
+	err := toml.NewDecoder(bytes.NewReader(data)).DisallowUnknownFields().Decode(Default())
+	var strict *toml.StrictMissingError
+	if !errors.As(err, &strict) {
+		return nil
+	}
+
+	var warnings []string
+	for _, e := range strict.Errors {
+		keyPath := []string(e.Key())
+		if len(keyPath) == 0 {
+			continue
+		}
+		row, _ := e.Position()
+		w := fmt.Sprintf("%s:%d: unknown key %q", filepath.Base(path), row, strings.Join(keyPath, "."))
+		parent, name := keyPath[:len(keyPath)-1], keyPath[len(keyPath)-1]
+		if s := suggestKey(name, knownKeys(reflect.TypeOf(Config{}), parent)); s != "" {
+			w += fmt.Sprintf(" (did you mean %q?)", s)
+		}
+		warnings = append(warnings, w)
+	}
+	return warnings
+}
+
+// describeDecodeError adds the file name and position to TOML syntax and
+// type errors, e.g. "config.toml:3:12: toml: cannot decode ...".
+func describeDecodeError(path string, err error) error {
+	var de *toml.DecodeError
+	if errors.As(err, &de) {
+		row, col := de.Position()
+		return fmt.Errorf("%s:%d:%d: %w", filepath.Base(path), row, col, err)
+	}
+	return fmt.Errorf("%s: %w", filepath.Base(path), err)
+}
+
+// knownKeys returns the TOML keys accepted at the given table path within t.
+// Map levels (e.g. nickname names) accept any key and are skipped over.
+func knownKeys(t reflect.Type, path []string) []string {
+	for _, seg := range path {
+		t = deref(t)
+		switch t.Kind() {
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			f, ok := fieldByTag(t, seg)
+			if !ok {
+				return nil
+			}
+			t = f.Type
+		default:
+			return nil
+		}
+	}
+
+	t = deref(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		if name := tagName(t.Field(i)); name != "" && name != "-" {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+// fieldByTag finds the struct field whose toml tag name is name.
+func fieldByTag(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if tagName(t.Field(i)) == name {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// tagName returns the key name from a field's toml tag.
+func tagName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+	return name
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// suggestKey returns the candidate closest to name by edit distance, or ""
+// when nothing is close enough to be a plausible typo.
+func suggestKey(name string, candidates []string) string {
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := levenshtein(strings.ToLower(name), c)
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	// Allow roughly one edit per three characters.
+	if bestDist < 0 || bestDist > max(2, len(name)/3) {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
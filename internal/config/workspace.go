@@ -0,0 +1,66 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// WorkspaceTarget is one entry of a workspace: a whole server when Site is
+// empty, otherwise one site on it.
+type WorkspaceTarget struct {
+	Server string
+	Site   string
+}
+
+// Workspace returns the targets of the named workspace (case-insensitive),
+// or false if it is not declared. Blank entries are skipped.
+func (c *Config) Workspace(name string) ([]WorkspaceTarget, bool) {
+	entries, ok := c.Workspaces[name]
+	if !ok {
+		for n, e := range c.Workspaces {
+			if strings.EqualFold(n, name) {
+				entries, ok = e, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return nil, false
+	}
+
+	targets := make([]WorkspaceTarget, 0, len(entries))
+	for _, entry := range entries {
+		server, site, _ := strings.Cut(entry, "/")
+		server, site = strings.TrimSpace(server), strings.TrimSpace(site)
+		if server == "" {
+			continue
+		}
+		targets = append(targets, WorkspaceTarget{Server: server, Site: site})
+	}
+	return targets, true
+}
+
+// WorkspaceNames returns the declared workspace names in sorted order.
+func (c *Config) WorkspaceNames() []string {
+	names := make([]string, 0, len(c.Workspaces))
+	for n := range c.Workspaces {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Includes reports whether the targets cover the named server and site
+// (case-insensitive). With an empty site it reports whether any target is
+// on the server.
+func Includes(targets []WorkspaceTarget, server, site string) bool {
+	for _, t := range targets {
+		if !strings.EqualFold(t.Server, server) {
+			continue
+		}
+		if site == "" || t.Site == "" || strings.EqualFold(t.Site, site) {
+			return true
+		}
+	}
+	return false
+}
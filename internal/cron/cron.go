@@ -0,0 +1,178 @@
+// Package cron describes cron schedules in plain English, e.g. "*/5 * * * *"
+// as "every 5 minutes", so scheduled jobs are readable at a glance.
+//
+// It understands the common shapes of five-field expressions and the
+// @daily style shorthands. Anything else is left for the caller to show
+// as the raw expression.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var shorthands = map[string]string{
+	"@yearly":   "yearly on Jan 1 at 00:00",
+	"@annually": "yearly on Jan 1 at 00:00",
+	"@monthly":  "monthly on the 1st at 00:00",
+	"@weekly":   "weekly on Sun at 00:00",
+	"@daily":    "daily at 00:00",
+	"@midnight": "daily at 00:00",
+	"@hourly":   "hourly at :00",
+	"@reboot":   "at reboot",
+}
+
+var weekdays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+var months = []string{"", "Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"}
+
+// Describe returns a plain-English description of expr, or false if the
+// expression is malformed or too unusual to describe.
+func Describe(expr string) (string, bool) {
+	expr = strings.TrimSpace(expr)
+	if s, ok := shorthands[strings.ToLower(expr)]; ok {
+		return s, true
+	}
+
+	f := strings.Fields(expr)
+	if len(f) != 5 {
+		return "", false
+	}
+	minute, hour, dom, month, dow := f[0], f[1], f[2], f[3], f[4]
+
+	// Sub-hourly and hourly schedules, every day.
+	if dom == "*" && month == "*" && dow == "*" {
+		switch {
+		case minute == "*" && hour == "*":
+			return "every minute", true
+		case hour == "*":
+			if n, ok := step(minute, 59); ok {
+				return every(n, "minute"), true
+			}
+			if m, ok := number(minute, 0, 59); ok {
+				return fmt.Sprintf("hourly at :%02d", m), true
+			}
+			return "", false
+		}
+		if n, ok := step(hour, 23); ok {
+			if m, ok := number(minute, 0, 59); ok {
+				return fmt.Sprintf("%s at :%02d", every(n, "hour"), m), true
+			}
+			return "", false
+		}
+	}
+
+	at, ok := times(minute, hour)
+	if !ok {
+		return "", false
+	}
+
+	switch {
+	case dom == "*" && month == "*" && dow == "*":
+		return "daily at " + at, true
+	case dom == "*" && month == "*":
+		days, ok := list(dow, 0, 7, func(n int) string { return weekdays[n%7] })
+		if !ok {
+			return "", false
+		}
+		return days + " at " + at, true
+	case dow == "*" && month == "*":
+		days, ok := list(dom, 1, 31, ordinal)
+		if !ok {
+			return "", false
+		}
+		return "monthly on the " + days + " at " + at, true
+	case dow == "*":
+		d, ok := number(dom, 1, 31)
+		if !ok {
+			return "", false
+		}
+		mons, ok := list(month, 1, 12, func(n int) string { return months[n] })
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("yearly on %s %d at %s", mons, d, at), true
+	}
+	return "", false
+}
+
+// times describes a fixed minute with one or more hours, e.g. "09:00" or
+// "09:30, 17:30".
+func times(minute, hour string) (string, bool) {
+	m, ok := number(minute, 0, 59)
+	if !ok {
+		return "", false
+	}
+	var out []string
+	for _, part := range strings.Split(hour, ",") {
+		h, ok := number(part, 0, 23)
+		if !ok {
+			return "", false
+		}
+		out = append(out, fmt.Sprintf("%02d:%02d", h, m))
+	}
+	return strings.Join(out, ", "), true
+}
+
+// list describes a field made of values and ranges, e.g. "1-5" as
+// "Mon-Fri" or "1,15" as "1st, 15th".
+func list(field string, lo, hi int, name func(int) string) (string, bool) {
+	var out []string
+	for _, part := range strings.Split(field, ",") {
+		if from, to, isRange := strings.Cut(part, "-"); isRange {
+			a, ok1 := number(from, lo, hi)
+			b, ok2 := number(to, lo, hi)
+			if !ok1 || !ok2 || a > b {
+				return "", false
+			}
+			out = append(out, name(a)+"-"+name(b))
+			continue
+		}
+		n, ok := number(part, lo, hi)
+		if !ok {
+			return "", false
+		}
+		out = append(out, name(n))
+	}
+	return strings.Join(out, ", "), true
+}
+
+// step parses "*/n" with 1 <= n <= hi.
+func step(field string, hi int) (int, bool) {
+	s, ok := strings.CutPrefix(field, "*/")
+	if !ok {
+		return 0, false
+	}
+	return number(s, 1, hi)
+}
+
+// number parses a plain number within [lo, hi].
+func number(s string, lo, hi int) (int, bool) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < lo || n > hi {
+		return 0, false
+	}
+	return n, true
+}
+
+func every(n int, unit string) string {
+	if n == 1 {
+		return "every " + unit
+	}
+	return fmt.Sprintf("every %d %ss", n, unit)
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
@@ -0,0 +1,56 @@
+package cron
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"* * * * *", "every minute"},
+		{"*/5 * * * *", "every 5 minutes"},
+		{"*/1 * * * *", "every minute"},
+		{"15 * * * *", "hourly at :15"},
+		{"0 */6 * * *", "every 6 hours at :00"},
+		{"0 2 * * *", "daily at 02:00"},
+		{"30 9,17 * * *", "daily at 09:30, 17:30"},
+		{"0 9 * * 1-5", "Mon-Fri at 09:00"},
+		{"0 0 * * 0", "Sun at 00:00"},
+		{"0 0 * * 7", "Sun at 00:00"},
+		{"0 3 1 * *", "monthly on the 1st at 03:00"},
+		{"0 3 1,15 * *", "monthly on the 1st, 15th at 03:00"},
+		{"0 0 25 12 *", "yearly on Dec 25 at 00:00"},
+		{"@daily", "daily at 00:00"},
+		{"@REBOOT", "at reboot"},
+	}
+	for _, tt := range tests {
+		got, ok := Describe(tt.expr)
+		if !ok || got != tt.want {
+			t.Errorf("Describe(%q) = %q, %v; want %q, true", tt.expr, got, ok, tt.want)
+		}
+	}
+}
+
+func TestDescribeUnsupported(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"61 * * * *",
+		"*/15 9-17 * * *",
+		"0 2 1 * 1",
+		"0 0 5-1 * *",
+		"nonsense",
+	} {
+		if got, ok := Describe(expr); ok {
+			t.Errorf("Describe(%q) = %q, want unsupported", expr, got)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 31: "31st"} {
+		if got := ordinal(n); got != want {
+			t.Errorf("ordinal(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 // List returns all SSL certificates for a site.
@@ -43,6 +44,28 @@ func (s *CertificatesService) CreateLetsEncrypt(ctx context.Context, serverID, s
 	return &resp.Certificate, nil
 }
 
+// Install installs an existing certificate and its private key, both PEM
+// encoded, on a site. Like any new certificate it isn't active until
+// activated.
+func (s *CertificatesService) Install(ctx context.Context, serverID, siteID int64, certificate, key string) (*Certificate, error) {
+	body := map[string]string{"type": "existing", "certificate": certificate, "key": key}
+	var resp struct {
+		Certificate Certificate `json:"certificate"`
+	}
+	path := fmt.Sprintf("/servers/%d/sites/%d/certificates", serverID, siteID)
+	err := s.client.do(ctx, http.MethodPost, path, body, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Certificate, nil
+}
+
+// Renew requests early renewal of a Let's Encrypt certificate.
+func (s *CertificatesService) Renew(ctx context.Context, serverID, siteID, certID int64) error {
+	path := fmt.Sprintf("/servers/%d/sites/%d/certificates/%d/renew", serverID, siteID, certID)
+	return s.client.do(ctx, http.MethodPost, path, nil, nil)
+}
+
 // Activate activates an SSL certificate.
 func (s *CertificatesService) Activate(ctx context.Context, serverID, siteID, certID int64) error {
 	path := fmt.Sprintf("/servers/%d/sites/%d/certificates/%d/activate", serverID, siteID, certID)
@@ -54,3 +77,47 @@ func (s *CertificatesService) Delete(ctx context.Context, serverID, siteID, cert
 	path := fmt.Sprintf("/servers/%d/sites/%d/certificates/%d", serverID, siteID, certID)
 	return s.client.do(ctx, http.MethodDelete, path, nil, nil)
 }
+
+// Domains returns the names the certificate covers. Forge reports them as
+// a comma-separated list in Domain.
+func (c Certificate) Domains() []string {
+	var domains []string
+	for _, d := range strings.Split(c.Domain, ",") {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+// Covers reports whether the certificate is valid for host. A wildcard
+// name such as *.example.com covers exactly one extra label.
+func (c Certificate) Covers(host string) bool {
+	host = strings.ToLower(strings.TrimSpace(host))
+	for _, d := range c.Domains() {
+		if d == host {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(d, "*."); ok {
+			if label, rest, found := strings.Cut(host, "."); found && label != "" && rest == suffix {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Active returns the site's active certificate with its details, or nil
+// when no certificate is active.
+func (s *CertificatesService) Active(ctx context.Context, serverID, siteID int64) (*Certificate, error) {
+	certs, err := s.List(ctx, serverID, siteID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range certs {
+		if c.Active {
+			return s.Get(ctx, serverID, siteID, c.ID)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,53 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// List returns the account's circles with their members and servers.
+func (s *CirclesService) List(ctx context.Context) ([]Circle, error) {
+	var resp struct {
+		Circles []Circle `json:"circles"`
+	}
+	err := s.client.do(ctx, http.MethodGet, "/circles", nil, &resp)
+	return resp.Circles, err
+}
+
+// ForServer returns the circles that grant access to a server.
+func (s *CirclesService) ForServer(ctx context.Context, serverID int64) ([]Circle, error) {
+	circles, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var out []Circle
+	for _, c := range circles {
+		if c.HasServer(serverID) {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+// Invite invites a user to a circle by email address. They become a
+// member once they accept.
+func (s *CirclesService) Invite(ctx context.Context, circleID int64, email string) (*CircleMember, error) {
+	body := map[string]string{"email": email}
+	var resp struct {
+		Member CircleMember `json:"member"`
+	}
+	path := fmt.Sprintf("/circles/%d/members", circleID)
+	err := s.client.do(ctx, http.MethodPost, path, body, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Member, nil
+}
+
+// RemoveMember removes a member, or withdraws an invitation, from a
+// circle.
+func (s *CirclesService) RemoveMember(ctx context.Context, circleID, memberID int64) error {
+	path := fmt.Sprintf("/circles/%d/members/%d", circleID, memberID)
+	return s.client.do(ctx, http.MethodDelete, path, nil, nil)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -18,6 +19,19 @@ type Client struct {
 	token   string
 	http    *http.Client
 
+	// fallbackToken, if set, is retried for reads the primary token is
+	// rate limited on. See WithFallbackToken.
+	fallbackToken string
+
+	// schemaLog, if set, records responses that don't match the types
+	// they are decoded into. See WithSchemaLog.
+	schemaLog *schemaLog
+
+	// OnAuthError, if set, is called with every AuthenticationError a
+	// request returns, e.g. to prompt for a new token once the current
+	// one has been revoked or has expired.
+	OnAuthError func(error)
+
 	// Services
 	Servers      *ServersService
 	Sites        *SitesService
@@ -35,6 +49,12 @@ type Client struct {
 	Git          *GitService
 	Logs         *LogsService
 	Events       *EventsService
+	Redirects    *RedirectsService
+	Circles      *CirclesService
+	Credentials  *CredentialsService
+	Nginx        *NginxService
+	Recipes      *RecipesService
+	Security     *SecurityRulesService
 }
 
 // Service types -- each holds a back-pointer to the parent Client.
@@ -55,6 +75,12 @@ type CommandsService struct{ client *Client }
 type GitService struct{ client *Client }
 type LogsService struct{ client *Client }
 type EventsService struct{ client *Client }
+type RedirectsService struct{ client *Client }
+type CirclesService struct{ client *Client }
+type CredentialsService struct{ client *Client }
+type NginxService struct{ client *Client }
+type RecipesService struct{ client *Client }
+type SecurityRulesService struct{ client *Client }
 
 // NewClient creates a new Forge API client authenticated with the given token.
 func NewClient(token string) *Client {
@@ -80,10 +106,67 @@ func NewClient(token string) *Client {
 	c.Git = &GitService{client: c}
 	c.Logs = &LogsService{client: c}
 	c.Events = &EventsService{client: c}
+	c.Redirects = &RedirectsService{client: c}
+	c.Circles = &CirclesService{client: c}
+	c.Credentials = &CredentialsService{client: c}
+	c.Nginx = &NginxService{client: c}
+	c.Recipes = &RecipesService{client: c}
+	c.Security = &SecurityRulesService{client: c}
+
+	return c
+}
 
+// WithFallbackToken sets a second token, e.g. a read-only organisation
+// token, that GET requests are retried with when the primary token hits the
+// rate limit. Writes are never retried. It returns c for chaining.
+func (c *Client) WithFallbackToken(token string) *Client {
+	c.fallbackToken = token
+	return c
+}
+
+// WithSchemaLog turns on schema checking for debugging: every response is
+// also compared with the type it is decoded into, and each unknown field
+// and mismatched type found is appended to the file at path, so changes to
+// the Forge API get noticed early. An empty path leaves it off.
+// It returns c for chaining.
+func (c *Client) WithSchemaLog(path string) *Client {
+	c.schemaLog = nil
+	if path != "" {
+		c.schemaLog = &schemaLog{path: path}
+	}
 	return c
 }
 
+// send executes req, authenticated with the primary token. A GET that is
+// rate limited is retried once with the fallback token, if one is set;
+// should that fail too, the original rate limit error is returned.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || req.Method != http.MethodGet || c.fallbackToken == "" {
+		return resp, nil
+	}
+
+	limited := c.parseError(resp)
+	resp.Body.Close()
+
+	retry := req.Clone(req.Context())
+	retry.Header.Set("Authorization", "Bearer "+c.fallbackToken)
+	resp, err = c.http.Do(retry)
+	if err != nil {
+		return nil, limited
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusTooManyRequests {
+		// A rejected fallback says nothing about the primary token.
+		resp.Body.Close()
+		return nil, limited
+	}
+	return resp, nil
+}
+
 // do executes an API request. If body is non-nil it is marshalled as JSON.
 // If result is non-nil the response body is decoded into it.
 func (c *Client) do(ctx context.Context, method, path string, body any, result any) error {
@@ -101,24 +184,30 @@ func (c *Client) do(ctx context.Context, method, path string, body any, result a
 		return fmt.Errorf("creating request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
 	req.Header.Set("Accept", "application/json")
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
-		return fmt.Errorf("executing request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return parseError(resp)
+		return c.parseError(resp)
 	}
 
 	if result != nil {
-		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("reading response: %w", err)
+		}
+		if c.schemaLog != nil {
+			c.schemaLog.check(method, path, data, result)
+		}
+		if err := json.NewDecoder(bytes.NewReader(data)).Decode(result); err != nil {
 			return fmt.Errorf("decoding response: %w", err)
 		}
 	}
@@ -128,34 +217,61 @@ func (c *Client) do(ctx context.Context, method, path string, body any, result a
 
 // getText fetches a plain-text response (e.g. environment files, deploy scripts).
 func (c *Client) getText(ctx context.Context, path string) (string, error) {
+	text, _, err := c.getTextFrom(ctx, path, 0)
+	return text, err
+}
+
+// getTextFrom fetches a plain-text response starting at byte offset. When
+// offset > 0 it sends a Range header; partial reports whether the server
+// honoured it, in which case text holds only the bytes after offset.
+// Otherwise text is the whole body.
+func (c *Client) getTextFrom(ctx context.Context, path string, offset int) (text string, partial bool, err error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
 	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
+		return "", false, fmt.Errorf("creating request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
 	req.Header.Set("Accept", "text/plain")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
 
-	resp, err := c.http.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
-		return "", fmt.Errorf("executing request: %w", err)
+		return "", false, err
 	}
 	defer resp.Body.Close()
 
+	// Nothing past offset yet.
+	if offset > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		return "", true, nil
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return "", parseError(resp)
+		return "", false, c.parseError(resp)
 	}
 
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("reading response body: %w", err)
+		return "", false, fmt.Errorf("reading response body: %w", err)
 	}
 
-	return string(data), nil
+	return string(data), resp.StatusCode == http.StatusPartialContent, nil
+}
+
+// parseError maps an HTTP error response to the appropriate error type,
+// reporting authentication failures to OnAuthError.
+func (c *Client) parseError(resp *http.Response) error {
+	err := parseErrorResponse(resp)
+	var authErr *AuthenticationError
+	if c.OnAuthError != nil && errors.As(err, &authErr) {
+		c.OnAuthError(err)
+	}
+	return err
 }
 
-// parseError maps an HTTP error response to the appropriate error type.
-func parseError(resp *http.Response) error {
+// parseErrorResponse maps an HTTP error response to the appropriate error type.
+func parseErrorResponse(resp *http.Response) error {
 	body, _ := io.ReadAll(resp.Body)
 
 	// Try to extract a message from the JSON body.
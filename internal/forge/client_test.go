@@ -3,8 +3,12 @@ package forge
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -91,10 +95,15 @@ func TestAuthError(t *testing.T) {
 	defer srv.Close()
 
 	client := newTestClient(t, srv)
+	var reported error
+	client.OnAuthError = func(err error) { reported = err }
 	_, err := client.Servers.List(context.Background())
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
+	if reported != err {
+		t.Errorf("OnAuthError got %v, want %v", reported, err)
+	}
 
 	var authErr *AuthenticationError
 	if !errors.As(err, &authErr) {
@@ -239,6 +248,53 @@ func TestGetText(t *testing.T) {
 	}
 }
 
+func TestGetLogSince(t *testing.T) {
+	const full = "step 1\nstep 2\nstep 3\n"
+	for _, tc := range []struct {
+		name         string
+		prev         string
+		honourRanges bool
+	}{
+		{"first fetch", "", true},
+		{"range honoured", "step 1\n", true},
+		{"range ignored", "step 1\n", false},
+		{"no new output", full, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				rng := r.Header.Get("Range")
+				if tc.prev == "" && rng != "" {
+					t.Errorf("Range = %q on first fetch, want none", rng)
+				}
+				if rng == "" || !tc.honourRanges {
+					_, _ = w.Write([]byte(full))
+					return
+				}
+				var offset int
+				if _, err := fmt.Sscanf(rng, "bytes=%d-", &offset); err != nil {
+					t.Fatalf("bad Range %q", rng)
+				}
+				if offset >= len(full) {
+					w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+					return
+				}
+				w.WriteHeader(http.StatusPartialContent)
+				_, _ = w.Write([]byte(full[offset:]))
+			}))
+			defer srv.Close()
+
+			client := newTestClient(t, srv)
+			got, err := client.Deployments.GetLogSince(context.Background(), 1, 2, tc.prev)
+			if err != nil {
+				t.Fatalf("GetLogSince: %v", err)
+			}
+			if got != full {
+				t.Errorf("GetLogSince = %q, want %q", got, full)
+			}
+		})
+	}
+}
+
 func TestNewClientDefaults(t *testing.T) {
 	c := NewClient("my-token")
 
@@ -294,3 +350,116 @@ func TestNewClientDefaults(t *testing.T) {
 		t.Fatal("Logs service is nil")
 	}
 }
+
+func TestFallbackTokenOnRateLimit(t *testing.T) {
+	var tokens []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		tokens = append(tokens, auth)
+		w.Header().Set("Content-Type", "application/json")
+		if auth != "Bearer read-only" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"message": "Too Many Attempts."}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"servers": [{"id": 1, "name": "production"}]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv).WithFallbackToken("read-only")
+	servers, err := client.Servers.List(context.Background())
+	if err != nil {
+		t.Fatalf("Servers.List: %v", err)
+	}
+	if len(servers) != 1 {
+		t.Errorf("got %d servers, want 1", len(servers))
+	}
+	if want := []string{"Bearer test-token", "Bearer read-only"}; fmt.Sprint(tokens) != fmt.Sprint(want) {
+		t.Errorf("tokens = %v, want %v", tokens, want)
+	}
+
+	// Writes are never retried with the fallback token.
+	tokens = nil
+	err = client.Servers.Reboot(context.Background(), 1)
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("Reboot: expected RateLimitError, got %T: %v", err, err)
+	}
+	if len(tokens) != 1 {
+		t.Errorf("Reboot made %d requests, want 1", len(tokens))
+	}
+}
+
+func TestFallbackTokenRejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") == "Bearer test-token" {
+			w.WriteHeader(http.StatusTooManyRequests)
+		} else {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv).WithFallbackToken("revoked")
+	client.OnAuthError = func(error) { t.Error("OnAuthError called for the fallback token") }
+	_, err := client.Servers.List(context.Background())
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("expected RateLimitError, got %T: %v", err, err)
+	}
+}
+
+func TestSchemaLog(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/servers/1":
+			_, _ = w.Write([]byte(`{"server": {"id": 1, "name": "web", "brand_new_field": true, "another_one": {"a": 1}}}`))
+		case "/servers":
+			_, _ = w.Write([]byte(`{"servers": [{"id": 1, "name": "web", "zone": "a"}, {"id": 2, "name": "db", "zone": "b"}]}`))
+		}
+	}))
+	defer srv.Close()
+
+	logPath := filepath.Join(t.TempDir(), "schema.log")
+	client := newTestClient(t, srv).WithSchemaLog(logPath)
+	for range 2 {
+		server, err := client.Servers.Get(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if server.Name != "web" {
+			t.Errorf("Name = %q; checking the schema changed the result", server.Name)
+		}
+		if _, err := client.Servers.List(context.Background()); err != nil {
+			t.Fatalf("List: %v", err)
+		}
+	}
+
+	// A changed type is logged too.
+	client.schemaLog.check("GET", "/servers/1", []byte(`{"server": {"id": "1", "name": "web"}}`), &struct {
+		Server Server `json:"server"`
+	}{})
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("reading the schema log: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	want := []string{
+		"GET /servers/1: server.another_one: unknown field",
+		"GET /servers/1: server.brand_new_field: unknown field",
+		"GET /servers: servers[].zone: unknown field",
+		"GET /servers/1: server.id: string where int64 expected",
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("schema log = %q, want each of %q once", data, want)
+	}
+	for i, w := range want {
+		if !strings.HasSuffix(lines[i], w) {
+			t.Errorf("schema log line %d = %q, want %q", i+1, lines[i], w)
+		}
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // List returns all commands that have been executed on a site.
@@ -20,12 +23,16 @@ func (s *CommandsService) List(ctx context.Context, serverID, siteID int64) ([]S
 func (s *CommandsService) Get(ctx context.Context, serverID, siteID, cmdID int64) (*SiteCommand, error) {
 	var resp struct {
 		Command SiteCommand `json:"command"`
+		Output  string      `json:"output"`
 	}
 	path := fmt.Sprintf("/servers/%d/sites/%d/commands/%d", serverID, siteID, cmdID)
 	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
 	if err != nil {
 		return nil, err
 	}
+	if resp.Command.Output == "" {
+		resp.Command.Output = resp.Output
+	}
 	return &resp.Command, nil
 }
 
@@ -42,3 +49,40 @@ func (s *CommandsService) Create(ctx context.Context, serverID, siteID int64, co
 	}
 	return &resp.Command, nil
 }
+
+// Elapsed returns how long the command ran. Forge reports the duration as
+// a number of seconds, either as a JSON number or a string such as "12",
+// "12s" or "12 seconds"; ok is false when it is missing or unrecognised.
+func (c SiteCommand) Elapsed() (d time.Duration, ok bool) {
+	var secs float64
+	switch v := c.Duration.(type) {
+	case float64:
+		secs = v
+	case string:
+		s := strings.TrimSpace(v)
+		if d, err := time.ParseDuration(s); err == nil {
+			return d, true
+		}
+		num, unit, _ := strings.Cut(s, " ")
+		f, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return 0, false
+		}
+		switch strings.TrimSuffix(unit, "s") {
+		case "", "second", "sec":
+			secs = f
+		case "minute", "min":
+			secs = f * 60
+		case "hour":
+			secs = f * 3600
+		default:
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+	if secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs * float64(time.Second)), true
+}
@@ -0,0 +1,25 @@
+package forge
+
+import (
+	"context"
+	"net/http"
+)
+
+// List returns the provider credentials linked to the account.
+func (s *CredentialsService) List(ctx context.Context) ([]Credential, error) {
+	var resp struct {
+		Credentials []Credential `json:"credentials"`
+	}
+	err := s.client.do(ctx, http.MethodGet, "/credentials", nil, &resp)
+	return resp.Credentials, err
+}
+
+// Regions returns the regions, and the sizes offered in each, of every
+// provider Forge can create servers on, keyed by credential type.
+func (s *CredentialsService) Regions(ctx context.Context) (map[string][]Region, error) {
+	var resp struct {
+		Regions map[string][]Region `json:"regions"`
+	}
+	err := s.client.do(ctx, http.MethodGet, "/regions", nil, &resp)
+	return resp.Regions, err
+}
@@ -22,6 +22,7 @@ func (s *DaemonsService) List(ctx context.Context, serverID int64) ([]Daemon, er
 	}
 	path := fmt.Sprintf("/servers/%d/daemons", serverID)
 	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	sortByID(resp.Daemons, func(d Daemon) int64 { return d.ID })
 	return resp.Daemons, err
 }
 
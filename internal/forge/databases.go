@@ -13,6 +13,9 @@ func (s *DatabasesService) List(ctx context.Context, serverID int64) ([]Database
 	}
 	path := fmt.Sprintf("/servers/%d/databases", serverID)
 	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	sortByName(resp.Databases,
+		func(d Database) string { return d.Name },
+		func(d Database) int64 { return d.ID })
 	return resp.Databases, err
 }
 
@@ -70,6 +73,9 @@ func (s *DatabasesService) ListUsers(ctx context.Context, serverID int64) ([]Dat
 	}
 	path := fmt.Sprintf("/servers/%d/database-users", serverID)
 	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	sortByName(resp.Users,
+		func(u DatabaseUser) string { return u.Name },
+		func(u DatabaseUser) int64 { return u.ID })
 	return resp.Users, err
 }
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // List returns deployment history for a site.
@@ -13,6 +15,7 @@ func (s *DeploymentsService) List(ctx context.Context, serverID, siteID int64) (
 	}
 	path := fmt.Sprintf("/servers/%d/sites/%d/deployment-history", serverID, siteID)
 	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	sortDeployments(resp.Deployments)
 	return resp.Deployments, err
 }
 
@@ -45,12 +48,96 @@ func (s *DeploymentsService) Deploy(ctx context.Context, serverID, siteID int64)
 	return s.client.do(ctx, http.MethodPost, path, nil, nil)
 }
 
+// DeployCommit triggers a deployment through the site's deployment trigger
+// URL (Site.DeploymentURL), passing commit as forge_deploy_commit. Forge
+// exposes it to the deploy script as $FORGE_DEPLOY_COMMIT; the script has
+// to check that commit out for the deployment to use it.
+func (s *DeploymentsService) DeployCommit(ctx context.Context, triggerURL, commit string) error {
+	u, err := url.Parse(triggerURL)
+	if err != nil || triggerURL == "" {
+		return fmt.Errorf("invalid deployment trigger URL %q", triggerURL)
+	}
+	q := u.Query()
+	q.Set("forge_deploy_commit", commit)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := s.client.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return s.client.parseError(resp)
+	}
+	return nil
+}
+
+// Rollback redeploys the commit of the newest successful deployment before
+// failedID through the site's trigger URL (see DeployCommit). It returns
+// the deployment whose commit was redeployed.
+func (s *DeploymentsService) Rollback(ctx context.Context, serverID, siteID int64, triggerURL string, failedID int64) (*Deployment, error) {
+	deployments, err := s.List(ctx, serverID, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("listing deployments: %w", err)
+	}
+	target := LastSuccessful(deployments, failedID)
+	if target == nil {
+		return nil, fmt.Errorf("no earlier successful deployment to roll back to")
+	}
+	if err := s.DeployCommit(ctx, triggerURL, target.CommitHash); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// DeploymentRunning reports whether a deployment with the given status is
+// still in progress, either deploying or queued behind another deploy.
+func DeploymentRunning(status string) bool {
+	return strings.EqualFold(status, "deploying") || strings.EqualFold(status, "queued")
+}
+
+// LastSuccessful returns the newest finished deployment older than beforeID
+// that has a commit hash, or nil if there is none.
+func LastSuccessful(deployments []Deployment, beforeID int64) *Deployment {
+	var best *Deployment
+	for i := range deployments {
+		d := &deployments[i]
+		if d.ID >= beforeID || d.Status != "finished" || d.CommitHash == "" {
+			continue
+		}
+		if best == nil || d.ID > best.ID {
+			best = d
+		}
+	}
+	return best
+}
+
 // GetLog returns the latest deployment log for the site.
 func (s *DeploymentsService) GetLog(ctx context.Context, serverID, siteID int64) (string, error) {
 	path := fmt.Sprintf("/servers/%d/sites/%d/deployment/log", serverID, siteID)
 	return s.client.getText(ctx, path)
 }
 
+// GetLogSince returns the latest deployment log given the part already
+// fetched. Only the bytes after prev are requested when the API supports
+// ranges; the full log is returned either way.
+func (s *DeploymentsService) GetLogSince(ctx context.Context, serverID, siteID int64, prev string) (string, error) {
+	path := fmt.Sprintf("/servers/%d/sites/%d/deployment/log", serverID, siteID)
+	text, partial, err := s.client.getTextFrom(ctx, path, len(prev))
+	if err != nil {
+		return "", err
+	}
+	if partial {
+		return prev + text, nil
+	}
+	return text, nil
+}
+
 // GetScript returns the deployment script contents as plain text.
 func (s *DeploymentsService) GetScript(ctx context.Context, serverID, siteID int64) (string, error) {
 	path := fmt.Sprintf("/servers/%d/sites/%d/deployment/script", serverID, siteID)
@@ -81,3 +168,11 @@ func (s *DeploymentsService) ResetStatus(ctx context.Context, serverID, siteID i
 	path := fmt.Sprintf("/servers/%d/sites/%d/deployment/reset", serverID, siteID)
 	return s.client.do(ctx, http.MethodPost, path, nil, nil)
 }
+
+// ResetTriggerURL regenerates the token in the site's deployment trigger
+// URL, so the old URL stops deploying the site. The new URL is the site's
+// deployment_url once this returns.
+func (s *DeploymentsService) ResetTriggerURL(ctx context.Context, serverID, siteID int64) error {
+	path := fmt.Sprintf("/servers/%d/sites/%d/deployment/token", serverID, siteID)
+	return s.client.do(ctx, http.MethodPost, path, nil, nil)
+}
@@ -15,3 +15,13 @@ func (s *EventsService) List(ctx context.Context, serverID int64) ([]Event, erro
 	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
 	return resp.Events, err
 }
+
+// GetOutput returns the output of a server event, such as a recipe run.
+func (s *EventsService) GetOutput(ctx context.Context, serverID, eventID int64) (string, error) {
+	var resp struct {
+		Output string `json:"output"`
+	}
+	path := fmt.Sprintf("/servers/%d/events/%d", serverID, eventID)
+	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	return resp.Output, err
+}
@@ -50,6 +50,52 @@ func (s *FirewallService) Create(ctx context.Context, serverID int64, opts Firew
 	return &resp.Rule, nil
 }
 
+// CreateMissing creates each of rules on a server unless the server already
+// has a rule with the same port, IP address and type. It returns how many
+// rules were created and how many were skipped, stopping at the first error.
+func (s *FirewallService) CreateMissing(ctx context.Context, serverID int64, rules []FirewallRule) (created, skipped int, err error) {
+	existing, err := s.List(ctx, serverID)
+	if err != nil {
+		return 0, 0, err
+	}
+	have := make(map[string]bool, len(existing))
+	for _, r := range existing {
+		have[firewallRuleKey(r)] = true
+	}
+
+	for _, r := range rules {
+		k := firewallRuleKey(r)
+		if have[k] {
+			skipped++
+			continue
+		}
+		opts := FirewallCreateOpts{
+			Name:      r.Name,
+			Port:      r.Port,
+			IPAddress: r.IPAddress,
+			Type:      r.Type,
+		}
+		if opts.Type == "" {
+			opts.Type = "allow"
+		}
+		if _, err := s.Create(ctx, serverID, opts); err != nil {
+			return created, skipped, fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+		have[k] = true
+		created++
+	}
+	return created, skipped, nil
+}
+
+// firewallRuleKey identifies a rule by what it allows, ignoring its name.
+func firewallRuleKey(r FirewallRule) string {
+	ruleType := r.Type
+	if ruleType == "" {
+		ruleType = "allow"
+	}
+	return fmt.Sprintf("%v|%s|%s", r.Port, r.IPAddress, ruleType)
+}
+
 // Delete removes a firewall rule from a server.
 func (s *FirewallService) Delete(ctx context.Context, serverID, ruleID int64) error {
 	path := fmt.Sprintf("/servers/%d/firewall-rules/%d", serverID, ruleID)
@@ -6,16 +6,24 @@ import (
 	"net/http"
 )
 
-// Install installs a Git repository on a site.
-func (s *GitService) Install(ctx context.Context, serverID, siteID int64, provider, repo, branch string, composer bool) error {
-	body := map[string]any{
-		"provider":   provider,
-		"repository": repo,
-		"branch":     branch,
-		"composer":   composer,
-	}
+// GitProviders are the repository providers a site can install from.
+var GitProviders = []string{"github", "gitlab", "gitlab-custom", "bitbucket", "custom"}
+
+// GitInstallOpts contains the options for installing a repository on a
+// site. Composer runs composer install once the repository is cloned.
+type GitInstallOpts struct {
+	Provider   string `json:"provider"`
+	Repository string `json:"repository"`
+	Branch     string `json:"branch"`
+	Composer   bool   `json:"composer"`
+}
+
+// Install installs a Git repository on a site. Forge clones it in the
+// background; the site's repository_status is "installing" until it is
+// done.
+func (s *GitService) Install(ctx context.Context, serverID, siteID int64, opts GitInstallOpts) error {
 	path := fmt.Sprintf("/servers/%d/sites/%d/git", serverID, siteID)
-	return s.client.do(ctx, http.MethodPost, path, body, nil)
+	return s.client.do(ctx, http.MethodPost, path, opts, nil)
 }
 
 // UpdateBranch changes the deployed branch for a site.
@@ -4,13 +4,44 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
 )
 
+// JobFrequencies lists the named schedules Forge accepts for a job, besides
+// "custom" with a cron expression.
+var JobFrequencies = []string{"minutely", "hourly", "nightly", "weekly", "monthly", "reboot"}
+
 // JobCreateOpts contains the options for creating a scheduled job.
 type JobCreateOpts struct {
 	Command   string `json:"command"`
 	Frequency string `json:"frequency"` // default "nightly"
 	User      string `json:"user"`      // default "forge"
+
+	// The fields of the cron expression, for the "custom" frequency.
+	Minute  string `json:"minute,omitempty"`
+	Hour    string `json:"hour,omitempty"`
+	Day     string `json:"day,omitempty"`
+	Month   string `json:"month,omitempty"`
+	Weekday string `json:"weekday,omitempty"`
+}
+
+// SetSchedule sets the job's frequency from schedule: one of
+// JobFrequencies, or a five-field cron expression such as "*/15 * * * *",
+// which becomes the "custom" frequency.
+func (o *JobCreateOpts) SetSchedule(schedule string) error {
+	schedule = strings.TrimSpace(schedule)
+	if name := strings.ToLower(schedule); slices.Contains(JobFrequencies, name) {
+		o.Frequency = name
+		return nil
+	}
+	f := strings.Fields(schedule)
+	if len(f) != 5 {
+		return fmt.Errorf("%q is neither a frequency (%s) nor a five-field cron expression", schedule, strings.Join(JobFrequencies, ", "))
+	}
+	o.Frequency = "custom"
+	o.Minute, o.Hour, o.Day, o.Month, o.Weekday = f[0], f[1], f[2], f[3], f[4]
+	return nil
 }
 
 // List returns all scheduled jobs on a server.
@@ -54,3 +85,13 @@ func (s *JobsService) Delete(ctx context.Context, serverID, jobID int64) error {
 	path := fmt.Sprintf("/servers/%d/jobs/%d", serverID, jobID)
 	return s.client.do(ctx, http.MethodDelete, path, nil, nil)
 }
+
+// GetOutput returns the output of a scheduled job's latest run.
+func (s *JobsService) GetOutput(ctx context.Context, serverID, jobID int64) (string, error) {
+	var resp struct {
+		Output string `json:"output"`
+	}
+	path := fmt.Sprintf("/servers/%d/jobs/%d/output", serverID, jobID)
+	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	return resp.Output, err
+}
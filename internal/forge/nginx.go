@@ -0,0 +1,98 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// ListTemplates returns the nginx templates on a server.
+func (s *NginxService) ListTemplates(ctx context.Context, serverID int64) ([]NginxTemplate, error) {
+	var resp struct {
+		Templates []NginxTemplate `json:"templates"`
+	}
+	path := fmt.Sprintf("/servers/%d/nginx/templates", serverID)
+	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	return resp.Templates, err
+}
+
+// GetTemplate returns a single nginx template with its content.
+func (s *NginxService) GetTemplate(ctx context.Context, serverID, templateID int64) (*NginxTemplate, error) {
+	var resp struct {
+		Template NginxTemplate `json:"template"`
+	}
+	path := fmt.Sprintf("/servers/%d/nginx/templates/%d", serverID, templateID)
+	if err := s.client.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Template, nil
+}
+
+// CreateTemplate adds an nginx template to a server.
+func (s *NginxService) CreateTemplate(ctx context.Context, serverID int64, name, content string) (*NginxTemplate, error) {
+	body := map[string]string{"name": name, "content": content}
+	var resp struct {
+		Template NginxTemplate `json:"template"`
+	}
+	path := fmt.Sprintf("/servers/%d/nginx/templates", serverID)
+	if err := s.client.do(ctx, http.MethodPost, path, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Template, nil
+}
+
+// UpdateTemplate replaces an nginx template's name and content.
+func (s *NginxService) UpdateTemplate(ctx context.Context, serverID, templateID int64, name, content string) error {
+	body := map[string]string{"name": name, "content": content}
+	path := fmt.Sprintf("/servers/%d/nginx/templates/%d", serverID, templateID)
+	return s.client.do(ctx, http.MethodPut, path, body, nil)
+}
+
+// DeleteTemplate removes an nginx template from a server.
+func (s *NginxService) DeleteTemplate(ctx context.Context, serverID, templateID int64) error {
+	path := fmt.Sprintf("/servers/%d/nginx/templates/%d", serverID, templateID)
+	return s.client.do(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// GetSiteConfig returns the nginx configuration of a site.
+func (s *NginxService) GetSiteConfig(ctx context.Context, serverID, siteID int64) (string, error) {
+	path := fmt.Sprintf("/servers/%d/sites/%d/nginx", serverID, siteID)
+	return s.client.getText(ctx, path)
+}
+
+// UpdateSiteConfig replaces the nginx configuration of a site. Forge
+// reloads nginx once it is written.
+func (s *NginxService) UpdateSiteConfig(ctx context.Context, serverID, siteID int64, content string) error {
+	body := map[string]string{"content": content}
+	path := fmt.Sprintf("/servers/%d/sites/%d/nginx", serverID, siteID)
+	return s.client.do(ctx, http.MethodPut, path, body, nil)
+}
+
+// RenderTemplate fills in the placeholders of an nginx template for a
+// site on srv, the way Forge does when a site is created with the
+// template. Sites without an isolated user belong to "forge".
+func RenderTemplate(content string, srv Server, site Site) string {
+	user := site.Username
+	if user == "" {
+		user = "forge"
+	}
+	domains := append([]string{site.Name}, site.Aliases...)
+	if site.Wildcards {
+		domains = append(domains, "*."+site.Name)
+	}
+	return strings.NewReplacer(
+		"{{DIRECTORY}}", site.Directory,
+		"{{DOMAINS}}", strings.Join(domains, " "),
+		"{{PATH}}", path.Join("/home", user, site.Name, site.Directory),
+		"{{PORT}}", "80",
+		"{{PORT_V6}}", "[::]:80",
+		"{{SERVER_PUBLIC_IP}}", srv.IPAddress,
+		"{{SERVER_PRIVATE_IP}}", srv.PrivateIPAddress,
+		"{{SITE}}", site.Name,
+		"{{SITE_ID}}", strconv.FormatInt(site.ID, 10),
+		"{{USER}}", user,
+	).Replace(content)
+}
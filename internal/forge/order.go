@@ -0,0 +1,55 @@
+package forge
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// The Forge API does not promise an order for its lists, and in practice
+// it varies between requests. The List methods sort their results so the
+// UI does not reshuffle on every refresh.
+
+// sortDeployments orders deployments newest first. Forge's timestamps
+// ("2006-01-02 15:04:05") sort lexically; the ID breaks ties and orders
+// deployments that have not started yet.
+func sortDeployments(ds []Deployment) {
+	slices.SortStableFunc(ds, func(a, b Deployment) int {
+		if c := cmp.Compare(b.StartedAt, a.StartedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+}
+
+// sortByID orders items by ascending ID, which is creation order.
+func sortByID[T any](items []T, id func(T) int64) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		return cmp.Compare(id(a), id(b))
+	})
+}
+
+// sortByName orders items alphabetically, ignoring case, with the ID
+// breaking ties.
+func sortByName[T any](items []T, name func(T) string, id func(T) int64) {
+	slices.SortStableFunc(items, func(a, b T) int {
+		if c := strings.Compare(strings.ToLower(name(a)), strings.ToLower(name(b))); c != 0 {
+			return c
+		}
+		return cmp.Compare(id(a), id(b))
+	})
+}
+
+// NormalizeAliases trims and lowercases domain aliases, drops blanks and
+// duplicates, and sorts the rest. Domains are case-insensitive, so
+// "WWW.example.com" and "www.example.com" are the same alias.
+func NormalizeAliases(aliases []string) []string {
+	out := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		if a = strings.ToLower(strings.TrimSpace(a)); a != "" {
+			out = append(out, a)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
@@ -0,0 +1,60 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// RecipeCreateOpts contains the options for creating a recipe.
+type RecipeCreateOpts struct {
+	Name   string `json:"name"`
+	User   string `json:"user"` // "root" or "forge"
+	Script string `json:"script"`
+}
+
+// List returns the account's recipes.
+func (s *RecipesService) List(ctx context.Context) ([]Recipe, error) {
+	var resp struct {
+		Recipes []Recipe `json:"recipes"`
+	}
+	err := s.client.do(ctx, http.MethodGet, "/recipes", nil, &resp)
+	return resp.Recipes, err
+}
+
+// Get returns a single recipe, with its script.
+func (s *RecipesService) Get(ctx context.Context, recipeID int64) (*Recipe, error) {
+	var resp struct {
+		Recipe Recipe `json:"recipe"`
+	}
+	path := fmt.Sprintf("/recipes/%d", recipeID)
+	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Recipe, nil
+}
+
+// Create creates a new recipe.
+func (s *RecipesService) Create(ctx context.Context, opts RecipeCreateOpts) (*Recipe, error) {
+	var resp struct {
+		Recipe Recipe `json:"recipe"`
+	}
+	err := s.client.do(ctx, http.MethodPost, "/recipes", opts, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Recipe, nil
+}
+
+// Run runs a recipe on the given servers. Forge runs it in the
+// background and doesn't return its output; with notify set it emails the
+// output once the recipe finishes.
+func (s *RecipesService) Run(ctx context.Context, recipeID int64, serverIDs []int64, notify bool) error {
+	body := struct {
+		Servers []int64 `json:"servers"`
+		Notify  bool    `json:"notify"`
+	}{serverIDs, notify}
+	path := fmt.Sprintf("/recipes/%d/run", recipeID)
+	return s.client.do(ctx, http.MethodPost, path, body, nil)
+}
@@ -0,0 +1,49 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Redirect rule types.
+const (
+	RedirectTemporary = "redirect"  // 302
+	RedirectPermanent = "permanent" // 301
+)
+
+// RedirectCreateOpts contains the options for creating a redirect rule.
+type RedirectCreateOpts struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"` // RedirectTemporary or RedirectPermanent
+}
+
+// List returns the redirect rules of a site.
+func (s *RedirectsService) List(ctx context.Context, serverID, siteID int64) ([]RedirectRule, error) {
+	var resp struct {
+		Rules []RedirectRule `json:"redirect_rules"`
+	}
+	path := fmt.Sprintf("/servers/%d/sites/%d/redirect-rules", serverID, siteID)
+	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	return resp.Rules, err
+}
+
+// Create adds a redirect rule to a site.
+func (s *RedirectsService) Create(ctx context.Context, serverID, siteID int64, opts RedirectCreateOpts) (*RedirectRule, error) {
+	var resp struct {
+		Rule RedirectRule `json:"redirect_rule"`
+	}
+	path := fmt.Sprintf("/servers/%d/sites/%d/redirect-rules", serverID, siteID)
+	err := s.client.do(ctx, http.MethodPost, path, opts, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Rule, nil
+}
+
+// Delete removes a redirect rule.
+func (s *RedirectsService) Delete(ctx context.Context, serverID, siteID, ruleID int64) error {
+	path := fmt.Sprintf("/servers/%d/sites/%d/redirect-rules/%d", serverID, siteID, ruleID)
+	return s.client.do(ctx, http.MethodDelete, path, nil, nil)
+}
@@ -0,0 +1,199 @@
+package forge
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// schemaLog notes where API responses stop matching the types they are
+// decoded into: fields Forge has added that the types don't declare, and
+// values whose type has changed. Each response is walked alongside the
+// type it is decoded into, and every mismatch found is appended to the
+// file at path with its place in the response, such as
+// "sites[].brand_new_field". The same mismatch is logged once per run,
+// however many requests hit it.
+type schemaLog struct {
+	path string
+
+	mu   sync.Mutex
+	seen map[string]bool
+}
+
+var unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// check compares data with the type result points to and logs each
+// mismatch not logged before.
+func (l *schemaLog) check(method, path string, data []byte, result any) {
+	t := reflect.TypeOf(result)
+	if t == nil || t.Kind() != reflect.Pointer {
+		return
+	}
+	var problems []string
+	walkSchema(json.RawMessage(data), t.Elem(), "", &problems)
+	if len(problems) == 0 {
+		return
+	}
+	slices.Sort(problems)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.seen == nil {
+		l.seen = make(map[string]bool)
+	}
+	var lines strings.Builder
+	for _, p := range problems {
+		if l.seen[p] {
+			continue
+		}
+		l.seen[p] = true
+		fmt.Fprintf(&lines, "%s %s %s: %s\n", time.Now().Format(time.RFC3339), method, path, p)
+	}
+	if lines.Len() == 0 {
+		return
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	_, _ = f.WriteString(lines.String())
+}
+
+// walkSchema compares the JSON value data, found at path in a response,
+// with the Go type t it is decoded into, and appends a description of each
+// unknown field and changed type to problems.
+func walkSchema(data json.RawMessage, t reflect.Type, path string, problems *[]string) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Interface || reflect.PointerTo(t).Implements(unmarshalerType) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			break
+		}
+		fields := jsonFields(t)
+		for key, value := range obj {
+			ft, ok := fields[strings.ToLower(key)]
+			if !ok {
+				*problems = append(*problems, joinPath(path, key)+": unknown field")
+				continue
+			}
+			walkSchema(value, ft, joinPath(path, key), problems)
+		}
+		return
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			break // []byte is decoded from a base64 string
+		}
+		var elems []json.RawMessage
+		if json.Unmarshal(data, &elems) != nil {
+			break
+		}
+		for _, e := range elems {
+			walkSchema(e, t.Elem(), path+"[]", problems)
+		}
+		return
+
+	case reflect.Map:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			break
+		}
+		for _, value := range obj {
+			walkSchema(value, t.Elem(), path+"[]", problems)
+		}
+		return
+
+	default:
+		if json.Unmarshal(data, reflect.New(t).Interface()) == nil {
+			return
+		}
+	}
+	*problems = append(*problems, fmt.Sprintf("%s: %s where %s expected", displayPath(path), jsonKind(data), t))
+}
+
+// jsonFields returns the types of the fields of struct type t by the
+// lower-cased JSON names they are decoded from, including those promoted
+// from embedded structs. Of fields with the same name, the least deeply
+// embedded wins.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	depth := make(map[string]int)
+	for _, f := range reflect.VisibleFields(t) {
+		tag := f.Tag.Get("json")
+		name, _, _ := strings.Cut(tag, ",")
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" && indirect(f.Type).Kind() == reflect.Struct {
+			continue // its fields are visited as promoted fields
+		}
+		if name == "" {
+			name = f.Name
+		}
+		name = strings.ToLower(name)
+		if d, ok := depth[name]; !ok || len(f.Index) < d {
+			fields[name] = f.Type
+			depth[name] = len(f.Index)
+		}
+	}
+	return fields
+}
+
+// indirect returns the type t points to, or t if it isn't a pointer.
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// displayPath returns path, or a name for the whole response if it is
+// empty.
+func displayPath(path string) string {
+	if path == "" {
+		return "(response)"
+	}
+	return path
+}
+
+// jsonKind names the kind of JSON value data holds.
+func jsonKind(data []byte) string {
+	switch data[0] {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "bool"
+	default:
+		return "number"
+	}
+}
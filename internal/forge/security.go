@@ -0,0 +1,51 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// SecurityRuleCreateOpts contains the options for creating a security
+// rule. An empty Path protects the whole site.
+type SecurityRuleCreateOpts struct {
+	Name        string                    `json:"name"`
+	Path        string                    `json:"path,omitempty"`
+	Credentials []SecurityCredentialInput `json:"credentials"`
+}
+
+// SecurityCredentialInput is a username and password for a new security
+// rule.
+type SecurityCredentialInput struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// List returns the security rules of a site.
+func (s *SecurityRulesService) List(ctx context.Context, serverID, siteID int64) ([]SecurityRule, error) {
+	var resp struct {
+		Rules []SecurityRule `json:"security_rules"`
+	}
+	path := fmt.Sprintf("/servers/%d/sites/%d/security-rules", serverID, siteID)
+	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	return resp.Rules, err
+}
+
+// Create adds a security rule to a site.
+func (s *SecurityRulesService) Create(ctx context.Context, serverID, siteID int64, opts SecurityRuleCreateOpts) (*SecurityRule, error) {
+	var resp struct {
+		Rule SecurityRule `json:"security_rule"`
+	}
+	path := fmt.Sprintf("/servers/%d/sites/%d/security-rules", serverID, siteID)
+	err := s.client.do(ctx, http.MethodPost, path, opts, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Rule, nil
+}
+
+// Delete removes a security rule.
+func (s *SecurityRulesService) Delete(ctx context.Context, serverID, siteID, ruleID int64) error {
+	path := fmt.Sprintf("/servers/%d/sites/%d/security-rules/%d", serverID, siteID, ruleID)
+	return s.client.do(ctx, http.MethodDelete, path, nil, nil)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 // List returns all servers for the authenticated user.
@@ -32,6 +33,55 @@ func (s *ServersService) Reboot(ctx context.Context, serverID int64) error {
 	return s.client.do(ctx, http.MethodPost, fmt.Sprintf("/servers/%d/reboot", serverID), nil, nil)
 }
 
+// SetMaxUploadSize sets PHP's upload_max_filesize and post_max_size on a
+// server, in megabytes.
+func (s *ServersService) SetMaxUploadSize(ctx context.Context, serverID int64, megabytes int) error {
+	body := map[string]int{"megabytes": megabytes}
+	return s.client.do(ctx, http.MethodPut, fmt.Sprintf("/servers/%d/php/max-upload-size", serverID), body, nil)
+}
+
+// EnableOPcache turns on PHP's OPcache on a server.
+func (s *ServersService) EnableOPcache(ctx context.Context, serverID int64) error {
+	return s.client.do(ctx, http.MethodPost, fmt.Sprintf("/servers/%d/php/opcache", serverID), nil, nil)
+}
+
+// DisableOPcache turns off PHP's OPcache on a server.
+func (s *ServersService) DisableOPcache(ctx context.Context, serverID int64) error {
+	return s.client.do(ctx, http.MethodDelete, fmt.Sprintf("/servers/%d/php/opcache", serverID), nil, nil)
+}
+
+// OPcacheEnabled reports whether Forge lists OPcache as enabled.
+func (s Server) OPcacheEnabled() bool {
+	return strings.EqualFold(s.OPcacheStatus, "enabled")
+}
+
+// TagNames returns the names of the server's tags. Forge returns tags as
+// objects with a name field; plain strings are accepted as well.
+func (s Server) TagNames() []string {
+	var names []string
+	for _, tag := range s.Tags {
+		switch t := tag.(type) {
+		case string:
+			names = append(names, t)
+		case map[string]any:
+			if name, ok := t["name"].(string); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// HasTag reports whether the server carries the named tag (case-insensitive).
+func (s Server) HasTag(name string) bool {
+	for _, tag := range s.TagNames() {
+		if strings.EqualFold(tag, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetUser returns the authenticated Forge user.
 func (s *ServersService) GetUser(ctx context.Context) (*User, error) {
 	var resp struct {
@@ -6,7 +6,9 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestSitesList(t *testing.T) {
@@ -77,6 +79,75 @@ func TestSitesList(t *testing.T) {
 	}
 }
 
+func TestSitesUpdate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		if r.URL.Path != "/servers/1/sites/10" {
+			t.Errorf("path = %s, want /servers/1/sites/10", r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("unmarshal body: %v", err)
+		}
+		if payload["directory"] != "/public_html" {
+			t.Errorf("directory = %v, want /public_html", payload["directory"])
+		}
+		if _, ok := payload["name"]; ok {
+			t.Error("name should be omitted when empty")
+		}
+		if _, ok := payload["wildcards"]; ok {
+			t.Error("wildcards should be omitted when nil")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"site": {"id": 10, "name": "example.com", "web_directory": "/home/forge/example.com/public_html"}}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	site, err := client.Sites.Update(context.Background(), 1, 10, SiteUpdateOpts{Directory: "/public_html"})
+	if err != nil {
+		t.Fatalf("Sites.Update: %v", err)
+	}
+	if site.WebDirectory != "/home/forge/example.com/public_html" {
+		t.Errorf("WebDirectory = %q", site.WebDirectory)
+	}
+}
+
+func TestSitesUpdateWildcards(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("unmarshal body: %v", err)
+		}
+		// false must be sent explicitly so wildcards can be disabled.
+		if v, ok := payload["wildcards"]; !ok || v != false {
+			t.Errorf("wildcards = %v (present %v), want false", v, ok)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"site": {"id": 10, "name": "example.com", "wildcards": false}}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	off := false
+	site, err := client.Sites.Update(context.Background(), 1, 10, SiteUpdateOpts{Wildcards: &off})
+	if err != nil {
+		t.Fatalf("Sites.Update: %v", err)
+	}
+	if site.Wildcards {
+		t.Error("Wildcards = true, want false")
+	}
+}
+
 func TestDeploymentsDeploy(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -100,6 +171,236 @@ func TestDeploymentsDeploy(t *testing.T) {
 	}
 }
 
+func TestDeploymentsDeployCommit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/servers/1/sites/10/deploy/http" {
+			t.Errorf("path = %s, want /servers/1/sites/10/deploy/http", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("token"); got != "abc" {
+			t.Errorf("token = %q, want abc", got)
+		}
+		if got := r.URL.Query().Get("forge_deploy_commit"); got != "deadbeef" {
+			t.Errorf("forge_deploy_commit = %q, want deadbeef", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("Authorization = %q, want none", got)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	err := client.Deployments.DeployCommit(context.Background(), srv.URL+"/servers/1/sites/10/deploy/http?token=abc", "deadbeef")
+	if err != nil {
+		t.Fatalf("Deployments.DeployCommit: %v", err)
+	}
+
+	if err := client.Deployments.DeployCommit(context.Background(), "", "deadbeef"); err == nil {
+		t.Error("DeployCommit with no trigger URL succeeded")
+	}
+}
+
+func TestDeploymentRunning(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{"deploying", true},
+		{"queued", true},
+		{"Queued", true},
+		{"finished", false},
+		{"failed", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := DeploymentRunning(tt.status); got != tt.want {
+			t.Errorf("DeploymentRunning(%q) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestLastSuccessful(t *testing.T) {
+	deployments := []Deployment{
+		{ID: 5, Status: "failed", CommitHash: "e"},
+		{ID: 4, Status: "finished", CommitHash: "d"},
+		{ID: 3, Status: "finished", CommitHash: ""},
+		{ID: 2, Status: "finished", CommitHash: "b"},
+	}
+
+	if got := LastSuccessful(deployments, 5); got == nil || got.ID != 4 {
+		t.Errorf("LastSuccessful(5) = %+v, want deployment 4", got)
+	}
+	if got := LastSuccessful(deployments, 4); got == nil || got.ID != 2 {
+		t.Errorf("LastSuccessful(4) = %+v, want deployment 2", got)
+	}
+	if got := LastSuccessful(deployments, 2); got != nil {
+		t.Errorf("LastSuccessful(2) = %+v, want nil", got)
+	}
+}
+
+func TestDeploymentsListOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"deployments": [
+			{"id": 1, "started_at": "2024-01-01 10:00:00"},
+			{"id": 3, "started_at": "2024-01-02 09:00:00"},
+			{"id": 4},
+			{"id": 2, "started_at": "2024-01-02 09:00:00"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	deployments, err := client.Deployments.List(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("Deployments.List: %v", err)
+	}
+
+	var got []int64
+	for _, d := range deployments {
+		got = append(got, d.ID)
+	}
+	if want := []int64{3, 2, 1, 4}; !slices.Equal(got, want) {
+		t.Errorf("deployment order = %v, want %v", got, want)
+	}
+}
+
+func TestDatabasesListOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"databases": [
+			{"id": 1, "name": "shop"},
+			{"id": 2, "name": "Blog"},
+			{"id": 3, "name": "analytics"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	dbs, err := client.Databases.List(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Databases.List: %v", err)
+	}
+
+	var got []string
+	for _, d := range dbs {
+		got = append(got, d.Name)
+	}
+	if want := []string{"analytics", "Blog", "shop"}; !slices.Equal(got, want) {
+		t.Errorf("database order = %v, want %v", got, want)
+	}
+}
+
+func TestWorkersListOrder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"workers": [{"id": 9}, {"id": 2}, {"id": 5}]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	workers, err := client.Workers.List(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("Workers.List: %v", err)
+	}
+
+	var got []int64
+	for _, w := range workers {
+		got = append(got, w.ID)
+	}
+	if want := []int64{2, 5, 9}; !slices.Equal(got, want) {
+		t.Errorf("worker order = %v, want %v", got, want)
+	}
+}
+
+func TestNormalizeAliases(t *testing.T) {
+	got := NormalizeAliases([]string{"www.example.com", " B.example.com", "", "WWW.example.com", "a.example.com"})
+	want := []string{"a.example.com", "b.example.com", "www.example.com"}
+	if !slices.Equal(got, want) {
+		t.Errorf("NormalizeAliases = %v, want %v", got, want)
+	}
+}
+
+func TestCertificatesRenew(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/servers/1/sites/10/certificates/5/renew" {
+			t.Errorf("path = %s, want /servers/1/sites/10/certificates/5/renew", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	err := client.Certificates.Renew(context.Background(), 1, 10, 5)
+	if err != nil {
+		t.Fatalf("Certificates.Renew: %v", err)
+	}
+}
+
+func TestFirewallCreateMissing(t *testing.T) {
+	var created []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/servers/2/firewall-rules" {
+			t.Errorf("path = %s, want /servers/2/firewall-rules", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"rules": [{"id": 1, "name": "HTTP", "port": 80, "type": "allow"}]}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var payload map[string]any
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Fatalf("unmarshal body: %v", err)
+		}
+		created = append(created, payload)
+		_, _ = w.Write([]byte(`{"rule": {"id": 2}}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	rules := []FirewallRule{
+		{Name: "web 80", Port: "80", Type: "allow"},
+		{Name: "web 443", Port: "443"},
+	}
+	n, skipped, err := client.Firewall.CreateMissing(context.Background(), 2, rules)
+	if err != nil {
+		t.Fatalf("Firewall.CreateMissing: %v", err)
+	}
+	if n != 1 || skipped != 1 {
+		t.Errorf("created, skipped = %d, %d, want 1, 1", n, skipped)
+	}
+	if len(created) != 1 || created[0]["port"] != "443" || created[0]["type"] != "allow" {
+		t.Errorf("created = %v, want one allow rule for port 443", created)
+	}
+}
+
+func TestServerTagNames(t *testing.T) {
+	var s Server
+	if err := json.Unmarshal([]byte(`{"id": 1, "tags": [{"id": 3, "name": "staging"}, "eu"]}`), &s); err != nil {
+		t.Fatal(err)
+	}
+	names := s.TagNames()
+	if len(names) != 2 || names[0] != "staging" || names[1] != "eu" {
+		t.Errorf("TagNames = %v, want [staging eu]", names)
+	}
+	if !s.HasTag("Staging") {
+		t.Error("HasTag(Staging) = false, want true")
+	}
+	if s.HasTag("prod") {
+		t.Error("HasTag(prod) = true, want false")
+	}
+}
+
 func TestEnvironmentGet(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -243,3 +544,489 @@ func TestDatabaseCreateWithoutOptionals(t *testing.T) {
 		t.Errorf("db.ID = %d, want 101", db.ID)
 	}
 }
+
+func TestSiteCommandElapsed(t *testing.T) {
+	tests := []struct {
+		duration any
+		want     time.Duration
+		ok       bool
+	}{
+		{float64(12), 12 * time.Second, true},
+		{1.5, 1500 * time.Millisecond, true},
+		{"7", 7 * time.Second, true},
+		{"3m5s", 3*time.Minute + 5*time.Second, true},
+		{"12 seconds", 12 * time.Second, true},
+		{"2 minutes", 2 * time.Minute, true},
+		{"soon", 0, false},
+		{nil, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := SiteCommand{Duration: tt.duration}.Elapsed()
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Elapsed(%v) = %v, %v; want %v, %v", tt.duration, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestCommandsGetOutput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"command": {"id": 3, "status": "finished"}, "output": "done\n"}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	cmd, err := client.Commands.Get(context.Background(), 1, 10, 3)
+	if err != nil {
+		t.Fatalf("Commands.Get: %v", err)
+	}
+	if cmd.Output != "done\n" {
+		t.Errorf("Output = %q, want %q", cmd.Output, "done\n")
+	}
+}
+
+func TestCertificateCovers(t *testing.T) {
+	cert := Certificate{Domain: "example.com, WWW.example.com,*.shop.example.com"}
+
+	for host, want := range map[string]bool{
+		"example.com":          true,
+		"www.example.com":      true,
+		"eu.shop.example.com":  true,
+		"shop.example.com":     false,
+		"a.b.shop.example.com": false,
+		"api.example.com":      false,
+	} {
+		if got := cert.Covers(host); got != want {
+			t.Errorf("Covers(%q) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestCertificatesActive(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/servers/1/sites/10/certificates":
+			_, _ = w.Write([]byte(`{"certificates": [{"id": 4, "active": false}, {"id": 5, "active": true}]}`))
+		case "/servers/1/sites/10/certificates/5":
+			_, _ = w.Write([]byte(`{"certificate": {"id": 5, "active": true, "domain": "example.com"}}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	cert, err := client.Certificates.Active(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("Certificates.Active: %v", err)
+	}
+	if cert == nil || cert.ID != 5 || cert.Domain != "example.com" {
+		t.Errorf("Active = %+v, want certificate 5", cert)
+	}
+}
+
+func TestRedirectsCreate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/servers/1/sites/10/redirect-rules" {
+			t.Errorf("path = %s, want /servers/1/sites/10/redirect-rules", r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["from"] != "https://www.example.com" || body["to"] != "https://example.com" || body["type"] != "permanent" {
+			t.Errorf("body = %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"redirect_rule": {"id": 7, "from": "https://www.example.com", "to": "https://example.com", "type": "permanent"}}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	rule, err := client.Redirects.Create(context.Background(), 1, 10, RedirectCreateOpts{
+		From: "https://www.example.com",
+		To:   "https://example.com",
+		Type: RedirectPermanent,
+	})
+	if err != nil {
+		t.Fatalf("Redirects.Create: %v", err)
+	}
+	if rule.ID != 7 {
+		t.Errorf("rule.ID = %d, want 7", rule.ID)
+	}
+}
+
+func TestSecurityRulesCreate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/servers/1/sites/10/security-rules" {
+			t.Errorf("path = %s, want /servers/1/sites/10/security-rules", r.URL.Path)
+		}
+		var body struct {
+			Name        string              `json:"name"`
+			Path        string              `json:"path"`
+			Credentials []map[string]string `json:"credentials"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body.Name != "Staging" || body.Path != "/admin" || len(body.Credentials) != 1 ||
+			body.Credentials[0]["username"] != "client" || body.Credentials[0]["password"] != "hunter2" {
+			t.Errorf("body = %+v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"security_rule": {"id": 4, "name": "Staging", "path": "/admin", "credentials": [{"id": 9, "username": "client"}]}}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	rule, err := client.Security.Create(context.Background(), 1, 10, SecurityRuleCreateOpts{
+		Name:        "Staging",
+		Path:        "/admin",
+		Credentials: []SecurityCredentialInput{{Username: "client", Password: "hunter2"}},
+	})
+	if err != nil {
+		t.Fatalf("Security.Create: %v", err)
+	}
+	if rule.ID != 4 || len(rule.Credentials) != 1 || rule.Credentials[0].Username != "client" {
+		t.Errorf("rule = %+v, want rule 4 for client", rule)
+	}
+}
+
+func TestDeploymentsResetTriggerURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/servers/1/sites/10/deployment/token" {
+			t.Errorf("path = %s, want /servers/1/sites/10/deployment/token", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	if err := client.Deployments.ResetTriggerURL(context.Background(), 1, 10); err != nil {
+		t.Fatalf("Deployments.ResetTriggerURL: %v", err)
+	}
+}
+
+func TestGitInstall(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/servers/1/sites/10/git" {
+			t.Errorf("path = %s, want /servers/1/sites/10/git", r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["provider"] != "github" || body["repository"] != "acme/api" || body["branch"] != "main" || body["composer"] != true {
+			t.Errorf("body = %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	err := client.Git.Install(context.Background(), 1, 10, GitInstallOpts{
+		Provider:   "github",
+		Repository: "acme/api",
+		Branch:     "main",
+		Composer:   true,
+	})
+	if err != nil {
+		t.Fatalf("Git.Install: %v", err)
+	}
+}
+
+func TestCirclesForServer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/circles" {
+			t.Errorf("path = %s, want /circles", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"circles": [
+			{"id": 1, "name": "Developers", "servers": [{"id": 1}, {"id": 2}], "members": [{"id": 5, "email": "sam@example.com"}]},
+			{"id": 2, "name": "Agency", "servers": [{"id": 3}]}
+		]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	circles, err := client.Circles.ForServer(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("Circles.ForServer: %v", err)
+	}
+	if len(circles) != 1 || circles[0].Name != "Developers" || len(circles[0].Members) != 1 {
+		t.Errorf("circles = %+v, want Developers only", circles)
+	}
+}
+
+func TestCirclesInvite(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/circles/1/members" {
+			t.Errorf("path = %s, want /circles/1/members", r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["email"] != "sam@example.com" {
+			t.Errorf("body = %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"member": {"id": 5, "email": "sam@example.com", "status": "pending"}}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	member, err := client.Circles.Invite(context.Background(), 1, "sam@example.com")
+	if err != nil {
+		t.Fatalf("Circles.Invite: %v", err)
+	}
+	if member.ID != 5 || member.Status != "pending" {
+		t.Errorf("member = %+v", member)
+	}
+}
+
+func TestCredentialsList(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/credentials" {
+			t.Errorf("path = %s, want /credentials", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"credentials": [{"id": 1, "type": "ocean2", "name": "Personal"}, {"id": 2, "type": "newcloud", "name": "Work"}]}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	creds, err := client.Credentials.List(context.Background())
+	if err != nil {
+		t.Fatalf("Credentials.List: %v", err)
+	}
+	if len(creds) != 2 {
+		t.Fatalf("got %d credentials, want 2", len(creds))
+	}
+	if got := creds[0].Provider(); got != "DigitalOcean" {
+		t.Errorf("creds[0].Provider() = %q, want DigitalOcean", got)
+	}
+	if got := creds[1].Provider(); got != "newcloud" {
+		t.Errorf("creds[1].Provider() = %q, want the raw type", got)
+	}
+}
+
+func TestCredentialsRegions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/regions" {
+			t.Errorf("path = %s, want /regions", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"regions": {"ocean2": [{"id": "ams3", "name": "Amsterdam 3", "sizes": [{"id": "01", "size": "s-1vcpu-1gb", "name": "1GB RAM - 1 CPU Core"}]}]}}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	regions, err := client.Credentials.Regions(context.Background())
+	if err != nil {
+		t.Fatalf("Credentials.Regions: %v", err)
+	}
+	ocean := regions["ocean2"]
+	if len(ocean) != 1 || ocean[0].ID != "ams3" || len(ocean[0].Sizes) != 1 || ocean[0].Sizes[0].Size != "s-1vcpu-1gb" {
+		t.Errorf("regions = %+v", regions)
+	}
+}
+
+func TestNginxUpdateTemplate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		if r.URL.Path != "/servers/1/nginx/templates/4" {
+			t.Errorf("path = %s, want /servers/1/nginx/templates/4", r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["name"] != "Laravel" || body["content"] != "server {}" {
+			t.Errorf("body = %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	if err := client.Nginx.UpdateTemplate(context.Background(), 1, 4, "Laravel", "server {}"); err != nil {
+		t.Fatalf("Nginx.UpdateTemplate: %v", err)
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	srv := Server{ID: 1, IPAddress: "203.0.113.5"}
+	site := Site{ID: 10, Name: "example.com", Directory: "/public", Aliases: []string{"www.example.com"}}
+	content := "server_name {{DOMAINS}};\nroot {{PATH}};\n# {{SITE_ID}} on {{SERVER_PUBLIC_IP}} as {{USER}}\n"
+	want := "server_name example.com www.example.com;\nroot /home/forge/example.com/public;\n# 10 on 203.0.113.5 as forge\n"
+	if got := RenderTemplate(content, srv, site); got != want {
+		t.Errorf("RenderTemplate = %q, want %q", got, want)
+	}
+
+	site.Username = "shop"
+	site.Wildcards = true
+	want = "server_name example.com www.example.com *.example.com;\nroot /home/shop/example.com/public;\n# 10 on 203.0.113.5 as shop\n"
+	if got := RenderTemplate(content, srv, site); got != want {
+		t.Errorf("RenderTemplate (isolated) = %q, want %q", got, want)
+	}
+}
+
+func TestServersSetMaxUploadSize(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %s, want PUT", r.Method)
+		}
+		if r.URL.Path != "/servers/1/php/max-upload-size" {
+			t.Errorf("path = %s, want /servers/1/php/max-upload-size", r.URL.Path)
+		}
+		var body map[string]int
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["megabytes"] != 128 {
+			t.Errorf("body = %v", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	if err := client.Servers.SetMaxUploadSize(context.Background(), 1, 128); err != nil {
+		t.Fatalf("Servers.SetMaxUploadSize: %v", err)
+	}
+}
+
+func TestJobsCreateCustom(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/servers/1/jobs" {
+			t.Errorf("request = %s %s, want POST /servers/1/jobs", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["frequency"] != "custom" || body["minute"] != "*/15" || body["hour"] != "*" || body["weekday"] != "1-5" {
+			t.Errorf("body = %v", body)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"job": {"id": 4, "command": "php artisan report", "cron": "*/15 * * * 1-5"}}`))
+	}))
+	defer srv.Close()
+
+	opts := JobCreateOpts{Command: "php artisan report", User: "forge"}
+	if err := opts.SetSchedule("*/15 * * * 1-5"); err != nil {
+		t.Fatalf("SetSchedule: %v", err)
+	}
+	client := newTestClient(t, srv)
+	job, err := client.Jobs.Create(context.Background(), 1, opts)
+	if err != nil {
+		t.Fatalf("Jobs.Create: %v", err)
+	}
+	if job.ID != 4 {
+		t.Errorf("job.ID = %d, want 4", job.ID)
+	}
+}
+
+func TestJobCreateOptsSetSchedule(t *testing.T) {
+	var opts JobCreateOpts
+	if err := opts.SetSchedule("Hourly"); err != nil || opts.Frequency != "hourly" || opts.Minute != "" {
+		t.Errorf("SetSchedule(Hourly) = %v, opts %+v", err, opts)
+	}
+	if err := opts.SetSchedule("every tuesday"); err == nil {
+		t.Error("SetSchedule(every tuesday) succeeded, want an error")
+	}
+}
+
+func TestJobsGetOutput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/servers/1/jobs/4/output" {
+			t.Errorf("path = %s, want /servers/1/jobs/4/output", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"output": "Report sent\n"}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	out, err := client.Jobs.GetOutput(context.Background(), 1, 4)
+	if err != nil {
+		t.Fatalf("Jobs.GetOutput: %v", err)
+	}
+	if out != "Report sent\n" {
+		t.Errorf("output = %q, want %q", out, "Report sent\n")
+	}
+}
+
+func TestRecipesRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/recipes/3/run" {
+			t.Errorf("path = %s, want /recipes/3/run", r.URL.Path)
+		}
+		var body struct {
+			Servers []int64 `json:"servers"`
+			Notify  bool    `json:"notify"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if len(body.Servers) != 2 || body.Servers[0] != 1 || body.Servers[1] != 2 || body.Notify {
+			t.Errorf("body = %+v", body)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	if err := client.Recipes.Run(context.Background(), 3, []int64{1, 2}, false); err != nil {
+		t.Fatalf("Recipes.Run: %v", err)
+	}
+}
+
+func TestEventsGetOutput(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/servers/1/events/9" {
+			t.Errorf("path = %s, want /servers/1/events/9", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"output": "Reading package lists...\n"}`))
+	}))
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+	out, err := client.Events.GetOutput(context.Background(), 1, 9)
+	if err != nil {
+		t.Fatalf("Events.GetOutput: %v", err)
+	}
+	if out != "Reading package lists...\n" {
+		t.Errorf("output = %q", out)
+	}
+}
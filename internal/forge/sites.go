@@ -6,12 +6,23 @@ import (
 	"net/http"
 )
 
+// SiteUpdateOpts contains the options for updating a site. Empty fields
+// are left unchanged.
+type SiteUpdateOpts struct {
+	Name      string `json:"name,omitempty"`      // new domain name
+	Directory string `json:"directory,omitempty"` // web directory, e.g. "/public"
+	Wildcards *bool  `json:"wildcards,omitempty"` // nil leaves unchanged
+}
+
 // List returns all sites on a server.
 func (s *SitesService) List(ctx context.Context, serverID int64) ([]Site, error) {
 	var resp struct {
 		Sites []Site `json:"sites"`
 	}
 	err := s.client.do(ctx, http.MethodGet, fmt.Sprintf("/servers/%d/sites", serverID), nil, &resp)
+	for i := range resp.Sites {
+		resp.Sites[i].Aliases = NormalizeAliases(resp.Sites[i].Aliases)
+	}
 	return resp.Sites, err
 }
 
@@ -25,12 +36,27 @@ func (s *SitesService) Get(ctx context.Context, serverID, siteID int64) (*Site,
 	if err != nil {
 		return nil, err
 	}
+	resp.Site.Aliases = NormalizeAliases(resp.Site.Aliases)
+	return &resp.Site, nil
+}
+
+// Update changes a site's name, web directory and/or wildcard setting.
+func (s *SitesService) Update(ctx context.Context, serverID, siteID int64, opts SiteUpdateOpts) (*Site, error) {
+	var resp struct {
+		Site Site `json:"site"`
+	}
+	path := fmt.Sprintf("/servers/%d/sites/%d", serverID, siteID)
+	err := s.client.do(ctx, http.MethodPut, path, opts, &resp)
+	if err != nil {
+		return nil, err
+	}
 	return &resp.Site, nil
 }
 
-// UpdateAliases updates the domain aliases for a site.
+// UpdateAliases replaces the domain aliases of a site. The list is
+// normalised first (see NormalizeAliases).
 func (s *SitesService) UpdateAliases(ctx context.Context, serverID, siteID int64, aliases []string) (*Site, error) {
-	body := map[string]any{"aliases": aliases}
+	body := map[string]any{"aliases": NormalizeAliases(aliases)}
 	var resp struct {
 		Site Site `json:"site"`
 	}
@@ -39,6 +65,7 @@ func (s *SitesService) UpdateAliases(ctx context.Context, serverID, siteID int64
 	if err != nil {
 		return nil, err
 	}
+	resp.Site.Aliases = NormalizeAliases(resp.Site.Aliases)
 	return &resp.Site, nil
 }
 
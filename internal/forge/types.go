@@ -25,6 +25,7 @@ type Server struct {
 	UbuntuVersion    string `json:"ubuntu_version,omitempty"`
 	DBStatus         string `json:"db_status,omitempty"`
 	RedisStatus      string `json:"redis_status,omitempty"`
+	OPcacheStatus    string `json:"opcache_status,omitempty"`
 	Network          []any  `json:"network,omitempty"`
 	Tags             []any  `json:"tags,omitempty"`
 }
@@ -49,6 +50,7 @@ type Site struct {
 	Wildcards          bool     `json:"wildcards"`
 	Aliases            []string `json:"aliases,omitempty"`
 	IsSecured          bool     `json:"is_secured"`
+	Username           string   `json:"username,omitempty"`
 	Tags               []any    `json:"tags,omitempty"`
 }
 
@@ -141,12 +143,13 @@ type Worker struct {
 
 // Certificate represents an SSL certificate on a site.
 type Certificate struct {
-	ID       int64  `json:"id"`
-	Domain   string `json:"domain,omitempty"`
-	Type     string `json:"type,omitempty"`
-	Active   bool   `json:"active"`
-	Status   string `json:"status,omitempty"`
-	Existing bool   `json:"existing"`
+	ID        int64  `json:"id"`
+	Domain    string `json:"domain,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Active    bool   `json:"active"`
+	Status    string `json:"status,omitempty"`
+	Existing  bool   `json:"existing"`
+	ExpiresAt string `json:"expires_at,omitempty"` // only returned by Get
 }
 
 // Backup represents a single backup snapshot.
@@ -184,6 +187,9 @@ type SiteCommand struct {
 	Duration        any    `json:"duration,omitempty"`
 	ProfilePhotoURL string `json:"profile_photo_url,omitempty"`
 	UserName        string `json:"user_name,omitempty"`
+
+	// Output is the command's output. Only Get fills it in.
+	Output string `json:"output,omitempty"`
 }
 
 // Event represents a server activity event (e.g. deployment, reboot).
@@ -204,3 +210,116 @@ type RedirectRule struct {
 	Type   string `json:"type,omitempty"`
 	Status string `json:"status,omitempty"`
 }
+
+// SecurityRule represents a site's HTTP basic authentication rule: the
+// path it protects and the users who may pass it.
+type SecurityRule struct {
+	ID          int64                `json:"id"`
+	Name        string               `json:"name"`
+	Path        string               `json:"path,omitempty"`
+	Status      string               `json:"status,omitempty"`
+	CreatedAt   string               `json:"created_at,omitempty"`
+	Credentials []SecurityCredential `json:"credentials,omitempty"`
+}
+
+// SecurityCredential is a username allowed through a security rule.
+type SecurityCredential struct {
+	ID       int64  `json:"id,omitempty"`
+	Username string `json:"username"`
+}
+
+// Circle represents a circle: a group of Forge users who share access to
+// a set of servers.
+type Circle struct {
+	ID      int64          `json:"id"`
+	Name    string         `json:"name"`
+	Members []CircleMember `json:"members,omitempty"`
+	Servers []CircleServer `json:"servers,omitempty"`
+}
+
+// CircleMember is a user in a circle, or an invitation that hasn't been
+// accepted yet.
+type CircleMember struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Email  string `json:"email,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// CircleServer is a server a circle grants access to.
+type CircleServer struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// HasServer reports whether the circle grants access to the server.
+func (c Circle) HasServer(serverID int64) bool {
+	for _, s := range c.Servers {
+		if s.ID == serverID {
+			return true
+		}
+	}
+	return false
+}
+
+// Credential is a cloud provider account linked to Forge, used when
+// provisioning servers.
+type Credential struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"` // e.g. "ocean2", "aws", "hetzner"
+	Name string `json:"name"`
+}
+
+// providerNames maps Forge's credential types to provider names.
+var providerNames = map[string]string{
+	"ocean2":  "DigitalOcean",
+	"linode":  "Akamai (Linode)",
+	"vultr":   "Vultr",
+	"vultr2":  "Vultr",
+	"aws":     "AWS",
+	"hetzner": "Hetzner",
+	"custom":  "Custom VPS",
+}
+
+// Provider returns the name of the credential's provider, or its type
+// when Forge adds one this doesn't know.
+func (c Credential) Provider() string {
+	if name, ok := providerNames[c.Type]; ok {
+		return name
+	}
+	return c.Type
+}
+
+// Region is a data centre a provider can create servers in, with the
+// server sizes offered there.
+type Region struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Sizes []Size `json:"sizes,omitempty"`
+}
+
+// Size is a server size offered in a region.
+type Size struct {
+	ID   string `json:"id"`
+	Size string `json:"size"` // the provider's name for it, e.g. "s-1vcpu-1gb"
+	Name string `json:"name"` // e.g. "1GB RAM - 1 CPU Core - 25GB SSD"
+}
+
+// NginxTemplate is a reusable nginx server block stored on a server.
+// Content may use Forge's placeholders, e.g. {{DOMAINS}} and {{PATH}}.
+type NginxTemplate struct {
+	ID       int64  `json:"id"`
+	ServerID int64  `json:"server_id,omitempty"`
+	Name     string `json:"name"`
+	Content  string `json:"content,omitempty"`
+}
+
+// Recipe is a saved bash script that can be run on any of the account's
+// servers.
+type Recipe struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	User      string `json:"user,omitempty"`
+	Script    string `json:"script,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
@@ -13,6 +13,7 @@ type WorkerCreateOpts struct {
 	Timeout    int    `json:"timeout"`               // default 60
 	Sleep      int    `json:"sleep"`                 // default 3
 	Processes  int    `json:"processes"`             // default 1
+	Tries      int    `json:"tries,omitempty"`       // 0 retries a failed job forever
 	Daemon     bool   `json:"daemon"`                // default true
 	Force      bool   `json:"force"`                 // default false
 	PHPVersion string `json:"php_version,omitempty"` // optional
@@ -25,6 +26,7 @@ func (s *WorkersService) List(ctx context.Context, serverID, siteID int64) ([]Wo
 	}
 	path := fmt.Sprintf("/servers/%d/sites/%d/workers", serverID, siteID)
 	err := s.client.do(ctx, http.MethodGet, path, nil, &resp)
+	sortByID(resp.Workers, func(w Worker) int64 { return w.ID })
 	return resp.Workers, err
 }
 
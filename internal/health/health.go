@@ -0,0 +1,63 @@
+// Package health probes a site's health-check URL, used after a deploy to
+// catch a broken release straight away.
+package health
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Timeout bounds a single probe, including reading the response body.
+const Timeout = 10 * time.Second
+
+// Result is the outcome of probing a URL.
+type Result struct {
+	URL      string
+	Status   int // HTTP status code, or 0 if no response was received
+	Duration time.Duration
+	Err      error
+}
+
+// OK reports whether the probe got a 2xx or 3xx response.
+func (r Result) OK() bool {
+	return r.Err == nil && r.Status >= 200 && r.Status < 400
+}
+
+// String describes the result, e.g. "200 OK in 84ms".
+func (r Result) String() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	return fmt.Sprintf("%d %s in %s", r.Status, http.StatusText(r.Status), r.Duration.Round(time.Millisecond))
+}
+
+// Probe sends a GET request to url and reports the response status.
+// Redirects are followed.
+func Probe(ctx context.Context, url string) Result {
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	result := Result{URL: url}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.Err = fmt.Errorf("invalid health check URL: %w", err)
+		return result
+	}
+	req.Header.Set("User-Agent", "phorge-health-check")
+
+	start := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	result.Status = resp.StatusCode
+	result.Duration = time.Since(start)
+	return result
+}
@@ -0,0 +1,73 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestProbeStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		ok     bool
+	}{
+		{http.StatusOK, true},
+		{http.StatusNoContent, true},
+		{http.StatusInternalServerError, false},
+		{http.StatusServiceUnavailable, false},
+		{http.StatusNotFound, false},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.Header.Get("User-Agent"); got != "phorge-health-check" {
+				t.Errorf("User-Agent = %q", got)
+			}
+			w.WriteHeader(tt.status)
+		}))
+		r := Probe(context.Background(), srv.URL)
+		srv.Close()
+
+		if r.Status != tt.status {
+			t.Errorf("Status = %d, want %d", r.Status, tt.status)
+		}
+		if r.OK() != tt.ok {
+			t.Errorf("status %d: OK() = %v, want %v", tt.status, r.OK(), tt.ok)
+		}
+	}
+}
+
+func TestProbeFollowsRedirects(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/up", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/health", http.StatusFound)
+	})
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	r := Probe(context.Background(), srv.URL+"/up")
+	if r.Status != http.StatusOK || !r.OK() {
+		t.Errorf("Probe = %v, want 200", r)
+	}
+}
+
+func TestProbeUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	r := Probe(context.Background(), url)
+	if r.Err == nil || r.OK() {
+		t.Errorf("Probe of closed server = %v, want error", r)
+	}
+}
+
+func TestProbeInvalidURL(t *testing.T) {
+	r := Probe(context.Background(), "://nope")
+	if r.Err == nil {
+		t.Error("Probe of invalid URL succeeded")
+	}
+}
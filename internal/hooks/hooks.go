@@ -0,0 +1,156 @@
+// Package hooks runs the user scripts configured as [[hooks]] when events
+// happen in the TUI, so Phorge can be extended without forking it.
+//
+// A hook is either a command, written in any language, or a Starlark
+// script run by the embedded interpreter (see RunScript), which can also
+// read from Forge.
+//
+// A command is run with sh -c. It gets the event as a JSON object on stdin
+// (and its name in $PHORGE_EVENT), and may ask Phorge to act by printing
+// requests to stdout, one JSON object per line:
+//
+//	{"action": "toast", "message": "Slack notified"}
+//	{"action": "toast", "message": "smoke test failed", "error": true}
+//	{"action": "deploy", "server": "prod-1", "site": "example.com"}
+//
+// Only these requests are available to hooks; anything else a command
+// needs it can do itself. Other output lines that don't start with "{" are
+// ignored.
+package hooks
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Timeout bounds a single hook run.
+const Timeout = 30 * time.Second
+
+// Event describes what happened. Fields that don't apply to an event are
+// left out of its JSON.
+type Event struct {
+	Name     string    `json:"event"`
+	Time     time.Time `json:"time"`
+	Server   string    `json:"server,omitempty"`
+	ServerID int64     `json:"server_id,omitempty"`
+	Site     string    `json:"site,omitempty"`
+	SiteID   int64     `json:"site_id,omitempty"`
+
+	// Tab is the ID of the opened tab (see config.TabIDs), for
+	// panel_loaded.
+	Tab string `json:"tab,omitempty"`
+
+	// Status is "finished" or "failed", for deploy_finished.
+	Status       string `json:"status,omitempty"`
+	DeploymentID int64  `json:"deployment_id,omitempty"`
+}
+
+// Request actions a hook may print.
+const (
+	ActionToast  = "toast"
+	ActionDeploy = "deploy"
+)
+
+// Request is something a hook asks Phorge to do.
+type Request struct {
+	Action string `json:"action"`
+
+	// Message and Error are the text of a toast and whether it is shown
+	// as an error.
+	Message string `json:"message,omitempty"`
+	Error   bool   `json:"error,omitempty"`
+
+	// Server and Site name the site to deploy. The deploy is subject to
+	// the same maintenance windows as one started by hand, without the
+	// option of an override.
+	Server string `json:"server,omitempty"`
+	Site   string `json:"site,omitempty"`
+}
+
+// Result is the outcome of running a hook.
+type Result struct {
+	Command  string
+	Event    string
+	Requests []Request
+	Err      error
+}
+
+// Run runs command for ev and collects the requests it prints. A hook that
+// exits non-zero, times out or prints an invalid request reports an error,
+// along with any valid requests printed before it.
+func Run(ctx context.Context, command string, ev Event) Result {
+	res := Result{Command: command, Event: ev.Name}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	input, err := json.Marshal(ev)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	c := exec.CommandContext(ctx, "sh", "-c", command)
+	c.Env = append(os.Environ(), "PHORGE_EVENT="+ev.Name)
+	c.Stdin = bytes.NewReader(input)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	runErr := c.Run()
+
+	res.Requests, res.Err = ParseRequests(stdout.Bytes())
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		res.Err = fmt.Errorf("timed out after %s", Timeout)
+	case runErr != nil:
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			runErr = fmt.Errorf("%w: %s", runErr, lastLine(msg))
+		}
+		res.Err = runErr
+	}
+	return res
+}
+
+// ParseRequests reads the requests in a hook's output, stopping at the
+// first invalid one.
+func ParseRequests(output []byte) ([]Request, error) {
+	var requests []Request
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "{") {
+			continue
+		}
+		var r Request
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			return requests, fmt.Errorf("output line %d: %w", n, err)
+		}
+		switch {
+		case r.Action == ActionToast && r.Message == "":
+			return requests, fmt.Errorf("output line %d: toast needs a message", n)
+		case r.Action == ActionDeploy && (r.Server == "" || r.Site == ""):
+			return requests, fmt.Errorf("output line %d: deploy needs a server and a site", n)
+		case r.Action != ActionToast && r.Action != ActionDeploy:
+			return requests, fmt.Errorf("output line %d: unknown action %q (want %q or %q)", n, r.Action, ActionToast, ActionDeploy)
+		}
+		requests = append(requests, r)
+	}
+	return requests, scanner.Err()
+}
+
+// lastLine returns the last line of s, which is usually the one that
+// explains a failure.
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,138 @@
+package hooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+func TestParseRequests(t *testing.T) {
+	output := `notifying slack...
+{"action": "toast", "message": "Slack notified"}
+{"action": "deploy", "server": "prod-1", "site": "example.com"}
+`
+	got, err := ParseRequests([]byte(output))
+	if err != nil {
+		t.Fatalf("ParseRequests: %v", err)
+	}
+	if len(got) != 2 || got[0].Message != "Slack notified" || got[1].Site != "example.com" {
+		t.Errorf("ParseRequests = %+v", got)
+	}
+
+	for _, bad := range []string{
+		`{"action": "toast"}`,
+		`{"action": "deploy", "site": "example.com"}`,
+		`{"action": "delete_server", "server": "prod-1"}`,
+		`{"action": `,
+	} {
+		if _, err := ParseRequests([]byte(bad)); err == nil {
+			t.Errorf("ParseRequests(%s): want an error", bad)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	// The hook echoes the event's site and name back as a toast.
+	command := `site=$(sed 's/.*"site":"\([^"]*\)".*/\1/'); echo "{\"action\":\"toast\",\"message\":\"$PHORGE_EVENT $site\"}"`
+	res := Run(context.Background(), command, Event{Name: "deploy_finished", Site: "example.com", Status: "finished"})
+	if res.Err != nil {
+		t.Fatalf("Run: %v", res.Err)
+	}
+	if len(res.Requests) != 1 || res.Requests[0].Message != "deploy_finished example.com" {
+		t.Errorf("Requests = %+v", res.Requests)
+	}
+
+	res = Run(context.Background(), `echo '{"action":"toast","message":"before"}'; echo boom >&2; exit 3`, Event{Name: "panel_loaded"})
+	if res.Err == nil || !strings.Contains(res.Err.Error(), "boom") {
+		t.Errorf("Err = %v, want the exit status and stderr", res.Err)
+	}
+	if len(res.Requests) != 1 {
+		t.Errorf("Requests = %+v, want the one printed before failing", res.Requests)
+	}
+}
+
+// writeScript writes a Starlark hook script to a temporary file.
+func writeScript(t *testing.T, src string) string {
+	path := filepath.Join(t.TempDir(), "hook.star")
+	if err := os.WriteFile(path, []byte(src), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunScript(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/servers":
+			_, _ = w.Write([]byte(`{"servers": [{"id": 1, "name": "prod-1"}, {"id": 2, "name": "staging"}]}`))
+		case "/servers/1/sites/10/deployment-history":
+			_, _ = w.Write([]byte(`{"deployments": [{"id": 7, "site_id": 10, "status": "failed"}, {"id": 6, "site_id": 10, "status": "finished"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	client := forge.NewClient("test-token")
+	client.BaseURL = srv.URL
+
+	// The script retries a failed deploy once, unless the previous
+	// deployment failed too.
+	path := writeScript(t, `
+servers = [s["name"] for s in phorge.servers()]
+history = phorge.deployments(event["server_id"], event["site_id"])
+if event["status"] == "failed" and history[1]["status"] == "finished":
+    phorge.toast("retrying %s on %s" % (event["site"], ", ".join(servers)))
+    phorge.deploy(event["server"], event["site"])
+`)
+	ev := Event{Name: "deploy_finished", Server: "prod-1", ServerID: 1, Site: "example.com", SiteID: 10, Status: "failed", DeploymentID: 7}
+	res := RunScript(context.Background(), path, ev, client)
+	if res.Err != nil {
+		t.Fatalf("RunScript: %v", res.Err)
+	}
+	want := []Request{
+		{Action: ActionToast, Message: "retrying example.com on prod-1, staging"},
+		{Action: ActionDeploy, Server: "prod-1", Site: "example.com"},
+	}
+	if !slices.Equal(res.Requests, want) {
+		t.Errorf("Requests = %+v, want %+v", res.Requests, want)
+	}
+
+	// A failing script reports the error along with the requests made
+	// before it.
+	path = writeScript(t, `
+phorge.toast("before")
+phorge.sites(99)
+`)
+	res = RunScript(context.Background(), path, ev, client)
+	if res.Err == nil || !strings.Contains(res.Err.Error(), "sites") {
+		t.Errorf("Err = %v, want the failed sites call", res.Err)
+	}
+	if len(res.Requests) != 1 {
+		t.Errorf("Requests = %+v, want the one made before failing", res.Requests)
+	}
+}
+
+func TestRunScriptSandboxed(t *testing.T) {
+	for _, src := range []string{
+		"for i in range(1 << 40):\n    pass\n", // runs out of steps
+		`load("os.star", "system")`,
+		`phorge.delete_server(1)`,
+		`phorge.deploy("prod-1", "")`,
+	} {
+		res := RunScript(context.Background(), writeScript(t, src), Event{Name: "panel_loaded"}, forge.NewClient("test-token"))
+		if res.Err == nil {
+			t.Errorf("script %q: want an error", src)
+		}
+		if len(res.Requests) != 0 {
+			t.Errorf("script %q: Requests = %+v", src, res.Requests)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkjson"
+	"go.starlark.net/starlarkstruct"
+	"go.starlark.net/syntax"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// maxScriptSteps bounds the work a script may do, so a runaway loop fails
+// quickly instead of running until the timeout.
+const maxScriptSteps = 10_000_000
+
+// scriptOptions lets hook scripts, which are run once from the top, use
+// if, for and while statements outside functions and reassign globals.
+var scriptOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+}
+
+// requestsKey is the thread-local key scripts' requests are collected
+// under.
+const requestsKey = "phorge.requests"
+
+// RunScript runs the Starlark script at path for ev and collects the
+// requests it makes.
+//
+// The script is run from the top each time its event fires, with these
+// predeclared:
+//
+//	event                    the event, as a dict with the keys of its JSON
+//	json                     the Starlark json module (encode, decode, indent)
+//	phorge.toast(message, error=False)
+//	phorge.deploy(server, site)
+//	phorge.servers()
+//	phorge.sites(server_id)
+//	phorge.site(server_id, site_id)
+//	phorge.deployments(server_id, site_id)
+//	phorge.deployment_output(server_id, site_id, deployment_id)
+//
+// toast and deploy are requests carried out by the TUI once the script
+// ends, like those a hook command prints. The rest read from Forge through
+// client and return dicts and lists with the API's field names. Scripts
+// can't write to Forge any other way, or reach the file system, network
+// or shell.
+func RunScript(ctx context.Context, path string, ev Event, client *forge.Client) Result {
+	res := Result{Command: path, Event: ev.Name}
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	src, err := os.ReadFile(path)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	event, err := toStarlark(ev)
+	if err != nil {
+		res.Err = err
+		return res
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+	thread := &starlark.Thread{
+		Name:  ev.Name + " hook",
+		Print: func(*starlark.Thread, string) {},
+	}
+	thread.SetMaxExecutionSteps(maxScriptSteps)
+	thread.SetLocal(requestsKey, &res.Requests)
+	stop := context.AfterFunc(ctx, func() { thread.Cancel("timed out after " + Timeout.String()) })
+	defer stop()
+
+	api := scriptAPI{ctx: ctx, client: client}
+	predeclared := starlark.StringDict{
+		"event":  event,
+		"json":   starlarkjson.Module,
+		"phorge": api.module(),
+	}
+	if _, err := starlark.ExecFileOptions(scriptOptions, thread, filepath.Base(path), src, predeclared); err != nil {
+		res.Err = err
+	}
+	return res
+}
+
+// scriptAPI implements the phorge module of hook scripts.
+type scriptAPI struct {
+	ctx    context.Context
+	client *forge.Client
+}
+
+func (a scriptAPI) module() *starlarkstruct.Module {
+	return &starlarkstruct.Module{
+		Name: "phorge",
+		Members: starlark.StringDict{
+			"toast":             starlark.NewBuiltin("toast", toastBuiltin),
+			"deploy":            starlark.NewBuiltin("deploy", deployBuiltin),
+			"servers":           starlark.NewBuiltin("servers", a.servers),
+			"sites":             starlark.NewBuiltin("sites", a.sites),
+			"site":              starlark.NewBuiltin("site", a.site),
+			"deployments":       starlark.NewBuiltin("deployments", a.deployments),
+			"deployment_output": starlark.NewBuiltin("deployment_output", a.deploymentOutput),
+		},
+	}
+}
+
+// addRequest records a request made by the script running on thread.
+func addRequest(thread *starlark.Thread, r Request) {
+	requests := thread.Local(requestsKey).(*[]Request)
+	*requests = append(*requests, r)
+}
+
+func toastBuiltin(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var message string
+	var isErr bool
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "message", &message, "error?", &isErr); err != nil {
+		return nil, err
+	}
+	if message == "" {
+		return nil, fmt.Errorf("%s: empty message", b.Name())
+	}
+	addRequest(thread, Request{Action: ActionToast, Message: message, Error: isErr})
+	return starlark.None, nil
+}
+
+func deployBuiltin(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var server, site string
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "server", &server, "site", &site); err != nil {
+		return nil, err
+	}
+	if server == "" || site == "" {
+		return nil, fmt.Errorf("%s: needs a server and a site", b.Name())
+	}
+	addRequest(thread, Request{Action: ActionDeploy, Server: server, Site: site})
+	return starlark.None, nil
+}
+
+func (a scriptAPI) servers(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs); err != nil {
+		return nil, err
+	}
+	servers, err := a.client.Servers.List(a.ctx)
+	return apiResult(b, servers, err)
+}
+
+func (a scriptAPI) sites(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var serverID int64
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "server_id", &serverID); err != nil {
+		return nil, err
+	}
+	sites, err := a.client.Sites.List(a.ctx, serverID)
+	return apiResult(b, sites, err)
+}
+
+func (a scriptAPI) site(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var serverID, siteID int64
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "server_id", &serverID, "site_id", &siteID); err != nil {
+		return nil, err
+	}
+	site, err := a.client.Sites.Get(a.ctx, serverID, siteID)
+	return apiResult(b, site, err)
+}
+
+func (a scriptAPI) deployments(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var serverID, siteID int64
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "server_id", &serverID, "site_id", &siteID); err != nil {
+		return nil, err
+	}
+	deployments, err := a.client.Deployments.List(a.ctx, serverID, siteID)
+	return apiResult(b, deployments, err)
+}
+
+func (a scriptAPI) deploymentOutput(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var serverID, siteID, deployID int64
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "server_id", &serverID, "site_id", &siteID, "deployment_id", &deployID); err != nil {
+		return nil, err
+	}
+	output, err := a.client.Deployments.GetOutput(a.ctx, serverID, siteID, deployID)
+	return apiResult(b, output, err)
+}
+
+// apiResult converts the result of a Forge call made by builtin b.
+func apiResult(b *starlark.Builtin, v any, err error) (starlark.Value, error) {
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", b.Name(), err)
+	}
+	return toStarlark(v)
+}
+
+// toStarlark converts v to Starlark values through its JSON, so scripts
+// see the same field names as the Forge API and hook commands.
+func toStarlark(v any) (starlark.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return jsonToStarlark(decoded), nil
+}
+
+func jsonToStarlark(v any) starlark.Value {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		d := starlark.NewDict(len(v))
+		for _, k := range keys {
+			_ = d.SetKey(starlark.String(k), jsonToStarlark(v[k]))
+		}
+		return d
+	case []any:
+		elems := make([]starlark.Value, len(v))
+		for i, e := range v {
+			elems[i] = jsonToStarlark(e)
+		}
+		return starlark.NewList(elems)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return starlark.MakeInt64(i)
+		}
+		f, _ := v.Float64()
+		return starlark.Float(f)
+	case string:
+		return starlark.String(v)
+	case bool:
+		return starlark.Bool(v)
+	default:
+		return starlark.None
+	}
+}
@@ -0,0 +1,161 @@
+// Package monitor collects the state of servers and sites from Forge and
+// checks it against alert rules such as "deployment failed on
+// tag:production" or "cert expires <7d".
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// concurrency caps the requests made at once for one server's sites.
+const concurrency = 4
+
+// Needs selects the parts of a Snapshot to collect. Each costs requests
+// per server or per site, so only what the rules use is fetched.
+type Needs struct {
+	Deploys bool
+	Certs   bool
+	Daemons bool
+	Workers bool
+}
+
+// sites reports whether any of the needed parts is per site.
+func (n Needs) sites() bool {
+	return n.Deploys || n.Certs || n.Workers
+}
+
+// Snapshot is the state of a set of servers at one point in time.
+type Snapshot struct {
+	Time    time.Time
+	Servers []ServerState
+
+	// Errors lists what couldn't be fetched. The rest of the snapshot is
+	// still usable.
+	Errors []error
+}
+
+// ServerState is what was collected about one server.
+type ServerState struct {
+	Server  forge.Server
+	Daemons []forge.Daemon
+	Sites   []SiteState
+}
+
+// SiteState is what was collected about one site.
+type SiteState struct {
+	Site forge.Site
+
+	// Deploy is the status of the latest deployment, or "" if the site
+	// has none.
+	Deploy string
+
+	// HasCert is set when the site has an active certificate, and
+	// CertExpires to its expiry when Forge reports one.
+	HasCert     bool
+	CertExpires time.Time
+
+	Workers []forge.Worker
+}
+
+// Collect fetches what needs asks for about each server. Failed requests
+// are recorded in the snapshot's Errors rather than stopping the
+// collection.
+func Collect(ctx context.Context, client *forge.Client, servers []forge.Server, needs Needs) Snapshot {
+	snap := Snapshot{Time: time.Now()}
+	var mu sync.Mutex
+	fail := func(err error) {
+		mu.Lock()
+		snap.Errors = append(snap.Errors, err)
+		mu.Unlock()
+	}
+
+	for _, srv := range servers {
+		state := ServerState{Server: srv}
+		if needs.Daemons {
+			daemons, err := client.Daemons.List(ctx, srv.ID)
+			if err != nil {
+				fail(fmt.Errorf("%s: daemons: %w", srv.Name, err))
+			}
+			state.Daemons = daemons
+		}
+
+		if needs.sites() {
+			sites, err := client.Sites.List(ctx, srv.ID)
+			if err != nil {
+				fail(fmt.Errorf("%s: sites: %w", srv.Name, err))
+			}
+			state.Sites = make([]SiteState, len(sites))
+			var wg sync.WaitGroup
+			sem := make(chan struct{}, concurrency)
+			for i, site := range sites {
+				state.Sites[i].Site = site
+				wg.Add(1)
+				go func(st *SiteState) {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+					if err := collectSite(ctx, client, srv.ID, st, needs); err != nil {
+						fail(fmt.Errorf("%s/%s: %w", srv.Name, st.Site.Name, err))
+					}
+				}(&state.Sites[i])
+			}
+			wg.Wait()
+		}
+		snap.Servers = append(snap.Servers, state)
+	}
+	return snap
+}
+
+// collectSite fills in st, returning the first error met.
+func collectSite(ctx context.Context, client *forge.Client, serverID int64, st *SiteState, needs Needs) error {
+	siteID := st.Site.ID
+	if needs.Deploys {
+		deployments, err := client.Deployments.List(ctx, serverID, siteID)
+		if err != nil {
+			return fmt.Errorf("deployments: %w", err)
+		}
+		if len(deployments) > 0 {
+			st.Deploy = deployments[0].Status
+		}
+	}
+	if needs.Certs {
+		certs, err := client.Certificates.List(ctx, serverID, siteID)
+		if err != nil {
+			return fmt.Errorf("certificates: %w", err)
+		}
+		for _, c := range certs {
+			if !c.Active {
+				continue
+			}
+			st.HasCert = true
+			// The expiry is only returned for a single certificate.
+			if full, err := client.Certificates.Get(ctx, serverID, siteID, c.ID); err == nil {
+				st.CertExpires, _ = parseTime(full.ExpiresAt)
+			}
+			break
+		}
+	}
+	if needs.Workers {
+		workers, err := client.Workers.List(ctx, serverID, siteID)
+		if err != nil {
+			return fmt.Errorf("workers: %w", err)
+		}
+		st.Workers = workers
+	}
+	return nil
+}
+
+// parseTime parses the timestamp formats Forge uses.
+func parseTime(ts string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05.000000Z", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, ts); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
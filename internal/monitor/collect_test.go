@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+func TestCollect(t *testing.T) {
+	responses := map[string]string{
+		"/servers/1/daemons":                     `{"daemons": [{"id": 1, "command": "php artisan horizon", "status": "running"}]}`,
+		"/servers/1/sites":                       `{"sites": [{"id": 10, "name": "api.example.com"}, {"id": 11, "name": "example.com"}]}`,
+		"/servers/1/sites/10/deployment-history": `{"deployments": [{"id": 2, "status": "failed"}, {"id": 1, "status": "finished"}]}`,
+		"/servers/1/sites/10/certificates":       `{"certificates": [{"id": 5, "active": false}, {"id": 6, "active": true}]}`,
+		"/servers/1/sites/10/certificates/6":     `{"certificate": {"id": 6, "active": true, "expires_at": "2026-03-04T12:00:00Z"}}`,
+		"/servers/1/sites/11/deployment-history": `{"deployments": []}`,
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not found."}`))
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer srv.Close()
+	client := forge.NewClient("test-token")
+	client.BaseURL = srv.URL
+
+	snap := Collect(context.Background(), client, []forge.Server{{ID: 1, Name: "prod"}}, Needs{Deploys: true, Certs: true, Daemons: true})
+
+	if len(snap.Servers) != 1 {
+		t.Fatalf("collected %d servers, want 1", len(snap.Servers))
+	}
+	state := snap.Servers[0]
+	if len(state.Daemons) != 1 || state.Daemons[0].Status != "running" {
+		t.Errorf("Daemons = %+v", state.Daemons)
+	}
+	if len(state.Sites) != 2 {
+		t.Fatalf("collected %d sites, want 2", len(state.Sites))
+	}
+	api := state.Sites[0]
+	if api.Deploy != "failed" || !api.HasCert || !api.CertExpires.Equal(time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("api.example.com = %+v", api)
+	}
+	// example.com has no certificates route: its error is recorded and
+	// the rest is kept.
+	if len(snap.Errors) != 1 {
+		t.Errorf("Errors = %v, want one for example.com's certificates", snap.Errors)
+	}
+	if state.Sites[1].Deploy != "" {
+		t.Errorf("example.com Deploy = %q, want none", state.Sites[1].Deploy)
+	}
+}
@@ -0,0 +1,227 @@
+package monitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Export formats.
+const (
+	FormatJSON       = "json"
+	FormatPrometheus = "prometheus"
+)
+
+// All collects every part of a snapshot, for exports.
+var All = Needs{Deploys: true, Certs: true, Daemons: true, Workers: true}
+
+// export is the JSON form of a snapshot and the alerts raised from it.
+type export struct {
+	Time    time.Time      `json:"time"`
+	Servers []exportServer `json:"servers"`
+	Alerts  []Alert        `json:"alerts"`
+	Errors  []string       `json:"errors"`
+}
+
+type exportServer struct {
+	ID      int64          `json:"id"`
+	Name    string         `json:"name"`
+	Daemons []exportStatus `json:"daemons,omitempty"`
+	Sites   []exportSite   `json:"sites,omitempty"`
+}
+
+type exportSite struct {
+	ID          int64          `json:"id"`
+	Name        string         `json:"name"`
+	Deploy      string         `json:"deploy,omitempty"`
+	HasCert     bool           `json:"has_cert"`
+	CertExpires *time.Time     `json:"cert_expires,omitempty"`
+	Workers     []exportStatus `json:"workers,omitempty"`
+}
+
+type exportStatus struct {
+	ID     int64  `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// WriteJSON writes snap and alerts to w as an indented JSON document.
+func WriteJSON(w io.Writer, snap Snapshot, alerts []Alert) error {
+	doc := export{Time: snap.Time, Servers: []exportServer{}, Alerts: alerts, Errors: []string{}}
+	if doc.Alerts == nil {
+		doc.Alerts = []Alert{}
+	}
+	for _, err := range snap.Errors {
+		doc.Errors = append(doc.Errors, err.Error())
+	}
+	for _, srv := range snap.Servers {
+		es := exportServer{ID: srv.Server.ID, Name: srv.Server.Name}
+		for _, d := range srv.Daemons {
+			es.Daemons = append(es.Daemons, exportStatus{ID: d.ID, Name: d.Command, Status: d.Status})
+		}
+		for _, st := range srv.Sites {
+			site := exportSite{ID: st.Site.ID, Name: st.Site.Name, Deploy: st.Deploy, HasCert: st.HasCert}
+			if !st.CertExpires.IsZero() {
+				exp := st.CertExpires
+				site.CertExpires = &exp
+			}
+			for _, wk := range st.Workers {
+				site.Workers = append(site.Workers, exportStatus{ID: wk.ID, Name: workerName(wk.Connection, wk.Queue), Status: wk.Status})
+			}
+			es.Sites = append(es.Sites, site)
+		}
+		doc.Servers = append(doc.Servers, es)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// WritePrometheus writes snap and alerts to w in the Prometheus text
+// exposition format, for node_exporter's textfile collector.
+func WritePrometheus(w io.Writer, snap Snapshot, alerts []Alert) error {
+	var b strings.Builder
+	metric := func(name, help, typ string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+	sample := func(name string, value float64, labels ...string) {
+		b.WriteString(name)
+		if len(labels) > 0 {
+			b.WriteByte('{')
+			for i := 0; i+1 < len(labels); i += 2 {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				fmt.Fprintf(&b, "%s=%q", labels[i], labels[i+1])
+			}
+			b.WriteByte('}')
+		}
+		fmt.Fprintf(&b, " %s\n", strconv.FormatFloat(value, 'f', -1, 64))
+	}
+
+	metric("phorge_last_collect_timestamp_seconds", "When the statuses were collected.", "gauge")
+	sample("phorge_last_collect_timestamp_seconds", float64(snap.Time.Unix()))
+	metric("phorge_collect_errors", "Requests that failed during the last collection.", "gauge")
+	sample("phorge_collect_errors", float64(len(snap.Errors)))
+
+	metric("phorge_site_deploy_failed", "1 if the site's latest deployment failed.", "gauge")
+	for _, srv := range snap.Servers {
+		for _, st := range srv.Sites {
+			if st.Deploy == "" {
+				continue
+			}
+			sample("phorge_site_deploy_failed", boolValue(status(st.Deploy) == "failed"),
+				"server", srv.Server.Name, "site", st.Site.Name, "status", status(st.Deploy))
+		}
+	}
+
+	metric("phorge_site_cert_expiry_timestamp_seconds", "When the site's active certificate expires.", "gauge")
+	for _, srv := range snap.Servers {
+		for _, st := range srv.Sites {
+			if st.HasCert && !st.CertExpires.IsZero() {
+				sample("phorge_site_cert_expiry_timestamp_seconds", float64(st.CertExpires.Unix()),
+					"server", srv.Server.Name, "site", st.Site.Name)
+			}
+		}
+	}
+
+	metric("phorge_daemon_up", "1 if the daemon is running.", "gauge")
+	for _, srv := range snap.Servers {
+		for _, d := range srv.Daemons {
+			sample("phorge_daemon_up", boolValue(status(d.Status) == "running"),
+				"server", srv.Server.Name, "daemon", fmt.Sprint(d.ID), "command", d.Command, "status", status(d.Status))
+		}
+	}
+
+	metric("phorge_worker_up", "1 if the queue worker is running.", "gauge")
+	for _, srv := range snap.Servers {
+		for _, st := range srv.Sites {
+			for _, wk := range st.Workers {
+				sample("phorge_worker_up", boolValue(status(wk.Status) == "running"),
+					"server", srv.Server.Name, "site", st.Site.Name, "worker", fmt.Sprint(wk.ID),
+					"queue", workerName(wk.Connection, wk.Queue), "status", status(wk.Status))
+			}
+		}
+	}
+
+	metric("phorge_alerts", "Alerts raised by each configured rule.", "gauge")
+	counts := map[string]int{}
+	for _, a := range alerts {
+		counts[a.Rule]++
+	}
+	rules := make([]string, 0, len(counts))
+	for r := range counts {
+		rules = append(rules, r)
+	}
+	sort.Strings(rules)
+	for _, r := range rules {
+		sample("phorge_alerts", float64(counts[r]), "rule", r)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteFile writes snap and alerts to path in format, replacing the file
+// in one step so a scraper never reads it half written.
+func WriteFile(path, format string, snap Snapshot, alerts []Alert) error {
+	write, err := writer(format)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := write(tmp, snap, alerts); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Write writes snap and alerts to w in format.
+func Write(w io.Writer, format string, snap Snapshot, alerts []Alert) error {
+	write, err := writer(format)
+	if err != nil {
+		return err
+	}
+	return write(w, snap, alerts)
+}
+
+func writer(format string) (func(io.Writer, Snapshot, []Alert) error, error) {
+	switch format {
+	case FormatJSON:
+		return WriteJSON, nil
+	case FormatPrometheus:
+		return WritePrometheus, nil
+	}
+	return nil, fmt.Errorf("unknown format %q (want %s or %s)", format, FormatJSON, FormatPrometheus)
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func workerName(connection, queue string) string {
+	if queue == "" {
+		return connection
+	}
+	return connection + ":" + queue
+}
@@ -0,0 +1,104 @@
+package monitor
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+func exportSnapshot() (Snapshot, []Alert) {
+	expires := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	snap := Snapshot{
+		Time: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Servers: []ServerState{{
+			Server:  forge.Server{ID: 1, Name: "prod"},
+			Daemons: []forge.Daemon{{ID: 3, Command: "php artisan horizon", Status: "stopped"}},
+			Sites: []SiteState{{
+				Site:        forge.Site{ID: 10, Name: "api.example.com"},
+				Deploy:      "failed",
+				HasCert:     true,
+				CertExpires: expires,
+				Workers:     []forge.Worker{{ID: 7, Connection: "redis", Queue: "default", Status: "running"}},
+			}},
+		}},
+		Errors: []error{errors.New("prod/example.com: certificates: not found")},
+	}
+	alerts := []Alert{{Rule: "deployment failed", Server: "prod", Subject: "api.example.com", Detail: "latest deployment failed"}}
+	return snap, alerts
+}
+
+func TestWritePrometheus(t *testing.T) {
+	snap, alerts := exportSnapshot()
+	var b strings.Builder
+	if err := WritePrometheus(&b, snap, alerts); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		"# TYPE phorge_site_deploy_failed gauge\n",
+		`phorge_site_deploy_failed{server="prod",site="api.example.com",status="failed"} 1` + "\n",
+		`phorge_site_cert_expiry_timestamp_seconds{server="prod",site="api.example.com"} 1772625600` + "\n",
+		`phorge_daemon_up{server="prod",daemon="3",command="php artisan horizon",status="stopped"} 0` + "\n",
+		`phorge_worker_up{server="prod",site="api.example.com",worker="7",queue="redis:default",status="running"} 1` + "\n",
+		`phorge_alerts{rule="deployment failed"} 1` + "\n",
+		"phorge_collect_errors 1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	snap, alerts := exportSnapshot()
+	var b strings.Builder
+	if err := WriteJSON(&b, snap, alerts); err != nil {
+		t.Fatal(err)
+	}
+	var doc export
+	if err := json.Unmarshal([]byte(b.String()), &doc); err != nil {
+		t.Fatalf("output isn't JSON: %v\n%s", err, b.String())
+	}
+	if len(doc.Servers) != 1 || len(doc.Servers[0].Sites) != 1 {
+		t.Fatalf("Servers = %+v", doc.Servers)
+	}
+	site := doc.Servers[0].Sites[0]
+	if site.Deploy != "failed" || site.CertExpires == nil || len(site.Workers) != 1 {
+		t.Errorf("site = %+v", site)
+	}
+	if len(doc.Alerts) != 1 || doc.Alerts[0].Subject != "api.example.com" {
+		t.Errorf("Alerts = %+v", doc.Alerts)
+	}
+	if len(doc.Errors) != 1 {
+		t.Errorf("Errors = %v", doc.Errors)
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	snap, alerts := exportSnapshot()
+	path := filepath.Join(t.TempDir(), "phorge.prom")
+	if err := WriteFile(path, FormatPrometheus, snap, alerts); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "phorge_daemon_up") {
+		t.Errorf("file holds %q", data)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d files, want the temporary file gone", len(entries))
+	}
+
+	if err := WriteFile(path, "xml", snap, alerts); err == nil {
+		t.Error("WriteFile with an unknown format succeeded")
+	}
+}
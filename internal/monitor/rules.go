@@ -0,0 +1,254 @@
+package monitor
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// Kind is what a rule checks.
+type Kind int
+
+const (
+	// DeployFailed fires for sites whose latest deployment failed.
+	DeployFailed Kind = iota
+	// CertExpiry fires for active certificates expiring within a window.
+	CertExpiry
+	// DaemonStatus fires for daemons whose status matches a condition.
+	DaemonStatus
+	// WorkerStatus fires for queue workers whose status matches a
+	// condition.
+	WorkerStatus
+)
+
+// ruleSyntax is shown when a rule doesn't parse.
+const ruleSyntax = `"deployment failed", "cert expires <7d", "daemon status != running" or "worker status != running", optionally followed by "on tag:<name>" or "on <server>"`
+
+// Rule is a parsed alert rule.
+type Rule struct {
+	Text string // as written in the config
+	Kind Kind
+
+	// Within is the expiry window of a CertExpiry rule.
+	Within time.Duration
+
+	// Negate and Status are the condition of a status rule: the status
+	// equals Status, or differs from it when Negate is set.
+	Negate bool
+	Status string
+
+	// Scope limits the rule to servers with a tag ("tag:<name>") or to one
+	// server by name. Empty means every server.
+	Scope string
+}
+
+// Parse parses an alert rule. Rules are case-insensitive.
+func Parse(text string) (Rule, error) {
+	r := Rule{Text: strings.TrimSpace(text)}
+	f := strings.Fields(strings.ToLower(r.Text))
+	if n := len(f); n >= 2 && f[n-2] == "on" {
+		r.Scope = f[n-1]
+		f = f[:n-2]
+	}
+	// Allow "<7d" and "< 7d", "!=running" and "!= running".
+	f = splitOps(f)
+
+	switch {
+	case len(f) == 2 && (f[0] == "deployment" || f[0] == "deploy") && f[1] == "failed":
+		r.Kind = DeployFailed
+	case len(f) == 4 && (f[0] == "cert" || f[0] == "certificate") && f[1] == "expires" && f[2] == "<":
+		d, err := parseDays(f[3])
+		if err != nil {
+			return Rule{}, fmt.Errorf("rule %q: %w", r.Text, err)
+		}
+		r.Kind, r.Within = CertExpiry, d
+	case len(f) == 4 && (f[0] == "daemon" || f[0] == "worker") && f[1] == "status" && (f[2] == "!=" || f[2] == "=="):
+		r.Kind = DaemonStatus
+		if f[0] == "worker" {
+			r.Kind = WorkerStatus
+		}
+		r.Negate, r.Status = f[2] == "!=", f[3]
+	default:
+		return Rule{}, fmt.Errorf("unknown rule %q (want %s)", r.Text, ruleSyntax)
+	}
+	return r, nil
+}
+
+// splitOps separates a leading "<", "!=" or "==" from the word after it.
+func splitOps(f []string) []string {
+	var out []string
+	for _, w := range f {
+		for _, op := range []string{"!=", "==", "<"} {
+			if rest, ok := strings.CutPrefix(w, op); ok && rest != "" {
+				out = append(out, op)
+				w = rest
+				break
+			}
+		}
+		out = append(out, w)
+	}
+	return out
+}
+
+// parseDays parses a window such as "7d" or "48h".
+func parseDays(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid number of days %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid window %q (want e.g. 7d or 48h)", s)
+	}
+	return d, nil
+}
+
+// Applies reports whether the rule covers srv.
+func (r Rule) Applies(srv forge.Server) bool {
+	if tag, ok := strings.CutPrefix(r.Scope, "tag:"); ok {
+		return srv.HasTag(tag)
+	}
+	return r.Scope == "" || strings.EqualFold(srv.Name, r.Scope)
+}
+
+// NeedsOf returns what a snapshot needs for rules to be evaluated.
+func NeedsOf(rules []Rule) Needs {
+	var n Needs
+	for _, r := range rules {
+		switch r.Kind {
+		case DeployFailed:
+			n.Deploys = true
+		case CertExpiry:
+			n.Certs = true
+		case DaemonStatus:
+			n.Daemons = true
+		case WorkerStatus:
+			n.Workers = true
+		}
+	}
+	return n
+}
+
+// Alert is a rule breached by one server, site, daemon or worker.
+type Alert struct {
+	Rule    string `json:"rule"` // the rule's text
+	Server  string `json:"server"`
+	Subject string `json:"subject,omitempty"` // the site, daemon or worker, or "" for the server
+	Detail  string `json:"detail"`            // e.g. "expires in 3 days"
+}
+
+// Key identifies the alert, to tell new alerts from ones already seen.
+func (a Alert) Key() string {
+	return a.Rule + "\n" + a.Server + "\n" + a.Subject
+}
+
+// String describes the alert, e.g. "web-1 / api.example.com: deployment
+// failed".
+func (a Alert) String() string {
+	target := a.Server
+	if a.Subject != "" {
+		target += " / " + a.Subject
+	}
+	return target + ": " + a.Detail
+}
+
+// Evaluate checks snap against rules, returning the breaches ordered by
+// server and subject.
+func Evaluate(rules []Rule, snap Snapshot, now time.Time) []Alert {
+	var alerts []Alert
+	for _, r := range rules {
+		for _, srv := range snap.Servers {
+			if !r.Applies(srv.Server) {
+				continue
+			}
+			alerts = append(alerts, r.check(srv, now)...)
+		}
+	}
+	slices.SortStableFunc(alerts, func(a, b Alert) int {
+		if c := strings.Compare(a.Server, b.Server); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Subject, b.Subject)
+	})
+	return alerts
+}
+
+// check returns the rule's breaches on one server.
+func (r Rule) check(srv ServerState, now time.Time) []Alert {
+	var alerts []Alert
+	add := func(subject, detail string) {
+		alerts = append(alerts, Alert{Rule: r.Text, Server: srv.Server.Name, Subject: subject, Detail: detail})
+	}
+
+	switch r.Kind {
+	case DeployFailed:
+		for _, st := range srv.Sites {
+			if st.Deploy == "failed" {
+				add(st.Site.Name, "latest deployment failed")
+			}
+		}
+	case CertExpiry:
+		for _, st := range srv.Sites {
+			if !st.HasCert || st.CertExpires.IsZero() {
+				continue
+			}
+			switch left := st.CertExpires.Sub(now); {
+			case left <= 0:
+				add(st.Site.Name, "certificate expired")
+			case left < r.Within:
+				add(st.Site.Name, "certificate expires in "+days(left))
+			}
+		}
+	case DaemonStatus:
+		for _, d := range srv.Daemons {
+			if r.matches(d.Status) {
+				add(d.Command, "daemon status "+status(d.Status))
+			}
+		}
+	case WorkerStatus:
+		for _, st := range srv.Sites {
+			for _, w := range st.Workers {
+				if r.matches(w.Status) {
+					add(st.Site.Name+" "+w.Connection+":"+w.Queue, "worker status "+status(w.Status))
+				}
+			}
+		}
+	}
+	return alerts
+}
+
+// matches reports whether a daemon or worker status meets the condition
+// of a status rule.
+func (r Rule) matches(s string) bool {
+	return strings.EqualFold(s, r.Status) != r.Negate
+}
+
+func status(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// days formats d in whole days, or hours below a day.
+func days(d time.Duration) string {
+	if d < 24*time.Hour {
+		h := max(int(d.Hours()), 1)
+		if h == 1 {
+			return "1 hour"
+		}
+		return fmt.Sprintf("%d hours", h)
+	}
+	n := int(d.Hours() / 24)
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
@@ -0,0 +1,102 @@
+package monitor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want Rule
+	}{
+		{"deployment failed", Rule{Kind: DeployFailed}},
+		{"Deploy failed on tag:Production", Rule{Kind: DeployFailed, Scope: "tag:production"}},
+		{"cert expires <7d", Rule{Kind: CertExpiry, Within: 7 * 24 * time.Hour}},
+		{"certificate expires < 48h on web-1", Rule{Kind: CertExpiry, Within: 48 * time.Hour, Scope: "web-1"}},
+		{"daemon status != running", Rule{Kind: DaemonStatus, Negate: true, Status: "running"}},
+		{"worker status ==stopped", Rule{Kind: WorkerStatus, Status: "stopped"}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.text)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.text, err)
+			continue
+		}
+		tt.want.Text = tt.text
+		if got != tt.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"deployment succeeded",
+		"cert expires <0d",
+		"cert expires <soon",
+		"cert expires 7d",
+		"daemon status running",
+		"on tag:production",
+	} {
+		if r, err := Parse(text); err == nil {
+			t.Errorf("Parse(%q) = %+v, want error", text, r)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	prod := forge.Server{Name: "prod", Tags: []any{map[string]any{"name": "production"}}}
+	snap := Snapshot{Servers: []ServerState{
+		{
+			Server:  prod,
+			Daemons: []forge.Daemon{{Command: "php artisan horizon", Status: "running"}, {Command: "node ws.js", Status: "stopped"}},
+			Sites: []SiteState{
+				{Site: forge.Site{Name: "api.example.com"}, Deploy: "failed", HasCert: true, CertExpires: now.Add(3 * 24 * time.Hour)},
+				{Site: forge.Site{Name: "example.com"}, Deploy: "finished", HasCert: true, CertExpires: now.Add(-time.Hour)},
+			},
+		},
+		{
+			Server: forge.Server{Name: "staging"},
+			Sites: []SiteState{
+				{Site: forge.Site{Name: "staging.example.com"}, Deploy: "failed", HasCert: true, CertExpires: now.Add(30 * 24 * time.Hour)},
+			},
+		},
+	}}
+
+	var rules []Rule
+	for _, text := range []string{"deployment failed on tag:production", "cert expires <7d", "daemon status != running"} {
+		r, err := Parse(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rules = append(rules, r)
+	}
+
+	var got []string
+	for _, a := range Evaluate(rules, snap, now) {
+		got = append(got, a.String())
+	}
+	want := []string{
+		"prod / api.example.com: latest deployment failed",
+		"prod / api.example.com: certificate expires in 3 days",
+		"prod / example.com: certificate expired",
+		"prod / node ws.js: daemon status stopped",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Evaluate =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestNeedsOf(t *testing.T) {
+	r1, _ := Parse("cert expires <7d")
+	r2, _ := Parse("worker status != running")
+	if got, want := NeedsOf([]Rule{r1, r2}), (Needs{Certs: true, Workers: true}); got != want {
+		t.Errorf("NeedsOf = %+v, want %+v", got, want)
+	}
+}
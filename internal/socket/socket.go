@@ -0,0 +1,305 @@
+// Package socket serves a local unix socket over which the TUI publishes
+// events and takes commands, for editor plugins and statusline scripts.
+//
+// Both directions carry JSON objects, one per line. Clients send commands:
+//
+//	{"id": 1, "command": "select", "server": "prod-1", "site": "example.com"}
+//	{"id": 2, "command": "deploy", "server": "prod-1", "site": "example.com"}
+//	{"id": 3, "command": "open", "dir": "/home/me/code/example"}
+//
+// and get a reply to each, carrying the same id:
+//
+//	{"id": 1, "ok": true}
+//	{"id": 2, "ok": false, "error": "site \"example.com\" not found on prod-1"}
+//
+// open selects the site of the project checked out in dir, for editor
+// plugins: the one its .phorge names, or else the first site deploying its
+// git origin. `phorge open` sends it from the command line.
+//
+// Events are sent to every client as they happen, e.g.
+// {"event": "selection_changed", "server": "prod-1", ...}. A client that
+// connects is first sent the latest state, so it needn't wait for a change.
+package socket
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// Commands a client can send.
+const (
+	CommandSelect = "select"
+	CommandDeploy = "deploy"
+	CommandOpen   = "open"
+)
+
+// clientBuffer is how many lines may wait for a slow client before further
+// events to it are dropped.
+const clientBuffer = 64
+
+// Command is a request from a client. Reply to it with Server.Reply.
+type Command struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Name   string          `json:"command"`
+	Server string          `json:"server,omitempty"`
+	Site   string          `json:"site,omitempty"`
+	Dir    string          `json:"dir,omitempty"`
+
+	from *client
+}
+
+// reply is the answer to a Command.
+type reply struct {
+	ID    json.RawMessage `json:"id,omitempty"`
+	OK    bool            `json:"ok"`
+	Error string          `json:"error,omitempty"`
+}
+
+// client is a connection and the lines waiting to be written to it.
+type client struct {
+	conn net.Conn
+	out  chan []byte
+	done chan struct{}
+}
+
+// send queues line for the client, dropping it if the client isn't keeping
+// up or has gone.
+func (c *client) send(line []byte) {
+	select {
+	case <-c.done:
+	case c.out <- line:
+	default:
+	}
+}
+
+// Server is a listening socket and its connected clients.
+type Server struct {
+	ln       net.Listener
+	path     string
+	commands chan Command
+
+	mu      sync.Mutex
+	clients map[*client]struct{}
+	state   []byte
+}
+
+// Listen creates the socket at path, readable and writable only by the
+// user. A socket file left behind by a Phorge that has exited is replaced;
+// one another Phorge is still listening on is an error.
+func Listen(path string) (*Server, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%s is in use by another phorge", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("removing stale socket: %w", err)
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	s := &Server{
+		ln:       ln,
+		path:     path,
+		commands: make(chan Command, 16),
+		clients:  make(map[*client]struct{}),
+	}
+	go s.accept()
+	return s, nil
+}
+
+// Path returns the socket's path.
+func (s *Server) Path() string {
+	return s.path
+}
+
+// Commands returns the channel clients' commands arrive on.
+func (s *Server) Commands() <-chan Command {
+	return s.commands
+}
+
+// Publish sends v as an event to every client.
+func (s *Server) Publish(v any) {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		c.send(line)
+	}
+}
+
+// SetState publishes v and keeps it to send to clients that connect later.
+func (s *Server) SetState(v any) {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.state = line
+	s.mu.Unlock()
+	s.Publish(v)
+}
+
+// Reply answers cmd: with ok, or with err when it failed.
+func (s *Server) Reply(cmd Command, err error) {
+	if cmd.from == nil {
+		return
+	}
+	r := reply{ID: cmd.ID, OK: err == nil}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	line, _ := json.Marshal(r)
+	cmd.from.send(line)
+}
+
+// Close stops listening, disconnects the clients and removes the socket.
+func (s *Server) Close() error {
+	err := s.ln.Close()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		c.conn.Close()
+	}
+	return err
+}
+
+func (s *Server) accept() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			continue
+		}
+		c := &client{conn: conn, out: make(chan []byte, clientBuffer), done: make(chan struct{})}
+		s.mu.Lock()
+		s.clients[c] = struct{}{}
+		if s.state != nil {
+			c.send(s.state)
+		}
+		s.mu.Unlock()
+		go s.write(c)
+		go s.read(c)
+	}
+}
+
+// write sends the client's queued lines until it disconnects.
+func (s *Server) write(c *client) {
+	for {
+		select {
+		case <-c.done:
+			return
+		case line := <-c.out:
+			if _, err := c.conn.Write(append(line, '\n')); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// read passes the client's commands on until it disconnects.
+func (s *Server) read(c *client) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+		close(c.done)
+		c.conn.Close()
+	}()
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var cmd Command
+		if err := json.Unmarshal(line, &cmd); err != nil {
+			s.Reply(Command{from: c}, fmt.Errorf("invalid command: %w", err))
+			continue
+		}
+		cmd.from = c
+		if err := cmd.validate(); err != nil {
+			s.Reply(cmd, err)
+			continue
+		}
+		s.commands <- cmd
+	}
+}
+
+// validate checks the command is known and names what it needs.
+func (c Command) validate() error {
+	switch c.Name {
+	case CommandSelect:
+		if c.Server == "" {
+			return errors.New("select needs a server")
+		}
+	case CommandDeploy:
+		if c.Server == "" || c.Site == "" {
+			return errors.New("deploy needs a server and a site")
+		}
+	case CommandOpen:
+		if c.Dir == "" {
+			return errors.New("open needs a dir")
+		}
+	default:
+		return fmt.Errorf("unknown command %q (want %q, %q or %q)", c.Name, CommandSelect, CommandDeploy, CommandOpen)
+	}
+	return nil
+}
+
+// ErrNotRunning is returned by Send when nothing is listening on the
+// socket.
+var ErrNotRunning = errors.New("phorge is not running with the event socket enabled")
+
+// Send sends cmd to the Phorge listening on the socket at path and waits
+// up to timeout for its reply, returning the error it reports.
+func Send(path string, cmd Command, timeout time.Duration) error {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return ErrNotRunning
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+
+	cmd.ID = json.RawMessage("1")
+	line, err := json.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	// Skip the state and events sent before the reply.
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var r reply
+		if json.Unmarshal(scanner.Bytes(), &r) != nil || string(r.ID) != "1" {
+			continue
+		}
+		if !r.OK {
+			return errors.New(r.Error)
+		}
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return errors.New("phorge closed the socket without replying")
+}
@@ -0,0 +1,124 @@
+package socket
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// dial connects to the socket and returns a reader of its lines.
+func dial(t *testing.T, path string) (net.Conn, *bufio.Scanner) {
+	t.Helper()
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	return conn, bufio.NewScanner(conn)
+}
+
+// readLine decodes the next line from the socket into a map.
+func readLine(t *testing.T, sc *bufio.Scanner) map[string]any {
+	t.Helper()
+	if !sc.Scan() {
+		t.Fatalf("no line from socket: %v", sc.Err())
+	}
+	var v map[string]any
+	if err := json.Unmarshal(sc.Bytes(), &v); err != nil {
+		t.Fatalf("decoding %q: %v", sc.Text(), err)
+	}
+	return v
+}
+
+func TestServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phorge.sock")
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer s.Close()
+	s.SetState(map[string]string{"event": "selection_changed", "server": "prod-1"})
+
+	conn, sc := dial(t, path)
+	if got := readLine(t, sc); got["server"] != "prod-1" {
+		t.Errorf("first line = %v, want the current state", got)
+	}
+
+	conn.Write([]byte(`{"id": 7, "command": "deploy", "server": "prod-1", "site": "example.com"}` + "\n"))
+	var cmd Command
+	select {
+	case cmd = <-s.Commands():
+	case <-time.After(5 * time.Second):
+		t.Fatal("no command received")
+	}
+	if cmd.Name != CommandDeploy || cmd.Site != "example.com" {
+		t.Errorf("command = %+v", cmd)
+	}
+	s.Reply(cmd, errors.New("outside the maintenance window"))
+	if got := readLine(t, sc); got["id"] != float64(7) || got["ok"] != false || got["error"] != "outside the maintenance window" {
+		t.Errorf("reply = %v", got)
+	}
+
+	conn.Write([]byte(`{"id": 8, "command": "reboot", "server": "prod-1"}` + "\n"))
+	if got := readLine(t, sc); got["id"] != float64(8) || got["ok"] != false {
+		t.Errorf("reply to an unknown command = %v, want an error", got)
+	}
+
+	s.Publish(map[string]string{"event": "deploy_finished", "status": "finished"})
+	if got := readLine(t, sc); got["event"] != "deploy_finished" {
+		t.Errorf("event = %v", got)
+	}
+}
+
+func TestListenInUse(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phorge.sock")
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	if _, err := Listen(path); err == nil {
+		t.Error("second Listen on a live socket succeeded, want an error")
+	}
+	s.Close()
+
+	// A file left behind by a Phorge that exited is replaced.
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	s, err = Listen(path)
+	if err != nil {
+		t.Fatalf("Listen over a stale file: %v", err)
+	}
+	s.Close()
+}
+
+func TestSend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phorge.sock")
+	if err := Send(path, Command{Name: CommandOpen, Dir: "/src/app"}, time.Second); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("Send with nothing listening = %v, want ErrNotRunning", err)
+	}
+
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer s.Close()
+	s.SetState(map[string]string{"event": "selection_changed"})
+	go func() {
+		cmd := <-s.Commands()
+		if cmd.Dir != "/src/app" {
+			s.Reply(cmd, errors.New("wrong dir "+cmd.Dir))
+			return
+		}
+		s.Reply(cmd, errors.New("no site found for acme/app"))
+	}()
+	if err := Send(path, Command{Name: CommandOpen, Dir: "/src/app"}, 5*time.Second); err == nil || err.Error() != "no site found for acme/app" {
+		t.Errorf("Send = %v, want the reply's error", err)
+	}
+}
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// migration upgrades the database by one schema version. dir is the
+// directory holding the database, for migrations that import files.
+type migration func(tx *bolt.Tx, dir string) error
+
+// migrations run in order; the schema version is the number applied.
+// Append new migrations, never reorder or edit existing ones.
+var migrations = []migration{
+	createBuckets,
+	importJSONFiles,
+}
+
+// legacyImports maps the JSON files kept before the store existed to the
+// bucket and key their contents moved to.
+var legacyImports = []struct {
+	file, bucket, key string
+}{
+	{"state.json", BucketState, "session"},
+	{"names.json", BucketCache, "names"},
+}
+
+// legacyFiles returns the paths of the pre-store JSON files in dir.
+func legacyFiles(dir string) []string {
+	paths := make([]string, len(legacyImports))
+	for i, l := range legacyImports {
+		paths[i] = filepath.Join(dir, l.file)
+	}
+	return paths
+}
+
+// migrate applies the migrations newer than the stored schema version.
+func (s *Store) migrate(dir string) error {
+	var version int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		version = schemaVersion(tx)
+		return nil
+	})
+	if err != nil || version >= len(migrations) {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		// Re-read inside the write transaction in case another process
+		// migrated in the meantime.
+		for v := schemaVersion(tx); v < len(migrations); v++ {
+			if err := migrations[v](tx, dir); err != nil {
+				return err
+			}
+			if err := setSchemaVersion(tx, v+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// schemaVersion returns the number of migrations applied.
+func schemaVersion(tx *bolt.Tx) int {
+	b := tx.Bucket([]byte(metaBucket))
+	if b == nil {
+		return 0
+	}
+	data := b.Get([]byte("schema_version"))
+	if len(data) != 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(data))
+}
+
+// setSchemaVersion records the number of migrations applied.
+func setSchemaVersion(tx *bolt.Tx, version int) error {
+	b, err := tx.CreateBucketIfNotExists([]byte(metaBucket))
+	if err != nil {
+		return err
+	}
+	var data [8]byte
+	binary.BigEndian.PutUint64(data[:], uint64(version))
+	return b.Put([]byte("schema_version"), data[:])
+}
+
+// createBuckets creates the buckets Phorge uses.
+func createBuckets(tx *bolt.Tx, _ string) error {
+	for _, name := range []string{BucketState, BucketCache} {
+		if _, err := tx.CreateBucketIfNotExists([]byte(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// importJSONFiles copies the JSON files that held state and caches before
+// the store existed into it, then removes them. Unreadable or corrupt
+// files are dropped: their contents are disposable.
+func importJSONFiles(tx *bolt.Tx, dir string) error {
+	for _, l := range legacyImports {
+		path := filepath.Join(dir, l.file)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if json.Valid(data) {
+			if err := tx.Bucket([]byte(l.bucket)).Put([]byte(l.key), data); err != nil {
+				return err
+			}
+		}
+		_ = os.Remove(path)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Package store is Phorge's local database for data that is remembered
+// between runs but is not user configuration: session state and caches.
+//
+// It wraps a single bbolt file next to config.toml. Values are stored as
+// JSON under a bucket and key. The file is opened for each operation rather
+// than held open, so the TUI and CLI commands can run side by side.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// Buckets used by Phorge.
+const (
+	BucketState = "state" // UI session state
+	BucketCache = "cache" // data fetched from the API, safe to lose
+)
+
+// FileName is the name of the database file in the config directory.
+const FileName = "phorge.db"
+
+// openTimeout bounds how long Open waits for another process holding the
+// database, which only happens during a concurrent write.
+const openTimeout = 2 * time.Second
+
+// metaBucket holds the schema version.
+const metaBucket = "meta"
+
+// Store is an open database. Close it when done.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens (creating if needed) the database at path and applies any
+// pending migrations.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", filepath.Base(path), err)
+	}
+	s := &Store{db: db}
+	if err := s.migrate(filepath.Dir(path)); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("migrating %s: %w", filepath.Base(path), err)
+	}
+	return s, nil
+}
+
+// Close releases the database file.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Get decodes the value stored under bucket/key into v. It reports false,
+// leaving v untouched, when there is no such value.
+func (s *Store) Get(bucket, key string, v any) (bool, error) {
+	var data []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket([]byte(bucket)); b != nil {
+			data = append(data, b.Get([]byte(key))...)
+		}
+		return nil
+	})
+	if err != nil || data == nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("decoding %s/%s: %w", bucket, key, err)
+	}
+	return true, nil
+}
+
+// Put stores v as JSON under bucket/key, creating the bucket if needed.
+func (s *Store) Put(bucket, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(key), data)
+	})
+}
+
+// Delete removes bucket/key. Deleting a missing key is not an error.
+func (s *Store) Delete(bucket, key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if b := tx.Bucket([]byte(bucket)); b != nil {
+			return b.Delete([]byte(key))
+		}
+		return nil
+	})
+}
+
+// Load opens the database at path, decodes bucket/key into v and closes
+// it again. See Get.
+func Load(path, bucket, key string, v any) (bool, error) {
+	s, err := Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer s.Close()
+	return s.Get(bucket, key, v)
+}
+
+// Save opens the database at path, stores v under bucket/key and closes it
+// again. See Put.
+func Save(path, bucket, key string, v any) error {
+	s, err := Open(path)
+	if err != nil {
+		return err
+	}
+	if err := s.Put(bucket, key, v); err != nil {
+		s.Close()
+		return err
+	}
+	return s.Close()
+}
+
+// Reset deletes the database at path along with the JSON files it
+// replaced. Everything in it is rebuilt on the next run.
+func Reset(path string) error {
+	for _, p := range append([]string{path}, legacyFiles(filepath.Dir(path))...) {
+		if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
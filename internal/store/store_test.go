@@ -0,0 +1,144 @@
+package store
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestPutGetDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", FileName)
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+
+	var got []int
+	if ok, err := s.Get(BucketState, "missing", &got); ok || err != nil {
+		t.Errorf("Get missing = %v, %v; want false, nil", ok, err)
+	}
+
+	if err := s.Put(BucketState, "ids", []int{3, 17}); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if ok, err := s.Get(BucketState, "ids", &got); !ok || err != nil {
+		t.Fatalf("Get = %v, %v; want true, nil", ok, err)
+	}
+	if len(got) != 2 || got[0] != 3 || got[1] != 17 {
+		t.Errorf("Get = %v, want [3 17]", got)
+	}
+
+	// Buckets are created on demand.
+	if err := s.Put("other", "k", "v"); err != nil {
+		t.Fatalf("Put new bucket: %v", err)
+	}
+
+	if err := s.Delete(BucketState, "ids"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if ok, _ := s.Get(BucketState, "ids", &got); ok {
+		t.Error("Get after Delete found a value")
+	}
+}
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := Save(path, BucketCache, "names", map[string]int{"a": 1}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	var got map[string]int
+	ok, err := Load(path, BucketCache, "names", &got)
+	if !ok || err != nil {
+		t.Fatalf("Load = %v, %v; want true, nil", ok, err)
+	}
+	if got["a"] != 1 {
+		t.Errorf("Load = %v, want map[a:1]", got)
+	}
+}
+
+func TestMigrationImportsJSONFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "state.json"), []byte(`{"expanded":[7]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "names.json"), []byte("{corrupt"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Open(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer s.Close()
+
+	var state struct {
+		Expanded []int `json:"expanded"`
+	}
+	if ok, err := s.Get(BucketState, "session", &state); !ok || err != nil {
+		t.Fatalf("Get imported state = %v, %v", ok, err)
+	}
+	if len(state.Expanded) != 1 || state.Expanded[0] != 7 {
+		t.Errorf("imported state = %+v, want expanded [7]", state)
+	}
+
+	var names any
+	if ok, _ := s.Get(BucketCache, "names", &names); ok {
+		t.Error("corrupt names.json was imported")
+	}
+
+	for _, name := range []string{"state.json", "names.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%s still exists after import", name)
+		}
+	}
+
+	var version int
+	s.db.View(func(tx *bolt.Tx) error {
+		version = schemaVersion(tx)
+		return nil
+	})
+	if version != len(migrations) {
+		t.Errorf("schema version = %d, want %d", version, len(migrations))
+	}
+}
+
+func TestReset(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	if err := Save(path, BucketState, "session", 1); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "state.json"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Reset(path); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	for _, name := range []string{FileName, "state.json"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%s still exists after Reset", name)
+		}
+	}
+
+	// Resetting again, with nothing left, is fine.
+	if err := Reset(path); err != nil {
+		t.Errorf("second Reset: %v", err)
+	}
+}
+
+func TestOpenCorrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := os.WriteFile(path, []byte("{not a database"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path); err == nil {
+		t.Error("Open corrupt file: expected error")
+	}
+}
@@ -0,0 +1,150 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// AboutModal is a floating overlay showing version and environment details
+// useful when filing bug reports, plus a live Forge API health check.
+type AboutModal struct {
+	active   bool
+	checking bool
+	user     *forge.User
+	latency  time.Duration
+	err      error
+}
+
+// aboutHealthMsg carries the result of the About modal's API health check.
+type aboutHealthMsg struct {
+	user    *forge.User
+	latency time.Duration
+	err     error
+}
+
+// NewAboutModal creates a new (inactive) about modal.
+func NewAboutModal() AboutModal {
+	return AboutModal{}
+}
+
+// Open activates the modal and starts an API health check using client.
+func (a AboutModal) Open(client *forge.Client) (AboutModal, tea.Cmd) {
+	a.active = true
+	a.checking = true
+	a.user = nil
+	a.err = nil
+	return a, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		start := time.Now()
+		user, err := client.Servers.GetUser(ctx)
+		return aboutHealthMsg{user: user, latency: time.Since(start), err: err}
+	}
+}
+
+// Active returns whether the about modal is currently visible.
+func (a AboutModal) Active() bool {
+	return a.active
+}
+
+// Update handles key events and health check results.
+func (a AboutModal) Update(msg tea.Msg) (AboutModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case aboutHealthMsg:
+		a.checking = false
+		a.user = msg.user
+		a.latency = msg.latency
+		a.err = msg.err
+	case tea.KeyPressMsg:
+		if key.Matches(msg, key.NewBinding(key.WithKeys("esc", "q", "A"))) {
+			a.active = false
+		}
+	}
+	return a, nil
+}
+
+// View renders the about modal as a box suitable for overlay.
+func (a AboutModal) View(width, height int) string {
+	if !a.active {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.ColorPrimary).
+		Align(lipgloss.Center)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorSubtle).
+		Width(14).
+		Align(lipgloss.Right)
+
+	valueStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorFg)
+
+	okStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorSecondary)
+
+	errStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorError)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorMuted).
+		Align(lipgloss.Center)
+
+	contentWidth := layout.Clamp(width-6, 30, 54)
+
+	row := func(label, value string) string {
+		return labelStyle.Render(label+": ") + valueStyle.Render(value)
+	}
+
+	account := "…"
+	var health string
+	switch {
+	case a.checking:
+		health = valueStyle.Render("checking…")
+	case a.err != nil:
+		account = "(unavailable)"
+		health = errStyle.Render(theme.GlyphFail + " " + a.err.Error())
+	default:
+		account = a.user.Name
+		if a.user.Email != "" {
+			account += " <" + a.user.Email + ">"
+		}
+		health = okStyle.Render(fmt.Sprintf("%s OK (%dms)", theme.GlyphOK, a.latency.Milliseconds()))
+	}
+
+	lines := []string{
+		titleStyle.Width(contentWidth).Render("About Phorge"),
+		"",
+		row("Version", Version),
+		row("Go", runtime.Version()+" "+runtime.GOOS+"/"+runtime.GOARCH),
+		row("Config", config.DefaultPath()),
+		row("Account", account),
+		labelStyle.Render("Forge API: ") + health,
+		"",
+		hintStyle.Width(contentWidth).Render("esc close"),
+	}
+
+	inner := strings.Join(lines, "\n")
+
+	return lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(theme.ColorPrimary).
+		Padding(1, 2).
+		Background(theme.ColorBg).
+		Width(contentWidth + 4).
+		Render(inner)
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/tui/components"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+)
+
+// openActionPalette lists the user-defined actions from config.toml for
+// running one against the selection.
+func (m App) openActionPalette() (tea.Model, tea.Cmd) {
+	if len(m.config.Actions) == 0 {
+		m.toast = "No actions defined; add [[actions]] to config.toml"
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	options := make([]components.PickerOption, len(m.config.Actions))
+	for i, a := range m.config.Actions {
+		options[i] = components.PickerOption{Label: a.Name, Detail: a.Key, Value: strconv.Itoa(i)}
+	}
+	m.dialogs = m.dialogs.Pick(components.NewPicker("action-palette", "Run action:", options))
+	return m, nil
+}
+
+// matchAction returns the user-defined action bound to the pressed key.
+func (m App) matchAction(msg tea.KeyPressMsg) (config.Action, bool) {
+	for _, a := range m.config.Actions {
+		if a.Key != "" && a.Command != "" && key.Matches(msg, key.NewBinding(key.WithKeys(a.Key))) {
+			return a, true
+		}
+	}
+	return config.Action{}, false
+}
+
+// runAction suspends the TUI and runs a user-defined action's command with
+// sh -c, its placeholders filled in from the selected server and site.
+func (m App) runAction(a config.Action) (tea.Model, tea.Cmd) {
+	if m.selectedSrv == nil || (a.NeedsSite() && m.selectedSite == nil) {
+		what := "server"
+		if a.NeedsSite() {
+			what = "site"
+		}
+		m.toast = fmt.Sprintf("%s: select a %s first", a.Name, what)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+
+	user := m.config.SSHUserFor(m.selectedSrv.Name)
+	vars := map[string]string{
+		"server_name": m.selectedSrv.Name,
+		"server_ip":   m.selectedSrv.IPAddress,
+		"ssh_user":    user,
+	}
+	if m.selectedSite != nil {
+		vars["site_name"] = m.selectedSite.Name
+		vars["site_dir"] = deriveSiteDirectory(m.selectedSite, user)
+	}
+
+	c := exec.Command("sh", "-c", a.Expand(vars))
+	return m, tea.ExecProcess(c, func(err error) tea.Msg {
+		return externalExitMsg{err}
+	})
+}
+
+// actionBindings returns footer hints for the action palette and the
+// actions bound to a key.
+func (m App) actionBindings() []panels.HelpBinding {
+	if len(m.config.Actions) == 0 {
+		return nil
+	}
+	bindings := []panels.HelpBinding{{Key: ":", Desc: "actions"}}
+	for _, a := range m.config.Actions {
+		if a.Key != "" && a.Command != "" {
+			bindings = append(bindings, panels.HelpBinding{Key: a.Key, Desc: a.Name})
+		}
+	}
+	return bindings
+}
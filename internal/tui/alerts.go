@@ -0,0 +1,274 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/monitor"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// alertState is the background alert evaluator's state: the parsed rules,
+// the breaches found by the last check and when it ran.
+type alertState struct {
+	rules    []monitor.Rule
+	interval time.Duration
+	alerts   []monitor.Alert
+	errs     []error // requests that failed during the last check
+	checked  time.Time
+
+	// invalid lists the rules that didn't parse.
+	invalid []error
+
+	// seq numbers the check loops, so a loop started before a config
+	// reload stops once a new one is running.
+	seq int
+}
+
+// alertTickMsg starts the next check of loop seq.
+type alertTickMsg struct{ seq int }
+
+// alertsCheckedMsg carries the result of a check.
+type alertsCheckedMsg struct {
+	seq    int
+	alerts []monitor.Alert
+	errs   []error
+	at     time.Time
+}
+
+// newAlertState parses cfg's alert rules for check loop seq.
+func newAlertState(cfg *config.Config, seq int) alertState {
+	a := alertState{interval: cfg.Alerts.CheckInterval(), seq: seq}
+	for _, text := range cfg.Alerts.Rules {
+		r, err := monitor.Parse(text)
+		if err != nil {
+			a.invalid = append(a.invalid, err)
+			continue
+		}
+		a.rules = append(a.rules, r)
+	}
+	return a
+}
+
+// invalidRulesToast reports alert rules that didn't parse.
+func (a alertState) invalidRulesToast() tea.Cmd {
+	if len(a.invalid) == 0 {
+		return nil
+	}
+	text := "Alerts: " + a.invalid[0].Error()
+	if n := len(a.invalid) - 1; n > 0 {
+		text += fmt.Sprintf(" (+%d more)", n)
+	}
+	return func() tea.Msg {
+		return toastMsg{message: text, isError: true}
+	}
+}
+
+// checkAlerts returns a command that collects what the rules need from
+// every server not on the ignore list and evaluates them.
+func (m App) checkAlerts() tea.Cmd {
+	if len(m.alerts.rules) == 0 {
+		return nil
+	}
+	client, cfg := m.forge, m.config
+	rules, seq := m.alerts.rules, m.alerts.seq
+	return func() tea.Msg {
+		ctx := context.Background()
+		servers, err := client.Servers.List(ctx)
+		if err != nil {
+			return alertsCheckedMsg{seq: seq, errs: []error{err}, at: time.Now()}
+		}
+		var targets []forge.Server
+		for _, srv := range servers {
+			if cfg.ServerHidden(srv.Name, srv.ID, srv.TagNames()) {
+				continue
+			}
+			for _, r := range rules {
+				if r.Applies(srv) {
+					targets = append(targets, srv)
+					break
+				}
+			}
+		}
+		snap := monitor.Collect(ctx, client, targets, monitor.NeedsOf(rules))
+		return alertsCheckedMsg{seq: seq, alerts: monitor.Evaluate(rules, snap, snap.Time), errs: snap.Errors, at: snap.Time}
+	}
+}
+
+// handleAlertsChecked stores a check's result, raises a toast for alerts
+// that weren't there last time and schedules the next check.
+func (m App) handleAlertsChecked(msg alertsCheckedMsg) (tea.Model, tea.Cmd) {
+	if msg.seq != m.alerts.seq {
+		return m, nil
+	}
+	seen := make(map[string]bool, len(m.alerts.alerts))
+	for _, a := range m.alerts.alerts {
+		seen[a.Key()] = true
+	}
+	var fresh []monitor.Alert
+	for _, a := range msg.alerts {
+		if !seen[a.Key()] {
+			fresh = append(fresh, a)
+		}
+	}
+	m.alerts.alerts, m.alerts.errs, m.alerts.checked = msg.alerts, msg.errs, msg.at
+	m.alertsModal = m.alertsModal.SetAlerts(m.alerts)
+
+	seq := m.alerts.seq
+	cmds := []tea.Cmd{tea.Tick(m.alerts.interval, func(time.Time) tea.Msg { return alertTickMsg{seq} })}
+	switch len(fresh) {
+	case 0:
+	case 1:
+		m.toast = "Alert: " + fresh[0].String()
+		m.toastIsErr = true
+		cmds = append(cmds, m.clearToastAfter(6*time.Second))
+	default:
+		m.toast = fmt.Sprintf("%d new alerts (! to view)", len(fresh))
+		m.toastIsErr = true
+		cmds = append(cmds, m.clearToastAfter(6*time.Second))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// reloadAlerts re-reads the rules after the config changed, starting a new
+// check loop if there are any.
+func (m App) reloadAlerts() (App, tea.Cmd) {
+	alerts := newAlertState(m.config, m.alerts.seq+1)
+	if len(alerts.rules) > 0 {
+		// Keep the current alerts until the new check replaces them, so
+		// they aren't all reported as new.
+		alerts.alerts = m.alerts.alerts
+	}
+	m.alerts = alerts
+	m.alertsModal = m.alertsModal.SetAlerts(m.alerts)
+	return m, tea.Batch(alerts.invalidRulesToast(), m.checkAlerts())
+}
+
+// alertBadge renders the footer badge counting current alerts, or "".
+func (m App) alertBadge() string {
+	if len(m.alerts.alerts) == 0 {
+		return ""
+	}
+	text := fmt.Sprintf("%s %d alert", theme.GlyphFail, len(m.alerts.alerts))
+	if len(m.alerts.alerts) > 1 {
+		text += "s"
+	}
+	return lipgloss.NewStyle().Bold(true).Foreground(theme.ColorError).Render(text)
+}
+
+// AlertsModal is a floating overlay listing the current alerts.
+type AlertsModal struct {
+	active bool
+	state  alertState
+	cursor int
+	offset int
+}
+
+// NewAlertsModal creates a new (inactive) alerts modal.
+func NewAlertsModal() AlertsModal {
+	return AlertsModal{}
+}
+
+// Open activates the modal.
+func (a AlertsModal) Open() AlertsModal {
+	a.active = true
+	a.cursor, a.offset = 0, 0
+	return a
+}
+
+// Active returns whether the alerts modal is currently visible.
+func (a AlertsModal) Active() bool {
+	return a.active
+}
+
+// SetAlerts updates the alerts shown.
+func (a AlertsModal) SetAlerts(state alertState) AlertsModal {
+	a.state = state
+	a.cursor = min(a.cursor, max(len(state.alerts)-1, 0))
+	return a
+}
+
+// Update handles key events: j/k move, esc, q or ! close.
+func (a AlertsModal) Update(msg tea.Msg) (AlertsModal, tea.Cmd) {
+	k, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return a, nil
+	}
+	switch {
+	case key.Matches(k, key.NewBinding(key.WithKeys("esc", "q", "!"))):
+		a.active = false
+	case key.Matches(k, key.NewBinding(key.WithKeys("j", "down"))):
+		a.cursor = min(a.cursor+1, max(len(a.state.alerts)-1, 0))
+	case key.Matches(k, key.NewBinding(key.WithKeys("k", "up"))):
+		a.cursor = max(a.cursor-1, 0)
+	}
+	return a, nil
+}
+
+// View renders the alerts modal as a box suitable for overlay.
+func (a AlertsModal) View(width, height int) string {
+	if !a.active {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.ColorPrimary).
+		Align(lipgloss.Center)
+	ruleStyle := lipgloss.NewStyle().Foreground(theme.ColorSubtle)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorMuted).
+		Align(lipgloss.Center)
+
+	contentWidth := layout.Clamp(width-6, 30, 90)
+	lines := []string{titleStyle.Width(contentWidth).Render("Alerts"), ""}
+
+	switch {
+	case len(a.state.rules) == 0:
+		lines = append(lines, "No alert rules configured. Add rules under [alerts] in config.toml.")
+	case a.state.checked.IsZero():
+		lines = append(lines, theme.LoadingStyle.Render("Checking..."))
+	case len(a.state.alerts) == 0:
+		lines = append(lines, theme.ActiveStatusStyle.Render(theme.GlyphOK+" All clear"))
+	default:
+		// Each alert takes two lines: what breached and the rule.
+		visible := max((height-12)/2, 1)
+		start := layout.ScrollStart(a.cursor, visible)
+		for i := start; i < len(a.state.alerts) && i < start+visible; i++ {
+			alert := a.state.alerts[i]
+			text := theme.Truncate(alert.String(), contentWidth-2)
+			prefix := "  "
+			if i == a.cursor {
+				prefix = theme.CursorStyle.Render("> ")
+				text = theme.SelectedItemStyle.Render(text)
+			}
+			lines = append(lines, prefix+text, "    "+ruleStyle.Render(theme.Truncate(alert.Rule, contentWidth-4)))
+		}
+	}
+
+	if !a.state.checked.IsZero() {
+		status := "Checked " + a.state.checked.Format("15:04")
+		if n := len(a.state.errs); n > 0 {
+			status += fmt.Sprintf(" · %d request(s) failed: %s", n, a.state.errs[0])
+		}
+		lines = append(lines, "", ruleStyle.Render(theme.Truncate(status, contentWidth)))
+	}
+	lines = append(lines, "", hintStyle.Width(contentWidth).Render("j/k move  esc close"))
+
+	return lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(theme.ColorPrimary).
+		Padding(1, 2).
+		Background(theme.ColorBg).
+		Width(contentWidth + 4).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/monitor"
+)
+
+func TestAlertsChecked(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	cfg := config.Default()
+	cfg.UI.TourSeen = true
+	cfg.Alerts.Rules = []string{"deployment failed", "cert expires soon"}
+	m := NewApp(cfg, "", LaunchNone)
+	if len(m.alerts.rules) != 1 || len(m.alerts.invalid) != 1 {
+		t.Fatalf("parsed %d rules with %d invalid, want 1 and 1", len(m.alerts.rules), len(m.alerts.invalid))
+	}
+
+	failed := monitor.Alert{Rule: "deployment failed", Server: "web", Subject: "a.example.com", Detail: "latest deployment failed"}
+	other := failed
+	other.Subject = "b.example.com"
+
+	model, _ := m.Update(alertsCheckedMsg{alerts: []monitor.Alert{failed, other}, at: time.Now()})
+	m = model.(App)
+	if m.toast != "2 new alerts (! to view)" || !m.toastIsErr {
+		t.Errorf("toast = %q, want two new alerts", m.toast)
+	}
+	if badge := m.alertBadge(); !strings.Contains(badge, "2 alerts") {
+		t.Errorf("badge = %q, want 2 alerts", badge)
+	}
+
+	m.toast = ""
+	model, _ = m.Update(alertsCheckedMsg{alerts: []monitor.Alert{failed}, at: time.Now()})
+	m = model.(App)
+	if m.toast != "" {
+		t.Errorf("toast = %q for alerts already seen", m.toast)
+	}
+
+	model, _ = m.Update(alertsCheckedMsg{seq: m.alerts.seq - 1, at: time.Now()})
+	m = model.(App)
+	if len(m.alerts.alerts) != 1 {
+		t.Errorf("a stale check replaced the alerts: %v", m.alerts.alerts)
+	}
+}
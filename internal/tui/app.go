@@ -3,10 +3,13 @@ package tui
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -16,11 +19,18 @@ import (
 
 	"github.com/hinkers/Phorge/internal/config"
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/socket"
 	"github.com/hinkers/Phorge/internal/tui/components"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/panels"
 	"github.com/hinkers/Phorge/internal/tui/theme"
+	"github.com/hinkers/Phorge/internal/update"
 )
 
+// Version is the running phorge version, set by main at startup.
+// It is "dev" for local builds, which skips the update check.
+var Version = "dev"
+
 // LaunchAction is an optional action to run immediately after resolving a jump target.
 type LaunchAction string
 
@@ -48,45 +58,23 @@ type App struct {
 	forge   *forge.Client
 	config  *config.Config
 	project config.ProjectConfig
+	state   *config.State
+	names   *config.NameCache // server and site names for shell completion
 
-	focus         Focus
 	width, height int
 
+	// nav is the stack of screens drilled into; its top has focus.
+	nav NavStack
+
 	// Sub-model panels.
-	treePanel         panels.TreePanel
-	outputPanel       panels.OutputPanel
-	serverInfo        panels.ServerInfo
-	siteInfo          panels.SiteInfo
-	deploymentsPanel  panels.DeploymentsPanel
-	deployScriptPanel panels.DeployScriptPanel
-	environmentPanel  panels.EnvironmentPanel
-	databasesPanel    panels.DatabasesPanel
-	dbUsersPanel      panels.DBUsersPanel
-	sslPanel          panels.SSLPanel
-	workersPanel      panels.WorkersPanel
-	daemonsPanel      panels.DaemonsPanel
-	firewallPanel     panels.FirewallPanel
-	jobsPanel         panels.JobsPanel
-	sshKeysPanel      panels.SSHKeysPanel
-	commandsPanel     panels.CommandsPanel
-	logsPanel         panels.LogsPanel
-	eventsPanel       panels.EventsPanel
-	gitPanel          panels.GitPanel
-	domainsPanel      panels.DomainsPanel
-
-	// showDeployScript is true when viewing the deploy script sub-view
-	// from within the deployments tab.
-	showDeployScript bool
-
-	// showDBUsers is true when viewing the database users sub-view
-	// from within the databases tab.
-	showDBUsers bool
-
-	// Confirmation dialog state.
-	confirm *components.Confirm
-
-	// Input dialog state.
-	inputDialog *components.Input
+	treePanel   panels.TreePanel
+	outputPanel panels.OutputPanel
+
+	// Detail area: section tab panels and the active tab.
+	detail DetailController
+
+	// Confirmation and input dialogs.
+	dialogs DialogController
 
 	// pendingInputValue stores a value from a multi-step input dialog
 	// (e.g. SSH key name before prompting for key content).
@@ -95,7 +83,6 @@ type App struct {
 	// Data kept at the app level for cross-panel concerns.
 	selectedSrv  *forge.Server
 	selectedSite *forge.Site
-	activeTab    int // 1-9 for detail section tabs
 
 	// UI state
 	toast      string
@@ -112,6 +99,65 @@ type App struct {
 	// Settings modal overlay.
 	settingsModal SettingsModal
 
+	// About modal overlay.
+	aboutModal AboutModal
+
+	// Database credentials overlay.
+	credsModal CredentialsModal
+
+	// alerts is the background alert evaluator, listed by alertsModal.
+	alerts      alertState
+	alertsModal AlertsModal
+
+	// Provider credentials overlay.
+	providersModal ProvidersModal
+
+	// scheduler runs the deploys scheduled for later in this session,
+	// listed by scheduledModal.
+	scheduler      *scheduler
+	scheduledModal ScheduledModal
+
+	// socket is the event socket when [socket] enables it; socketErr is
+	// why it couldn't be opened.
+	socket    *socket.Server
+	socketErr error
+
+	// reauth asks for a new API key after the API rejected the current
+	// one, signalled on authExpired. reauthDismissed is set once the user
+	// backs out of it, so it isn't shown again.
+	reauth          *Setup
+	authExpired     chan struct{}
+	reauthDismissed bool
+
+	// pendingDelete is a confirmed delete that can still be undone, and
+	// deleteSeq numbers them so stale countdown ticks are ignored.
+	pendingDelete *pendingDelete
+	deleteSeq     int
+
+	// diag backs the F12 diagnostics overlay.
+	diag *diagnostics
+
+	// pendingRules holds the firewall rules waiting for a target server.
+	pendingRules []forge.FirewallRule
+
+	// pendingEnvCopy holds the .env lines being copied to another site.
+	pendingEnvCopy *envCopy
+
+	// pendingMultiLog holds the sites offered for log aggregation.
+	pendingMultiLog []multiLogSite
+
+	// pendingJob holds the scheduled job being created, between the steps
+	// of its input dialogs.
+	pendingJob *forge.JobCreateOpts
+
+	// pendingRecipe holds the recipe being created, between its dialogs
+	// and the editor; pendingRecipeRun the recipe run being set up.
+	pendingRecipe    *forge.RecipeCreateOpts
+	pendingRecipeRun *recipeRun
+
+	// First-run onboarding tour overlay.
+	tour Tour
+
 	// jumpTarget is a nickname or site name from the CLI arg.
 	// Used to auto-navigate after servers load.
 	jumpTarget string
@@ -122,6 +168,18 @@ type App struct {
 	// Output polling state for auto-updating deployment/command output.
 	outputPoll outputPollState
 
+	// queuedDeploy is a deploy to trigger once a running one finishes.
+	queuedDeploy *queuedDeploy
+
+	// updateVersion is the newer release available on GitHub, if any.
+	updateVersion string
+
+	// activeEnv is the .phorge environment last switched to with E.
+	activeEnv string
+
+	// pendingJump is a site to select once its server's sites have loaded.
+	pendingJump *jumpRequest
+
 	// Keymaps
 	globalKeys    GlobalKeyMap
 	navKeys       NavKeyMap
@@ -137,6 +195,31 @@ type treeSitesLoadedMsg struct {
 	sites    []forge.Site
 }
 
+// treeDeployStatusMsg carries the latest deployment status of each site
+// on a server, keyed by site ID, for the tree badges.
+type treeDeployStatusMsg struct {
+	statuses map[int64]string
+}
+
+// serverReachabilityMsg reports which servers answered on their SSH port.
+type serverReachabilityMsg struct {
+	reachable map[int64]bool
+}
+
+// treeStatusConcurrency bounds the number of deployment history requests
+// in flight when fetching tree badges.
+const treeStatusConcurrency = 4
+
+// reachabilityConcurrency bounds the number of SSH port checks in flight.
+const reachabilityConcurrency = 8
+
+// jumpRequest identifies a site to navigate to once its server's sites
+// have been fetched.
+type jumpRequest struct {
+	serverID int64
+	siteName string
+}
+
 // outputPollState tracks the active output polling context.
 type outputPollState struct {
 	serverID     int64
@@ -144,17 +227,23 @@ type outputPollState struct {
 	deploymentID int64
 	active       bool
 	frame        int // spinner frame index
+
+	// log is the live deployment log fetched so far, so each poll only
+	// needs to fetch (and render) what was appended since.
+	log string
 }
 
-// spinnerFrames are the characters cycled through while polling.
-var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 
 // NewApp creates a new App model with the given configuration.
 // jumpTarget is an optional nickname or site name from CLI args.
 // action is an optional action to run after resolving the target (ssh/sftp/db).
 func NewApp(cfg *config.Config, jumpTarget string, action LaunchAction) App {
-	client := forge.NewClient(cfg.Forge.APIKey)
+	authExpired := make(chan struct{}, 1)
+	client := newForgeClient(cfg, authExpired)
+	sched := newScheduler()
+	sock, sockErr := openSocket(cfg)
 	project := config.LoadProjectConfig()
+	state := config.LoadState()
 
 	// If a jump target is given, resolve it: check nicknames first, then
 	// treat it as a site name. This overrides the .phorge project config.
@@ -174,20 +263,38 @@ func NewApp(cfg *config.Config, jumpTarget string, action LaunchAction) App {
 		nickMap[entry.Server+"\n"+entry.Site] = nick
 	}
 
+	tour := NewTour()
+	if !cfg.UI.TourSeen && action == LaunchNone {
+		tour = tour.Start()
+	}
+
 	return App{
 		forge:       client,
 		config:      cfg,
 		project:     project,
+		state:       state,
+		names:       config.LoadNameCache(),
 		jumpTarget:   jumpTarget,
 		launchAction: action,
-		focus:        FocusTree,
-		activeTab:   1,
-		treePanel:   panels.NewTreePanel().SetDefaultServer(project.Server).SetDefaultSite(project.Site).SetNicknames(nickMap),
+		nav:          NewNavStack(),
+		treePanel:   panels.NewTreePanel().SetDefaultServer(project.Server).SetDefaultSite(project.Site).SetNicknames(nickMap).SetHidden(hiddenServers(cfg)).SetRecent(recentSites(state)).SetFilter(state.TreeFilter).SetGroupByApp(state.TreeByApp).SetWorkspace(workspaceScope(cfg, state.Workspace)),
 		outputPanel: panels.NewOutputPanel(),
-		serverInfo:  panels.NewServerInfo(),
-		siteInfo:    panels.NewSiteInfo(),
+		detail:      NewDetailController().SetDisabledTabs(cfg.UI.DisabledTabs).SetReducedMotion(cfg.UI.ReducedMotion),
+		dialogs:     DialogController{}.SetHistory(state.InputHistory),
 		helpModal:     NewHelpModal(),
 		settingsModal: NewSettingsModal(),
+		aboutModal:    NewAboutModal(),
+		credsModal:    NewCredentialsModal(),
+		alerts:        newAlertState(cfg, 0),
+		alertsModal:   NewAlertsModal(),
+		providersModal: NewProvidersModal(),
+		scheduler:      sched,
+		scheduledModal: NewScheduledModal(sched),
+		socket:         sock,
+		socketErr:      sockErr,
+		authExpired:   authExpired,
+		diag:          &diagnostics{},
+		tour:          tour,
 		globalKeys:    DefaultGlobalKeyMap(),
 		navKeys:       DefaultNavKeyMap(),
 		sectionKeys:   DefaultSectionKeyMap(),
@@ -196,13 +303,40 @@ func NewApp(cfg *config.Config, jumpTarget string, action LaunchAction) App {
 	}
 }
 
-// Init fetches the initial server list.
+// Init fetches the initial server list, checks for a newer release and
+// surfaces any config warnings. Without a .phorge, it also looks for a site
+// deploying the current directory's git repository to suggest as default.
 func (m App) Init() tea.Cmd {
-	return m.fetchServers()
+	cmds := []tea.Cmd{m.fetchServers(), checkForUpdate(), configWarningsToast(m.config), waitAuthExpired(m.authExpired), waitScheduled(m.scheduler), waitSocket(m.socket), socketErrToast(m.socketErr), m.alerts.invalidRulesToast(), m.checkAlerts()}
+	if m.jumpTarget == "" && config.FindProjectConfig(".") == "" {
+		cmds = append(cmds, m.detectRepoSite())
+	}
+	return tea.Batch(cmds...)
 }
 
-// Update handles all incoming messages.
-func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// update handles all incoming messages.
+func (m App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The new API key prompt takes over the screen while it is open.
+	if m.reauth != nil {
+		switch msg.(type) {
+		case tea.KeyPressMsg, tea.PasteMsg, setupValidateMsg:
+			return m.updateReauth(msg)
+		case tea.WindowSizeMsg:
+			s, _ := m.reauth.Update(msg)
+			setup := s.(Setup)
+			m.reauth = &setup
+		}
+	}
+
+	// The onboarding tour intercepts all keys when active.
+	if m.tour.Active() {
+		if _, ok := msg.(tea.KeyPressMsg); ok {
+			var cmd tea.Cmd
+			m.tour, cmd = m.tour.Update(msg)
+			return m, cmd
+		}
+	}
+
 	// If the help modal is active, route all key events to it.
 	if m.helpModal.Active() {
 		if _, ok := msg.(tea.KeyPressMsg); ok {
@@ -221,24 +355,66 @@ func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	}
 
-	// If an input dialog is active, route all key events to it.
-	if m.inputDialog != nil && m.inputDialog.Active {
+	// About modal intercepts all keys when active.
+	if m.aboutModal.Active() {
 		if _, ok := msg.(tea.KeyPressMsg); ok {
-			i, cmd := m.inputDialog.Update(msg)
-			m.inputDialog = &i
+			var cmd tea.Cmd
+			m.aboutModal, cmd = m.aboutModal.Update(msg)
 			return m, cmd
 		}
 	}
 
-	// If a confirmation dialog is active, route all key events to it.
-	if m.confirm != nil && m.confirm.Active {
+	// Credentials modal intercepts all keys when active.
+	if m.credsModal.Active() {
 		if _, ok := msg.(tea.KeyPressMsg); ok {
-			c, cmd := m.confirm.Update(msg)
-			m.confirm = &c
+			var cmd tea.Cmd
+			m.credsModal, cmd = m.credsModal.Update(msg)
 			return m, cmd
 		}
 	}
 
+	// Providers modal intercepts all keys when active.
+	if m.providersModal.Active() {
+		if _, ok := msg.(tea.KeyPressMsg); ok {
+			var cmd tea.Cmd
+			m.providersModal, cmd = m.providersModal.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Alerts modal intercepts all keys when active.
+	if m.alertsModal.Active() {
+		if _, ok := msg.(tea.KeyPressMsg); ok {
+			var cmd tea.Cmd
+			m.alertsModal, cmd = m.alertsModal.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Scheduled actions modal intercepts all keys when active.
+	if m.scheduledModal.Active() {
+		if _, ok := msg.(tea.KeyPressMsg); ok {
+			var cmd tea.Cmd
+			m.scheduledModal, cmd = m.scheduledModal.Update(msg)
+			return m, cmd
+		}
+	}
+
+	// Open dialogs intercept all keys and pastes; their results are
+	// acted on once the dialog controller has closed them.
+	if d, cmd, ok := m.dialogs.Update(msg); ok {
+		m.dialogs = d
+		model, resultCmd := m.handleDialogResult(msg)
+		return model, tea.Batch(cmd, resultCmd)
+	}
+
+	// Tab panel data, action results and keys are routed by the detail
+	// controller.
+	if d, cmd, ok := m.detail.Update(msg); ok {
+		m.detail = d
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -249,72 +425,11 @@ func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleKey(msg)
 
 	case serversLoadedMsg:
-		m.loading = false
-		m.treePanel = m.treePanel.SetServers(msg.servers).SetLoading(false)
-
-		var cmds []tea.Cmd
-
-		if m.jumpTarget != "" && m.project.Server == "" {
-			// Bare site name: expand all servers to search for the site.
-			for _, srv := range msg.servers {
-				var cmd tea.Cmd
-				m.treePanel, cmd = m.treePanel.ExpandServer(srv.ID)
-				if cmd != nil {
-					cmds = append(cmds, cmd)
-				}
-			}
-		} else if defaultName := m.project.Server; defaultName != "" {
-			// Auto-expand default server from per-directory .phorge config or nickname.
-			if srv := m.treePanel.FindServerByName(defaultName); srv != nil {
-				var cmd tea.Cmd
-				m.treePanel, cmd = m.treePanel.ExpandServer(srv.ID)
-				if cmd != nil {
-					cmds = append(cmds, cmd)
-				}
-				var found bool
-				m.treePanel, found = m.treePanel.SetCursorToServer(srv.ID)
-				_ = found
-			}
-		}
-
-		// Select whatever the cursor is on.
-		srv, site := m.treePanel.Selected()
-		if srv.ID != 0 {
-			m.selectedSrv = &srv
-			m.serverInfo = m.serverInfo.SetServer(&srv)
-		} else {
-			m.selectedSrv = nil
-			m.serverInfo = m.serverInfo.SetServer(nil)
-		}
-		m.selectedSite = site
-		m.siteInfo = m.siteInfo.SetSite(site)
-
-		// If jump target resolved to server-only (no site), fire launch action now.
-		if m.launchAction != LaunchNone && m.project.Server != "" && m.project.Site == "" && m.selectedSrv != nil {
-			action := m.launchAction
-			m.launchAction = LaunchNone
-			cmds = append(cmds, m.execLaunchAction(action))
-		}
-
-		if len(cmds) > 0 {
-			return m, tea.Batch(cmds...)
-		}
-		return m, nil
+		return m.handleServersLoaded(msg)
 
 	// Tree panel: user navigated to a node.
 	case panels.TreeNodeSelectedMsg:
-		srv := msg.Server
-		m.selectedSrv = &srv
-		m.serverInfo = m.serverInfo.SetServer(&srv)
-		if msg.Site != nil {
-			site := *msg.Site
-			m.selectedSite = &site
-			m.siteInfo = m.siteInfo.SetSite(&site)
-		} else {
-			m.selectedSite = nil
-			m.siteInfo = m.siteInfo.SetSite(nil)
-		}
-		return m, nil
+		return m.handleTreeNodeSelected(msg)
 
 	// Tree panel: needs sites for a server.
 	case panels.TreeFetchSitesMsg:
@@ -323,513 +438,121 @@ func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Sites loaded for tree expansion.
 	case treeSitesLoadedMsg:
-		m.treePanel = m.treePanel.SetSites(msg.serverID, msg.sites)
-
-		// If a default site is configured, navigate to it when its server's
-		// sites are first loaded.
-		siteFound := false
-		if defaultSite := m.project.Site; defaultSite != "" {
-			if defaultServer := m.project.Server; defaultServer != "" {
-				// Known server: only match within that server.
-				if srv := m.treePanel.FindServerByName(defaultServer); srv != nil && srv.ID == msg.serverID {
-					_, site := m.treePanel.FindSiteByName(defaultSite)
-					if site != nil {
-						m.treePanel, _ = m.treePanel.SetCursorToSite(site.ID)
-						m.selectedSite = site
-						m.siteInfo = m.siteInfo.SetSite(site)
-						siteFound = true
-					}
-				}
-			} else {
-				// Bare site name search: check this batch of sites.
-				for i := range msg.sites {
-					if strings.EqualFold(msg.sites[i].Name, defaultSite) {
-						m.treePanel, _ = m.treePanel.SetCursorToSite(msg.sites[i].ID)
-						m.selectedSite = &msg.sites[i]
-						m.siteInfo = m.siteInfo.SetSite(&msg.sites[i])
-						// Also select the parent server.
-						if srv := m.treePanel.FindServerByID(msg.serverID); srv != nil {
-							m.selectedSrv = srv
-							m.serverInfo = m.serverInfo.SetServer(srv)
-						}
-						siteFound = true
-						break
-					}
-				}
-			}
-		}
-
-		// If the jump target resolved and there's a pending launch action, fire it.
-		if siteFound && m.launchAction != LaunchNone {
-			action := m.launchAction
-			m.launchAction = LaunchNone // consume it
-			return m, m.execLaunchAction(action)
-		}
-		return m, nil
-
-	// Deployment panel messages.
-	case panels.DeploymentsLoadedMsg:
-		p, cmd := m.deploymentsPanel.Update(msg)
-		m.deploymentsPanel = p.(panels.DeploymentsPanel)
-		return m, cmd
-
-	// Events panel messages.
-	case panels.EventsLoadedMsg:
-		p, cmd := m.eventsPanel.Update(msg)
-		m.eventsPanel = p.(panels.EventsPanel)
-		return m, cmd
+		return m.handleTreeSitesLoaded(msg)
 
-	// User pressed Enter on a deployment to view output.
-	case panels.DeployViewOutputMsg:
-		// Start polling if the deployment might still be running.
-		m.outputPoll = outputPollState{
-			serverID:     msg.ServerID,
-			siteID:       msg.SiteID,
-			deploymentID: msg.DeploymentID,
-			active:       true,
-		}
-		return m, tea.Batch(
-			m.fetchDeployOutputWithStatus(msg.ServerID, msg.SiteID, msg.DeploymentID),
-			m.spinnerTick(),
-		)
-
-	// Deploy output fetched — route to output panel.
-	case panels.DeployOutputMsg:
-		m.outputPanel = m.outputPanel.SetContent("Deploy Output", msg.Output)
-		m.focus = FocusOutput
+	case serverReachabilityMsg:
+		m.treePanel = m.treePanel.SetReachability(msg.reachable)
 		return m, nil
 
-	// Polled output+status result.
-	case pollOutputResultMsg:
-		spinner := spinnerFrames[m.outputPoll.frame%len(spinnerFrames)]
-		m.outputPanel = m.outputPanel.SetContent(
-			fmt.Sprintf("Deploy Output %s deploying…", spinner),
-			msg.output,
-		)
-		m.focus = FocusOutput
-		if msg.finished {
-			// Deployment finished — wait briefly then re-fetch output
-			// to ensure the API has flushed the complete log.
-			return m, tea.Tick(time.Second, func(time.Time) tea.Msg {
-				return pollFinalFetchMsg{}
-			})
-		}
-		// Continue polling.
-		return m, m.pollOutputTick()
-
-	// Delay expired after deployment finished — do the final fetch.
-	case pollFinalFetchMsg:
-		if !m.outputPoll.active {
-			return m, nil
-		}
-		client := m.forge
-		serverID := m.outputPoll.serverID
-		siteID := m.outputPoll.siteID
-		deployID := m.outputPoll.deploymentID
-		return m, func() tea.Msg {
-			output, err := client.Deployments.GetOutput(
-				context.Background(), serverID, siteID, deployID,
-			)
-			if err != nil {
-				return panels.PanelErrMsg{Err: err}
-			}
-			return pollFinalOutputMsg{output: output}
-		}
-
-	// Final output re-fetch after deployment finished.
-	case pollFinalOutputMsg:
-		m.outputPanel = m.outputPanel.SetContent("Deploy Output", msg.output)
-		m.outputPoll.active = false
-		m.outputPoll.frame = 0
-		// Refresh the deployments list to show updated status.
-		if m.activeTab == 1 {
-			return m, m.deploymentsPanel.LoadDeployments()
-		}
+	case treeDeployStatusMsg:
+		m.treePanel = m.treePanel.SetDeployStatuses(msg.statuses)
 		return m, nil
 
-	// Spinner animation tick — runs independently of the data poll.
-	case pollSpinnerTickMsg:
-		if !m.outputPoll.active {
-			return m, nil
-		}
-		m.outputPoll.frame++
-		spinner := spinnerFrames[m.outputPoll.frame%len(spinnerFrames)]
-		m.outputPanel = m.outputPanel.SetTitle(
-			fmt.Sprintf("Deploy Output %s deploying…", spinner),
-		)
-		return m, m.spinnerTick()
+	// Deploy output being watched in the output panel.
+	case panels.DeployViewOutputMsg, pollOutputResultMsg, pollFinalFetchMsg, pollFinalOutputMsg, pollSpinnerTickMsg, pollOutputTickMsg:
+		return m.updateDeployOutput(msg)
 
-	// Poll timer fired.
-	case pollOutputTickMsg:
-		if !m.outputPoll.active {
+	// Tab bar spinner tick — stops once the active tab has loaded.
+	case tabSpinnerTickMsg:
+		if !m.detail.Loading(m.selectedSrv, m.selectedSite, m.nav.Top()) {
+			m.detail.spinning = false
 			return m, nil
 		}
-		return m, m.fetchDeployOutputWithStatus(
-			m.outputPoll.serverID,
-			m.outputPoll.siteID,
-			m.outputPoll.deploymentID,
-		)
-
-	case panels.DeployResetMsg:
-		if msg.Err != nil {
-			m.toast = fmt.Sprintf("Reset failed: %v", msg.Err)
-			m.toastIsErr = true
-		} else {
-			m.toast = "Deployment status reset"
-			m.toastIsErr = false
-		}
-		// Refresh the deployments list after reset.
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.deploymentsPanel.LoadDeployments(),
-		)
+		m.detail.spinFrame++
+		return m, tabSpinnerTick()
 
 	// Deploy triggered (from deployments panel commands).
 	case panels.DeployTriggerMsg:
-		m.toast = "Deployment started"
-		m.toastIsErr = false
-		cmds := []tea.Cmd{m.clearToastAfter(3 * time.Second)}
-		if m.activeTab == 1 {
-			cmds = append(cmds, m.deploymentsPanel.LoadDeployments())
-		}
-		return m, tea.Batch(cmds...)
-
-	// Deploy script panel messages.
-	case panels.ScriptLoadedMsg:
-		p, cmd := m.deployScriptPanel.Update(msg)
-		m.deployScriptPanel = p.(panels.DeployScriptPanel)
-		return m, cmd
-
-	case panels.ScriptEditorDoneMsg:
-		p, cmd := m.deployScriptPanel.Update(msg)
-		m.deployScriptPanel = p.(panels.DeployScriptPanel)
-		if msg.Changed {
-			m.toast = "Saving deploy script..."
-			m.toastIsErr = false
-		}
-		return m, cmd
-
-	case panels.ScriptSavedMsg:
-		if msg.Err != nil {
-			m.toast = fmt.Sprintf("Script save failed: %v", msg.Err)
-			m.toastIsErr = true
-		} else {
-			m.toast = "Deploy script updated"
-			m.toastIsErr = false
-		}
-		return m, m.clearToastAfter(3 * time.Second)
+		return m.handleDeployTrigger(msg)
 
-	// Environment panel messages.
-	case panels.EnvLoadedMsg:
-		p, cmd := m.environmentPanel.Update(msg)
-		m.environmentPanel = p.(panels.EnvironmentPanel)
-		return m, cmd
-
-	case panels.EnvEditorDoneMsg:
-		p, cmd := m.environmentPanel.Update(msg)
-		m.environmentPanel = p.(panels.EnvironmentPanel)
-		if msg.Changed {
-			m.toast = "Saving environment..."
-			m.toastIsErr = false
-		}
-		return m, cmd
+	case deployWatchTickMsg:
+		return m, m.checkDeployWatch(msg.watch)
 
-	case panels.EnvSavedMsg:
-		if msg.Err != nil {
-			m.toast = fmt.Sprintf("Environment save failed: %v", msg.Err)
-			m.toastIsErr = true
-		} else {
-			m.toast = "Environment updated"
-			m.toastIsErr = false
+	case queuedDeployTickMsg:
+		if m.queuedDeploy == nil || m.queuedDeploy.seq != msg.seq {
+			return m, nil
 		}
-		return m, m.clearToastAfter(3 * time.Second)
-
-	// Databases panel messages.
-	case panels.DatabasesLoadedMsg:
-		p, cmd := m.databasesPanel.Update(msg)
-		m.databasesPanel = p.(panels.DatabasesPanel)
-		return m, cmd
-
-	case panels.DatabaseCreatedMsg:
-		m.toast = "Database created"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.databasesPanel.LoadDatabases(),
-		)
-
-	case panels.DatabaseDeletedMsg:
-		m.toast = "Database deleted"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.databasesPanel.LoadDatabases(),
-		)
-
-	// Database users panel messages.
-	case panels.DBUsersLoadedMsg:
-		p, cmd := m.dbUsersPanel.Update(msg)
-		m.dbUsersPanel = p.(panels.DBUsersPanel)
-		return m, cmd
-
-	case panels.DBUserCreatedMsg:
-		m.toast = "Database user created"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.dbUsersPanel.LoadUsers(),
-		)
-
-	case panels.DBUserDeletedMsg:
-		m.toast = "Database user deleted"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.dbUsersPanel.LoadUsers(),
-		)
-
-	// SSL panel messages.
-	case panels.CertsLoadedMsg:
-		p, cmd := m.sslPanel.Update(msg)
-		m.sslPanel = p.(panels.SSLPanel)
-		return m, cmd
+		return m, m.checkQueuedDeploy()
 
-	case panels.CertCreatedMsg:
-		m.toast = "Certificate created"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.sslPanel.LoadCerts(),
-		)
+	case queuedDeployStatusMsg:
+		return m.handleQueuedDeployStatus(msg)
 
-	case panels.CertActivatedMsg:
-		m.toast = "Certificate activated"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.sslPanel.LoadCerts(),
-		)
+	case autoDeployStartedMsg:
+		return m.handleAutoDeployStarted(msg)
 
-	case panels.CertDeletedMsg:
-		m.toast = "Certificate deleted"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.sslPanel.LoadCerts(),
-		)
+	case hookDoneMsg:
+		return m.handleHookDone(msg)
 
-	// Workers panel messages.
-	case panels.WorkersLoadedMsg:
-		p, cmd := m.workersPanel.Update(msg)
-		m.workersPanel = p.(panels.WorkersPanel)
-		return m, cmd
+	case deployLogSavedMsg:
+		return m.handleDeployLogSaved(msg)
 
-	case panels.WorkerCreatedMsg:
-		m.toast = "Worker created"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.workersPanel.LoadWorkers(),
-		)
+	case socketCommandMsg:
+		return m.handleSocketCommand(msg)
 
-	case panels.WorkerRestartedMsg:
-		m.toast = "Worker restarted"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.workersPanel.LoadWorkers(),
-		)
+	case projectSiteMsg:
+		return m.handleProjectSite(msg)
 
-	case panels.WorkerDeletedMsg:
-		m.toast = "Worker deleted"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.workersPanel.LoadWorkers(),
-		)
+	case scheduledDueMsg:
+		return m.handleScheduledDue(msg)
 
-	// Daemons panel messages.
-	case panels.DaemonsLoadedMsg:
-		p, cmd := m.daemonsPanel.Update(msg)
-		m.daemonsPanel = p.(panels.DaemonsPanel)
-		return m, cmd
+	case deployWatchStatusMsg:
+		return m.handleDeployWatchStatus(msg)
 
-	case panels.DaemonCreatedMsg:
-		m.toast = "Daemon created"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.daemonsPanel.LoadDaemons(),
-		)
+	case healthCheckedMsg:
+		return m.handleHealthChecked(msg)
 
-	case panels.DaemonRestartedMsg:
-		m.toast = "Daemon restarted"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.daemonsPanel.LoadDaemons(),
-		)
+	case rollbackDoneMsg:
+		return m.handleRollbackDone(msg)
 
-	case panels.DaemonDeletedMsg:
-		m.toast = "Daemon deleted"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.daemonsPanel.LoadDaemons(),
-		)
-
-	// Firewall panel messages.
-	case panels.FirewallLoadedMsg:
-		p, cmd := m.firewallPanel.Update(msg)
-		m.firewallPanel = p.(panels.FirewallPanel)
-		return m, cmd
-
-	case panels.FirewallCreatedMsg:
-		m.toast = "Firewall rule created"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.firewallPanel.LoadRules(),
-		)
-
-	case panels.FirewallDeletedMsg:
-		m.toast = "Firewall rule deleted"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.firewallPanel.LoadRules(),
-		)
-
-	// Jobs panel messages.
-	case panels.JobsLoadedMsg:
-		p, cmd := m.jobsPanel.Update(msg)
-		m.jobsPanel = p.(panels.JobsPanel)
-		return m, cmd
-
-	// SSH Keys panel messages.
-	case panels.SSHKeysLoadedMsg:
-		p, cmd := m.sshKeysPanel.Update(msg)
-		m.sshKeysPanel = p.(panels.SSHKeysPanel)
-		return m, cmd
-
-	case panels.SSHKeyCreatedMsg:
-		m.toast = "SSH key created"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.sshKeysPanel.LoadKeys(),
-		)
-
-	case panels.SSHKeyDeletedMsg:
-		m.toast = "SSH key deleted"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.sshKeysPanel.LoadKeys(),
-		)
-
-	// Commands panel messages.
-	case panels.CommandsLoadedMsg:
-		p, cmd := m.commandsPanel.Update(msg)
-		m.commandsPanel = p.(panels.CommandsPanel)
-		return m, cmd
-
-	case panels.CommandCreatedMsg:
-		m.toast = "Command executed"
-		m.toastIsErr = false
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.commandsPanel.LoadCommands(),
-		)
-
-	case panels.CommandDetailMsg:
-		p, cmd := m.commandsPanel.Update(msg)
-		m.commandsPanel = p.(panels.CommandsPanel)
-		return m, cmd
-
-	// Logs panel messages.
-	case panels.LogsLoadedMsg:
-		p, cmd := m.logsPanel.Update(msg)
-		m.logsPanel = p.(panels.LogsPanel)
-		return m, cmd
-
-	case panels.LogEditorDoneMsg:
-		p, cmd := m.logsPanel.Update(msg)
-		m.logsPanel = p.(panels.LogsPanel)
-		return m, cmd
-
-	// Domains panel messages.
-	case panels.DomainsLoadedMsg:
-		p, cmd := m.domainsPanel.Update(msg)
-		m.domainsPanel = p.(panels.DomainsPanel)
-		return m, cmd
-
-	case panels.DomainsSavedMsg:
-		if msg.Err != nil {
-			m.toast = fmt.Sprintf("Domain update failed: %v", msg.Err)
-			m.toastIsErr = true
-		} else {
-			m.toast = "Domains updated"
-			m.toastIsErr = false
+	case daemonsRestartedMsg:
+		m.toast = fmt.Sprintf("%s daemons: %s", msg.siteName, strings.Join(msg.results, ", "))
+		m.toastIsErr = msg.failed > 0
+		cmds := []tea.Cmd{m.clearToastAfter(5 * time.Second)}
+		if m.detail.activeTab == 6 && m.selectedSite == nil {
+			cmds = append(cmds, m.detail.daemonsPanel.LoadDaemons())
 		}
-		return m, tea.Batch(
-			m.clearToastAfter(3*time.Second),
-			m.domainsPanel.RefreshAliases(),
-		)
+		return m, tea.Batch(cmds...)
+
+	// Panel-level errors (from panel API commands).
+	case authExpiredMsg:
+		return m.handleAuthExpired()
 
-	// Input dialog results.
-	case components.InputResult:
-		m.inputDialog = nil
-		return m.handleInputResult(msg)
+	case reauthDoneMsg:
+		return m.handleReauthDone(msg)
 
-	case components.InputCancelled:
-		m.inputDialog = nil
-		// Re-open settings modal if the cancelled input was a settings field.
-		if strings.HasPrefix(msg.ID, "settings-") {
-			m.settingsModal = m.settingsModal.Open(m.config)
+	case panelLoadMsg:
+		// A tab's load goes to its panel: in its slot, or in the cache if
+		// the user has moved on meanwhile, and nowhere once it's gone.
+		if m.detail.Owns(msg.key) {
+			return m.Update(msg.msg)
 		}
-		return m, nil
+		var cmd tea.Cmd
+		m.detail, cmd = m.detail.UpdateCached(msg.key, msg.msg)
+		return m, cmd
 
-	// Panel-level errors (from panel API commands).
 	case panels.PanelErrMsg:
 		m.loading = false
+		m.detail.loadFailed = true
 		m.toast = fmt.Sprintf("Error: %v", msg.Err)
 		m.toastIsErr = true
 		return m, m.clearToastAfter(5 * time.Second)
 
-	// Confirmation dialog result.
-	case components.ConfirmResult:
-		m.confirm = nil
-		return m.handleConfirmResult(msg)
+	case repoMatchMsg:
+		// Don't interrupt if a default was set meanwhile or a dialog is open.
+		if m.project.Server != "" || m.dialogs.ConfirmActive() {
+			return m, nil
+		}
+		m.pendingInputValue = msg.server.Name + "\n" + msg.site.Name
+		m.dialogs = m.dialogs.Confirm("write-phorge", fmt.Sprintf("This repo (%s) deploys to %s on %s. Save as default in .phorge?", msg.repo, msg.site.Name, msg.server.Name))
+		return m, nil
 
 	case setDefaultMsg:
-		if msg.err != nil {
-			m.toast = fmt.Sprintf("Failed to save default: %v", msg.err)
-			m.toastIsErr = true
-		} else if msg.serverName == "" && msg.siteName == "" {
-			m.project.Server = ""
-			m.project.Site = ""
-			m.treePanel = m.treePanel.SetDefaultServer("").SetDefaultSite("")
-			m.toast = "Cleared default"
-			m.toastIsErr = false
-		} else {
-			m.project.Server = msg.serverName
-			m.project.Site = msg.siteName
-			m.treePanel = m.treePanel.SetDefaultServer(msg.serverName).SetDefaultSite(msg.siteName)
-			if msg.siteName != "" {
-				m.toast = fmt.Sprintf("Set %s/%s as default", msg.serverName, msg.siteName)
-			} else {
-				m.toast = fmt.Sprintf("Set %s as default server", msg.serverName)
-			}
-			m.toastIsErr = false
-		}
-		return m, m.clearToastAfter(3 * time.Second)
+		return m.handleSetDefault(msg)
 
 	case settingsEditMsg:
 		// Close settings modal so the input dialog can receive key events.
 		m.settingsModal = m.settingsModal.Close()
-		i := components.NewInputWide(msg.inputID, msg.label+":", msg.current)
-		m.inputDialog = &i
+		m.dialogs = m.dialogs.Prompt(components.NewInputWide(msg.inputID, msg.label+":", msg.current))
 		return m, nil
 
 	case settingsOpenEditorMsg:
@@ -845,24 +568,38 @@ func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		})
 
 	case settingsEditorDoneMsg:
-		if msg.err != nil {
-			m.toast = fmt.Sprintf("Editor error: %v", msg.err)
-			m.toastIsErr = true
-			return m, m.clearToastAfter(3 * time.Second)
-		}
-		// Reload config from disk.
-		newCfg, err := config.Load()
-		if err != nil {
-			m.toast = fmt.Sprintf("Config reload error: %v", err)
-			m.toastIsErr = true
-			return m, m.clearToastAfter(3 * time.Second)
+		return m.handleSettingsEditorDone(msg)
+
+	case tourStartMsg:
+		m.tour = m.tour.Start()
+		return m, nil
+
+	case tourDoneMsg:
+		if !m.config.UI.TourSeen {
+			m.config.UI.TourSeen = true
+			if err := m.config.Save(); err != nil {
+				m.toast = fmt.Sprintf("Save error: %v", err)
+				m.toastIsErr = true
+				return m, m.clearToastAfter(3 * time.Second)
+			}
 		}
-		m.config = newCfg
-		m.forge = forge.NewClient(newCfg.Forge.APIKey)
-		m.settingsModal = m.settingsModal.Open(m.config)
-		m.toast = "Config reloaded"
-		m.toastIsErr = false
-		return m, m.clearToastAfter(3 * time.Second)
+		return m, nil
+
+	case aboutHealthMsg:
+		m.aboutModal, _ = m.aboutModal.Update(msg)
+		return m, nil
+
+	case credentialsLoadedMsg:
+		m.credsModal, _ = m.credsModal.Update(msg)
+		return m, nil
+
+	case providersLoadedMsg, providerRegionsMsg:
+		m.providersModal, _ = m.providersModal.Update(msg)
+		return m, nil
+
+	case updateAvailableMsg:
+		m.updateVersion = msg.version
+		return m, nil
 
 	case errMsg:
 		m.loading = false
@@ -874,13 +611,96 @@ func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case toastMsg:
 		m.toast = msg.message
 		m.toastIsErr = msg.isError
-		return m, m.clearToastAfter(3 * time.Second)
+		if msg.sticky {
+			return m, nil
+		}
+		if msg.duration == 0 {
+			msg.duration = 3 * time.Second
+		}
+		return m, m.clearToastAfter(msg.duration)
+
+	case showOutputMsg:
+		m.outputPanel = m.outputPanel.SetContent(msg.title, msg.content)
+		m.nav = m.nav.Push(ScreenOutput)
+		return m, nil
+
+	case generatedPasswordMsg:
+		// Show a generated password once; it can't be fetched again.
+		m.pendingInputValue = msg.password
+		m.dialogs = m.dialogs.Confirm("copy-password", fmt.Sprintf(
+			"Generated password for %s:\n\n%s\n\nIt won't be shown again. Copy to clipboard?", msg.user, msg.password))
+		return m, nil
+
+	case deleteTickMsg:
+		return m.handleDeleteTick(msg)
 
 	case clearToastMsg:
+		if m.pendingDelete != nil {
+			// Keep the undo toast up until the delete is done or undone.
+			return m, nil
+		}
 		m.toast = ""
 		m.toastIsErr = false
 		return m, nil
 
+	case siteUpdatedMsg:
+		return m.handleSiteUpdated(msg)
+
+	case phpSettingMsg:
+		return m.handlePHPSetting(msg)
+
+	case quickDeployMsg:
+		return m.handleQuickDeploy(msg)
+
+	case triggerURLMsg:
+		return m.handleTriggerURL(msg)
+
+	case envCopyTargetsMsg:
+		return m.handleEnvCopyTargets(msg)
+
+	case envCopyEditedMsg:
+		return m.handleEnvCopyEdited(msg)
+
+	case envCopiedMsg:
+		return m.handleEnvCopied(msg)
+
+	case recipeRanMsg:
+		return m.handleRecipeRan(msg)
+
+	case recipeEditedMsg:
+		return m.handleRecipeEdited(msg)
+
+	case gitSiteMsg:
+		return m.handleGitSite(msg)
+
+	case gitPollMsg:
+		return m, m.pollGitInstall(msg)
+
+	case multiLogSitesMsg:
+		return m.handleMultiLogSites(msg)
+
+	case multiLogsMsg:
+		return m.handleMultiLogs(msg)
+
+	case alertTickMsg:
+		if msg.seq != m.alerts.seq {
+			return m, nil
+		}
+		return m, m.checkAlerts()
+
+	case alertsCheckedMsg:
+		return m.handleAlertsChecked(msg)
+
+	case bulkResultMsg:
+		if len(msg.failed) > 0 {
+			m.toast = fmt.Sprintf("Bulk %s: %d/%d failed — %s", msg.action, len(msg.failed), msg.total, strings.Join(msg.failed, "; "))
+			m.toastIsErr = true
+			return m, m.clearToastAfter(6 * time.Second)
+		}
+		m.toast = fmt.Sprintf("Bulk %s: done on %d server(s)", msg.action, msg.total)
+		m.toastIsErr = false
+		return m, m.clearToastAfter(3 * time.Second)
+
 	case rebootResultMsg:
 		if msg.err != nil {
 			m.toast = fmt.Sprintf("Reboot failed: %v", msg.err)
@@ -901,8 +721,8 @@ func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Refresh the deployments list after a successful deploy trigger.
 		cmds := []tea.Cmd{m.clearToastAfter(3 * time.Second)}
-		if msg.err == nil && m.activeTab == 1 {
-			cmds = append(cmds, m.deploymentsPanel.LoadDeployments())
+		if msg.err == nil && m.detail.activeTab == 1 {
+			cmds = append(cmds, m.detail.deploymentsPanel.LoadDeployments())
 		}
 		return m, tea.Batch(cmds...)
 
@@ -924,38 +744,387 @@ func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
-	return m, nil
+	return m, nil
+}
+
+// handleServersLoaded fills the tree with the server list, expands the
+// servers it should show open and selects the node under the cursor.
+func (m App) handleServersLoaded(msg serversLoadedMsg) (tea.Model, tea.Cmd) {
+	m.loading = false
+	m.treePanel = m.treePanel.SetServers(msg.servers).SetLoading(false)
+	m.names.SetServers(serverNames(msg.servers))
+
+	var cmds []tea.Cmd
+
+	// Restore servers expanded in the previous session (or before a refresh).
+	for _, id := range m.state.Expanded {
+		if m.treePanel.FindServerByID(id) == nil {
+			continue
+		}
+		var cmd tea.Cmd
+		m.treePanel, cmd = m.treePanel.ExpandServer(id)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	// Fetch sites referenced by the Recent group so it can be shown.
+	var recentCmd tea.Cmd
+	m.treePanel, recentCmd = m.treePanel.LoadRecentSites()
+	if recentCmd != nil {
+		cmds = append(cmds, recentCmd)
+	}
+
+	// Open the selected workspace's servers so its sites show.
+	if m.treePanel.Workspace() != "" {
+		var cmd tea.Cmd
+		m.treePanel, cmd = m.treePanel.ExpandAll()
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	// Grouping by application, and a "repo:" filter, need every
+	// server's sites.
+	if m.treePanel.GroupingByApp() || m.treePanel.RepoFilter() != "" {
+		var cmd tea.Cmd
+		m.treePanel, cmd = m.treePanel.LoadAllSites()
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+
+	if m.jumpTarget != "" && m.project.Server == "" {
+		// Bare site name: expand all servers to search for the site.
+		for _, srv := range msg.servers {
+			var cmd tea.Cmd
+			m.treePanel, cmd = m.treePanel.ExpandServer(srv.ID)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+		}
+	} else if defaultName := m.project.Server; defaultName != "" {
+		// Auto-expand default server from per-directory .phorge config or nickname.
+		if srv := m.treePanel.FindServerByName(defaultName); srv != nil {
+			var cmd tea.Cmd
+			m.treePanel, cmd = m.treePanel.ExpandServer(srv.ID)
+			if cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			var found bool
+			m.treePanel, found = m.treePanel.SetCursorToServer(srv.ID)
+			_ = found
+		}
+	}
+
+	// Select whatever the cursor is on.
+	srv, site := m.treePanel.Selected()
+	if srv.ID != 0 {
+		m.selectedSrv = &srv
+		m.detail.serverInfo = m.detail.serverInfo.SetServer(&srv)
+	} else {
+		m.selectedSrv = nil
+		m.detail.serverInfo = m.detail.serverInfo.SetServer(nil)
+	}
+	m.selectedSite = site
+	m.detail.siteInfo = m.detail.siteInfo.SetSite(site)
+
+	if m.config.UI.Reachability {
+		cmds = append(cmds, checkReachability(msg.servers))
+	}
+
+	// If jump target resolved to server-only (no site), fire launch action now.
+	if m.launchAction != LaunchNone && m.project.Server != "" && m.project.Site == "" && m.selectedSrv != nil {
+		action := m.launchAction
+		m.launchAction = LaunchNone
+		cmds = append(cmds, m.execLaunchAction(action))
+	}
+
+	if len(cmds) > 0 {
+		return m, tea.Batch(cmds...)
+	}
+	return m, nil
+}
+
+// handleTreeNodeSelected selects the server and site under the tree's
+// cursor.
+func (m App) handleTreeNodeSelected(msg panels.TreeNodeSelectedMsg) (tea.Model, tea.Cmd) {
+	srv := msg.Server
+	if srv.ID == 0 {
+		// An application heading or an empty tree: nothing to act on.
+		m.selectedSrv = nil
+		m.selectedSite = nil
+		m.detail.serverInfo = m.detail.serverInfo.SetServer(nil)
+		m.detail.siteInfo = m.detail.siteInfo.SetSite(nil)
+		return m, nil
+	}
+	m.selectedSrv = &srv
+	m.detail.serverInfo = m.detail.serverInfo.SetServer(&srv)
+	if msg.Site != nil {
+		site := *msg.Site
+		m.selectedSite = &site
+		m.detail.siteInfo = m.detail.siteInfo.SetSite(&site)
+	} else {
+		m.selectedSite = nil
+		m.detail.siteInfo = m.detail.siteInfo.SetSite(nil)
+	}
+	return m, nil
+}
+
+// handleTreeSitesLoaded shows a server's sites in the tree and selects a
+// default or jumped-to site among them.
+func (m App) handleTreeSitesLoaded(msg treeSitesLoadedMsg) (tea.Model, tea.Cmd) {
+	m.treePanel = m.treePanel.SetSites(msg.serverID, msg.sites)
+	if srv := m.treePanel.FindServerByID(msg.serverID); srv != nil {
+		m.names.SetSites(srv.Name, siteNames(msg.sites))
+	}
+	statusCmd := m.fetchDeployStatuses(msg.serverID, msg.sites)
+
+	// Complete a pending environment switch for this server.
+	if j := m.pendingJump; j != nil && j.serverID == msg.serverID {
+		m.pendingJump = nil
+		m = m.selectSiteOnServer(j.serverID, j.siteName)
+		return m, statusCmd
+	}
+
+	// If a default site is configured, navigate to it when its server's
+	// sites are first loaded.
+	siteFound := false
+	if defaultSite := m.project.Site; defaultSite != "" {
+		if defaultServer := m.project.Server; defaultServer != "" {
+			// Known server: only match within that server.
+			if srv := m.treePanel.FindServerByName(defaultServer); srv != nil && srv.ID == msg.serverID {
+				_, site := m.treePanel.FindSiteByName(defaultSite)
+				if site != nil {
+					m.treePanel, _ = m.treePanel.SetCursorToSite(site.ID)
+					m.selectedSite = site
+					m.detail.siteInfo = m.detail.siteInfo.SetSite(site)
+					siteFound = true
+				}
+			}
+		} else {
+			// Bare site name search: check this batch of sites.
+			for i := range msg.sites {
+				if strings.EqualFold(msg.sites[i].Name, defaultSite) {
+					m.treePanel, _ = m.treePanel.SetCursorToSite(msg.sites[i].ID)
+					m.selectedSite = &msg.sites[i]
+					m.detail.siteInfo = m.detail.siteInfo.SetSite(&msg.sites[i])
+					// Also select the parent server.
+					if srv := m.treePanel.FindServerByID(msg.serverID); srv != nil {
+						m.selectedSrv = srv
+						m.detail.serverInfo = m.detail.serverInfo.SetServer(srv)
+					}
+					siteFound = true
+					break
+				}
+			}
+		}
+	}
+
+	// If the jump target resolved and there's a pending launch action, fire it.
+	if siteFound && m.launchAction != LaunchNone {
+		action := m.launchAction
+		m.launchAction = LaunchNone // consume it
+		return m, tea.Batch(statusCmd, m.execLaunchAction(action))
+	}
+	return m, statusCmd
+}
+
+// handleDeployTrigger refreshes the deployments list once a deployment has
+// been started from it, and watches the deployment to finish.
+func (m App) handleDeployTrigger(msg panels.DeployTriggerMsg) (tea.Model, tea.Cmd) {
+	m.toast = "Deployment started"
+	m.toastIsErr = false
+	cmds := []tea.Cmd{m.clearToastAfter(3 * time.Second)}
+	if m.detail.activeTab == 1 {
+		cmds = append(cmds, m.detail.deploymentsPanel.LoadDeployments())
+	}
+	// Watch the deployment to save its output once it is done, and
+	// to restart daemons, probe the health check and tell hooks and
+	// socket clients.
+	if m.selectedSite != nil && m.selectedSite.ID == msg.SiteID {
+		healthURL := m.healthURLFor(m.selectedSite.Name)
+		cmds = append(cmds, watchDeployTick(deployWatch{
+			serverID:   msg.ServerID,
+			siteID:     msg.SiteID,
+			prevID:     msg.PrevID,
+			siteName:   m.selectedSite.Name,
+			daemons:    m.restartDaemonsFor(m.selectedSite.Name),
+			healthURL:  healthURL,
+			rollback:   healthURL != "" && m.autoRollbackFor(m.selectedSite.Name),
+			triggerURL: m.selectedSite.DeploymentURL,
+		}))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleSetDefault reports a change to the .phorge default and marks the
+// new default in the tree.
+func (m App) handleSetDefault(msg setDefaultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Failed to save default: %v", msg.err)
+		m.toastIsErr = true
+	} else if msg.serverName == "" && msg.siteName == "" {
+		m.project.Server = ""
+		m.project.Site = ""
+		m.treePanel = m.treePanel.SetDefaultServer("").SetDefaultSite("")
+		m.toast = "Cleared default"
+		m.toastIsErr = false
+	} else {
+		m.project.Server = msg.serverName
+		m.project.Site = msg.siteName
+		m.treePanel = m.treePanel.SetDefaultServer(msg.serverName).SetDefaultSite(msg.siteName)
+		if msg.siteName != "" {
+			m.toast = fmt.Sprintf("Set %s/%s as default", msg.serverName, msg.siteName)
+		} else {
+			m.toast = fmt.Sprintf("Set %s as default server", msg.serverName)
+		}
+		m.toastIsErr = false
+	}
+	return m, m.clearToastAfter(3 * time.Second)
+}
+
+// handleSiteUpdated shows a site's new details once an update has been
+// saved.
+func (m App) handleSiteUpdated(msg siteUpdatedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Site update failed: %v", msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	m.treePanel = m.treePanel.UpdateSite(*msg.site)
+	if m.selectedSite != nil && m.selectedSite.ID == msg.site.ID {
+		m.selectedSite = msg.site
+		m.detail.siteInfo = m.detail.siteInfo.SetSite(msg.site)
+	}
+	m.toast = fmt.Sprintf("Updated %s", msg.site.Name)
+	m.toastIsErr = false
+	cmds := []tea.Cmd{m.clearToastAfter(3 * time.Second)}
+
+	// Keep a .phorge default pointing at the renamed site.
+	if msg.site.Name != msg.oldName && strings.EqualFold(m.project.Site, msg.oldName) {
+		if srv := m.treePanel.FindServerByID(msg.serverID); srv != nil {
+			cmds = append(cmds, m.toggleDefault(srv.Name, msg.site.Name))
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleSettingsEditorDone reloads the config after it was edited in the
+// external editor and reopens the settings modal.
+func (m App) handleSettingsEditorDone(msg settingsEditorDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Editor error: %v", msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	// Reload config from disk.
+	newCfg, err := config.Load()
+	if err == nil {
+		err = newCfg.ResolveAPIKey()
+	}
+	if err != nil {
+		m.toast = fmt.Sprintf("Config reload error: %v", err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	m.config = newCfg
+	m.forge = newForgeClient(newCfg, m.authExpired)
+	m.treePanel = m.treePanel.SetHidden(hiddenServers(newCfg)).SetWorkspace(workspaceScope(newCfg, m.treePanel.Workspace()))
+	m.reauthDismissed = false
+	m.detail = m.detail.ForgetPanels().SetDisabledTabs(newCfg.UI.DisabledTabs).SetReducedMotion(newCfg.UI.ReducedMotion)
+	m.settingsModal = m.settingsModal.Open(m.config)
+	var alertsCmd tea.Cmd
+	m, alertsCmd = m.reloadAlerts()
+	if cmd := configWarningsToast(newCfg); cmd != nil {
+		return m, tea.Batch(cmd, alertsCmd)
+	}
+	m.toast = "Config reloaded"
+	m.toastIsErr = false
+	return m, tea.Batch(m.clearToastAfter(3*time.Second), alertsCmd)
 }
 
 // handleKey processes key events, routing to global keys first, then focus-specific keys.
 func (m App) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	// When the tree panel's filter is active, route all key events to it.
-	if m.focus == FocusTree && m.treePanel.FilterActive() {
+	if m.nav.Focus() == FocusTree && m.treePanel.FilterActive() {
 		var cmd tea.Cmd
 		panel, cmd := m.treePanel.Update(msg)
 		m.treePanel = panel.(panels.TreePanel)
 		return m, cmd
 	}
 
+	// Likewise for the grep input of the Logs tab.
+	if m.nav.Focus() == FocusDetail && m.logsShowing() && m.detail.logsPanel.FilterActive() {
+		return m.updateTab(msg)
+	}
+
+	// z undoes a delete still in its grace period.
+	if m.pendingDelete != nil && key.Matches(msg, key.NewBinding(key.WithKeys("z"))) {
+		return m.undoDelete()
+	}
+
+	// ctrl+x cancels a deploy queued behind a running one.
+	if m.queuedDeploy != nil && key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+x"))) {
+		return m.cancelQueuedDeploy()
+	}
+
+	m.recordUsage(msg)
+
 	// Global keys take priority.
 	switch {
 	case key.Matches(msg, m.globalKeys.Quit):
-		return m, tea.Quit
+		if n := len(m.scheduler.Pending()); n > 0 {
+			// Scheduled actions only live as long as the session.
+			m.dialogs = m.dialogs.Confirm("quit-scheduled",
+				fmt.Sprintf("Quitting drops %d scheduled action(s). Quit anyway?", n))
+			return m, nil
+		}
+		return m.quit()
 	case key.Matches(msg, m.globalKeys.Help):
-		m.helpModal = m.helpModal.Toggle()
+		m.helpModal = m.helpModal.Toggle().SetMostUsed(m.state.MostUsed(mostUsedCount))
 		return m, nil
 	case key.Matches(msg, m.globalKeys.Settings):
 		m.settingsModal = m.settingsModal.Open(m.config)
 		return m, nil
+	case key.Matches(msg, m.globalKeys.Env):
+		return m.switchEnvironment()
+	case key.Matches(msg, m.globalKeys.Alerts):
+		m.alertsModal = m.alertsModal.SetAlerts(m.alerts).Open()
+		return m, nil
+	case key.Matches(msg, m.globalKeys.Scheduled):
+		m.scheduledModal = m.scheduledModal.Open()
+		return m, nil
+	case key.Matches(msg, m.globalKeys.Providers):
+		var cmd tea.Cmd
+		m.providersModal, cmd = m.providersModal.Open(m.forge)
+		return m, cmd
+	case key.Matches(msg, m.globalKeys.About):
+		var cmd tea.Cmd
+		m.aboutModal, cmd = m.aboutModal.Open(m.forge)
+		return m, cmd
 	case key.Matches(msg, m.globalKeys.Tab):
-		m.focus = (m.focus + 1) % panelCount
+		m.nav = m.nav.FocusPanel((m.nav.Focus() + 1) % panelCount)
 		return m, nil
 	case key.Matches(msg, m.globalKeys.ShiftTab):
-		m.focus = (m.focus + panelCount - 1) % panelCount
+		m.nav = m.nav.FocusPanel((m.nav.Focus() + panelCount - 1) % panelCount)
 		return m, nil
 	case key.Matches(msg, m.globalKeys.Refresh):
+		// Keep the current expansion across the reload.
+		m.state.Expanded = m.treePanel.ExpandedServers()
 		m.loading = true
 		m.treePanel = m.treePanel.SetLoading(true)
+		// Tab panels are reused between visits; a refresh reloads the one
+		// on screen and makes the others reload when next shown.
+		m.detail = m.detail.ForgetPanels()
+		if m.nav.Focus() == FocusDetail && m.selectedSrv != nil {
+			siteID := int64(0)
+			if m.selectedSite != nil {
+				siteID = m.selectedSite.ID
+			}
+			model, tabCmd := m.initTabPanel(m.detail.activeTab, m.selectedSrv.ID, siteID)
+			return model, tea.Batch(m.fetchServers(), tabCmd)
+		}
 		return m, m.fetchServers()
 	case key.Matches(msg, m.globalKeys.SSH):
 		cmd := m.sshCmd()
@@ -983,10 +1152,18 @@ func (m App) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		m.toast = "Fetching database credentials..."
 		m.toastIsErr = false
 		return m, cmd
+	case key.Matches(msg, m.globalKeys.Actions):
+		return m.openActionPalette()
+	}
+
+	// User-defined actions come after the global keys but before the
+	// panels' own.
+	if a, ok := m.matchAction(msg); ok {
+		return m.runAction(a)
 	}
 
 	// Panel-specific keys.
-	switch m.focus {
+	switch m.nav.Focus() {
 	case FocusTree:
 		return m.handleTreeKey(msg)
 	case FocusDetail:
@@ -1006,7 +1183,8 @@ func (m App) handleTreeKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	if onServer && m.selectedSrv != nil {
 		switch {
 		case key.Matches(msg, m.serverActKeys.Reboot):
-			return m, m.rebootServer(m.selectedSrv.ID)
+			m.dialogs = m.dialogs.Confirm("reboot-server", fmt.Sprintf("Reboot %s?\nIts sites are down until it is back up.", m.selectedSrv.Name))
+			return m, nil
 		case key.Matches(msg, m.serverActKeys.SSH):
 			cmd := m.sshCmd()
 			if cmd != nil {
@@ -1025,6 +1203,9 @@ func (m App) handleTreeKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("n"))):
 			// Set/remove nickname for server.
 			return m.promptNickname(m.selectedSrv.Name, "")
+		case key.Matches(msg, key.NewBinding(key.WithKeys("H"))):
+			// Add the server to or remove it from the ignore list.
+			return m.toggleHidden(*m.selectedSrv)
 		}
 	}
 
@@ -1043,6 +1224,13 @@ func (m App) handleTreeKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 				return m, cmd
 			}
 			return m, nil
+		case key.Matches(msg, m.siteActKeys.Wildcard):
+			question := fmt.Sprintf("Disable wildcard subdomains for %s?", m.selectedSite.Name)
+			if !m.selectedSite.Wildcards {
+				question = fmt.Sprintf("Enable wildcard subdomains for %s?\nExisting certificates won't cover *.%s;\nHTTPS needs a wildcard certificate (DNS challenge).", m.selectedSite.Name, m.selectedSite.Name)
+			}
+			m.dialogs = m.dialogs.Confirm("toggle-wildcards", question)
+			return m, nil
 		case key.Matches(msg, key.NewBinding(key.WithKeys("D"))):
 			// Toggle default site for this directory (.phorge file).
 			return m, m.toggleDefault(m.selectedSrv.Name, m.selectedSite.Name)
@@ -1052,13 +1240,43 @@ func (m App) handleTreeKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	// w narrows the tree to a workspace, or back out of one.
+	if key.Matches(msg, key.NewBinding(key.WithKeys("w"))) {
+		return m.pickWorkspace()
+	}
+
+	// B runs a bulk action on every server in the selected workspace, or
+	// with a tag, using the tree's "tag:<name>" filter when one is active.
+	if key.Matches(msg, key.NewBinding(key.WithKeys("B"))) {
+		if ws := m.treePanel.Workspace(); ws != "" {
+			return m.startBulk("workspace:" + ws)
+		}
+		if tag := m.treePanel.TagFilter(); tag != "" {
+			return m.startBulk(tag)
+		}
+		options := tagOptions(m.treePanel.Servers())
+		if len(options) == 0 {
+			m.toast = "No servers have tags"
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		m.dialogs = m.dialogs.Pick(components.NewPicker("bulk-tag", "Servers with tag:", options))
+		return m, nil
+	}
+
+	// M interleaves the logs of several sites by timestamp.
+	if key.Matches(msg, key.NewBinding(key.WithKeys("M"))) {
+		return m.startMultiLog()
+	}
+
 	// Enter focuses the detail panel for both server and site nodes.
 	if key.Matches(msg, m.navKeys.Enter) {
 		srv, site := m.treePanel.Selected()
 		if site != nil {
-			m.focus = FocusDetail
+			m.nav = m.nav.Push(ScreenDetail)
 			if m.selectedSrv != nil {
-				return m.initTabPanel(m.activeTab, m.selectedSrv.ID, site.ID)
+				m = m.visitSite(m.selectedSrv.ID, site.ID)
+				return m.initTabPanel(m.detail.activeTab, m.selectedSrv.ID, site.ID)
 			}
 			return m, nil
 		}
@@ -1071,7 +1289,7 @@ func (m App) handleTreeKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 				return m, cmd
 			}
 			// Already expanded: focus the detail panel.
-			m.focus = FocusDetail
+			m.nav = m.nav.Push(ScreenDetail)
 			return m, nil
 		}
 	}
@@ -1080,9 +1298,10 @@ func (m App) handleTreeKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	if key.Matches(msg, key.NewBinding(key.WithKeys("l", "right"))) {
 		_, site := m.treePanel.Selected()
 		if site != nil {
-			m.focus = FocusDetail
+			m.nav = m.nav.Push(ScreenDetail)
 			if m.selectedSrv != nil {
-				return m.initTabPanel(m.activeTab, m.selectedSrv.ID, site.ID)
+				m = m.visitSite(m.selectedSrv.ID, site.ID)
+				return m.initTabPanel(m.detail.activeTab, m.selectedSrv.ID, site.ID)
 			}
 			return m, nil
 		}
@@ -1092,8 +1311,16 @@ func (m App) handleTreeKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	// Server-level tab switching from tree (so the detail panel updates).
 	if onServer && m.selectedSrv != nil {
 		switch {
+		case key.Matches(msg, m.sectionKeys.Info):
+			return m.switchToServerTab(0)
+		case key.Matches(msg, m.sectionKeys.Environment):
+			return m.switchToServerTab(2)
 		case key.Matches(msg, m.sectionKeys.Databases):
 			return m.switchToServerTab(3)
+		case key.Matches(msg, m.sectionKeys.SSL):
+			return m.switchToServerTab(4)
+		case key.Matches(msg, m.sectionKeys.Workers):
+			return m.switchToServerTab(5)
 		case key.Matches(msg, m.sectionKeys.Daemons):
 			return m.switchToServerTab(6)
 		case key.Matches(msg, m.sectionKeys.Firewall):
@@ -1102,6 +1329,10 @@ func (m App) handleTreeKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			return m.switchToServerTab(8)
 		case key.Matches(msg, m.sectionKeys.Domains):
 			return m.switchToServerTab(9)
+		case key.Matches(msg, m.sectionKeys.Backups):
+			return m.switchToServerTab(backupsTab)
+		case key.Matches(msg, m.sectionKeys.Recipes):
+			return m.switchToServerTab(recipesTab)
 		}
 	}
 
@@ -1112,157 +1343,19 @@ func (m App) handleTreeKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-// handleDetailKey processes keys when the detail panel is focused.
-func (m App) handleDetailKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	// If the deploy script sub-view is active, route keys to it.
-	if m.activeTab == 1 && m.selectedSite != nil && m.showDeployScript {
-		if key.Matches(msg, m.navKeys.Back) {
-			m.showDeployScript = false
-			return m, nil
-		}
-		p, cmd := m.deployScriptPanel.Update(msg)
-		m.deployScriptPanel = p.(panels.DeployScriptPanel)
-		return m, cmd
-	}
-
-	// If the DB users sub-view is active, route keys to it.
-	if m.activeTab == 3 && m.showDBUsers {
-		if key.Matches(msg, m.navKeys.Back) {
-			m.showDBUsers = false
-			return m, nil
-		}
-		return m.handleDBUsersKey(msg)
-	}
-
-	// If the commands panel is showing detail and user presses Esc,
-	// go back to the commands list (not up to tree panel).
-	if m.activeTab == 6 && m.selectedSite != nil && m.commandsPanel.ShowingDetail() {
-		if key.Matches(msg, m.navKeys.Back) {
-			p, cmd := m.commandsPanel.Update(msg)
-			m.commandsPanel = p.(panels.CommandsPanel)
-			return m, cmd
-		}
-	}
-
-	switch {
-	case key.Matches(msg, m.navKeys.Back):
-		// In server-only context with a server tab active, go back to Info first.
-		if m.selectedSite == nil && m.selectedSrv != nil && serverTabNums[m.activeTab] {
-			m.activeTab = 1 // Reset to default (shows Info in server context).
-			return m, nil
-		}
-		m.focus = FocusTree
-		return m, nil
-
-	// Section tab switching (1-9).
-	case key.Matches(msg, m.sectionKeys.Deployments):
-		return m.switchToTab(1)
-	case key.Matches(msg, m.sectionKeys.Environment):
-		return m.switchToTab(2)
-	case key.Matches(msg, m.sectionKeys.Databases):
-		return m.switchToTab(3)
-	case key.Matches(msg, m.sectionKeys.SSL):
-		return m.switchToTab(4)
-	case key.Matches(msg, m.sectionKeys.Workers):
-		return m.switchToTab(5)
-	case key.Matches(msg, m.sectionKeys.Daemons):
-		return m.switchToTab(6)
-	case key.Matches(msg, m.sectionKeys.Firewall):
-		return m.switchToTab(7)
-	case key.Matches(msg, m.sectionKeys.Jobs):
-		return m.switchToTab(8)
-	case key.Matches(msg, m.sectionKeys.Domains):
-		return m.switchToTab(9)
-	}
-
-	// Tab 1: Deploy (site) or Events (server).
-	if m.activeTab == 1 {
-		if m.selectedSite != nil {
-			return m.handleDeploymentsKey(msg)
-		}
-		if m.selectedSrv != nil {
-			return m.handleEventsKey(msg)
-		}
-	}
-
-	// If the environment panel is active, delegate keys.
-	if m.activeTab == 2 && m.selectedSite != nil {
-		return m.handleEnvironmentKey(msg)
-	}
-
-	// Databases (tab 3) - server-level.
-	if m.activeTab == 3 && m.selectedSrv != nil {
-		return m.handleDatabasesKey(msg)
-	}
-
-	// SSL (tab 4) - site-level.
-	if m.activeTab == 4 && m.selectedSite != nil {
-		return m.handleSSLKey(msg)
-	}
-
-	// Workers (tab 5) - site-level.
-	if m.activeTab == 5 && m.selectedSite != nil {
-		return m.handleWorkersKey(msg)
-	}
-
-	// Tab 6: Commands (site) or Daemons (server).
-	if m.activeTab == 6 {
-		if m.selectedSite != nil {
-			return m.handleCommandsKey(msg)
-		}
-		if m.selectedSrv != nil {
-			return m.handleDaemonsKey(msg)
-		}
-	}
-
-	// Tab 7: Logs (site) or Firewall (server).
-	if m.activeTab == 7 {
-		if m.selectedSite != nil {
-			p, cmd := m.logsPanel.Update(msg)
-			m.logsPanel = p.(panels.LogsPanel)
-			return m, cmd
-		}
-		if m.selectedSrv != nil {
-			return m.handleFirewallKey(msg)
-		}
-	}
-
-	// Tab 8: Git (site, read-only) or Jobs (server, read-only).
-	if m.activeTab == 8 {
-		if m.selectedSite != nil {
-			// Git panel is read-only, no key handling needed.
-			return m, nil
-		}
-		if m.selectedSrv != nil {
-			p, cmd := m.jobsPanel.Update(msg)
-			m.jobsPanel = p.(panels.JobsPanel)
-			return m, cmd
-		}
-	}
-
-	// Tab 9: Domains (site) or SSH Keys (server).
-	if m.activeTab == 9 {
-		if m.selectedSite != nil {
-			return m.handleDomainsKey(msg)
-		}
-		if m.selectedSrv != nil {
-			return m.handleSSHKeysKey(msg)
-		}
-	}
-
-	return m, nil
-}
-
 // handleOutputKey processes keys when the output panel is focused.
 func (m App) handleOutputKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, m.navKeys.Back):
-		m.focus = FocusDetail
+		m.nav = m.nav.Pop()
 		m.outputPoll.active = false // Stop polling when leaving output.
+		m.outputPanel = m.outputPanel.EndStream()
 		return m, nil
 	}
 
 	// Delegate scrolling keys to the output panel.
+	_, _, outputHeight := m.panelHeights()
+	m.outputPanel = m.outputPanel.SetHeight(outputHeight)
 	p, cmd := m.outputPanel.Update(msg)
 	m.outputPanel = p.(panels.OutputPanel)
 	return m, cmd
@@ -1270,9 +1363,12 @@ func (m App) handleOutputKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 
 // switchToServerTab changes to a server-level tab without changing focus.
 func (m App) switchToServerTab(tab int) (tea.Model, tea.Cmd) {
-	m.activeTab = tab
-	m.showDeployScript = false
-	m.showDBUsers = false
+	if !m.detail.TabEnabled(tab, false) {
+		return m.tabDisabled(tab, false)
+	}
+	m.detail.activeTab = tab
+	m.state.RecordUse(m.detail.tabName(false)+" tab", strconv.Itoa(tab))
+	m.nav = m.nav.PopTo(ScreenDetail)
 	if m.selectedSrv == nil {
 		return m, nil
 	}
@@ -1281,9 +1377,12 @@ func (m App) switchToServerTab(tab int) (tea.Model, tea.Cmd) {
 
 // switchToTab changes the active detail tab and initialises the panel if needed.
 func (m App) switchToTab(tab int) (tea.Model, tea.Cmd) {
-	m.activeTab = tab
-	m.showDeployScript = false // always reset sub-view when switching tabs
-	m.showDBUsers = false      // always reset sub-view when switching tabs
+	if !m.detail.TabEnabled(tab, m.selectedSite != nil) {
+		return m.tabDisabled(tab, m.selectedSite != nil)
+	}
+	m.detail.activeTab = tab
+	m.state.RecordUse(m.detail.tabName(m.selectedSite != nil)+" tab", strconv.Itoa(tab))
+	m.nav = m.nav.PopTo(ScreenDetail) // always leave sub-views when switching tabs
 
 	if m.selectedSrv == nil {
 		return m, nil
@@ -1299,381 +1398,256 @@ func (m App) switchToTab(tab int) (tea.Model, tea.Cmd) {
 	return m.initTabPanel(tab, m.selectedSrv.ID, siteID)
 }
 
-// initTabPanel creates and loads the panel for the given tab.
-// Tabs 1-5 are always the same: Deploy, Env, DB, SSL, Workers.
-// Tabs 6-9 are context-sensitive:
-//   - With a site selected: Commands, Logs, Git, Domains
-//   - Without a site (server-only): Daemons, Firewall, Jobs, SSH Keys
+// tabDisabled reports that a tab turned off in ui.disabled_tabs was asked
+// for.
+func (m App) tabDisabled(tab int, site bool) (tea.Model, tea.Cmd) {
+	tabs := serverTabs
+	if site {
+		tabs = siteTabs
+	}
+	for _, t := range tabs {
+		if t.num == tab {
+			m.toast = fmt.Sprintf("The %s tab is disabled (ui.disabled_tabs)", t.name)
+		}
+	}
+	m.toastIsErr = true
+	return m, m.clearToastAfter(3 * time.Second)
+}
+
+// initTabPanel shows the panel for the given tab, reusing the instance
+// from the last visit to this tab for the same server and site, or
+// creating and loading one. ctrl+r drops the reused instances.
 func (m App) initTabPanel(tab int, serverID, siteID int64) (tea.Model, tea.Cmd) {
+	if !m.detail.TabEnabled(tab, siteID != 0) {
+		// Carried over from the other context, where the number is an
+		// enabled tab: show the info view instead.
+		m.detail.activeTab = 0
+		return m, nil
+	}
+	key := newPanelKey(tab, serverID, siteID)
+	var reused bool
+	m.detail, reused = m.detail.ReusePanel(key)
+	// A panel swapped out before its load finished may have missed the
+	// result, so it loads afresh.
+	if reused && !m.detail.stillLoading(key) {
+		if tab == 1 && siteID != 0 {
+			m.nav = m.nav.PopTo(ScreenDetail)
+		}
+		return m, nil
+	}
+	model, cmd := m.loadTabPanel(tab, serverID, siteID)
+	m = model.(App)
+	m, spinCmd := m.startTabSpinner()
+	return m, tea.Batch(tagLoad(key, cmd), spinCmd, m.firePanelLoaded(tab, serverID, siteID))
+}
+
+// startTabSpinner starts the spinner next to the active tab's label, which
+// turns while its freshly created panel loads. With ui.reduced_motion the
+// mark stays still and there is nothing to tick.
+func (m App) startTabSpinner() (App, tea.Cmd) {
+	m.detail.loadFailed = false
+	if m.detail.spinning || m.config.UI.ReducedMotion {
+		return m, nil
+	}
+	m.detail.spinning = true
+	return m, tabSpinnerTick()
+}
+
+// tabSpinnerTick returns a command that sends a tabSpinnerTickMsg after
+// 100ms.
+func tabSpinnerTick() tea.Cmd {
+	return tea.Tick(100*time.Millisecond, func(time.Time) tea.Msg {
+		return tabSpinnerTickMsg{}
+	})
+}
+
+// loadTabPanel creates and loads the panel for the given tab.
+// Tabs 1-4 are Deploy (Events without a site), Env (Nginx templates
+// without a site), DB and SSL.
+// Tabs 5-9 are context-sensitive:
+//   - With a site selected: Workers, Commands, Logs, Git, Domains
+//   - Without a site (server-only): Circles, Daemons, Firewall, Jobs, SSH Keys
+func (m App) loadTabPanel(tab int, serverID, siteID int64) (tea.Model, tea.Cmd) {
 	switch tab {
 	case 1:
 		if siteID == 0 {
 			// Server context: Events.
-			m.eventsPanel = panels.NewEventsPanel(m.forge, serverID)
-			return m, m.eventsPanel.LoadEvents()
+			m.detail.eventsPanel = panels.NewEventsPanel(m.forge, serverID)
+			return m, m.detail.eventsPanel.LoadEvents()
 		}
-		m.showDeployScript = false
-		m.deploymentsPanel = panels.NewDeploymentsPanel(m.forge, serverID, siteID)
-		return m, m.deploymentsPanel.LoadDeployments()
+		m.nav = m.nav.PopTo(ScreenDetail)
+		m.detail.deploymentsPanel = panels.NewDeploymentsPanel(m.forge, serverID, siteID).SetAuthor(m.config.UI.Author)
+		if m.selectedSite != nil {
+			m.detail.deploymentsPanel = m.detail.deploymentsPanel.SetSite(m.selectedSite)
+		}
+		return m, m.detail.deploymentsPanel.LoadDeployments()
 	case 2:
 		if siteID == 0 {
-			return m, nil
+			// Server context: nginx templates.
+			m.detail.nginxPanel = panels.NewNginxTemplatesPanel(m.forge, serverID, m.config.Editor.Command)
+			return m, m.detail.nginxPanel.LoadTemplates()
 		}
-		m.environmentPanel = panels.NewEnvironmentPanel(
+		m.detail.environmentPanel = panels.NewEnvironmentPanel(
 			m.forge, serverID, siteID, m.config.Editor.Command,
 		)
-		return m, m.environmentPanel.LoadEnv()
+		return m, m.detail.environmentPanel.LoadEnv()
 	case 3:
 		// Databases are server-level.
-		m.showDBUsers = false
-		m.databasesPanel = panels.NewDatabasesPanel(m.forge, serverID)
-		return m, m.databasesPanel.LoadDatabases()
+		m.nav = m.nav.PopTo(ScreenDetail)
+		m.detail.databasesPanel = panels.NewDatabasesPanel(m.forge, serverID)
+		return m, m.detail.databasesPanel.LoadDatabases()
 	case 4:
 		if siteID == 0 {
-			return m, nil
+			// Server context: certificates across all sites.
+			m.detail.sslOverviewPanel = panels.NewSSLOverviewPanel(m.forge, serverID)
+			return m, m.detail.sslOverviewPanel.LoadOverview()
 		}
-		m.sslPanel = panels.NewSSLPanel(m.forge, serverID, siteID)
-		return m, m.sslPanel.LoadCerts()
+		m.detail.sslPanel = panels.NewSSLPanel(m.forge, serverID, siteID)
+		return m, m.detail.sslPanel.LoadCerts()
 	case 5:
 		if siteID == 0 {
-			return m, nil
+			// Server context: Circles.
+			m.detail.circlesPanel = panels.NewCirclesPanel(m.forge, serverID)
+			return m, m.detail.circlesPanel.LoadCircles()
 		}
-		m.workersPanel = panels.NewWorkersPanel(m.forge, serverID, siteID)
-		return m, m.workersPanel.LoadWorkers()
+		m.detail.workersPanel = panels.NewWorkersPanel(m.forge, serverID, siteID)
+		return m, m.detail.workersPanel.LoadWorkers()
 	case 6:
 		if siteID > 0 {
 			// Site context: Commands.
-			m.commandsPanel = panels.NewCommandsPanel(m.forge, serverID, siteID)
-			return m, m.commandsPanel.LoadCommands()
+			m.detail.commandsPanel = panels.NewCommandsPanel(m.forge, serverID, siteID)
+			return m, m.detail.commandsPanel.LoadCommands()
 		}
 		// Server context: Daemons.
-		m.daemonsPanel = panels.NewDaemonsPanel(m.forge, serverID)
-		return m, m.daemonsPanel.LoadDaemons()
+		m.detail.daemonsPanel = panels.NewDaemonsPanel(m.forge, serverID)
+		return m, m.detail.daemonsPanel.LoadDaemons()
 	case 7:
 		if siteID > 0 {
 			// Site context: Logs (site-level).
-			m.logsPanel = panels.NewLogsPanel(m.forge, serverID, siteID, m.config.Editor.Command)
-			return m, m.logsPanel.LoadLogs()
+			m.detail.logsPanel = panels.NewLogsPanel(m.forge, serverID, siteID, m.config.Editor.Command)
+			return m, m.detail.logsPanel.LoadLogs()
 		}
 		// Server context: Firewall.
-		m.firewallPanel = panels.NewFirewallPanel(m.forge, serverID)
-		return m, m.firewallPanel.LoadRules()
+		m.detail.firewallPanel = panels.NewFirewallPanel(m.forge, serverID)
+		return m, m.detail.firewallPanel.LoadRules()
 	case 8:
 		if siteID > 0 {
-			// Site context: Git info (read-only).
-			m.gitPanel = panels.NewGitPanel(m.selectedSite)
+			// Site context: Git info, from the selected site.
+			m.detail.gitPanel = panels.NewGitPanel(m.selectedSite)
 			return m, nil
 		}
 		// Server context: Scheduled jobs.
-		m.jobsPanel = panels.NewJobsPanel(m.forge, serverID)
-		return m, m.jobsPanel.LoadJobs()
+		m.detail.jobsPanel = panels.NewJobsPanel(m.forge, serverID)
+		return m, m.detail.jobsPanel.LoadJobs()
 	case 9:
 		if siteID > 0 {
 			// Site context: Domains.
-			aliases := []string{}
+			primary, aliases := "", []string{}
 			if m.selectedSite != nil {
-				aliases = m.selectedSite.Aliases
+				primary, aliases = m.selectedSite.Name, m.selectedSite.Aliases
 			}
-			m.domainsPanel = panels.NewDomainsPanel(m.forge, serverID, siteID, aliases)
-			return m, nil
+			m.detail.domainsPanel = panels.NewDomainsPanel(m.forge, serverID, siteID, primary, aliases)
+			return m, m.detail.domainsPanel.LoadCert()
 		}
 		// Server context: SSH Keys.
-		m.sshKeysPanel = panels.NewSSHKeysPanel(m.forge, serverID)
-		return m, m.sshKeysPanel.LoadKeys()
-	}
-	return m, nil
-}
-
-// handleDeploymentsKey handles keys specific to the deployments panel tab.
-func (m App) handleDeploymentsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	// Check for action keys before delegating to the panel.
-	switch {
-	case key.Matches(msg, m.siteActKeys.Deploy):
-		c := components.NewConfirm("deploy", "Deploy site now?")
-		m.confirm = &c
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
-		c := components.NewConfirm("reset-deploy", "Reset deployment status?")
-		m.confirm = &c
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("S"))):
-		// Open the deploy script sub-view.
-		if m.selectedSrv != nil && m.selectedSite != nil {
-			m.showDeployScript = true
-			m.deployScriptPanel = panels.NewDeployScriptPanel(
-				m.forge, m.selectedSrv.ID, m.selectedSite.ID, m.config.Editor.Command,
-			)
-			return m, m.deployScriptPanel.LoadScript()
-		}
-		return m, nil
-	}
-
-	// Delegate navigation and other keys to the deployments panel.
-	p, cmd := m.deploymentsPanel.Update(msg)
-	m.deploymentsPanel = p.(panels.DeploymentsPanel)
-	return m, cmd
-}
-
-// handleEventsKey handles keys specific to the events panel tab.
-func (m App) handleEventsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	p, cmd := m.eventsPanel.Update(msg)
-	m.eventsPanel = p.(panels.EventsPanel)
-	return m, cmd
-}
-
-// handleEnvironmentKey handles keys specific to the environment panel tab.
-func (m App) handleEnvironmentKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	// Delegate all keys to the environment panel.
-	p, cmd := m.environmentPanel.Update(msg)
-	m.environmentPanel = p.(panels.EnvironmentPanel)
-	return m, cmd
-}
-
-// handleDatabasesKey handles keys specific to the databases panel tab.
-func (m App) handleDatabasesKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
-		i := components.NewInput("create-db", "Database name:", "my_database")
-		m.inputDialog = &i
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
-		if db := m.databasesPanel.SelectedDatabase(); db != nil {
-			c := components.NewConfirm("delete-db", fmt.Sprintf("Delete database %q?", db.Name))
-			m.confirm = &c
-		}
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("u"))):
-		if m.selectedSrv != nil {
-			m.showDBUsers = true
-			m.dbUsersPanel = panels.NewDBUsersPanel(m.forge, m.selectedSrv.ID)
-			return m, m.dbUsersPanel.LoadUsers()
-		}
-		return m, nil
-	}
-
-	p, cmd := m.databasesPanel.Update(msg)
-	m.databasesPanel = p.(panels.DatabasesPanel)
-	return m, cmd
-}
-
-// handleDBUsersKey handles keys specific to the database users sub-view.
-func (m App) handleDBUsersKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
-		i := components.NewInput("create-dbuser", "Username:", "forge_user")
-		m.inputDialog = &i
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
-		if u := m.dbUsersPanel.SelectedUser(); u != nil {
-			c := components.NewConfirm("delete-dbuser", fmt.Sprintf("Delete user %q?", u.Name))
-			m.confirm = &c
-		}
-		return m, nil
-	}
-
-	p, cmd := m.dbUsersPanel.Update(msg)
-	m.dbUsersPanel = p.(panels.DBUsersPanel)
-	return m, cmd
-}
-
-// handleSSLKey handles keys specific to the SSL panel tab.
-func (m App) handleSSLKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
-		i := components.NewInput("create-cert", "Domain(s) (comma-separated):", "example.com")
-		m.inputDialog = &i
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
-		if cert := m.sslPanel.SelectedCert(); cert != nil {
-			c := components.NewConfirm("activate-cert", fmt.Sprintf("Activate certificate for %q?", cert.Domain))
-			m.confirm = &c
+		m.detail.sshKeysPanel = panels.NewSSHKeysPanel(m.forge, serverID)
+		return m, m.detail.sshKeysPanel.LoadKeys()
+	case backupsTab:
+		if siteID > 0 {
+			// Backups are server-level only; sites show their info.
+			return m, nil
 		}
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
-		if cert := m.sslPanel.SelectedCert(); cert != nil {
-			c := components.NewConfirm("delete-cert", fmt.Sprintf("Delete certificate for %q?", cert.Domain))
-			m.confirm = &c
+		m.detail.backupsPanel = panels.NewBackupsPanel(m.forge, serverID)
+		return m, m.detail.backupsPanel.LoadBackups()
+	case recipesTab:
+		if siteID > 0 {
+			return m, nil
 		}
-		return m, nil
+		m.detail.recipesPanel = panels.NewRecipesPanel(m.forge)
+		return m, m.detail.recipesPanel.LoadRecipes()
 	}
-
-	p, cmd := m.sslPanel.Update(msg)
-	m.sslPanel = p.(panels.SSLPanel)
-	return m, cmd
+	return m, nil
 }
 
-// handleWorkersKey handles keys specific to the workers panel tab.
-func (m App) handleWorkersKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
-		c := components.NewConfirm("create-worker", "Create worker with defaults (redis/default/1 proc)?")
-		m.confirm = &c
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
-		if w := m.workersPanel.SelectedWorker(); w != nil {
-			c := components.NewConfirm("restart-worker", fmt.Sprintf("Restart worker %s:%s?", w.Connection, w.Queue))
-			m.confirm = &c
-		}
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
-		if w := m.workersPanel.SelectedWorker(); w != nil {
-			c := components.NewConfirm("delete-worker", fmt.Sprintf("Delete worker %s:%s?", w.Connection, w.Queue))
-			m.confirm = &c
-		}
+// deployNow triggers a deploy of the selected site.
+func (m App) deployNow() (tea.Model, tea.Cmd) {
+	if m.selectedSite == nil || m.selectedSrv == nil {
 		return m, nil
 	}
-
-	p, cmd := m.workersPanel.Update(msg)
-	m.workersPanel = p.(panels.WorkersPanel)
-	return m, cmd
+	m.toast = "Deploying..."
+	m.toastIsErr = false
+	return m, m.detail.deploymentsPanel.TriggerDeploy()
 }
 
-// handleDaemonsKey handles keys specific to the daemons panel tab.
-func (m App) handleDaemonsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
-		i := components.NewInput("create-daemon", "Daemon command:", "php artisan queue:work")
-		m.inputDialog = &i
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
-		if d := m.daemonsPanel.SelectedDaemon(); d != nil {
-			c := components.NewConfirm("restart-daemon", fmt.Sprintf("Restart daemon %q?", truncateStr(d.Command, 30)))
-			m.confirm = &c
-		}
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
-		if d := m.daemonsPanel.SelectedDaemon(); d != nil {
-			c := components.NewConfirm("delete-daemon", fmt.Sprintf("Delete daemon %q?", truncateStr(d.Command, 30)))
-			m.confirm = &c
-		}
-		return m, nil
-	}
-
-	p, cmd := m.daemonsPanel.Update(msg)
-	m.daemonsPanel = p.(panels.DaemonsPanel)
-	return m, cmd
+// quit saves the session state and exits.
+func (m App) quit() (tea.Model, tea.Cmd) {
+	m.state.Expanded = m.treePanel.ExpandedServers()
+	m.state.TreeFilter = m.treePanel.FilterText()
+	m.state.TreeByApp = m.treePanel.GroupingByApp()
+	m.state.Workspace = m.treePanel.Workspace()
+	m.state.InputHistory = m.dialogs.History()
+	_ = m.state.Save() // best effort; session state is disposable
+	_ = m.names.Save()
+	if m.socket != nil {
+		m.socket.Close()
+	}
+	if m.pendingDelete != nil {
+		// Quitting doesn't cancel a delete that was confirmed.
+		return m, tea.Sequence(m.pendingDelete.run, tea.Quit)
+	}
+	return m, tea.Quit
 }
 
-// handleFirewallKey handles keys specific to the firewall panel tab.
-func (m App) handleFirewallKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
-		i := components.NewInput("create-firewall", "Rule name and port (name:port):", "HTTP:80")
-		m.inputDialog = &i
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
-		if r := m.firewallPanel.SelectedRule(); r != nil {
-			c := components.NewConfirm("delete-firewall", fmt.Sprintf("Delete rule %q?", r.Name))
-			m.confirm = &c
+// otherServers returns every loaded server except the selected one.
+func (m App) otherServers() []forge.Server {
+	var out []forge.Server
+	for _, srv := range m.treePanel.Servers() {
+		if m.selectedSrv != nil && srv.ID == m.selectedSrv.ID {
+			continue
 		}
-		return m, nil
+		out = append(out, srv)
 	}
-
-	p, cmd := m.firewallPanel.Update(msg)
-	m.firewallPanel = p.(panels.FirewallPanel)
-	return m, cmd
+	return out
 }
 
-// handleCommandsKey handles keys specific to the commands panel tab.
-func (m App) handleCommandsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
-		i := components.NewInput("run-command", "Command to execute:", "php artisan migrate")
-		m.inputDialog = &i
-		return m, nil
+// serverOptions lists servers for a picker, valued by server ID.
+func serverOptions(servers []forge.Server) []components.PickerOption {
+	options := make([]components.PickerOption, 0, len(servers))
+	for _, srv := range servers {
+		options = append(options, components.PickerOption{
+			Label:  srv.Name,
+			Detail: srv.IPAddress,
+			Value:  strconv.FormatInt(srv.ID, 10),
+		})
 	}
-
-	p, cmd := m.commandsPanel.Update(msg)
-	m.commandsPanel = p.(panels.CommandsPanel)
-	return m, cmd
+	return options
 }
 
-// handleDomainsKey handles keys specific to the domains panel tab.
-func (m App) handleDomainsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
-		i := components.NewInput("add-domain", "Domain alias:", "example.com")
-		m.inputDialog = &i
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
-		if alias := m.domainsPanel.SelectedAlias(); alias != "" {
-			c := components.NewConfirm("remove-domain", fmt.Sprintf("Remove alias %q?", alias))
-			m.confirm = &c
-		}
-		return m, nil
+// defaultSSHKey reads the configured default public key and returns a key
+// name derived from its file name along with its contents.
+func (m App) defaultSSHKey() (name, content string, err error) {
+	keyPath := m.config.Forge.DefaultSSHKey
+	if keyPath == "" {
+		return "", "", fmt.Errorf("No default SSH key configured (ctrl+o to set)")
 	}
-
-	p, cmd := m.domainsPanel.Update(msg)
-	m.domainsPanel = p.(panels.DomainsPanel)
-	return m, cmd
-}
-
-// handleSSHKeysKey handles keys specific to the SSH keys panel tab.
-func (m App) handleSSHKeysKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
-	switch {
-	case key.Matches(msg, key.NewBinding(key.WithKeys("i"))):
-		// Install default SSH key from config.
-		keyPath := m.config.Forge.DefaultSSHKey
-		if keyPath == "" {
-			m.toast = "No default SSH key configured (ctrl+o to set)"
-			m.toastIsErr = true
-			return m, m.clearToastAfter(3 * time.Second)
-		}
-		// Expand ~ in path.
-		if strings.HasPrefix(keyPath, "~/") || keyPath == "~" {
-			home, err := os.UserHomeDir()
-			if err != nil {
-				m.toast = fmt.Sprintf("Cannot resolve home directory: %v", err)
-				m.toastIsErr = true
-				return m, m.clearToastAfter(3 * time.Second)
-			}
-			keyPath = filepath.Join(home, keyPath[2:])
-		}
-		content, err := os.ReadFile(keyPath)
+	// Expand ~ in path.
+	if strings.HasPrefix(keyPath, "~/") || keyPath == "~" {
+		home, err := os.UserHomeDir()
 		if err != nil {
-			m.toast = fmt.Sprintf("Cannot read key: %v", err)
-			m.toastIsErr = true
-			return m, m.clearToastAfter(3 * time.Second)
-		}
-		keyContent := strings.TrimSpace(string(content))
-		if keyContent == "" {
-			m.toast = "Key file is empty"
-			m.toastIsErr = true
-			return m, m.clearToastAfter(3 * time.Second)
-		}
-		name := filepath.Base(keyPath)
-		name = strings.TrimSuffix(name, ".pub")
-		return m, m.sshKeysPanel.CreateKey(name, keyContent, "forge")
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
-		i := components.NewInputWide("create-sshkey-path", "Path to public key (or paste key directly):", "~/.ssh/id_rsa.pub")
-		m.inputDialog = &i
-		return m, nil
-
-	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
-		if k := m.sshKeysPanel.SelectedKey(); k != nil {
-			c := components.NewConfirm("delete-sshkey", fmt.Sprintf("Delete SSH key %q?", k.Name))
-			m.confirm = &c
+			return "", "", fmt.Errorf("Cannot resolve home directory: %v", err)
 		}
-		return m, nil
+		keyPath = filepath.Join(home, keyPath[2:])
 	}
-
-	p, cmd := m.sshKeysPanel.Update(msg)
-	m.sshKeysPanel = p.(panels.SSHKeysPanel)
-	return m, cmd
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return "", "", fmt.Errorf("Cannot read key: %v", err)
+	}
+	content = strings.TrimSpace(string(data))
+	if content == "" {
+		return "", "", fmt.Errorf("Key file is empty")
+	}
+	name = strings.TrimSuffix(filepath.Base(keyPath), ".pub")
+	return name, content, nil
 }
 
 // handleSSHKeyCreate handles the result of the SSH key creation input.
@@ -1700,148 +1674,12 @@ func (m App) handleSSHKeyCreate(input string) (tea.Model, tea.Cmd) {
 			m.toastIsErr = true
 			return m, m.clearToastAfter(3 * time.Second)
 		}
-		return m, m.sshKeysPanel.CreateKey(name, keyContent, "forge")
+		return m, m.detail.sshKeysPanel.CreateKey(name, keyContent, "forge")
 	}
 
 	// Not a file — treat as raw key content. Prompt for a name.
 	m.pendingInputValue = input
-	i := components.NewInput("create-sshkey-name", "Key name:", "my-key")
-	m.inputDialog = &i
-	return m, nil
-}
-
-// handleInputResult processes the result of an input dialog.
-func (m App) handleInputResult(msg components.InputResult) (tea.Model, tea.Cmd) {
-	value := strings.TrimSpace(msg.Value)
-	if value == "" {
-		return m, nil
-	}
-
-	switch msg.ID {
-	case "create-db":
-		return m, m.databasesPanel.CreateDatabase(value)
-	case "create-dbuser":
-		// Use the username as both name and password for simplicity.
-		return m, m.dbUsersPanel.CreateUser(value, value)
-	case "create-cert":
-		// Split comma-separated domains.
-		domains := strings.Split(value, ",")
-		for i := range domains {
-			domains[i] = strings.TrimSpace(domains[i])
-		}
-		return m, m.sslPanel.CreateLetsEncrypt(domains)
-	case "create-daemon":
-		return m, m.daemonsPanel.CreateDaemon(value)
-	case "create-firewall":
-		// Parse "name:port" format.
-		parts := strings.SplitN(value, ":", 2)
-		name := strings.TrimSpace(parts[0])
-		port := "80"
-		if len(parts) > 1 {
-			port = strings.TrimSpace(parts[1])
-		}
-		return m, m.firewallPanel.CreateRule(name, port)
-	case "run-command":
-		return m, m.commandsPanel.CreateCommand(value)
-	case "add-domain":
-		return m, m.domainsPanel.AddAlias(value)
-	case "create-sshkey-path":
-		return m.handleSSHKeyCreate(value)
-	case "create-sshkey-name":
-		// Second step: user provided a name for a pasted key.
-		keyContent := m.pendingInputValue
-		m.pendingInputValue = ""
-		return m, m.sshKeysPanel.CreateKey(value, keyContent, "forge")
-
-	case "set-nickname":
-		// value is the nickname. pendingInputValue holds "server\nsite".
-		parts := strings.SplitN(m.pendingInputValue, "\n", 2)
-		m.pendingInputValue = ""
-		serverName := parts[0]
-		siteName := ""
-		if len(parts) > 1 {
-			siteName = parts[1]
-		}
-		m.config.SetNickname(value, serverName, siteName)
-		m.treePanel = m.treePanel.SetNicknames(m.buildNicknameMap())
-		if err := m.config.Save(); err != nil {
-			m.toast = fmt.Sprintf("Save error: %v", err)
-			m.toastIsErr = true
-			return m, m.clearToastAfter(3 * time.Second)
-		}
-		target := serverName
-		if siteName != "" {
-			target = siteName
-		}
-		m.toast = fmt.Sprintf("Nickname '%s' set for %s", value, target)
-		m.toastIsErr = false
-		return m, m.clearToastAfter(3 * time.Second)
-
-	case "settings-api-key", "settings-ssh-user", "settings-editor", "settings-default-ssh-key":
-		m.settingsModal = m.settingsModal.ApplyValue(msg.ID, value)
-		// Re-open settings modal after inline edit.
-		m.settingsModal = m.settingsModal.Open(m.config)
-		// Save config to disk.
-		if err := m.config.Save(); err != nil {
-			m.toast = fmt.Sprintf("Save error: %v", err)
-			m.toastIsErr = true
-			return m, m.clearToastAfter(3 * time.Second)
-		}
-		// If API key changed, recreate the client.
-		if msg.ID == "settings-api-key" {
-			m.forge = forge.NewClient(m.config.Forge.APIKey)
-		}
-		m.toast = "Settings saved"
-		m.toastIsErr = false
-		return m, m.clearToastAfter(3 * time.Second)
-	}
-
-	return m, nil
-}
-
-// handleConfirmResult processes the result of a confirmation dialog.
-func (m App) handleConfirmResult(msg components.ConfirmResult) (tea.Model, tea.Cmd) {
-	if !msg.Confirmed {
-		return m, nil
-	}
-
-	switch msg.ID {
-	case "deploy":
-		if m.selectedSite != nil && m.selectedSrv != nil {
-			m.toast = "Deploying..."
-			m.toastIsErr = false
-			return m, m.deploymentsPanel.TriggerDeploy()
-		}
-	case "reset-deploy":
-		if m.selectedSite != nil && m.selectedSrv != nil {
-			return m, m.deploymentsPanel.ResetDeployStatus()
-		}
-	case "delete-db":
-		return m, m.databasesPanel.DeleteDatabase()
-	case "delete-dbuser":
-		return m, m.dbUsersPanel.DeleteUser()
-	case "activate-cert":
-		return m, m.sslPanel.ActivateCert()
-	case "delete-cert":
-		return m, m.sslPanel.DeleteCert()
-	case "create-worker":
-		return m, m.workersPanel.CreateWorker()
-	case "restart-worker":
-		return m, m.workersPanel.RestartWorker()
-	case "delete-worker":
-		return m, m.workersPanel.DeleteWorker()
-	case "restart-daemon":
-		return m, m.daemonsPanel.RestartDaemon()
-	case "delete-daemon":
-		return m, m.daemonsPanel.DeleteDaemon()
-	case "delete-firewall":
-		return m, m.firewallPanel.DeleteRule()
-	case "remove-domain":
-		return m, m.domainsPanel.RemoveAlias()
-	case "delete-sshkey":
-		return m, m.sshKeysPanel.DeleteKey()
-	}
-
+	m.dialogs = m.dialogs.Prompt(components.NewInput("create-sshkey-name", "Key name:", "my-key"))
 	return m, nil
 }
 
@@ -1860,14 +1698,25 @@ func (m App) View() tea.View {
 		v.AltScreen = true
 		return v
 	}
+	if m.reauth != nil {
+		return m.reauth.View()
+	}
+	start := time.Now()
 
-	// Reserve space for the footer (1 line) and optional toast (1 line).
-	footerHeight := 1
-	toastHeight := 0
-	if m.toast != "" {
-		toastHeight = 1
+	// While the tour is running, focus the panel the current step points
+	// at so its border is highlighted.
+	if m.tour.Active() {
+		switch m.tour.Target() {
+		case tourTree:
+			m.nav = m.nav.FocusPanel(FocusTree)
+		case tourDetail:
+			m.nav = m.nav.FocusPanel(FocusDetail)
+		case tourOutput:
+			m.nav = m.nav.FocusPanel(FocusOutput)
+		}
 	}
-	contentHeight := m.height - footerHeight - toastHeight
+
+	contentHeight, detailHeight, outputHeight := m.panelHeights()
 
 	// Left panel = ~30% width, right panel = rest.
 	leftWidth := m.width * 3 / 10
@@ -1877,35 +1726,10 @@ func (m App) View() tea.View {
 	rightWidth := m.width - leftWidth
 
 	// Tree panel on the left, full content height.
-	treeView := m.treePanel.View(leftWidth, contentHeight, m.focus == FocusTree)
-
-	// Right side: detail panel on top, output panel on bottom.
-	// Adaptive: if output has no content, give detail more space.
-	var detailHeight, outputHeight int
-	if m.outputPanel.HasContent() {
-		detailHeight = contentHeight * 60 / 100
-		outputHeight = contentHeight - detailHeight
-	} else {
-		detailHeight = contentHeight * 85 / 100
-		outputHeight = contentHeight - detailHeight
-	}
-	if detailHeight < 4 {
-		detailHeight = 4
-	}
-	if outputHeight < 3 {
-		outputHeight = 3
-	}
-	// Re-balance if sum exceeds content height.
-	if detailHeight+outputHeight > contentHeight {
-		outputHeight = contentHeight - detailHeight
-		if outputHeight < 3 {
-			outputHeight = 3
-			detailHeight = contentHeight - outputHeight
-		}
-	}
+	treeView := m.treePanel.View(leftWidth, contentHeight, m.nav.Focus() == FocusTree)
 
-	detailView := m.renderDetailPanel(rightWidth, detailHeight)
-	outputView := m.outputPanel.View(rightWidth, outputHeight, m.focus == FocusOutput)
+	detailView := m.detail.View(rightWidth, detailHeight, m.nav.Focus() == FocusDetail, m.selectedSrv, m.selectedSite, m.nav.DetailScreen())
+	outputView := m.outputPanel.View(rightWidth, outputHeight, m.nav.Focus() == FocusOutput)
 
 	// Join the right panels vertically.
 	rightSide := lipgloss.JoinVertical(lipgloss.Left, detailView, outputView)
@@ -1934,21 +1758,8 @@ func (m App) View() tea.View {
 
 	content := lipgloss.JoinVertical(lipgloss.Left, parts...)
 
-	// Overlay the input dialog if active (float on top of existing UI).
-	if m.inputDialog != nil && m.inputDialog.Active {
-		overlay := m.inputDialog.View(m.width, m.height)
-		if overlay != "" {
-			content = overlayCenter(overlay, content, m.width, m.height)
-		}
-	}
-
-	// Overlay the confirmation dialog if active (float on top of existing UI).
-	if m.confirm != nil && m.confirm.Active {
-		overlay := m.confirm.View(m.width, m.height)
-		if overlay != "" {
-			content = overlayCenter(overlay, content, m.width, m.height)
-		}
-	}
+	// Overlay input and confirmation dialogs (float on top of existing UI).
+	content = m.dialogs.View(content, m.width, m.height)
 
 	// Overlay the help modal on top of the existing UI.
 	if m.helpModal.Active() {
@@ -1966,213 +1777,113 @@ func (m App) View() tea.View {
 		}
 	}
 
-	v := tea.NewView(content)
-	v.AltScreen = true
-	return v
-}
-
-// renderDetailPanel renders the top-right detail panel.
-// When a site is selected it shows a tab bar with site-level panels;
-// when only a server is selected it shows a tab bar with server-level panels;
-// otherwise it shows server info.
-func (m App) renderDetailPanel(width, height int) string {
-	focused := m.focus == FocusDetail
-
-	if m.selectedSite != nil {
-		// Render the tab bar as a single line above the section panel.
-		tabBar := m.renderTabBar(width)
-		tabBarHeight := lipgloss.Height(tabBar)
-
-		// The section panel gets the remaining height below the tab bar.
-		sectionHeight := height - tabBarHeight
-		if sectionHeight < 2 {
-			sectionHeight = 2
-		}
-
-		var sectionPanel string
-		switch m.activeTab {
-		case 1:
-			if m.showDeployScript {
-				sectionPanel = m.deployScriptPanel.View(width, sectionHeight, focused)
-			} else {
-				sectionPanel = m.deploymentsPanel.View(width, sectionHeight, focused)
-			}
-		case 2:
-			sectionPanel = m.environmentPanel.View(width, sectionHeight, focused)
-		case 3:
-			if m.showDBUsers {
-				sectionPanel = m.dbUsersPanel.View(width, sectionHeight, focused)
-			} else {
-				sectionPanel = m.databasesPanel.View(width, sectionHeight, focused)
-			}
-		case 4:
-			sectionPanel = m.sslPanel.View(width, sectionHeight, focused)
-		case 5:
-			sectionPanel = m.workersPanel.View(width, sectionHeight, focused)
-		case 6:
-			sectionPanel = m.commandsPanel.View(width, sectionHeight, focused)
-		case 7:
-			sectionPanel = m.logsPanel.View(width, sectionHeight, focused)
-		case 8:
-			sectionPanel = m.gitPanel.View(width, sectionHeight, focused)
-		case 9:
-			sectionPanel = m.domainsPanel.View(width, sectionHeight, focused)
+	// Overlay the tour box beside the panel the current step points at.
+	if m.tour.Active() {
+		box := m.tour.View(m.width)
+		switch m.tour.Target() {
+		case tourTree:
+			content = overlayAt(box, content, leftWidth+2, 2, m.height)
+		case tourDetail:
+			content = overlayAt(box, content, 2, 2, m.height)
+		case tourOutput:
+			content = overlayAt(box, content, 2, detailHeight, m.height)
 		default:
-			sectionPanel = m.siteInfo.View(width, sectionHeight, focused)
+			content = overlayCenter(box, content, m.width, m.height)
 		}
-
-		return lipgloss.JoinVertical(lipgloss.Left, tabBar, sectionPanel)
 	}
 
-	// Server-only context: always show server tab bar.
-	if m.selectedSrv != nil {
-		tabBar := m.renderServerTabBar(width)
-		tabBarHeight := lipgloss.Height(tabBar)
-
-		sectionHeight := height - tabBarHeight
-		if sectionHeight < 2 {
-			sectionHeight = 2
+	// Overlay the about modal on top of the existing UI.
+	if m.aboutModal.Active() {
+		box := m.aboutModal.View(m.width, m.height)
+		if box != "" {
+			content = overlayCenter(box, content, m.width, m.height)
 		}
+	}
 
-		var sectionPanel string
-		switch m.activeTab {
-		case 1:
-			sectionPanel = m.eventsPanel.View(width, sectionHeight, focused)
-		case 3:
-			if m.showDBUsers {
-				sectionPanel = m.dbUsersPanel.View(width, sectionHeight, focused)
-			} else {
-				sectionPanel = m.databasesPanel.View(width, sectionHeight, focused)
-			}
-		case 6:
-			sectionPanel = m.daemonsPanel.View(width, sectionHeight, focused)
-		case 7:
-			sectionPanel = m.firewallPanel.View(width, sectionHeight, focused)
-		case 8:
-			sectionPanel = m.jobsPanel.View(width, sectionHeight, focused)
-		case 9:
-			sectionPanel = m.sshKeysPanel.View(width, sectionHeight, focused)
-		default:
-			sectionPanel = m.serverInfo.View(width, sectionHeight, focused)
+	// Overlay the provider credentials.
+	if m.providersModal.Active() {
+		box := m.providersModal.View(m.width, m.height)
+		if box != "" {
+			content = overlayCenter(box, content, m.width, m.height)
 		}
-
-		return lipgloss.JoinVertical(lipgloss.Left, tabBar, sectionPanel)
 	}
 
-	return m.serverInfo.View(width, height, focused)
-}
-
-// renderTabBar renders the numbered section tabs at the top of the detail panel.
-func (m App) renderTabBar(width int) string {
-	// Tabs 6-9 change based on context (site selected vs server only).
-	tabs := []struct {
-		num  int
-		name string
-	}{
-		{1, "Deploy"}, {2, "Env"}, {3, "DB"},
-		{4, "SSL"}, {5, "Workers"}, {6, "Cmds"},
-		{7, "Logs"}, {8, "Git"}, {9, "Domains"},
+	// Overlay the alerts list.
+	if m.alertsModal.Active() {
+		box := m.alertsModal.View(m.width, m.height)
+		if box != "" {
+			content = overlayCenter(box, content, m.width, m.height)
+		}
 	}
 
-	var parts []string
-	for _, t := range tabs {
-		label := fmt.Sprintf("%d:%s", t.num, t.name)
-		if t.num == m.activeTab {
-			parts = append(parts, SelectedItemStyle.Render(label))
-		} else {
-			parts = append(parts, HelpBarStyle.Render(label))
+	// Overlay the scheduled actions.
+	if m.scheduledModal.Active() {
+		box := m.scheduledModal.View(m.width, m.height)
+		if box != "" {
+			content = overlayCenter(box, content, m.width, m.height)
 		}
 	}
 
-	bar := strings.Join(parts, "  ")
-	return theme.Truncate(bar, width)
-}
-
-// serverTabNums lists which activeTab values correspond to server-level panels.
-var serverTabNums = map[int]bool{1: true, 3: true, 6: true, 7: true, 8: true, 9: true}
+	// Overlay the database credentials.
+	if m.credsModal.Active() {
+		box := m.credsModal.View(m.width, m.height)
+		if box != "" {
+			content = overlayCenter(box, content, m.width, m.height)
+		}
+	}
 
-// renderServerTabBar renders the server-level tab bar.
-func (m App) renderServerTabBar(width int) string {
-	tabs := []struct {
-		num  int
-		name string
-	}{
-		{0, "Info"}, {1, "Events"}, {3, "DB"}, {6, "Daemons"}, {7, "Firewall"}, {8, "Jobs"}, {9, "SSH Keys"},
+	// The diagnostics overlay sits in the top right corner and times
+	// everything rendered under it.
+	if m.diag.enabled {
+		m.diag.renders.add(time.Since(start))
+		box := m.renderDiagnostics()
+		content = overlayAt(box, content, max(m.width-lipgloss.Width(box), 0), 0, m.height)
 	}
 
-	// If the active tab isn't a server-level tab, highlight Info.
-	activeForBar := m.activeTab
-	if !serverTabNums[activeForBar] {
-		activeForBar = 0
+	v := tea.NewView(content)
+	v.AltScreen = true
+	return v
+}
+
+// panelHeights returns the height available to the panels (above the
+// footer and any toast) and how it is split between the detail panel on
+// top and the output panel below.
+func (m App) panelHeights() (contentHeight, detailHeight, outputHeight int) {
+	// Reserve space for the footer (1 line) and optional toast (1 line).
+	footerHeight := 1
+	toastHeight := 0
+	if m.toast != "" {
+		toastHeight = 1
 	}
+	contentHeight = m.height - footerHeight - toastHeight
 
-	var parts []string
-	for _, t := range tabs {
-		var label string
-		if t.num == 0 {
-			label = t.name
-		} else {
-			label = fmt.Sprintf("%d:%s", t.num, t.name)
-		}
-		if t.num == activeForBar {
-			parts = append(parts, SelectedItemStyle.Render(label))
-		} else {
-			parts = append(parts, HelpBarStyle.Render(label))
-		}
+	// Right side: detail panel on top, output panel on bottom.
+	// Adaptive: if output has no content, give detail more space.
+	detailPct := 85
+	if m.outputPanel.HasContent() {
+		detailPct = 60
 	}
+	detailHeight, outputHeight = layout.SplitVertical(contentHeight, detailPct, 4, 3)
 
-	bar := strings.Join(parts, "  ")
-	return theme.Truncate(bar, width)
+	return contentHeight, detailHeight, outputHeight
 }
 
-// renderFooter renders the context-sensitive footer with pipe-separated keybindings.
-func (m App) renderFooter() string {
-	var helpBindings []panels.HelpBinding
-
-	switch m.focus {
+// panelBindings returns the keybindings of the focused panel.
+func (m App) panelBindings() []panels.HelpBinding {
+	switch m.nav.Focus() {
 	case FocusTree:
-		helpBindings = m.treePanel.HelpBindings()
+		return m.treePanel.HelpBindings()
 	case FocusOutput:
-		helpBindings = m.outputPanel.HelpBindings()
+		return m.outputPanel.HelpBindings()
 	case FocusDetail:
-		if m.selectedSite != nil && m.activeTab == 1 && m.showDeployScript {
-			helpBindings = m.deployScriptPanel.HelpBindings()
-		} else if m.selectedSite != nil && m.activeTab == 1 {
-			helpBindings = m.deploymentsPanel.HelpBindings()
-		} else if m.selectedSite == nil && m.activeTab == 1 {
-			helpBindings = m.eventsPanel.HelpBindings()
-		} else if m.selectedSite != nil && m.activeTab == 2 {
-			helpBindings = m.environmentPanel.HelpBindings()
-		} else if m.activeTab == 3 && m.showDBUsers {
-			helpBindings = m.dbUsersPanel.HelpBindings()
-		} else if m.activeTab == 3 {
-			helpBindings = m.databasesPanel.HelpBindings()
-		} else if m.selectedSite != nil && m.activeTab == 4 {
-			helpBindings = m.sslPanel.HelpBindings()
-		} else if m.selectedSite != nil && m.activeTab == 5 {
-			helpBindings = m.workersPanel.HelpBindings()
-		} else if m.activeTab == 6 && m.selectedSite != nil {
-			helpBindings = m.commandsPanel.HelpBindings()
-		} else if m.activeTab == 6 {
-			helpBindings = m.daemonsPanel.HelpBindings()
-		} else if m.activeTab == 7 && m.selectedSite != nil {
-			helpBindings = m.logsPanel.HelpBindings()
-		} else if m.activeTab == 7 {
-			helpBindings = m.firewallPanel.HelpBindings()
-		} else if m.activeTab == 8 && m.selectedSite != nil {
-			helpBindings = m.gitPanel.HelpBindings()
-		} else if m.activeTab == 8 {
-			helpBindings = m.jobsPanel.HelpBindings()
-		} else if m.activeTab == 9 && m.selectedSite != nil {
-			helpBindings = m.domainsPanel.HelpBindings()
-		} else if m.activeTab == 9 {
-			helpBindings = m.sshKeysPanel.HelpBindings()
-		} else if m.selectedSite != nil {
-			helpBindings = m.siteInfo.HelpBindings()
-		} else {
-			helpBindings = m.serverInfo.HelpBindings()
-		}
+		return m.detail.ActivePanel(m.selectedSrv, m.selectedSite, m.nav.DetailScreen()).HelpBindings()
 	}
+	return nil
+}
+
+// renderFooter renders the context-sensitive footer with pipe-separated keybindings.
+func (m App) renderFooter() string {
+	helpBindings := m.panelBindings()
 
 	// Append context-sensitive global keybindings.
 	if m.selectedSrv != nil {
@@ -2186,14 +1897,30 @@ func (m App) renderFooter() string {
 			)
 		}
 	}
+	helpBindings = append(helpBindings, m.actionBindings()...)
 	helpBindings = append(helpBindings, panels.HelpBinding{Key: "?", Desc: "help"})
+	if m.updateVersion != "" {
+		helpBindings = append(helpBindings, panels.HelpBinding{
+			Key:  "v" + m.updateVersion,
+			Desc: "available (phorge update)",
+		})
+	}
 
 	var formatted []string
+	if badge := m.alertBadge(); badge != "" {
+		formatted = append(formatted, badge+HelpBarStyle.Render(" (!)"))
+	}
+	if badge := m.scheduledBadge(); badge != "" {
+		formatted = append(formatted, badge+HelpBarStyle.Render(" (T)"))
+	}
+	if badge := m.queuedDeployBadge(); badge != "" {
+		formatted = append(formatted, badge+" "+helpBinding("ctrl+x", "cancel"))
+	}
 	for _, b := range helpBindings {
 		formatted = append(formatted, helpBinding(b.Key, b.Desc))
 	}
 
-	bar := strings.Join(formatted, HelpBarStyle.Render(" \u2502 "))
+	bar := strings.Join(formatted, HelpBarStyle.Render(" "+theme.GlyphSeparator+" "))
 
 	return HelpBarStyle.Width(m.width).Render(bar)
 }
@@ -2234,65 +1961,205 @@ func (m App) fetchSitesForTree(serverID int64) tea.Cmd {
 	}
 }
 
-// fetchDeployOutput returns a command that fetches deployment output and
-// sends a DeployOutputMsg to be routed to the output panel.
-func (m App) fetchDeployOutput(serverID, siteID, deployID int64) tea.Cmd {
+// fetchDeployStatuses returns a command that fetches the latest deployment
+// of each site, at most treeStatusConcurrency at a time, and reports them
+// in a single treeDeployStatusMsg. Sites whose history can't be fetched
+// are left without a badge.
+func (m App) fetchDeployStatuses(serverID int64, sites []forge.Site) tea.Cmd {
+	if len(sites) == 0 {
+		return nil
+	}
 	client := m.forge
 	return func() tea.Msg {
-		output, err := client.Deployments.GetOutput(context.Background(), serverID, siteID, deployID)
-		if err != nil {
-			return panels.PanelErrMsg{Err: err}
+		ctx := context.Background()
+		statuses := make(map[int64]string, len(sites))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, treeStatusConcurrency)
+
+		for _, site := range sites {
+			wg.Add(1)
+			go func(siteID int64) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				deployments, err := client.Deployments.List(ctx, serverID, siteID)
+				if err != nil || len(deployments) == 0 {
+					return
+				}
+				mu.Lock()
+				statuses[siteID] = deployments[0].Status
+				mu.Unlock()
+			}(site.ID)
 		}
-		return panels.DeployOutputMsg{Output: output}
+		wg.Wait()
+
+		return treeDeployStatusMsg{statuses: statuses}
 	}
 }
 
-// fetchDeployOutputWithStatus fetches both status and output for a deployment.
-// Status is checked first so that when the deployment has finished, the
-// subsequent output fetch captures the complete log.
-func (m App) fetchDeployOutputWithStatus(serverID, siteID, deployID int64) tea.Cmd {
-	client := m.forge
+// checkReachability returns a command that attempts a TCP connection to
+// each server's SSH port, at most reachabilityConcurrency at a time, and
+// reports which ones answered. Servers without a public IP are skipped.
+func checkReachability(servers []forge.Server) tea.Cmd {
 	return func() tea.Msg {
-		// Check status first to avoid a race where output is fetched before
-		// the deployment finishes but status is checked after.
-		dep, err := client.Deployments.Get(context.Background(), serverID, siteID, deployID)
-		finished := err != nil || dep.Status != "deploying"
-
-		// Use the live deployment log endpoint while deploying (it updates
-		// in real time), and the archived history output once finished.
-		var output string
-		if finished {
-			output, err = client.Deployments.GetOutput(context.Background(), serverID, siteID, deployID)
-		} else {
-			output, err = client.Deployments.GetLog(context.Background(), serverID, siteID)
+		reachable := make(map[int64]bool, len(servers))
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, reachabilityConcurrency)
+
+		for _, srv := range servers {
+			if srv.IPAddress == "" {
+				continue
+			}
+			port := srv.SSHPort
+			if port == 0 {
+				port = 22
+			}
+			wg.Add(1)
+			go func(id int64, addr string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				conn, err := net.DialTimeout("tcp", addr, 3*time.Second)
+				if err == nil {
+					conn.Close()
+				}
+				mu.Lock()
+				reachable[id] = err == nil
+				mu.Unlock()
+			}(srv.ID, net.JoinHostPort(srv.IPAddress, strconv.Itoa(port)))
 		}
-		if err != nil {
-			return panels.PanelErrMsg{Err: err}
+		wg.Wait()
+
+		return serverReachabilityMsg{reachable: reachable}
+	}
+}
+
+// switchEnvironment cycles to the next environment declared in .phorge
+// and moves the tree selection to its server/site.
+func (m App) switchEnvironment() (tea.Model, tea.Cmd) {
+	names := m.project.EnvironmentNames()
+	if len(names) == 0 {
+		m.toast = "No environments declared in .phorge"
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+
+	next := names[0]
+	for i, n := range names {
+		if n == m.activeEnv {
+			next = names[(i+1)%len(names)]
+			break
 		}
-		return pollOutputResultMsg{output: output, finished: finished}
 	}
+	env, _ := m.project.Environment(next)
+
+	srv := m.treePanel.FindServerByName(env.Server)
+	if srv == nil {
+		m.toast = fmt.Sprintf("Environment %s: server %q not found", next, env.Server)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+
+	m.activeEnv = next
+	m.toast = fmt.Sprintf("Environment: %s", next)
+	m.toastIsErr = false
+
+	m, cmd := m.jumpTo(srv, env.Site)
+	return m, tea.Batch(cmd, m.clearToastAfter(3*time.Second))
 }
 
-// pollOutputTick returns a command that sends a pollOutputTickMsg after 2 seconds.
-func (m App) pollOutputTick() tea.Cmd {
-	return tea.Tick(2*time.Second, func(time.Time) tea.Msg {
-		return pollOutputTickMsg{}
-	})
+// jumpTo focuses the tree, expands srv and selects the named site on it
+// (or the server itself when siteName is empty). If the server's sites are
+// still loading, the selection completes when they arrive.
+func (m App) jumpTo(srv *forge.Server, siteName string) (App, tea.Cmd) {
+	m.nav = NewNavStack()
+
+	var cmd tea.Cmd
+	m.treePanel, cmd = m.treePanel.ExpandServer(srv.ID)
+	if siteName != "" && cmd != nil {
+		// Sites are still loading; finish the jump when they arrive.
+		m.pendingJump = &jumpRequest{serverID: srv.ID, siteName: siteName}
+		m.treePanel, _ = m.treePanel.SetCursorToServer(srv.ID)
+		m.selectedSrv = srv
+		m.detail.serverInfo = m.detail.serverInfo.SetServer(srv)
+		m.selectedSite = nil
+		m.detail.siteInfo = m.detail.siteInfo.SetSite(nil)
+		return m, cmd
+	}
+
+	return m.selectSiteOnServer(srv.ID, siteName), cmd
 }
 
-// spinnerTick returns a command that sends a pollSpinnerTickMsg after 150ms.
-func (m App) spinnerTick() tea.Cmd {
-	return tea.Tick(150*time.Millisecond, func(time.Time) tea.Msg {
-		return pollSpinnerTickMsg{}
-	})
+// selectSiteOnServer moves the tree cursor to the named site on a server
+// (or to the server itself when siteName is empty) and selects it.
+func (m App) selectSiteOnServer(serverID int64, siteName string) App {
+	srv := m.treePanel.FindServerByID(serverID)
+	if srv == nil {
+		return m
+	}
+	m.selectedSrv = srv
+	m.detail.serverInfo = m.detail.serverInfo.SetServer(srv)
+
+	if site := m.treePanel.FindSiteOnServer(serverID, siteName); site != nil {
+		m.treePanel, _ = m.treePanel.SetCursorToSite(site.ID)
+		m.selectedSite = site
+		m.detail.siteInfo = m.detail.siteInfo.SetSite(site)
+		return m
+	}
+
+	m.treePanel, _ = m.treePanel.SetCursorToServer(serverID)
+	m.selectedSite = nil
+	m.detail.siteInfo = m.detail.siteInfo.SetSite(nil)
+	return m
+}
+
+// visitSite records a site as recently visited and refreshes the tree's
+// Recent group.
+func (m App) visitSite(serverID, siteID int64) App {
+	m.state.AddRecent(serverID, siteID)
+	m.treePanel = m.treePanel.SetRecent(recentSites(m.state))
+	return m
+}
+
+// recentSites converts the persisted recent list for the tree panel.
+func recentSites(state *config.State) []panels.RecentSite {
+	recent := make([]panels.RecentSite, len(state.Recent))
+	for i, r := range state.Recent {
+		recent[i] = panels.RecentSite{ServerID: r.ServerID, SiteID: r.SiteID}
+	}
+	return recent
+}
+
+// serverNames returns the names of servers, for the name cache.
+func serverNames(servers []forge.Server) []string {
+	names := make([]string, len(servers))
+	for i, srv := range servers {
+		names[i] = srv.Name
+	}
+	return names
+}
+
+// siteNames returns the names of sites, for the name cache.
+func siteNames(sites []forge.Site) []string {
+	names := make([]string, len(sites))
+	for i, site := range sites {
+		names[i] = site.Name
+	}
+	return names
 }
 
 // toggleDefault saves or clears the default server/site in .phorge.
 // If siteName is empty, it toggles only the server default.
 // If siteName is non-empty, it sets/clears both server and site.
+// Environments declared in .phorge are preserved.
 func (m App) toggleDefault(serverName, siteName string) tea.Cmd {
 	currentServer := m.project.Server
 	currentSite := m.project.Site
+	environments := m.project.Environments
 	return func() tea.Msg {
 		var newServer, newSite string
 		if siteName != "" {
@@ -2316,7 +2183,11 @@ func (m App) toggleDefault(serverName, siteName string) tea.Cmd {
 				newSite = ""
 			}
 		}
-		err := config.SaveProjectConfig(config.ProjectConfig{Server: newServer, Site: newSite})
+		err := config.SaveProjectConfig(config.ProjectConfig{
+			Server:       newServer,
+			Site:         newSite,
+			Environments: environments,
+		})
 		return setDefaultMsg{serverName: newServer, siteName: newSite, err: err}
 	}
 }
@@ -2369,11 +2240,69 @@ func (m App) promptNickname(serverName, siteName string) (tea.Model, tea.Cmd) {
 
 	// No existing nickname — prompt for one.
 	m.pendingInputValue = serverName + "\n" + siteName
-	input := components.NewInput("set-nickname", fmt.Sprintf("Nickname for %s:", target), "my-site")
-	m.inputDialog = &input
+	m.dialogs = m.dialogs.Prompt(components.NewInput("set-nickname", fmt.Sprintf("Nickname for %s:", target), "my-site"))
 	return m, nil
 }
 
+// hiddenServers returns the tree's predicate for servers on cfg's ignore
+// list.
+func hiddenServers(cfg *config.Config) func(forge.Server) bool {
+	return func(srv forge.Server) bool {
+		return cfg.ServerHidden(srv.Name, srv.ID, srv.TagNames())
+	}
+}
+
+// toggleHidden adds srv to the ignore list, or takes it off if it is on it
+// by name or ID. A server hidden by a tag rule stays hidden until the rule
+// is removed from the config.
+func (m App) toggleHidden(srv forge.Server) (tea.Model, tea.Cmd) {
+	var toast string
+	if !m.treePanel.IsHidden(srv) {
+		m.config.HideServer(srv.Name)
+		toast = fmt.Sprintf("Hid %s (. shows hidden servers)", srv.Name)
+	} else {
+		if !m.config.UnhideServer(srv.Name, srv.ID) {
+			m.toast = fmt.Sprintf("%s is hidden by a tag rule in hidden_servers", srv.Name)
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		toast = fmt.Sprintf("Unhid %s", srv.Name)
+		if m.config.ServerHidden(srv.Name, srv.ID, srv.TagNames()) {
+			toast += " (still hidden by a tag rule)"
+		}
+	}
+	if err := m.config.Save(); err != nil {
+		m.toast = fmt.Sprintf("Save error: %v", err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	m.treePanel = m.treePanel.SetHidden(hiddenServers(m.config))
+	m.toast = toast
+	m.toastIsErr = false
+
+	// The cursor may have moved off a server that is no longer shown.
+	cmds := []tea.Cmd{m.clearToastAfter(3 * time.Second)}
+	if sel, site := m.treePanel.Selected(); sel.ID != 0 {
+		cmds = append(cmds, func() tea.Msg {
+			return panels.TreeNodeSelectedMsg{Server: sel, Site: site}
+		})
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// updateSite returns a command that applies opts to the selected site.
+func (m App) updateSite(opts forge.SiteUpdateOpts) tea.Cmd {
+	if m.selectedSrv == nil || m.selectedSite == nil {
+		return nil
+	}
+	client := m.forge
+	serverID, siteID, oldName := m.selectedSrv.ID, m.selectedSite.ID, m.selectedSite.Name
+	return func() tea.Msg {
+		site, err := client.Sites.Update(context.Background(), serverID, siteID, opts)
+		return siteUpdatedMsg{serverID: serverID, oldName: oldName, site: site, err: err}
+	}
+}
+
 // rebootServer returns a command that initiates a server reboot.
 func (m App) rebootServer(serverID int64) tea.Cmd {
 	client := m.forge
@@ -2383,6 +2312,40 @@ func (m App) rebootServer(serverID int64) tea.Cmd {
 	}
 }
 
+// checkForUpdate returns a command that looks up the latest GitHub release
+// and reports it if it is newer than the running version. Failures are
+// silent: the check is best-effort and must never disturb the UI.
+func checkForUpdate() tea.Cmd {
+	if Version == "dev" {
+		return nil
+	}
+	current := Version
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		rel, err := update.NewUpdater().Latest(ctx)
+		if err != nil || !update.IsNewer(current, rel.Version()) {
+			return nil
+		}
+		return updateAvailableMsg{version: rel.Version()}
+	}
+}
+
+// configWarningsToast returns a command that shows all of the config's
+// load warnings as an error toast, or nil when there are none.
+func configWarningsToast(cfg *config.Config) tea.Cmd {
+	if len(cfg.Warnings) == 0 {
+		return nil
+	}
+	text := "Config: " + cfg.Warnings[0]
+	if n := len(cfg.Warnings); n > 1 {
+		text = fmt.Sprintf("%d config warnings: %s", n, strings.Join(cfg.Warnings, "; "))
+	}
+	return func() tea.Msg {
+		return toastMsg{message: text, isError: true}
+	}
+}
+
 // clearToastAfter returns a command that clears the toast after a delay.
 func (m App) clearToastAfter(d time.Duration) tea.Cmd {
 	return tea.Tick(d, func(time.Time) tea.Msg {
@@ -2402,6 +2365,15 @@ func helpBinding(k, desc string) string {
 // background content on both the left and right sides of the overlay box,
 // giving a true floating-popup effect.
 func overlayCenter(fg, bg string, width, height int) string {
+	x := (width - lipgloss.Width(fg)) / 2
+	y := (height - lipgloss.Height(fg)) / 2
+	return overlayAt(fg, bg, x, y, height)
+}
+
+// overlayAt places fg on top of bg with its top-left corner at column x,
+// row y, preserving background content around the overlay box. Parts of
+// fg above or left of the screen are cut off.
+func overlayAt(fg, bg string, x, y, height int) string {
 	fgLines := strings.Split(fg, "\n")
 	bgLines := strings.Split(bg, "\n")
 
@@ -2410,82 +2382,59 @@ func overlayCenter(fg, bg string, width, height int) string {
 		bgLines = append(bgLines, "")
 	}
 
-	fgH := len(fgLines)
 	fgW := lipgloss.Width(fg)
-
-	startY := (height - fgH) / 2
-	if startY < 0 {
-		startY = 0
+	if x < 0 {
+		for i, line := range fgLines {
+			fgLines[i] = ansiCutLeft(line, -x)
+		}
+		fgW = max(fgW+x, 0)
+		x = 0
 	}
-	leftPad := (width - fgW) / 2
-	if leftPad < 0 {
-		leftPad = 0
+	// Pad ragged fg lines so the background to their right stays in place.
+	for i, line := range fgLines {
+		if w := lipgloss.Width(line); w < fgW {
+			fgLines[i] = line + strings.Repeat(" ", fgW-w)
+		}
 	}
 
 	result := make([]string, len(bgLines))
 	for i, bgLine := range bgLines {
-		fgIdx := i - startY
-		if fgIdx >= 0 && fgIdx < fgH {
-			bgW := lipgloss.Width(bgLine)
-
-			// Left portion: truncate background to leftPad visual width.
-			left := ansi.Truncate(bgLine, leftPad, "")
-			leftW := lipgloss.Width(left)
-			if leftW < leftPad {
-				left += strings.Repeat(" ", leftPad-leftW)
-			}
-
-			// Right portion: background content after the overlay area.
-			rightStart := leftPad + fgW
-			right := ""
-			if rightStart < bgW {
-				right = ansiCutLeft(bgLine, rightStart)
-			}
-
-			result[i] = left + fgLines[fgIdx] + right
-		} else {
+		fgIdx := i - y
+		if fgIdx < 0 || fgIdx >= len(fgLines) {
 			result[i] = bgLine
+			continue
+		}
+
+		// Left portion: truncate background to x visual width.
+		left := ansi.Truncate(bgLine, x, "")
+		if leftW := lipgloss.Width(left); leftW < x {
+			left += strings.Repeat(" ", x-leftW)
 		}
+
+		// Right portion: background content after the overlay area.
+		right := ansiCutLeft(bgLine, x+fgW)
+
+		// Reset between the parts so no style bleeds from one into the next.
+		result[i] = left + ansi.ResetStyle + fgLines[fgIdx] + ansi.ResetStyle + right
 	}
 
 	return strings.Join(result, "\n")
 }
 
 // ansiCutLeft returns the portion of an ANSI string starting at visual
-// position `skip`. It walks through the string, counting visible characters
-// and preserving ANSI escape sequences.
+// position `skip`, keeping the escape sequences before it so the remainder
+// is styled as it was. A wide character cut in half is replaced by a space.
 func ansiCutLeft(s string, skip int) string {
-	var visWidth int
-	var result strings.Builder
-	inEsc := false
-	skipping := true
-
-	for _, r := range s {
-		if inEsc {
-			if !skipping {
-				result.WriteRune(r)
-			}
-			if (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') {
-				inEsc = false
-			}
-			continue
-		}
-		if r == '\x1b' {
-			inEsc = true
-			if !skipping {
-				result.WriteRune(r)
-			}
-			continue
-		}
-
-		visWidth++
-		if visWidth > skip {
-			if skipping {
-				skipping = false
-			}
-			result.WriteRune(r)
-		}
+	want := lipgloss.Width(s) - skip
+	if want <= 0 {
+		return ""
 	}
-
-	return result.String()
+	if skip <= 0 {
+		return s
+	}
+	right := ansi.TruncateLeft(s, skip, "")
+	if lipgloss.Width(right) > want {
+		right = " " + ansi.TruncateLeft(s, skip+1, "")
+	}
+	return right
 }
@@ -0,0 +1,449 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+func TestAnsiCutLeft(t *testing.T) {
+	red := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	tests := []struct {
+		name string
+		s    string
+		skip int
+		want string // with escapes stripped
+	}{
+		{"plain", "hello world", 6, "world"},
+		{"nothing skipped", "hello", 0, "hello"},
+		{"all skipped", "hello", 5, ""},
+		{"past the end", "hello", 9, ""},
+		{"styled", red.Render("hello") + " world", 3, "lo world"},
+		{"wide characters", "日本語テキスト", 4, "語テキスト"},
+		{"wide character cut in half", "日本語", 3, " 語"},
+		{"hyperlink", ansi.SetHyperlink("https://example.com") + "link" + ansi.ResetHyperlink(), 2, "nk"},
+		{"csi ending in tilde", "ab\x1b[2~cd", 1, "bcd"},
+	}
+	for _, tt := range tests {
+		got := ansiCutLeft(tt.s, tt.skip)
+		if plain := ansi.Strip(got); plain != tt.want {
+			t.Errorf("%s: ansiCutLeft(%q, %d) = %q, want %q", tt.name, tt.s, tt.skip, plain, tt.want)
+		}
+	}
+}
+
+func TestAnsiCutLeftKeepsStyle(t *testing.T) {
+	s := lipgloss.NewStyle().Bold(true).Render("hello world")
+	got := ansiCutLeft(s, 6)
+	if !strings.HasPrefix(got, "\x1b[") {
+		t.Errorf("ansiCutLeft dropped the style of the remainder: %q", got)
+	}
+}
+
+func TestOverlayAt(t *testing.T) {
+	bg := "0123456789\n0123456789\n0123456789"
+	tests := []struct {
+		name string
+		fg   string
+		x, y int
+		want string
+	}{
+		{"inside", "ab\ncd", 2, 1, "0123456789\n01ab456789\n01cd456789"},
+		{"ragged", "abc\nd", 2, 0, "01abc56789\n01d  56789\n0123456789"},
+		{"above the screen", "ab\ncd", 4, -1, "0123cd6789\n0123456789\n0123456789"},
+		{"left of the screen", "abc\ndef", -1, 0, "bc23456789\nef23456789\n0123456789"},
+		{"right edge", "ab", 9, 2, "0123456789\n0123456789\n012345678ab"},
+	}
+	for _, tt := range tests {
+		got := ansi.Strip(overlayAt(tt.fg, bg, tt.x, tt.y, 3))
+		if got != tt.want {
+			t.Errorf("%s: overlayAt =\n%s\nwant\n%s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOverlayCenterPadsHeight(t *testing.T) {
+	got := overlayCenter("x", "", 5, 3)
+	if want := "\n  x\n"; ansi.Strip(got) != want {
+		t.Errorf("overlayCenter = %q, want %q", ansi.Strip(got), want)
+	}
+}
+
+// FuzzOverlayCenter overlays arbitrary text on a screen of fixed width.
+// Malformed escape sequences must not panic; well-formed styled text must
+// leave the screen its shape.
+func FuzzOverlayCenter(f *testing.F) {
+	f.Add("box", "background line", 20, 5)
+	f.Add("日本\n語", "テキスト\nテキスト", 8, 2)
+	f.Add("\x1b[1mbold\x1b[m\nx", "\x1b[31mred\x1b]8;;url\x07link\x1b]8;;\x07", 12, 3)
+	f.Add("\x1b[", "\x1b]unterminated", 4, 1)
+	f.Add("日末語", "テキスト", 8, 1) // wide character cut at the right edge
+	f.Fuzz(func(t *testing.T, fg, bg string, width, height int) {
+		if width <= 0 || width > 200 || height <= 0 || height > 50 {
+			t.Skip()
+		}
+		overlayCenter(fg, bg, width, height)
+
+		// Restyle printable text from both sides so their escape sequences
+		// are well formed.
+		printable := func(s string) string {
+			return strings.Map(func(r rune) rune {
+				if r == '\n' || unicode.IsPrint(r) {
+					return r
+				}
+				return -1
+			}, strings.ToValidUTF8(ansi.Strip(s), ""))
+		}
+		bold := lipgloss.NewStyle().Bold(true)
+		red := lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+		fg = bold.Render(printable(fg))
+		var screen []string
+		for _, line := range strings.Split(printable(bg), "\n") {
+			line = ansi.Truncate(red.Render(line), width, "")
+			screen = append(screen, line+strings.Repeat(" ", width-lipgloss.Width(line)))
+		}
+		if len(screen) > height {
+			screen = screen[:height]
+		}
+		for len(screen) < height {
+			screen = append(screen, strings.Repeat(" ", width))
+		}
+
+		out := overlayCenter(fg, strings.Join(screen, "\n"), width, height)
+
+		lines := strings.Split(out, "\n")
+		if len(lines) != height {
+			t.Fatalf("overlay has %d lines, want %d", len(lines), height)
+		}
+		if lipgloss.Width(fg) > width || lipgloss.Height(fg) > height {
+			return
+		}
+		for i, line := range lines {
+			if w := lipgloss.Width(line); w != width {
+				t.Errorf("line %d is %d wide, want %d: %q", i, w, width, line)
+			}
+		}
+	})
+}
+
+// benchApp returns an App sized to a large terminal, showing servers
+// servers with sitesPerServer sites each (expanded when expand is set)
+// and outputLines lines of output.
+func benchApp(b *testing.B, servers, sitesPerServer, outputLines int, expand bool) App {
+	b.Helper()
+	dir := b.TempDir()
+	b.Setenv("HOME", dir)
+	b.Setenv("XDG_CONFIG_HOME", dir)
+
+	cfg := config.Default()
+	cfg.UI.TourSeen = true
+	m := NewApp(cfg, "", LaunchNone)
+
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 220, Height: 60})
+	m = model.(App)
+
+	list := make([]forge.Server, servers)
+	for i := range list {
+		list[i] = forge.Server{ID: int64(i + 1), Name: fmt.Sprintf("web-%03d", i+1), IPAddress: fmt.Sprintf("10.0.%d.%d", i/250, i%250), IsReady: true}
+	}
+	model, _ = m.Update(serversLoadedMsg{servers: list})
+	m = model.(App)
+
+	for _, srv := range list {
+		sites := make([]forge.Site, sitesPerServer)
+		for j := range sites {
+			sites[j] = forge.Site{ID: srv.ID*1000 + int64(j), Name: fmt.Sprintf("site-%d.%s.example.com", j, srv.Name), Status: "installed"}
+		}
+		m.treePanel = m.treePanel.SetSites(srv.ID, sites)
+	}
+	if expand {
+		m.treePanel, _ = m.treePanel.ExpandAll()
+	}
+
+	if outputLines > 0 {
+		var out strings.Builder
+		for i := range outputLines {
+			fmt.Fprintf(&out, "%6d  Installing dependencies from lock file (including require-dev)\n", i)
+		}
+		m.outputPanel = m.outputPanel.SetContent("Deployment output", out.String())
+	}
+	return m
+}
+
+// BenchmarkView measures rendering the whole screen for increasingly
+// large accounts and outputs.
+func BenchmarkView(b *testing.B) {
+	cases := []struct {
+		name                   string
+		servers, sites, output int
+		expand                 bool
+	}{
+		{"empty", 0, 0, 0, false},
+		{"200 servers", 200, 10, 0, false},
+		{"2000 sites expanded", 200, 10, 0, true},
+		{"50k output lines", 1, 1, 50_000, false},
+		{"everything", 200, 10, 50_000, true},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			m := benchApp(b, c.servers, c.sites, c.output, c.expand)
+			b.ReportAllocs()
+			for b.Loop() {
+				m.View()
+			}
+		})
+	}
+}
+
+// BenchmarkOutputContent measures loading a large output, as polling a
+// running deployment does every few seconds.
+func BenchmarkOutputContent(b *testing.B) {
+	var out strings.Builder
+	for i := range 50_000 {
+		fmt.Fprintf(&out, "%6d  Installing dependencies from lock file (including require-dev)\n", i)
+	}
+	content := out.String()
+	p := panels.NewOutputPanel()
+	b.ReportAllocs()
+	for b.Loop() {
+		p.StreamContent("Deployment output", content)
+	}
+}
+
+func TestDisabledTabs(t *testing.T) {
+	d := NewDetailController().SetDisabledTabs([]string{"Firewall", "sshkeys"})
+	if d.TabEnabled(7, false) || d.TabEnabled(9, false) {
+		t.Error("firewall or SSH keys tab enabled, want disabled")
+	}
+	if !d.TabEnabled(7, true) || !d.TabEnabled(0, false) {
+		t.Error("logs or info tab disabled, want enabled")
+	}
+
+	bar := ansi.Strip(d.renderServerTabBar(200, ""))
+	if strings.Contains(bar, "Firewall") || strings.Contains(bar, "SSH Keys") || !strings.Contains(bar, "8:Jobs") || !strings.Contains(bar, "b:Backups") {
+		t.Errorf("server tab bar = %q, want it without the disabled tabs", bar)
+	}
+
+	// Each tab's ID must be one the config accepts.
+	for _, tabs := range [][]detailTab{siteTabs, serverTabs} {
+		for _, tab := range tabs {
+			if tab.id != "" && !slices.Contains(config.TabIDs, tab.id) {
+				t.Errorf("tab %s has ID %q, which is not in config.TabIDs", tab.name, tab.id)
+			}
+		}
+	}
+}
+
+func TestTabLoadingMark(t *testing.T) {
+	srv := &forge.Server{ID: 1}
+	d := NewDetailController()
+	d.deploymentsPanel = panels.NewDeploymentsPanel(nil, 1, 10)
+	site := &forge.Site{ID: 10}
+
+	bar := ansi.Strip(d.renderTabBar(200, d.loadingMark(d.ActivePanel(srv, site, ScreenDetail))))
+	if !strings.Contains(bar, "1:Deploy "+theme.SpinnerFrames[0]) {
+		t.Errorf("tab bar while loading = %q, want a spinner after 1:Deploy", bar)
+	}
+	if d.SetReducedMotion(true).loadingMark(d.deploymentsPanel) != "…" {
+		t.Error("the mark with reduced motion isn't the still ellipsis")
+	}
+
+	d.deploymentsPanel, _ = updatePanel(d.deploymentsPanel, panels.DeploymentsLoadedMsg{})
+	if d.Loading(srv, site, ScreenDetail) {
+		t.Error("still loading once the deployments are in")
+	}
+	if view := ansi.Strip(d.deploymentsPanel.View(80, 10, false)); !strings.Contains(view, "Deployments (0)") {
+		t.Errorf("loaded deployments panel = %q, want the count in its title", view)
+	}
+}
+
+func TestDeploymentsSiteHeader(t *testing.T) {
+	p := panels.NewDeploymentsPanel(nil, 1, 10)
+	if view := ansi.Strip(p.View(80, 10, false)); !strings.Contains(view, "quick deploy off") {
+		t.Errorf("deployments panel = %q, want quick deploy off in its title", view)
+	}
+	p = p.SetSite(&forge.Site{QuickDeploy: true, DeploymentURL: "https://forge.example/deploy?token=abc"})
+	view := ansi.Strip(p.View(80, 10, false))
+	if !strings.Contains(view, "quick deploy on") {
+		t.Errorf("deployments panel = %q, want quick deploy on in its title", view)
+	}
+	if !strings.Contains(view, "https://forge.example/deploy?token=abc") {
+		t.Errorf("deployments panel = %q, want the trigger URL under its title", view)
+	}
+}
+
+func TestConfigWarningsToast(t *testing.T) {
+	if cmd := configWarningsToast(&config.Config{}); cmd != nil {
+		t.Fatal("got a toast with no warnings")
+	}
+	cfg := &config.Config{Warnings: []string{`unknown key "ui.them"`, `unknown key "forge.api"`}}
+	msg := configWarningsToast(cfg)().(toastMsg)
+	if !msg.isError {
+		t.Error("warnings toast is not an error toast")
+	}
+	want := `2 config warnings: unknown key "ui.them"; unknown key "forge.api"`
+	if msg.message != want {
+		t.Errorf("toast = %q, want %q", msg.message, want)
+	}
+}
+
+func TestSiteInfoEditKeys(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	cfg := config.Default()
+	cfg.UI.TourSeen = true
+	m := NewApp(cfg, "", LaunchNone)
+	m.selectedSrv = &forge.Server{ID: 1, Name: "web"}
+	m.selectedSite = &forge.Site{ID: 10, Name: "example.com"}
+
+	// Site nodes in the tree no longer take R and W.
+	model, _ := m.handleTreeKey(tea.KeyPressMsg{Code: 'R', Text: "R"})
+	if model.(App).dialogs.InputActive() {
+		t.Error("R on a site in the tree opened a prompt")
+	}
+
+	m.nav = m.nav.FocusPanel(FocusDetail)
+	model, _ = m.handleDetailKey(tea.KeyPressMsg{Code: '0', Text: "0"})
+	m = model.(App)
+	if _, ok := m.detail.ActivePanel(m.selectedSrv, m.selectedSite, m.nav.Top()).(panels.SiteInfo); !ok {
+		t.Fatal("0 with a site selected didn't show the site info tab")
+	}
+	for _, k := range []string{"R", "W"} {
+		model, _ = m.handleDetailKey(tea.KeyPressMsg{Code: rune(k[0]), Text: k})
+		if !model.(App).dialogs.InputActive() {
+			t.Errorf("%s on the site info tab didn't open a prompt", k)
+		}
+	}
+}
+
+// TestStaleTabLoadDropped checks a tab's load that finishes after the user
+// has moved to another server lands in the first server's panel, not the
+// one on screen.
+func TestStaleTabLoadDropped(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	cfg := config.Default()
+	cfg.UI.TourSeen = true
+	m := NewApp(cfg, "", LaunchNone)
+	daemonsOf := func(m App) string {
+		return ansi.Strip(m.detail.daemonsPanel.View(120, 10, false))
+	}
+	loaded := func(serverID int64, command string) panelLoadMsg {
+		return panelLoadMsg{
+			key: newPanelKey(6, serverID, 0),
+			msg: panels.DaemonsLoadedMsg{Daemons: []forge.Daemon{{ID: serverID, Command: command, Status: "installed"}}},
+		}
+	}
+
+	// Open server 1's daemons, then server 2's before server 1's load
+	// comes back.
+	model, _ := m.initTabPanel(6, 1, 0)
+	model, _ = model.(App).initTabPanel(6, 2, 0)
+	model, _ = model.Update(loaded(1, "server-one-worker"))
+	m = model.(App)
+	if view := daemonsOf(m); strings.Contains(view, "server-one-worker") {
+		t.Fatalf("server 1's daemons are showing for server 2:\n%s", view)
+	}
+
+	model, _ = m.Update(loaded(2, "server-two-worker"))
+	m = model.(App)
+	if view := daemonsOf(m); !strings.Contains(view, "server-two-worker") {
+		t.Errorf("server 2's daemons aren't showing:\n%s", view)
+	}
+
+	// Going back to server 1 shows the data loaded for it, without
+	// loading again.
+	model, cmd := m.initTabPanel(6, 1, 0)
+	m = model.(App)
+	if view := daemonsOf(m); !strings.Contains(view, "server-one-worker") || strings.Contains(view, "server-two-worker") {
+		t.Errorf("server 1's daemons after going back:\n%s", view)
+	}
+	if cmd != nil {
+		t.Error("going back to a loaded panel loaded it again")
+	}
+}
+
+func TestDomainsBulkPasteKey(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	cfg := config.Default()
+	cfg.UI.TourSeen = true
+	m := NewApp(cfg, "", LaunchNone)
+	m.selectedSrv = &forge.Server{ID: 1, Name: "web"}
+	m.selectedSite = &forge.Site{ID: 10, Name: "example.com"}
+	m.detail.activeTab = 9
+	m.detail.domainsPanel = panels.NewDomainsPanel(nil, 1, 10, "example.com", nil)
+	m.nav = m.nav.FocusPanel(FocusDetail)
+
+	model, _ := m.Update(tea.KeyPressMsg{Code: 'p', Text: "p"})
+	m = model.(App)
+	if !m.dialogs.InputActive() || m.dialogs.input.ID != "bulk-domains" {
+		t.Error("p on the domains tab didn't open the bulk alias prompt")
+	}
+}
+
+func TestReservedNicknameRejected(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	cfg := config.Default()
+	cfg.UI.TourSeen = true
+	m := NewApp(cfg, "", LaunchNone)
+	m.pendingInputValue = "web\nexample.com"
+	model, _ := m.handleInputResult(components.InputResult{ID: "set-nickname", Value: "deploy"})
+	m = model.(App)
+	if _, ok := m.config.LookupNickname("deploy"); ok {
+		t.Error("a subcommand was set as a nickname")
+	}
+	if !m.toastIsErr {
+		t.Errorf("toast = %q, want an error", m.toast)
+	}
+}
+
+func TestTabResultOpensOutput(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	cfg := config.Default()
+	cfg.UI.TourSeen = true
+	m := NewApp(cfg, "", LaunchNone)
+
+	// The detail controller handles the jobs panel's result and asks the
+	// app to show the output.
+	model, cmd := m.Update(panels.JobOutputMsg{Command: "php artisan schedule:run", Output: "ran"})
+	if cmd == nil {
+		t.Fatal("the job's output wasn't passed on")
+	}
+	show, ok := cmd().(showOutputMsg)
+	if !ok {
+		t.Fatal("the job's output didn't become a showOutputMsg")
+	}
+	model, _ = model.Update(show)
+	m = model.(App)
+	if m.nav.Top() != ScreenOutput {
+		t.Errorf("screen = %v, want the output screen", m.nav.Top())
+	}
+	if view := ansi.Strip(m.outputPanel.View(80, 10, false)); !strings.Contains(view, "ran") {
+		t.Errorf("output panel = %q, want the job's output", view)
+	}
+}
@@ -0,0 +1,216 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+)
+
+// bulkConcurrency caps the number of servers acted on at once.
+const bulkConcurrency = 4
+
+// bulkConfirmListMax is how many server names the confirmation lists
+// before summarising the rest.
+const bulkConfirmListMax = 8
+
+// bulkResultMsg is sent when a bulk action has run on every target server.
+type bulkResultMsg struct {
+	action string
+	total  int
+	failed []string // "server: error" for each failure
+}
+
+// bulkActionLabel describes a parsed bulk action for prompts and toasts.
+// Valid actions are "reboot", "ssh-key" and "firewall:<set>".
+func (m App) bulkActionLabel(action string) (string, error) {
+	switch {
+	case action == "reboot":
+		return "Reboot", nil
+	case action == "ssh-key":
+		if m.config.Forge.DefaultSSHKey == "" {
+			return "", fmt.Errorf("no default SSH key configured (ctrl+o to set)")
+		}
+		return "Install default SSH key on", nil
+	case strings.HasPrefix(action, "firewall:"):
+		set := strings.TrimPrefix(action, "firewall:")
+		if _, ok := m.config.FirewallSet(set); !ok {
+			return "", fmt.Errorf("unknown firewall set %q", set)
+		}
+		return fmt.Sprintf("Apply firewall set %q to", set), nil
+	}
+	return "", fmt.Errorf("unknown action %q (reboot, ssh-key, firewall:<set>)", action)
+}
+
+// bulkTargets returns the servers a bulk action on scope runs on and how
+// to describe them. scope is a tag, or "workspace:<name>" for the servers
+// of the selected workspace.
+func (m App) bulkTargets(scope string) ([]forge.Server, string) {
+	if name, ok := strings.CutPrefix(scope, "workspace:"); ok {
+		return m.treePanel.WorkspaceServers(), fmt.Sprintf("in workspace %q", name)
+	}
+	return m.treePanel.ServersWithTag(scope), fmt.Sprintf("tagged %q", scope)
+}
+
+// startBulk begins a bulk operation on the servers in scope (see
+// bulkTargets) by asking which action to run.
+func (m App) startBulk(scope string) (tea.Model, tea.Cmd) {
+	servers, desc := m.bulkTargets(scope)
+	if len(servers) == 0 {
+		m.toast = "No servers " + desc
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	m.pendingInputValue = scope
+	label := fmt.Sprintf("Action for %d server(s) %s:", len(servers), desc)
+	m.dialogs = m.dialogs.Pick(components.NewPicker("bulk-action", label, m.bulkActionOptions()))
+	return m, nil
+}
+
+// bulkActionOptions lists the bulk actions available with the current
+// config: the default SSH key and each firewall set are only offered when
+// configured.
+func (m App) bulkActionOptions() []components.PickerOption {
+	options := []components.PickerOption{{Label: "Reboot", Value: "reboot"}}
+	if m.config.Forge.DefaultSSHKey != "" {
+		options = append(options, components.PickerOption{
+			Label: "Install default SSH key", Detail: m.config.Forge.DefaultSSHKey, Value: "ssh-key",
+		})
+	}
+	for _, name := range m.config.FirewallSetNames() {
+		ports, _ := m.config.FirewallSet(name)
+		options = append(options, components.PickerOption{
+			Label: "Apply firewall set " + name, Detail: joinPorts(ports), Value: "firewall:" + name,
+		})
+	}
+	return options
+}
+
+// tagOptions lists every tag on servers with how many servers carry it.
+func tagOptions(servers []forge.Server) []components.PickerOption {
+	counts := make(map[string]int)
+	var names []string
+	for _, srv := range servers {
+		for _, tag := range srv.TagNames() {
+			if counts[tag] == 0 {
+				names = append(names, tag)
+			}
+			counts[tag]++
+		}
+	}
+	sort.Strings(names)
+
+	options := make([]components.PickerOption, 0, len(names))
+	for _, name := range names {
+		options = append(options, components.PickerOption{
+			Label: name, Detail: fmt.Sprintf("%d server(s)", counts[name]),
+		})
+	}
+	return options
+}
+
+// joinPorts formats a firewall set's ports for display.
+func joinPorts(ports []int) string {
+	parts := make([]string, len(ports))
+	for i, p := range ports {
+		parts[i] = strconv.Itoa(p)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// confirmBulk shows a summary of the servers a bulk action will touch.
+func (m App) confirmBulk(scope, action string) (tea.Model, tea.Cmd) {
+	label, err := m.bulkActionLabel(action)
+	if err != nil {
+		m.pendingInputValue = ""
+		m.toast = err.Error()
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	servers, desc := m.bulkTargets(scope)
+
+	lines := []string{fmt.Sprintf("%s %d server(s) %s?", label, len(servers), desc), ""}
+	for i, srv := range servers {
+		if i == bulkConfirmListMax {
+			lines = append(lines, fmt.Sprintf("…and %d more", len(servers)-i))
+			break
+		}
+		lines = append(lines, srv.Name)
+	}
+
+	m.pendingInputValue = scope + "\n" + action
+	m.dialogs = m.dialogs.Confirm("bulk-run", strings.Join(lines, "\n"))
+	return m, nil
+}
+
+// runBulk returns a command that runs action on every server in scope.
+func (m App) runBulk(scope, action string) (App, tea.Cmd) {
+	servers, desc := m.bulkTargets(scope)
+	label, err := m.bulkActionLabel(action)
+	if err == nil && len(servers) == 0 {
+		err = fmt.Errorf("no servers %s", desc)
+	}
+
+	var keyName, keyContent string
+	if err == nil && action == "ssh-key" {
+		keyName, keyContent, err = m.defaultSSHKey()
+	}
+	if err != nil {
+		m.toast = err.Error()
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+
+	var rules []forge.FirewallRule
+	if set, ok := strings.CutPrefix(action, "firewall:"); ok {
+		ports, _ := m.config.FirewallSet(set)
+		rules = panels.FirewallSetRules(set, ports)
+	}
+
+	client := m.forge
+	m.toast = fmt.Sprintf("%s %d server(s)...", label, len(servers))
+	m.toastIsErr = false
+	return m, func() tea.Msg {
+		ctx := context.Background()
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, bulkConcurrency)
+		result := bulkResultMsg{action: action, total: len(servers)}
+
+		for _, srv := range servers {
+			wg.Add(1)
+			go func(srv forge.Server) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				var err error
+				switch {
+				case action == "reboot":
+					err = client.Servers.Reboot(ctx, srv.ID)
+				case action == "ssh-key":
+					_, err = client.SSHKeys.Create(ctx, srv.ID, keyName, keyContent, "forge")
+				case rules != nil:
+					_, _, err = client.Firewall.CreateMissing(ctx, srv.ID, rules)
+				}
+				if err != nil {
+					mu.Lock()
+					result.failed = append(result.failed, fmt.Sprintf("%s: %v", srv.Name, err))
+					mu.Unlock()
+				}
+			}(srv)
+		}
+		wg.Wait()
+
+		return result
+	}
+}
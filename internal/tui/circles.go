@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// handleCirclesKey handles keys specific to the circles panel tab.
+func (m App) handleCirclesKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		circles := m.detail.circlesPanel.Circles()
+		if len(circles) == 0 {
+			m.toast = "No circles have access to this server"
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		if c := m.detail.circlesPanel.SelectedCircle(); c != nil && len(circles) == 1 {
+			return m.promptCircleInvite(c.ID, c.Name)
+		}
+		options := make([]components.PickerOption, len(circles))
+		for i, c := range circles {
+			options[i] = components.PickerOption{
+				Label:  c.Name,
+				Detail: fmt.Sprintf("%d member(s)", len(c.Members)),
+				Value:  strconv.FormatInt(c.ID, 10),
+			}
+		}
+		m.dialogs = m.dialogs.Pick(components.NewPicker("circle-invite", "Invite to circle:", options))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		c, member := m.detail.circlesPanel.SelectedCircle(), m.detail.circlesPanel.SelectedMember()
+		if c != nil && member != nil {
+			who := member.Name
+			if who == "" {
+				who = member.Email
+			}
+			m.dialogs = m.dialogs.Confirm("remove-circle-member", fmt.Sprintf("Remove %s from %s?", who, c.Name))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// promptCircleInvite asks for the email address to invite to a circle.
+func (m App) promptCircleInvite(circleID int64, name string) (tea.Model, tea.Cmd) {
+	m.pendingInputValue = strconv.FormatInt(circleID, 10)
+	m.dialogs = m.dialogs.Prompt(components.NewInputWide("circle-invite", fmt.Sprintf("Invite to %s (email):", name), "name@example.com").Remember())
+	return m, nil
+}
+
+// pickedInviteCircle continues an invitation once its circle is picked.
+func (m App) pickedInviteCircle(value string) (tea.Model, tea.Cmd) {
+	for _, c := range m.detail.circlesPanel.Circles() {
+		if strconv.FormatInt(c.ID, 10) == value {
+			return m.promptCircleInvite(c.ID, c.Name)
+		}
+	}
+	return m, nil
+}
+
+// inviteToCircle invites email to the circle chosen before the prompt.
+func (m App) inviteToCircle(email string) (tea.Model, tea.Cmd) {
+	id := m.pendingInputValue
+	m.pendingInputValue = ""
+	if !strings.Contains(email, "@") {
+		m.toast = fmt.Sprintf("%q isn't an email address", email)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	for _, c := range m.detail.circlesPanel.Circles() {
+		if strconv.FormatInt(c.ID, 10) == id {
+			return m, m.detail.circlesPanel.Invite(c, email)
+		}
+	}
+	return m, nil
+}
@@ -74,7 +74,7 @@ func (c Confirm) View(width, height int) string {
 		boxWidth = width - 4
 	}
 
-	box := dialogBox.Width(boxWidth).Render(inner)
+	box := dialogBox().Width(boxWidth).Render(inner)
 
 	return box
 }
@@ -0,0 +1,345 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// FormResult is sent when the user submits a form that passed validation.
+// Values are keyed by FormField.Key.
+type FormResult struct {
+	ID     string
+	Values map[string]string
+}
+
+// Get returns the trimmed value of a field.
+func (r FormResult) Get(key string) string {
+	return strings.TrimSpace(r.Values[key])
+}
+
+// Bool returns the value of a FieldBool field.
+func (r FormResult) Bool(key string) bool {
+	return r.Values[key] == "true"
+}
+
+// Int returns the value of a field as an integer, or def when the field is
+// empty or not a number.
+func (r FormResult) Int(key string, def int) int {
+	n, err := strconv.Atoi(r.Get(key))
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+// FormCancelled is sent when the user cancels a form.
+type FormCancelled struct {
+	ID string
+}
+
+// FieldKind selects the widget used for a form field.
+type FieldKind int
+
+const (
+	FieldText   FieldKind = iota // free text
+	FieldSelect                  // one of Options, cycled with ←/→
+	FieldBool                    // yes/no, toggled with space or ←/→
+)
+
+// FormField describes one row of a form.
+type FormField struct {
+	Key         string
+	Label       string
+	Kind        FieldKind
+	Placeholder string
+
+	// Value is the initial value: text, one of Options, or "true"/"false".
+	Value   string
+	Options []string
+
+	// Secret masks a text field's value, for passwords.
+	Secret bool
+
+	// Required rejects an empty value; Validate, if set, checks the rest.
+	Required bool
+	Validate func(string) error
+}
+
+// Form is a multi-field dialog overlay. Tab/↓ and Shift+Tab/↑ move between
+// fields, Enter moves on and submits from the last field, Esc cancels.
+type Form struct {
+	Title  string
+	ID     string
+	Active bool
+
+	fields []FormField
+	inputs []textinput.Model // one per field; only text fields use theirs
+	errs   []string
+	cursor int
+}
+
+// NewForm creates a form dialog with the given fields, focusing the first.
+func NewForm(id, title string, fields ...FormField) Form {
+	f := Form{
+		Title:  title,
+		ID:     id,
+		Active: true,
+		fields: fields,
+		inputs: make([]textinput.Model, len(fields)),
+		errs:   make([]string, len(fields)),
+	}
+	for i, field := range fields {
+		switch field.Kind {
+		case FieldText:
+			ti := textinput.New()
+			ti.Placeholder = field.Placeholder
+			ti.Prompt = "  "
+			ti.CharLimit = 0
+			ti.SetWidth(36)
+			ti.SetValue(field.Value)
+			if field.Secret {
+				ti.EchoMode = textinput.EchoPassword
+			}
+			f.inputs[i] = ti
+		case FieldSelect:
+			if field.Value == "" && len(field.Options) > 0 {
+				f.fields[i].Value = field.Options[0]
+			}
+		case FieldBool:
+			if field.Value != "true" {
+				f.fields[i].Value = "false"
+			}
+		}
+	}
+	return f.focus(0)
+}
+
+// Values returns the current value of every field, keyed by FormField.Key.
+func (f Form) Values() map[string]string {
+	values := make(map[string]string, len(f.fields))
+	for i, field := range f.fields {
+		values[field.Key] = f.value(i)
+	}
+	return values
+}
+
+// Update handles key events for the form.
+func (f Form) Update(msg tea.Msg) (Form, tea.Cmd) {
+	if !f.Active {
+		return f, nil
+	}
+
+	msgKey, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return f.updateInput(msg)
+	}
+
+	switch {
+	case key.Matches(msgKey, key.NewBinding(key.WithKeys("esc"))):
+		f.Active = false
+		id := f.ID
+		return f, func() tea.Msg { return FormCancelled{ID: id} }
+	case key.Matches(msgKey, key.NewBinding(key.WithKeys("enter"))):
+		if f.cursor < len(f.fields)-1 {
+			return f.focus(f.cursor + 1), nil
+		}
+		return f.submit()
+	case key.Matches(msgKey, key.NewBinding(key.WithKeys("tab", "down"))):
+		return f.focus((f.cursor + 1) % len(f.fields)), nil
+	case key.Matches(msgKey, key.NewBinding(key.WithKeys("shift+tab", "up"))):
+		return f.focus((f.cursor + len(f.fields) - 1) % len(f.fields)), nil
+	}
+
+	if len(f.fields) == 0 {
+		return f, nil
+	}
+	field := &f.fields[f.cursor]
+	switch field.Kind {
+	case FieldSelect:
+		switch {
+		case key.Matches(msgKey, key.NewBinding(key.WithKeys("right", "l", "space"))):
+			field.Value = cycle(field.Options, field.Value, 1)
+		case key.Matches(msgKey, key.NewBinding(key.WithKeys("left", "h"))):
+			field.Value = cycle(field.Options, field.Value, -1)
+		}
+		return f, nil
+	case FieldBool:
+		if key.Matches(msgKey, key.NewBinding(key.WithKeys("space", "left", "right", "h", "l"))) {
+			field.Value = strconv.FormatBool(field.Value != "true")
+		}
+		return f, nil
+	}
+	return f.updateInput(msg)
+}
+
+// updateInput passes msg to the focused text field.
+func (f Form) updateInput(msg tea.Msg) (Form, tea.Cmd) {
+	if len(f.fields) == 0 || f.fields[f.cursor].Kind != FieldText {
+		return f, nil
+	}
+	var cmd tea.Cmd
+	f.inputs[f.cursor], cmd = f.inputs[f.cursor].Update(msg)
+	f.errs[f.cursor] = ""
+	return f, cmd
+}
+
+// submit validates every field and, if they all pass, closes the form
+// with a FormResult. Otherwise the first invalid field is focused.
+func (f Form) submit() (Form, tea.Cmd) {
+	first := -1
+	for i, field := range f.fields {
+		f.errs[i] = ""
+		value := strings.TrimSpace(f.value(i))
+		switch {
+		case value == "" && field.Required:
+			f.errs[i] = "required"
+		case value != "" && field.Validate != nil:
+			if err := field.Validate(value); err != nil {
+				f.errs[i] = err.Error()
+			}
+		}
+		if f.errs[i] != "" && first < 0 {
+			first = i
+		}
+	}
+	if first >= 0 {
+		return f.focus(first), nil
+	}
+
+	f.Active = false
+	result := FormResult{ID: f.ID, Values: f.Values()}
+	return f, func() tea.Msg { return result }
+}
+
+// focus moves the cursor to field i and focuses its text input, if any.
+func (f Form) focus(i int) Form {
+	if i < 0 || i >= len(f.fields) {
+		return f
+	}
+	f.cursor = i
+	for j := range f.inputs {
+		if f.fields[j].Kind != FieldText {
+			continue
+		}
+		if j == i {
+			f.inputs[j].Focus()
+		} else {
+			f.inputs[j].Blur()
+		}
+	}
+	return f
+}
+
+// value returns the raw value of field i.
+func (f Form) value(i int) string {
+	if f.fields[i].Kind == FieldText {
+		return f.inputs[i].Value()
+	}
+	return f.fields[i].Value
+}
+
+// cycle returns the option step places from current, wrapping around.
+func cycle(options []string, current string, step int) string {
+	if len(options) == 0 {
+		return current
+	}
+	idx := 0
+	for i, o := range options {
+		if o == current {
+			idx = i
+			break
+		}
+	}
+	return options[(idx+step+len(options))%len(options)]
+}
+
+// View renders the form as a box suitable for overlay.
+// Returns an empty string if the form is not active.
+func (f Form) View(width, height int) string {
+	if !f.Active {
+		return ""
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(theme.ColorMuted)
+	activeLabelStyle := lipgloss.NewStyle().Foreground(theme.ColorPrimary).Bold(true)
+	errStyle := lipgloss.NewStyle().Foreground(theme.ColorError)
+
+	lines := []string{"", dialogText.Render(f.Title), ""}
+	for i, field := range f.fields {
+		label := labelStyle.Render(field.Label)
+		if i == f.cursor {
+			label = activeLabelStyle.Render(field.Label)
+		}
+		lines = append(lines, label)
+
+		switch field.Kind {
+		case FieldText:
+			lines = append(lines, f.inputs[i].View())
+		case FieldSelect:
+			lines = append(lines, "  "+renderOptions(field.Options, field.Value, i == f.cursor))
+		case FieldBool:
+			box := "[ ]"
+			if field.Value == "true" {
+				box = "[x]"
+			}
+			lines = append(lines, "  "+dialogText.Render(box))
+		}
+		if f.errs[i] != "" {
+			lines = append(lines, "  "+errStyle.Render(f.errs[i]))
+		}
+	}
+	lines = append(lines, "", dialogHint.Render("tab next  ←/→ change  enter submit  esc cancel"), "")
+
+	inner := lipgloss.JoinVertical(lipgloss.Left, lines...)
+
+	// Size the box to fit the content with padding.
+	boxWidth := lipgloss.Width(inner) + 4
+	if boxWidth < 44 {
+		boxWidth = 44
+	}
+	if boxWidth > width-4 {
+		boxWidth = width - 4
+	}
+
+	return dialogBox().Width(boxWidth).Render(inner)
+}
+
+// renderOptions renders a select field's options with the chosen one
+// highlighted.
+func renderOptions(options []string, current string, focused bool) string {
+	chosen := dialogText
+	if focused {
+		chosen = chosen.Foreground(theme.ColorPrimary)
+	}
+	var parts []string
+	for _, o := range options {
+		if o == current {
+			parts = append(parts, chosen.Render("‹"+o+"›"))
+		} else {
+			parts = append(parts, dialogHint.Render(" "+o+" "))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// IntRange returns a validator accepting whole numbers from lo to hi.
+func IntRange(lo, hi int) func(string) error {
+	return func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		if n < lo || n > hi {
+			return fmt.Errorf("must be between %d and %d", lo, hi)
+		}
+		return nil
+	}
+}
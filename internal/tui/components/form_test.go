@@ -0,0 +1,147 @@
+package components
+
+import (
+	"maps"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// typeText sends s to f one key at a time.
+func typeText(f Form, s string) Form {
+	for _, r := range s {
+		f, _ = f.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return f
+}
+
+func press(f Form, code rune, mod tea.KeyMod) (Form, tea.Cmd) {
+	return f.Update(tea.KeyPressMsg{Code: code, Mod: mod})
+}
+
+func testForm() Form {
+	return NewForm("test", "Test",
+		FormField{Key: "name", Label: "Name", Required: true},
+		FormField{Key: "port", Label: "Port", Validate: IntRange(1, 65535)},
+		FormField{Key: "password", Label: "Password", Secret: true},
+		FormField{Key: "type", Label: "Type", Kind: FieldSelect, Options: []string{"allow", "deny"}},
+		FormField{Key: "force", Label: "Force", Kind: FieldBool},
+	)
+}
+
+func TestFormValidationBlocksSubmit(t *testing.T) {
+	tests := []struct {
+		name      string
+		nameValue string
+		portValue string
+		wantField int
+		wantErr   string
+	}{
+		{"required field empty", "", "22", 0, "required"},
+		{"validator fails", "ssh", "99999", 1, "must be between 1 and 65535"},
+		{"not a number", "ssh", "abc", 1, "must be a number"},
+	}
+	for _, tt := range tests {
+		f := testForm()
+		f = typeText(f, tt.nameValue)
+		f, _ = press(f, tea.KeyTab, 0)
+		f = typeText(f, tt.portValue)
+
+		for f.cursor < len(f.fields)-1 {
+			f, _ = press(f, tea.KeyTab, 0)
+		}
+		f, cmd := press(f, tea.KeyEnter, 0)
+		if cmd != nil {
+			t.Errorf("%s: submitted with an invalid field", tt.name)
+		}
+		if !f.Active {
+			t.Errorf("%s: form closed with an invalid field", tt.name)
+		}
+		if f.cursor != tt.wantField {
+			t.Errorf("%s: cursor = %d, want the invalid field %d", tt.name, f.cursor, tt.wantField)
+		}
+		if f.errs[tt.wantField] != tt.wantErr {
+			t.Errorf("%s: error = %q, want %q", tt.name, f.errs[tt.wantField], tt.wantErr)
+		}
+	}
+}
+
+func TestFormFocusMoves(t *testing.T) {
+	f := testForm()
+	steps := []struct {
+		code rune
+		mod  tea.KeyMod
+		want int
+	}{
+		{tea.KeyTab, 0, 1},
+		{tea.KeyTab, 0, 2},
+		{tea.KeyTab, tea.ModShift, 1},
+		{tea.KeyTab, tea.ModShift, 0},
+		{tea.KeyTab, tea.ModShift, 4}, // wraps to the last field
+		{tea.KeyTab, 0, 0},            // and back to the first
+		{tea.KeyDown, 0, 1},
+		{tea.KeyUp, 0, 0},
+	}
+	for i, s := range steps {
+		f, _ = press(f, s.code, s.mod)
+		if f.cursor != s.want {
+			t.Fatalf("step %d: cursor = %d, want %d", i, f.cursor, s.want)
+		}
+	}
+
+	// Only the focused text field takes typing.
+	f = typeText(f, "web")
+	f, _ = press(f, tea.KeyTab, 0)
+	f = typeText(f, "22")
+	if v := f.Values(); v["name"] != "web" || v["port"] != "22" {
+		t.Errorf("values = %v, want each field to keep what was typed into it", v)
+	}
+}
+
+func TestFormMasksSecretFields(t *testing.T) {
+	f := testForm()
+	f, _ = press(f, tea.KeyTab, 0)
+	f, _ = press(f, tea.KeyTab, 0)
+	f = typeText(f, "hunter2")
+
+	view := ansi.Strip(f.View(80, 40))
+	if strings.Contains(view, "hunter2") || !strings.Contains(view, "*******") {
+		t.Errorf("view = %q, want the secret field's value masked", view)
+	}
+	if f.Values()["password"] != "hunter2" {
+		t.Errorf("password = %q, want the typed value", f.Values()["password"])
+	}
+}
+
+func TestFormSubmitCarriesEveryValue(t *testing.T) {
+	f := testForm()
+	f = typeText(f, "ssh")
+	f, _ = press(f, tea.KeyEnter, 0)
+	f = typeText(f, "22")
+	f, _ = press(f, tea.KeyEnter, 0)
+	f = typeText(f, "s3cret")
+	f, _ = press(f, tea.KeyEnter, 0)
+	f, _ = press(f, tea.KeyRight, 0)
+	f, _ = press(f, tea.KeyEnter, 0)
+	f, _ = press(f, tea.KeySpace, 0)
+	f, cmd := press(f, tea.KeyEnter, 0)
+	if cmd == nil {
+		t.Fatal("Enter on the last field didn't submit")
+	}
+	if f.Active {
+		t.Error("form still open after submitting")
+	}
+	result, ok := cmd().(FormResult)
+	if !ok {
+		t.Fatal("submitting didn't produce a FormResult")
+	}
+	want := map[string]string{"name": "ssh", "port": "22", "password": "s3cret", "type": "deny", "force": "true"}
+	if result.ID != "test" || !maps.Equal(result.Values, want) {
+		t.Errorf("result = %s %v, want test %v", result.ID, result.Values, want)
+	}
+	if result.Int("port", 0) != 22 || !result.Bool("force") {
+		t.Errorf("Int(port) = %d, Bool(force) = %v", result.Int("port", 0), result.Bool("force"))
+	}
+}
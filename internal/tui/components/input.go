@@ -18,12 +18,26 @@ type InputCancelled struct {
 	ID string
 }
 
+// InputPasted carries the clipboard contents read for an input dialog's
+// ctrl+v. Route it back to the dialog like a key press.
+type InputPasted struct {
+	msg tea.Msg
+}
+
 // Input is a text input modal overlay using the bubbles textinput widget.
+// Besides textinput's emacs-style editing keys (ctrl+a/e, ctrl+b/f,
+// alt+b/f, ctrl+w, ctrl+k, ctrl+u), up and down recall the values
+// submitted before to inputs with the same ID; see Remember.
 type Input struct {
 	Label  string
 	ID     string
 	Active bool
 	input  textinput.Model
+
+	remember bool
+	history  []string // oldest first
+	histIdx  int      // index into history, len(history) for the draft
+	draft    string   // the value being typed before recalling history
 }
 
 // NewInput creates a new text input dialog with the given label and placeholder.
@@ -59,15 +73,64 @@ func NewInputWide(id, label, placeholder string) Input {
 	}
 }
 
+// Remember marks the input's submitted values to be kept for recall by
+// the next input with the same ID. Don't use it for secrets.
+func (i Input) Remember() Input {
+	i.remember = true
+	return i
+}
+
+// Remembers reports whether the input's submitted values are kept.
+func (i Input) Remembers() bool {
+	return i.remember
+}
+
+// SetHistory sets the values up and down recall, oldest first.
+func (i Input) SetHistory(values []string) Input {
+	i.history = values
+	i.histIdx = len(values)
+	return i
+}
+
+// recall shows the history entry at idx, or the draft past the newest.
+func (i Input) recall(idx int) Input {
+	if idx < 0 || idx > len(i.history) || idx == i.histIdx {
+		return i
+	}
+	if i.histIdx == len(i.history) {
+		i.draft = i.input.Value()
+	}
+	i.histIdx = idx
+	if idx == len(i.history) {
+		i.input.SetValue(i.draft)
+	} else {
+		i.input.SetValue(i.history[idx])
+	}
+	i.input.CursorEnd()
+	return i
+}
+
 // Update handles key events for the input dialog.
-// Enter submits, Esc cancels, other keys are delegated to the textinput.
+// Enter submits, Esc cancels, up/down recall history, ctrl+v pastes and
+// other keys are delegated to the textinput.
 func (i Input) Update(msg tea.Msg) (Input, tea.Cmd) {
 	if !i.Active {
 		return i, nil
 	}
 
+	if pasted, ok := msg.(InputPasted); ok {
+		msg = pasted.msg
+	}
 	if msg, ok := msg.(tea.KeyPressMsg); ok {
 		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "ctrl+p"))):
+			return i.recall(i.histIdx - 1), nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "ctrl+n"))):
+			return i.recall(i.histIdx + 1), nil
+		case key.Matches(msg, i.input.KeyMap.Paste):
+			// textinput's own paste message is private, so wrap it for the
+			// app to route back here.
+			return i, func() tea.Msg { return InputPasted{msg: textinput.Paste()} }
 		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
 			i.Active = false
 			value := i.input.Value()
@@ -100,7 +163,11 @@ func (i Input) View(width, height int) string {
 	// Build the dialog content.
 	label := dialogText.Render(i.Label)
 	inputView := i.input.View()
-	hint := dialogHint.Render("enter confirm  esc cancel")
+	hintText := "enter confirm  esc cancel"
+	if len(i.history) > 0 {
+		hintText += "  ↑/↓ history"
+	}
+	hint := dialogHint.Render(hintText)
 	inner := lipgloss.JoinVertical(lipgloss.Left, "", label, "", inputView, "", hint, "")
 
 	// Size the box to fit the content with padding.
@@ -112,7 +179,7 @@ func (i Input) View(width, height int) string {
 		boxWidth = width - 4
 	}
 
-	box := dialogBox.Width(boxWidth).Render(inner)
+	box := dialogBox().Width(boxWidth).Render(inner)
 
 	return box
 }
@@ -0,0 +1,235 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// PickerResult is sent when the user chooses an option in a picker. A
+// multi-select picker also sets Values to every checked option, in list
+// order; Value is then the first of them.
+type PickerResult struct {
+	ID     string
+	Value  string
+	Values []string
+}
+
+// PickerCancelled is sent when the user closes a picker without choosing.
+type PickerCancelled struct {
+	ID string
+}
+
+// PickerOption is one choice in a picker. Detail is shown dimmed after the
+// label and is searched too. Value defaults to Label when empty.
+type PickerOption struct {
+	Label  string
+	Detail string
+	Value  string
+}
+
+// Picker is a searchable list dialog overlay for choosing one of a fixed
+// set of values. Typing filters the list, ↑/↓ move, Enter picks, Esc
+// cancels. A multi-select picker checks options with space (ctrl+a checks
+// or clears every match) and Enter picks the checked ones.
+type Picker struct {
+	Title  string
+	ID     string
+	Active bool
+
+	options []PickerOption
+	matches []int // indexes into options that match the filter
+	cursor  int   // index into matches
+	filter  textinput.Model
+
+	multi   bool
+	checked map[int]bool // indexes into options
+}
+
+// NewPicker creates a picker listing options.
+func NewPicker(id, title string, options []PickerOption) Picker {
+	ti := textinput.New()
+	ti.Placeholder = "type to filter"
+	ti.Prompt = "/ "
+	ti.SetWidth(36)
+	ti.Focus()
+
+	p := Picker{
+		Title:   title,
+		ID:      id,
+		Active:  true,
+		options: options,
+		filter:  ti,
+	}
+	return p.refilter()
+}
+
+// NewMultiPicker creates a picker listing options, any number of which can
+// be checked and picked together.
+func NewMultiPicker(id, title string, options []PickerOption) Picker {
+	p := NewPicker(id, title, options)
+	p.multi = true
+	p.checked = make(map[int]bool)
+	return p
+}
+
+// Update handles key events for the picker.
+func (p Picker) Update(msg tea.Msg) (Picker, tea.Cmd) {
+	if !p.Active {
+		return p, nil
+	}
+
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+			p.Active = false
+			id := p.ID
+			return p, func() tea.Msg { return PickerCancelled{ID: id} }
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			if len(p.matches) == 0 {
+				return p, nil
+			}
+			p.Active = false
+			result := PickerResult{ID: p.ID, Value: p.value(p.matches[p.cursor])}
+			if p.multi {
+				for i := range p.options {
+					if p.checked[i] {
+						result.Values = append(result.Values, p.value(i))
+					}
+				}
+				if len(result.Values) == 0 {
+					result.Values = []string{result.Value}
+				}
+				result.Value = result.Values[0]
+			}
+			return p, func() tea.Msg { return result }
+		case p.multi && key.Matches(msg, key.NewBinding(key.WithKeys("space"))):
+			if len(p.matches) > 0 {
+				i := p.matches[p.cursor]
+				p.checked[i] = !p.checked[i]
+				p.cursor = min(p.cursor+1, len(p.matches)-1)
+			}
+			return p, nil
+		case p.multi && key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+a"))):
+			all := true
+			for _, i := range p.matches {
+				all = all && p.checked[i]
+			}
+			for _, i := range p.matches {
+				p.checked[i] = !all
+			}
+			return p, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "ctrl+n"))):
+			if len(p.matches) > 0 {
+				p.cursor = min(p.cursor+1, len(p.matches)-1)
+			}
+			return p, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "ctrl+p"))):
+			p.cursor = max(p.cursor-1, 0)
+			return p, nil
+		}
+	}
+
+	// Everything else edits the filter.
+	before := p.filter.Value()
+	var cmd tea.Cmd
+	p.filter, cmd = p.filter.Update(msg)
+	if p.filter.Value() != before {
+		p = p.refilter()
+	}
+	return p, cmd
+}
+
+// value returns what picking option i yields: its Value, or its Label.
+func (p Picker) value(i int) string {
+	if p.options[i].Value != "" {
+		return p.options[i].Value
+	}
+	return p.options[i].Label
+}
+
+// refilter recomputes the options matching the filter text, matching
+// every space-separated word case-insensitively against label and detail.
+func (p Picker) refilter() Picker {
+	words := strings.Fields(strings.ToLower(p.filter.Value()))
+	p.matches = p.matches[:0:0]
+	for i, opt := range p.options {
+		text := strings.ToLower(opt.Label + " " + opt.Detail)
+		ok := true
+		for _, w := range words {
+			if !strings.Contains(text, w) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			p.matches = append(p.matches, i)
+		}
+	}
+	p.cursor = 0
+	return p
+}
+
+// View renders the picker as a box suitable for overlay.
+// Returns an empty string if the picker is not active.
+func (p Picker) View(width, height int) string {
+	if !p.Active {
+		return ""
+	}
+
+	contentWidth := layout.Clamp(width-8, 20, 48)
+
+	// Leave room for the border, padding, title, filter and hint lines.
+	visible := max(height-14, 3)
+	start := layout.ScrollStart(p.cursor, visible)
+
+	lines := []string{dialogText.Render(p.Title), "", p.filter.View(), ""}
+	if len(p.matches) == 0 {
+		lines = append(lines, dialogHint.Render("  No matches"))
+	}
+	for i := start; i < len(p.matches) && i < start+visible; i++ {
+		opt := p.options[p.matches[i]]
+		label := theme.Truncate(opt.Label, contentWidth-2)
+		if p.multi {
+			box := "[ ] "
+			if p.checked[p.matches[i]] {
+				box = "[x] "
+			}
+			label = box + theme.Truncate(opt.Label, contentWidth-6)
+		}
+		detail := ""
+		if opt.Detail != "" {
+			if room := contentWidth - 3 - lipgloss.Width(label); room > 3 {
+				detail = " " + dialogHint.Render(theme.Truncate(opt.Detail, room))
+			}
+		}
+		if i == p.cursor {
+			lines = append(lines, theme.CursorStyle.Render("> ")+theme.SelectedItemStyle.Render(label)+detail)
+		} else {
+			lines = append(lines, "  "+theme.NormalItemStyle.Render(label)+detail)
+		}
+	}
+	if n := len(p.matches); n > visible {
+		lines = append(lines, dialogHint.Render(fmt.Sprintf("  %d/%d", p.cursor+1, n)))
+	}
+	hint := "↑/↓ move  enter select  esc cancel"
+	if p.multi {
+		n := 0
+		for _, c := range p.checked {
+			if c {
+				n++
+			}
+		}
+		hint = fmt.Sprintf("%d checked  space check  ctrl+a all  enter done  esc cancel", n)
+	}
+	lines = append(lines, "", dialogHint.Render(hint))
+
+	return dialogBox().Width(contentWidth + 4).Render(strings.Join(lines, "\n"))
+}
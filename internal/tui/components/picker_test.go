@@ -0,0 +1,104 @@
+package components
+
+import (
+	"slices"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func testPicker() Picker {
+	return NewPicker("server", "Server", []PickerOption{
+		{Label: "web-1", Detail: "10.0.0.1"},
+		{Label: "web-2", Detail: "10.0.0.2"},
+		{Label: "db-1", Detail: "10.0.1.1"},
+		{Label: "worker", Detail: "queue box", Value: "42"},
+	})
+}
+
+// pickerType sends s to p one key at a time.
+func pickerType(p Picker, s string) Picker {
+	for _, r := range s {
+		p, _ = p.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return p
+}
+
+// labels returns the labels of the options p currently lists.
+func labels(p Picker) []string {
+	var out []string
+	for _, i := range p.matches {
+		out = append(out, p.options[i].Label)
+	}
+	return out
+}
+
+func TestPickerFilters(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"", []string{"web-1", "web-2", "db-1", "worker"}},
+		{"web", []string{"web-1", "web-2"}},
+		{"WEB 2", []string{"web-2"}},
+		{"10.0.1", []string{"db-1"}},
+		{"queue", []string{"worker"}},
+		{"nothing", nil},
+	}
+	for _, tt := range tests {
+		p := pickerType(testPicker(), tt.filter)
+		if got := labels(p); !slices.Equal(got, tt.want) {
+			t.Errorf("filter %q lists %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestPickerCursorClampedWhenFiltered(t *testing.T) {
+	p := testPicker()
+	for range 3 {
+		p, _ = p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	}
+	if p.cursor != 3 {
+		t.Fatalf("cursor = %d after moving to the end, want 3", p.cursor)
+	}
+
+	p = pickerType(p, "db")
+	if p.cursor >= len(p.matches) {
+		t.Fatalf("cursor = %d with %d matches", p.cursor, len(p.matches))
+	}
+	p, cmd := p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter on a match didn't pick it")
+	}
+	if got := cmd().(PickerResult); got.Value != "db-1" {
+		t.Errorf("picked %q, want db-1", got.Value)
+	}
+
+	// Moving down stops at the last match.
+	p = pickerType(testPicker(), "web")
+	for range 5 {
+		p, _ = p.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	}
+	if p.cursor != 1 {
+		t.Errorf("cursor = %d after moving past the end, want 1", p.cursor)
+	}
+}
+
+func TestPickerEnterWithNoMatches(t *testing.T) {
+	p := pickerType(testPicker(), "nothing")
+	p, cmd := p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd != nil {
+		t.Errorf("Enter with no matches sent %v", cmd())
+	}
+	if !p.Active {
+		t.Error("Enter with no matches closed the picker")
+	}
+}
+
+func TestPickerValue(t *testing.T) {
+	p := pickerType(testPicker(), "worker")
+	_, cmd := p.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if got := cmd().(PickerResult); got.ID != "server" || got.Value != "42" {
+		t.Errorf("result = %+v, want the option's Value 42", got)
+	}
+}
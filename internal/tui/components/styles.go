@@ -6,12 +6,14 @@ import (
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
-// Dialog box style — rounded border, centered content.
-var dialogBox = lipgloss.NewStyle().
-	Border(lipgloss.RoundedBorder()).
-	BorderForeground(theme.ColorPrimary).
-	Padding(1, 2).
-	Background(theme.ColorBg)
+// dialogBox returns the dialog box style — theme border, centered content.
+func dialogBox() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(theme.ColorPrimary).
+		Padding(1, 2).
+		Background(theme.ColorBg)
+}
 
 // Dialog text style for the question/label.
 var dialogText = lipgloss.NewStyle().
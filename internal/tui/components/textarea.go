@@ -0,0 +1,96 @@
+package components
+
+import (
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textarea"
+	lipgloss "charm.land/lipgloss/v2"
+)
+
+// TextArea is a multi-line input modal overlay using the bubbles textarea
+// widget, for values whose newlines matter: pasted keys, certificate PEM
+// blocks and short scripts. Enter starts a new line, so ctrl+s submits.
+// Results come back as InputResult and InputCancelled, like Input's.
+type TextArea struct {
+	Label  string
+	ID     string
+	Active bool
+	area   textarea.Model
+}
+
+// Size of the text area, in cells.
+const (
+	textAreaWidth  = 66
+	textAreaHeight = 12
+)
+
+// NewTextArea creates a new multi-line input dialog with the given label
+// and placeholder.
+func NewTextArea(id, label, placeholder string) TextArea {
+	ta := textarea.New()
+	ta.Placeholder = placeholder
+	ta.ShowLineNumbers = false
+	ta.CharLimit = 0 // unlimited
+	ta.MaxHeight = 0
+	ta.SetWidth(textAreaWidth)
+	ta.SetHeight(textAreaHeight)
+	ta.Focus()
+
+	return TextArea{
+		Label:  label,
+		ID:     id,
+		Active: true,
+		area:   ta,
+	}
+}
+
+// Update handles key events for the dialog. Ctrl+s submits, Esc cancels,
+// ctrl+v pastes and other keys are delegated to the textarea.
+func (t TextArea) Update(msg tea.Msg) (TextArea, tea.Cmd) {
+	if !t.Active {
+		return t, nil
+	}
+
+	if pasted, ok := msg.(InputPasted); ok {
+		msg = pasted.msg
+	}
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch {
+		case key.Matches(msg, t.area.KeyMap.Paste):
+			// As with Input, textarea's paste message is private.
+			return t, func() tea.Msg { return InputPasted{msg: textarea.Paste()} }
+		case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+s"))):
+			t.Active = false
+			value := t.area.Value()
+			id := t.ID
+			return t, func() tea.Msg {
+				return InputResult{Value: value, ID: id}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+			t.Active = false
+			id := t.ID
+			return t, func() tea.Msg {
+				return InputCancelled{ID: id}
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	t.area, cmd = t.area.Update(msg)
+	return t, cmd
+}
+
+// View renders the dialog centered on the screen.
+// Returns an empty string if the dialog is not active.
+func (t TextArea) View(width, height int) string {
+	if !t.Active {
+		return ""
+	}
+
+	label := dialogText.Render(t.Label)
+	hint := dialogHint.Render("ctrl+s save  enter new line  esc cancel")
+	inner := lipgloss.JoinVertical(lipgloss.Left, "", label, "", t.area.View(), "", hint, "")
+
+	boxWidth := min(lipgloss.Width(inner)+4, width-4)
+	return dialogBox().Width(boxWidth).Render(inner)
+}
@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// credentialFields are the .env keys shown by the credentials modal, in
+// display order.
+var credentialFields = []struct{ key, label string }{
+	{"DB_CONNECTION", "Connection"},
+	{"DB_HOST", "Host"},
+	{"DB_PORT", "Port"},
+	{"DB_DATABASE", "Database"},
+	{"DB_USERNAME", "Username"},
+	{"DB_PASSWORD", "Password"},
+}
+
+// CredentialsModal is a floating overlay showing the database credentials
+// from a site's .env. The password stays masked until revealed, and any
+// value can be copied to the clipboard.
+type CredentialsModal struct {
+	active   bool
+	loading  bool
+	site     string
+	creds    map[string]string
+	err      error
+	cursor   int
+	revealed bool
+	status   string
+}
+
+// credentialsLoadedMsg carries the parsed .env of the credentials modal's site.
+type credentialsLoadedMsg struct {
+	creds map[string]string
+	err   error
+}
+
+// NewCredentialsModal creates a new (inactive) credentials modal.
+func NewCredentialsModal() CredentialsModal {
+	return CredentialsModal{}
+}
+
+// Open activates the modal and fetches site's .env using client.
+func (c CredentialsModal) Open(client *forge.Client, serverID int64, site forge.Site) (CredentialsModal, tea.Cmd) {
+	c = CredentialsModal{active: true, loading: true, site: site.Name}
+	return c, func() tea.Msg {
+		creds, err := fetchDBCreds(client, serverID, site.ID)
+		return credentialsLoadedMsg{creds: creds, err: err}
+	}
+}
+
+// Active returns whether the credentials modal is currently visible.
+func (c CredentialsModal) Active() bool {
+	return c.active
+}
+
+// Update handles key events and the .env fetch result.
+// j/k move, r reveals the password, y copies the selected value.
+func (c CredentialsModal) Update(msg tea.Msg) (CredentialsModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case credentialsLoadedMsg:
+		c.loading = false
+		c.creds = msg.creds
+		c.err = msg.err
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "q", "v"))):
+			c.active = false
+			c.creds = nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("j", "down"))):
+			c.cursor = min(c.cursor+1, len(credentialFields)-1)
+			c.status = ""
+		case key.Matches(msg, key.NewBinding(key.WithKeys("k", "up"))):
+			c.cursor = max(c.cursor-1, 0)
+			c.status = ""
+		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+			c.revealed = !c.revealed
+		case key.Matches(msg, key.NewBinding(key.WithKeys("y", "c"))):
+			field := credentialFields[c.cursor]
+			value := c.creds[field.key]
+			if value == "" {
+				return c, nil
+			}
+			c.status = field.label + " copied"
+			return c, tea.SetClipboard(value)
+		}
+	}
+	return c, nil
+}
+
+// View renders the credentials modal as a box suitable for overlay.
+func (c CredentialsModal) View(width, height int) string {
+	if !c.active {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.ColorPrimary).
+		Align(lipgloss.Center)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorSubtle).
+		Width(12).
+		Align(lipgloss.Right)
+
+	valueStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorFg)
+
+	errStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorError)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorMuted).
+		Align(lipgloss.Center)
+
+	contentWidth := layout.Clamp(width-6, 30, 60)
+
+	lines := []string{
+		titleStyle.Width(contentWidth).Render("Database credentials · " + c.site),
+		"",
+	}
+	switch {
+	case c.loading:
+		lines = append(lines, valueStyle.Render("Fetching .env…"))
+	case c.err != nil:
+		lines = append(lines, errStyle.Render(theme.GlyphFail+" "+c.err.Error()))
+	default:
+		for i, field := range credentialFields {
+			value := c.creds[field.key]
+			switch {
+			case value == "":
+				value = "—"
+			case field.key == "DB_PASSWORD" && !c.revealed:
+				value = strings.Repeat("•", min(len(value), 12))
+			}
+			value = theme.Truncate(value, contentWidth-16)
+			prefix := "  "
+			if i == c.cursor {
+				prefix = theme.CursorStyle.Render("> ")
+				value = theme.SelectedItemStyle.Render(value)
+			} else {
+				value = valueStyle.Render(value)
+			}
+			lines = append(lines, prefix+labelStyle.Render(field.label+": ")+value)
+		}
+	}
+
+	lines = append(lines, "")
+	if c.status != "" {
+		lines = append(lines, hintStyle.Width(contentWidth).Render(c.status))
+	}
+	reveal := "r reveal"
+	if c.revealed {
+		reveal = "r hide"
+	}
+	lines = append(lines, hintStyle.Width(contentWidth).Render(fmt.Sprintf("j/k move  y copy  %s  esc close", reveal)))
+
+	return lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(theme.ColorPrimary).
+		Padding(1, 2).
+		Background(theme.ColorBg).
+		Width(contentWidth + 4).
+		Render(strings.Join(lines, "\n"))
+}
+
+// fetchDBCreds fetches a site's .env and parses the variables in it.
+func fetchDBCreds(client *forge.Client, serverID, siteID int64) (map[string]string, error) {
+	envContent, err := client.Environment.Get(context.Background(), serverID, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch .env: %w", err)
+	}
+	return parseEnvVars(envContent), nil
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// deployLogSavedMsg reports the outcome of saving a deployment's output.
+type deployLogSavedMsg struct {
+	siteName string
+	err      error
+}
+
+// saveDeployOutput returns a command that fetches the output of the
+// watched deployment and saves it under config.DeployLogsDir.
+func (m App) saveDeployOutput(w deployWatch) tea.Cmd {
+	if w.deployID == 0 {
+		return nil
+	}
+	client := m.forge
+	return func() tea.Msg {
+		output, err := client.Deployments.GetOutput(context.Background(), w.serverID, w.siteID, w.deployID)
+		if err == nil {
+			_, err = config.SaveDeployLog(config.DeployLogsDir(), w.siteName, w.deployID, output)
+		}
+		return deployLogSavedMsg{siteName: w.siteName, err: err}
+	}
+}
+
+// handleDeployLogSaved reports a deployment output that couldn't be saved.
+// Saves that worked are silent: the deploy's own toast says enough.
+func (m App) handleDeployLogSaved(msg deployLogSavedMsg) (tea.Model, tea.Cmd) {
+	if msg.err == nil {
+		return m, nil
+	}
+	m.toast = fmt.Sprintf("Saving deploy output of %s: %v", msg.siteName, msg.err)
+	m.toastIsErr = true
+	return m, m.clearToastAfter(5 * time.Second)
+}
+
+// browseDeployLogs opens a picker of the deployment outputs saved for the
+// selected site, newest first.
+func (m App) browseDeployLogs() (tea.Model, tea.Cmd) {
+	if m.selectedSite == nil {
+		return m, nil
+	}
+	logs, err := config.ListDeployLogs(config.DeployLogsDir(), m.selectedSite.Name)
+	if err != nil || len(logs) == 0 {
+		m.toast = fmt.Sprintf("No saved deploy logs for %s", m.selectedSite.Name)
+		if err != nil {
+			m.toast = fmt.Sprintf("Reading saved deploy logs: %v", err)
+		}
+		m.toastIsErr = err != nil
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	options := make([]components.PickerOption, len(logs))
+	for i, l := range logs {
+		options[i] = components.PickerOption{
+			Label:  fmt.Sprintf("#%d", l.ID),
+			Detail: l.ModTime.Format("2006-01-02 15:04"),
+			Value:  l.Path,
+		}
+	}
+	m.dialogs = m.dialogs.Pick(components.NewPicker("saved-deploy-log",
+		fmt.Sprintf("Saved deploy logs for %s:", m.selectedSite.Name), options))
+	return m, nil
+}
+
+// openDeployLog shows a saved deployment output in the output panel.
+func (m App) openDeployLog(path string) (tea.Model, tea.Cmd) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		m.toast = fmt.Sprintf("Reading deploy log: %v", err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	id := strings.TrimSuffix(filepath.Base(path), ".log")
+	m.outputPanel = m.outputPanel.SetContent("Saved Deploy Output #"+id, string(data))
+	m.nav = m.nav.Push(ScreenOutput)
+	return m, nil
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// updateDeployOutput streams a deployment's output into the output panel,
+// polling until the deployment finishes and then fetching the full log.
+func (m App) updateDeployOutput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	// User pressed Enter on a deployment to view output.
+	case panels.DeployViewOutputMsg:
+		// Start polling if the deployment might still be running.
+		m.outputPoll = outputPollState{
+			serverID:     msg.ServerID,
+			siteID:       msg.SiteID,
+			deploymentID: msg.DeploymentID,
+			active:       true,
+		}
+		m.outputPanel = m.outputPanel.StartStream()
+		return m, tea.Batch(
+			m.fetchDeployOutputWithStatus(msg.ServerID, msg.SiteID, msg.DeploymentID),
+			m.spinnerTick(),
+		)
+
+	// Polled output+status result.
+	case pollOutputResultMsg:
+		// Skip replacing identical content; the spinner keeps the title moving.
+		if msg.finished || msg.output != m.outputPoll.log {
+			m.outputPanel = m.outputPanel.StreamContent(m.deployingTitle(), msg.output)
+		}
+		if !msg.finished {
+			m.outputPoll.log = msg.output
+		}
+		m.nav = m.nav.Push(ScreenOutput)
+		if msg.finished {
+			// Deployment finished — wait briefly then re-fetch output
+			// to ensure the API has flushed the complete log.
+			return m, tea.Tick(time.Second, func(time.Time) tea.Msg {
+				return pollFinalFetchMsg{}
+			})
+		}
+		// Continue polling.
+		return m, m.pollOutputTick()
+
+	// Delay expired after deployment finished — do the final fetch.
+	case pollFinalFetchMsg:
+		if !m.outputPoll.active {
+			return m, nil
+		}
+		client := m.forge
+		serverID := m.outputPoll.serverID
+		siteID := m.outputPoll.siteID
+		deployID := m.outputPoll.deploymentID
+		return m, func() tea.Msg {
+			output, err := client.Deployments.GetOutput(
+				context.Background(), serverID, siteID, deployID,
+			)
+			if err != nil {
+				return panels.PanelErrMsg{Err: err}
+			}
+			return pollFinalOutputMsg{output: output}
+		}
+
+	// Final output re-fetch after deployment finished.
+	case pollFinalOutputMsg:
+		m.outputPanel = m.outputPanel.StreamContent("Deploy Output", msg.output).EndStream()
+		m.outputPoll.active = false
+		m.outputPoll.frame = 0
+		// Refresh the deployments list to show updated status.
+		if m.detail.activeTab == 1 {
+			return m, m.detail.deploymentsPanel.LoadDeployments()
+		}
+		return m, nil
+
+	// Spinner animation tick — runs independently of the data poll.
+	case pollSpinnerTickMsg:
+		if !m.outputPoll.active {
+			return m, nil
+		}
+		m.outputPoll.frame++
+		m.outputPanel = m.outputPanel.SetTitle(m.deployingTitle())
+		return m, m.spinnerTick()
+
+	// Poll timer fired.
+	case pollOutputTickMsg:
+		if !m.outputPoll.active {
+			return m, nil
+		}
+		return m, m.fetchDeployOutputWithStatus(
+			m.outputPoll.serverID,
+			m.outputPoll.siteID,
+			m.outputPoll.deploymentID,
+		)
+	}
+	return m, nil
+}
+
+// fetchDeployOutput returns a command that fetches deployment output and
+// sends a DeployOutputMsg to be routed to the output panel.
+func (m App) fetchDeployOutput(serverID, siteID, deployID int64) tea.Cmd {
+	client := m.forge
+	return func() tea.Msg {
+		output, err := client.Deployments.GetOutput(context.Background(), serverID, siteID, deployID)
+		if err != nil {
+			return panels.PanelErrMsg{Err: err}
+		}
+		return panels.DeployOutputMsg{Output: output}
+	}
+}
+
+// fetchDeployOutputWithStatus fetches both status and output for a deployment.
+// Status is checked first so that when the deployment has finished, the
+// subsequent output fetch captures the complete log.
+func (m App) fetchDeployOutputWithStatus(serverID, siteID, deployID int64) tea.Cmd {
+	client := m.forge
+	prevLog := m.outputPoll.log
+	return func() tea.Msg {
+		// Check status first to avoid a race where output is fetched before
+		// the deployment finishes but status is checked after.
+		dep, err := client.Deployments.Get(context.Background(), serverID, siteID, deployID)
+		finished := err != nil || !forge.DeploymentRunning(dep.Status)
+
+		// Use the live deployment log endpoint while deploying (it updates
+		// in real time), and the archived history output once finished.
+		var output string
+		if finished {
+			output, err = client.Deployments.GetOutput(context.Background(), serverID, siteID, deployID)
+		} else {
+			output, err = client.Deployments.GetLogSince(context.Background(), serverID, siteID, prevLog)
+		}
+		if err != nil {
+			return panels.PanelErrMsg{Err: err}
+		}
+		return pollOutputResultMsg{output: output, finished: finished}
+	}
+}
+
+// pollOutputTick returns a command that sends a pollOutputTickMsg after 2
+// seconds, or 6 with ui.reduced_motion.
+func (m App) pollOutputTick() tea.Cmd {
+	d := 2 * time.Second
+	if m.config.UI.ReducedMotion {
+		d *= 3
+	}
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return pollOutputTickMsg{}
+	})
+}
+
+// spinnerTick returns a command that sends a pollSpinnerTickMsg after 150ms.
+// With ui.reduced_motion there is no spinner and it returns nil.
+func (m App) spinnerTick() tea.Cmd {
+	if m.config.UI.ReducedMotion {
+		return nil
+	}
+	return tea.Tick(150*time.Millisecond, func(time.Time) tea.Msg {
+		return pollSpinnerTickMsg{}
+	})
+}
+
+// deployingTitle is the output panel title while deploy output streams in,
+// with the current spinner frame unless ui.reduced_motion is set.
+func (m App) deployingTitle() string {
+	if m.config.UI.ReducedMotion {
+		return "Deploy Output deploying…"
+	}
+	spinner := theme.SpinnerFrames[m.outputPoll.frame%len(theme.SpinnerFrames)]
+	return fmt.Sprintf("Deploy Output %s deploying…", spinner)
+}
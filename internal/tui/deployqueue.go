@@ -0,0 +1,164 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// queuedDeploy is a deploy waiting for a site's running deployment to
+// finish. seq tells its ticks apart from those of one cancelled earlier.
+type queuedDeploy struct {
+	seq      int
+	serverID int64
+	siteID   int64
+	siteName string
+	afterID  int64 // the running deployment to wait for
+	polls    int
+}
+
+// queuedDeployTickMsg asks for the running deployment to be checked again.
+type queuedDeployTickMsg struct {
+	seq int
+}
+
+// queuedDeployStatusMsg carries the status of the deployment a queued
+// deploy is waiting for.
+type queuedDeployStatusMsg struct {
+	seq    int
+	status string
+	err    error
+}
+
+// autoDeployStartedMsg reports the outcome of triggering a deploy that
+// was queued or scheduled earlier. what names it, e.g. "Queued deploy of
+// example.com".
+type autoDeployStartedMsg struct {
+	what    string
+	trigger panels.DeployTriggerMsg
+	err     error
+}
+
+// queueDeploy queues a deploy of the selected site to run once its
+// running deployment finishes, replacing any deploy already queued.
+func (m App) queueDeploy() (tea.Model, tea.Cmd) {
+	running := m.detail.deploymentsPanel.Running()
+	if m.selectedSite == nil || m.selectedSrv == nil || running == nil {
+		return m, nil
+	}
+	seq := 1
+	if m.queuedDeploy != nil {
+		seq = m.queuedDeploy.seq + 1
+	}
+	m.queuedDeploy = &queuedDeploy{
+		seq:      seq,
+		serverID: m.selectedSrv.ID,
+		siteID:   m.selectedSite.ID,
+		siteName: m.selectedSite.Name,
+		afterID:  running.ID,
+	}
+	m.toast = fmt.Sprintf("Deploy of %s queued after #%d", m.selectedSite.Name, running.ID)
+	m.toastIsErr = false
+	return m, tea.Batch(m.clearToastAfter(3*time.Second), queuedDeployTick(seq))
+}
+
+// cancelQueuedDeploy drops the queued deploy. Its pending tick is ignored
+// once it fires.
+func (m App) cancelQueuedDeploy() (tea.Model, tea.Cmd) {
+	m.toast = fmt.Sprintf("Queued deploy of %s cancelled", m.queuedDeploy.siteName)
+	m.toastIsErr = false
+	m.queuedDeploy = nil
+	return m, m.clearToastAfter(3 * time.Second)
+}
+
+// queuedDeployTick schedules the next check of the deployment a queued
+// deploy is waiting for.
+func queuedDeployTick(seq int) tea.Cmd {
+	return tea.Tick(deployWatchInterval, func(time.Time) tea.Msg {
+		return queuedDeployTickMsg{seq: seq}
+	})
+}
+
+// checkQueuedDeploy returns a command that fetches the status of the
+// deployment the queued deploy is waiting for.
+func (m App) checkQueuedDeploy() tea.Cmd {
+	q := *m.queuedDeploy
+	client := m.forge
+	return func() tea.Msg {
+		dep, err := client.Deployments.Get(context.Background(), q.serverID, q.siteID, q.afterID)
+		if err != nil {
+			return queuedDeployStatusMsg{seq: q.seq, err: err}
+		}
+		return queuedDeployStatusMsg{seq: q.seq, status: dep.Status}
+	}
+}
+
+// handleQueuedDeployStatus triggers the queued deploy once the deployment
+// it waits for has finished, or keeps polling.
+func (m App) handleQueuedDeployStatus(msg queuedDeployStatusMsg) (tea.Model, tea.Cmd) {
+	q := m.queuedDeploy
+	if q == nil || q.seq != msg.seq {
+		return m, nil
+	}
+	if msg.err == nil && !forge.DeploymentRunning(msg.status) {
+		m.queuedDeploy = nil
+		return m, startAutoDeploy(m.forge, q.serverID, q.siteID, "Queued deploy of "+q.siteName)
+	}
+
+	// Still deploying or queued (or a transient API error): try again unless the
+	// deployment has been waited on for too long.
+	q.polls++
+	if q.polls >= deployWatchMaxPolls {
+		m.queuedDeploy = nil
+		m.toast = fmt.Sprintf("Stopped waiting to deploy %s", q.siteName)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	return m, queuedDeployTick(q.seq)
+}
+
+// startAutoDeploy returns a command that triggers a deploy of the site,
+// noting its newest deployment first so the new one can be told apart.
+func startAutoDeploy(client *forge.Client, serverID, siteID int64, what string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		trigger := panels.DeployTriggerMsg{ServerID: serverID, SiteID: siteID}
+		if deployments, err := client.Deployments.List(ctx, serverID, siteID); err == nil {
+			for _, d := range deployments {
+				trigger.PrevID = max(trigger.PrevID, d.ID)
+			}
+		}
+		err := client.Deployments.Deploy(ctx, serverID, siteID)
+		return autoDeployStartedMsg{what: what, trigger: trigger, err: err}
+	}
+}
+
+// handleAutoDeployStarted reports a triggered queued or scheduled deploy
+// and hands it on as a regular deploy trigger, so it is watched like one.
+func (m App) handleAutoDeployStarted(msg autoDeployStartedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("%s failed: %v", msg.what, msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	model, cmd := m.Update(msg.trigger)
+	m = model.(App)
+	m.toast = msg.what + " started"
+	return m, cmd
+}
+
+// queuedDeployBadge renders the footer badge of the queued deploy, or "".
+func (m App) queuedDeployBadge() string {
+	if m.queuedDeploy == nil {
+		return ""
+	}
+	text := fmt.Sprintf("%s deploy of %s queued", theme.GlyphDot, m.queuedDeploy.siteName)
+	return lipgloss.NewStyle().Bold(true).Foreground(theme.ColorHighlight).Render(text)
+}
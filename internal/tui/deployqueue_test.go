@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+)
+
+func deployQueueApp(t *testing.T) App {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	cfg := config.Default()
+	cfg.UI.TourSeen = true
+	m := NewApp(cfg, "", LaunchNone)
+	m.selectedSrv = &forge.Server{ID: 1, Name: "web-1"}
+	m.selectedSite = &forge.Site{ID: 10, Name: "example.com"}
+	m.nav = m.nav.FocusPanel(FocusDetail)
+	return m
+}
+
+// TestDeployOffersQueue checks deploying while the latest deployment is
+// deploying or queued offers to queue the deploy instead.
+func TestDeployOffersQueue(t *testing.T) {
+	for _, status := range []string{"deploying", "queued"} {
+		m := deployQueueApp(t)
+		p, _ := m.detail.deploymentsPanel.Update(panels.DeploymentsLoadedMsg{Deployments: []forge.Deployment{{ID: 7, Status: status}}})
+		m.detail.deploymentsPanel = p.(panels.DeploymentsPanel)
+
+		model, _ := m.handleDeploymentsKey(tea.KeyPressMsg{Code: 'd', Text: "d"})
+		m = model.(App)
+		if m.dialogs.confirm == nil || m.dialogs.confirm.ID != "queue-deploy" {
+			t.Errorf("deploy with the latest deployment %s didn't offer to queue", status)
+		}
+	}
+}
+
+// TestQueuedDeployWaitsWhileRunning checks a queued deploy keeps waiting
+// while the deployment before it is deploying or queued.
+func TestQueuedDeployWaitsWhileRunning(t *testing.T) {
+	for _, status := range []string{"deploying", "queued"} {
+		m := deployQueueApp(t)
+		m.queuedDeploy = &queuedDeploy{seq: 1, serverID: 1, siteID: 10, siteName: "example.com", afterID: 7}
+
+		model, cmd := m.handleQueuedDeployStatus(queuedDeployStatusMsg{seq: 1, status: status})
+		m = model.(App)
+		if m.queuedDeploy == nil || cmd == nil {
+			t.Errorf("queued deploy started while the deployment before it was %s", status)
+		} else if m.queuedDeploy.polls != 1 {
+			t.Errorf("polls = %d while %s, want 1", m.queuedDeploy.polls, status)
+		}
+	}
+
+	m := deployQueueApp(t)
+	m.queuedDeploy = &queuedDeploy{seq: 1, serverID: 1, siteID: 10, siteName: "example.com", afterID: 7}
+	model, cmd := m.handleQueuedDeployStatus(queuedDeployStatusMsg{seq: 1, status: "finished"})
+	if model.(App).queuedDeploy != nil || cmd == nil {
+		t.Error("queued deploy didn't start once the deployment before it finished")
+	}
+}
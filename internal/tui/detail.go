@@ -0,0 +1,667 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// DetailController owns the top-right detail area: the info panels, the
+// section tab panels and which tab (and sub-view within it) is showing.
+// The app decides when tabs are created and loaded and which sub-view is
+// open; the controller routes their data messages and renders them.
+type DetailController struct {
+	serverInfo        panels.ServerInfo
+	siteInfo          panels.SiteInfo
+	deploymentsPanel  panels.DeploymentsPanel
+	deployScriptPanel panels.DeployScriptPanel
+	environmentPanel  panels.EnvironmentPanel
+	databasesPanel    panels.DatabasesPanel
+	dbUsersPanel      panels.DBUsersPanel
+	sslPanel          panels.SSLPanel
+	workersPanel      panels.WorkersPanel
+	daemonsPanel      panels.DaemonsPanel
+	firewallPanel     panels.FirewallPanel
+	jobsPanel         panels.JobsPanel
+	sslOverviewPanel  panels.SSLOverviewPanel
+	sshKeysPanel      panels.SSHKeysPanel
+	circlesPanel      panels.CirclesPanel
+	nginxPanel        panels.NginxTemplatesPanel
+	commandsPanel     panels.CommandsPanel
+	logsPanel         panels.LogsPanel
+	eventsPanel       panels.EventsPanel
+	gitPanel          panels.GitPanel
+	domainsPanel      panels.DomainsPanel
+	redirectsPanel    panels.RedirectsPanel
+	securityPanel     panels.SecurityRulesPanel
+	backupsPanel      panels.BackupsPanel
+	recipesPanel      panels.RecipesPanel
+
+	activeTab int // 1-9 for detail section tabs, backupsTab and recipesTab for the lettered ones
+
+	// disabled holds the IDs of the tabs turned off with ui.disabled_tabs.
+	disabled map[string]bool
+
+	// While the active tab's panel loads, a spinner shows next to its
+	// label: spinFrame is its frame and spinning is set while its tick
+	// runs. With reducedMotion it is a still ellipsis instead. loadFailed
+	// hides it after a fetch fails, until the next tab is loaded.
+	spinFrame     int
+	spinning      bool
+	reducedMotion bool
+	loadFailed    bool
+
+	// owners records which server/site the panel in each slot belongs to,
+	// and cached holds the panels swapped out of their slot, so switching
+	// back to a tab reuses its data, cursor and scroll position. cacheOrder
+	// lists the cached keys, least recently swapped out first.
+	owners     map[panelSlot]panelKey
+	cached     map[panelKey]panels.Panel
+	cacheOrder []panelKey
+}
+
+// maxCachedPanels bounds how many swapped-out tab panels are kept.
+const maxCachedPanels = 32
+
+// panelKey identifies a tab panel instance. siteID is 0 for server-level
+// panels.
+type panelKey struct {
+	tab      int
+	serverID int64
+	siteID   int64
+}
+
+// panelSlot identifies a panel field of the controller: a tab number in
+// the site or server context.
+type panelSlot struct {
+	tab  int
+	site bool
+}
+
+// newPanelKey returns the key of the panel shown for tab. Databases are
+// server-level in both contexts, so their key ignores the site.
+func newPanelKey(tab int, serverID, siteID int64) panelKey {
+	if tab == 3 {
+		siteID = 0
+	}
+	return panelKey{tab: tab, serverID: serverID, siteID: siteID}
+}
+
+func (k panelKey) slot() panelSlot {
+	return panelSlot{tab: k.tab, site: k.siteID != 0}
+}
+
+// NewDetailController creates a detail area showing the first tab.
+func NewDetailController() DetailController {
+	return DetailController{
+		serverInfo: panels.NewServerInfo(),
+		siteInfo:   panels.NewSiteInfo(),
+		activeTab:  1,
+		owners:     make(map[panelSlot]panelKey),
+		cached:     make(map[panelKey]panels.Panel),
+	}
+}
+
+// ReusePanel puts the panel for key in its slot when one has been loaded
+// before, reporting whether it did. Otherwise it reports false and the
+// caller creates and loads a fresh panel, which is then owned by key. The
+// panel previously in the slot is cached for later reuse.
+func (d DetailController) ReusePanel(key panelKey) (DetailController, bool) {
+	slot := key.slot()
+	if d.slotPanel(slot) == nil {
+		return d, false
+	}
+	if owner, ok := d.owners[slot]; ok {
+		if owner == key {
+			return d, true
+		}
+		d = d.cachePanel(owner, d.slotPanel(slot))
+	}
+	d.owners[slot] = key
+
+	p, ok := d.cached[key]
+	if !ok {
+		return d, false
+	}
+	delete(d.cached, key)
+	d.cacheOrder = slices.DeleteFunc(d.cacheOrder, func(k panelKey) bool { return k == key })
+	return d.setSlotPanel(p), true
+}
+
+// Owns reports whether the panel in key's slot is the one for key.
+func (d DetailController) Owns(key panelKey) bool {
+	owner, ok := d.owners[key.slot()]
+	return ok && owner == key
+}
+
+// UpdateCached passes msg to the cached panel for key, if there is one.
+func (d DetailController) UpdateCached(key panelKey, msg tea.Msg) (DetailController, tea.Cmd) {
+	p, ok := d.cached[key]
+	if !ok {
+		return d, nil
+	}
+	p, cmd := p.Update(msg)
+	d.cached[key] = p
+	return d, tagLoad(key, cmd)
+}
+
+// stillLoading reports whether the panel in key's slot hasn't finished
+// loading.
+func (d DetailController) stillLoading(key panelKey) bool {
+	l, ok := d.slotPanel(key.slot()).(panels.Loader)
+	return ok && l.Loading()
+}
+
+// panelLoadMsg is a message from a tab panel's load, tagged with the panel
+// it was loaded for. Load messages don't say which server or site they
+// belong to, so a load that finishes after the user has moved to another
+// server would otherwise land in that server's panel.
+type panelLoadMsg struct {
+	key panelKey
+	msg tea.Msg
+}
+
+// tagLoad wraps cmd so the messages it produces are tagged with key.
+func tagLoad(key panelKey, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			tagged := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				tagged[i] = tagLoad(key, c)
+			}
+			return tagged
+		}
+		return panelLoadMsg{key: key, msg: msg}
+	}
+}
+
+// ForgetPanels drops every cached panel, so each tab loads afresh the next
+// time it is shown.
+func (d DetailController) ForgetPanels() DetailController {
+	d.owners = make(map[panelSlot]panelKey)
+	d.cached = make(map[panelKey]panels.Panel)
+	d.cacheOrder = nil
+	return d
+}
+
+// cachePanel stores p under key, evicting the oldest entry when full.
+func (d DetailController) cachePanel(key panelKey, p panels.Panel) DetailController {
+	if _, ok := d.cached[key]; !ok {
+		d.cacheOrder = append(d.cacheOrder, key)
+	}
+	d.cached[key] = p
+	if len(d.cacheOrder) > maxCachedPanels {
+		delete(d.cached, d.cacheOrder[0])
+		d.cacheOrder = slices.Delete(d.cacheOrder, 0, 1)
+	}
+	return d
+}
+
+// slotPanel returns the panel field for slot, or nil when the tab has no
+// panel in that context.
+func (d DetailController) slotPanel(slot panelSlot) panels.Panel {
+	if slot.site {
+		switch slot.tab {
+		case 1:
+			return d.deploymentsPanel
+		case 2:
+			return d.environmentPanel
+		case 4:
+			return d.sslPanel
+		case 5:
+			return d.workersPanel
+		case 6:
+			return d.commandsPanel
+		case 7:
+			return d.logsPanel
+		case 8:
+			return d.gitPanel
+		case 9:
+			return d.domainsPanel
+		}
+		return nil
+	}
+	switch slot.tab {
+	case 1:
+		return d.eventsPanel
+	case 2:
+		return d.nginxPanel
+	case 3:
+		return d.databasesPanel
+	case 4:
+		return d.sslOverviewPanel
+	case 5:
+		return d.circlesPanel
+	case 6:
+		return d.daemonsPanel
+	case 7:
+		return d.firewallPanel
+	case 8:
+		return d.jobsPanel
+	case 9:
+		return d.sshKeysPanel
+	case backupsTab:
+		return d.backupsPanel
+	case recipesTab:
+		return d.recipesPanel
+	}
+	return nil
+}
+
+// setSlotPanel stores p in the field for its type.
+func (d DetailController) setSlotPanel(p panels.Panel) DetailController {
+	switch p := p.(type) {
+	case panels.ServerInfo:
+		d.serverInfo = p
+	case panels.SiteInfo:
+		d.siteInfo = p
+	case panels.DeployScriptPanel:
+		d.deployScriptPanel = p
+	case panels.DBUsersPanel:
+		d.dbUsersPanel = p
+	case panels.RedirectsPanel:
+		d.redirectsPanel = p
+	case panels.SecurityRulesPanel:
+		d.securityPanel = p
+	case panels.DeploymentsPanel:
+		d.deploymentsPanel = p
+	case panels.EnvironmentPanel:
+		d.environmentPanel = p
+	case panels.SSLPanel:
+		d.sslPanel = p
+	case panels.WorkersPanel:
+		d.workersPanel = p
+	case panels.CommandsPanel:
+		d.commandsPanel = p
+	case panels.LogsPanel:
+		d.logsPanel = p
+	case panels.GitPanel:
+		d.gitPanel = p
+	case panels.DomainsPanel:
+		d.domainsPanel = p
+	case panels.EventsPanel:
+		d.eventsPanel = p
+	case panels.DatabasesPanel:
+		d.databasesPanel = p
+	case panels.SSLOverviewPanel:
+		d.sslOverviewPanel = p
+	case panels.DaemonsPanel:
+		d.daemonsPanel = p
+	case panels.FirewallPanel:
+		d.firewallPanel = p
+	case panels.JobsPanel:
+		d.jobsPanel = p
+	case panels.SSHKeysPanel:
+		d.sshKeysPanel = p
+	case panels.CirclesPanel:
+		d.circlesPanel = p
+	case panels.NginxTemplatesPanel:
+		d.nginxPanel = p
+	case panels.BackupsPanel:
+		d.backupsPanel = p
+	case panels.RecipesPanel:
+		d.recipesPanel = p
+	}
+	return d
+}
+
+// Update routes a panel's data message, the result of an action taken in
+// a tab, or a key for the active tab to its panel. It reports false for
+// messages that aren't for the tab panels, which the app handles itself.
+func (d DetailController) Update(msg tea.Msg) (DetailController, tea.Cmd, bool) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tabKeyMsg:
+		p, cmd := d.ActivePanel(msg.srv, msg.site, msg.screen).Update(msg.key)
+		return d.setSlotPanel(p), cmd, true
+	case panels.EventsLoadedMsg:
+		d.eventsPanel, cmd = updatePanel(d.eventsPanel, msg)
+	case panels.ScriptLoadedMsg:
+		d.deployScriptPanel, cmd = updatePanel(d.deployScriptPanel, msg)
+	case panels.EnvLoadedMsg:
+		d.environmentPanel, cmd = updatePanel(d.environmentPanel, msg)
+	case panels.DatabasesLoadedMsg:
+		d.databasesPanel, cmd = updatePanel(d.databasesPanel, msg)
+	case panels.DBUsersLoadedMsg:
+		d.dbUsersPanel, cmd = updatePanel(d.dbUsersPanel, msg)
+	case panels.CertsLoadedMsg, panels.CertExpiryMsg:
+		d.sslPanel, cmd = updatePanel(d.sslPanel, msg)
+	case panels.WorkersLoadedMsg:
+		d.workersPanel, cmd = updatePanel(d.workersPanel, msg)
+	case panels.DaemonsLoadedMsg:
+		d.daemonsPanel, cmd = updatePanel(d.daemonsPanel, msg)
+	case panels.FirewallLoadedMsg:
+		d.firewallPanel, cmd = updatePanel(d.firewallPanel, msg)
+	case panels.SSLOverviewLoadedMsg:
+		d.sslOverviewPanel, cmd = updatePanel(d.sslOverviewPanel, msg)
+	case panels.JobsLoadedMsg:
+		d.jobsPanel, cmd = updatePanel(d.jobsPanel, msg)
+	case panels.SSHKeysLoadedMsg:
+		d.sshKeysPanel, cmd = updatePanel(d.sshKeysPanel, msg)
+	case panels.CirclesLoadedMsg:
+		d.circlesPanel, cmd = updatePanel(d.circlesPanel, msg)
+	case panels.NginxTemplatesLoadedMsg:
+		d.nginxPanel, cmd = updatePanel(d.nginxPanel, msg)
+	case panels.BackupsLoadedMsg:
+		d.backupsPanel, cmd = updatePanel(d.backupsPanel, msg)
+	case panels.RecipesLoadedMsg:
+		d.recipesPanel, cmd = updatePanel(d.recipesPanel, msg)
+	case panels.CommandsLoadedMsg, panels.CommandDetailMsg:
+		d.commandsPanel, cmd = updatePanel(d.commandsPanel, msg)
+	case panels.LogsLoadedMsg, panels.LogEditorDoneMsg:
+		d.logsPanel, cmd = updatePanel(d.logsPanel, msg)
+	case panels.DomainsLoadedMsg, panels.DomainsCertMsg:
+		d.domainsPanel, cmd = updatePanel(d.domainsPanel, msg)
+	case panels.RedirectsLoadedMsg:
+		d.redirectsPanel, cmd = updatePanel(d.redirectsPanel, msg)
+	case panels.SecurityRulesLoadedMsg:
+		d.securityPanel, cmd = updatePanel(d.securityPanel, msg)
+	default:
+		return d.updateResult(msg)
+	}
+	return d, cmd, true
+}
+
+// tabKeyMsg is a key for the active tab's panel, which is picked as
+// ActivePanel picks it.
+type tabKeyMsg struct {
+	key    tea.KeyPressMsg
+	srv    *forge.Server
+	site   *forge.Site
+	screen Screen
+}
+
+// updatePanel passes msg to p and asserts the result back to p's type.
+func updatePanel[P panels.Panel](p P, msg tea.Msg) (P, tea.Cmd) {
+	updated, cmd := p.Update(msg)
+	return updated.(P), cmd
+}
+
+// ActivePanel returns the panel shown for the active tab. Site-level tabs
+// are used when site is set, server-level tabs when only srv is, and the
+// server info panel when nothing is selected. screen selects a sub-view
+// (deploy script, database users, redirect or security rules) of the
+// active tab.
+func (d DetailController) ActivePanel(srv *forge.Server, site *forge.Site, screen Screen) panels.Panel {
+	if !d.TabEnabled(d.activeTab, site != nil) {
+		if site != nil {
+			return d.siteInfo
+		}
+		return d.serverInfo
+	}
+	if site != nil {
+		switch d.activeTab {
+		case 1:
+			if screen == ScreenDeployScript {
+				return d.deployScriptPanel
+			}
+			return d.deploymentsPanel
+		case 2:
+			return d.environmentPanel
+		case 3:
+			if screen == ScreenDBUsers {
+				return d.dbUsersPanel
+			}
+			return d.databasesPanel
+		case 4:
+			return d.sslPanel
+		case 5:
+			return d.workersPanel
+		case 6:
+			return d.commandsPanel
+		case 7:
+			return d.logsPanel
+		case 8:
+			return d.gitPanel
+		case 9:
+			switch screen {
+			case ScreenRedirects:
+				return d.redirectsPanel
+			case ScreenSecurity:
+				return d.securityPanel
+			}
+			return d.domainsPanel
+		}
+		return d.siteInfo
+	}
+
+	if srv != nil {
+		switch d.activeTab {
+		case 1:
+			return d.eventsPanel
+		case 2:
+			return d.nginxPanel
+		case 3:
+			if screen == ScreenDBUsers {
+				return d.dbUsersPanel
+			}
+			return d.databasesPanel
+		case 4:
+			return d.sslOverviewPanel
+		case 5:
+			return d.circlesPanel
+		case 6:
+			return d.daemonsPanel
+		case 7:
+			return d.firewallPanel
+		case 8:
+			return d.jobsPanel
+		case 9:
+			return d.sshKeysPanel
+		case backupsTab:
+			return d.backupsPanel
+		case recipesTab:
+			return d.recipesPanel
+		}
+	}
+	return d.serverInfo
+}
+
+// View renders the detail area.
+// When a site is selected it shows a tab bar with site-level panels;
+// when only a server is selected it shows a tab bar with server-level panels;
+// otherwise it shows server info.
+func (d DetailController) View(width, height int, focused bool, srv *forge.Server, site *forge.Site, screen Screen) string {
+	panel := d.ActivePanel(srv, site, screen)
+	if site == nil && srv == nil {
+		return panel.View(width, height, focused)
+	}
+
+	// Render the tab bar as a single line above the section panel.
+	var tabBar string
+	if site != nil {
+		tabBar = d.renderTabBar(width, d.loadingMark(panel))
+	} else {
+		tabBar = d.renderServerTabBar(width, d.loadingMark(panel))
+	}
+
+	// The section panel gets the remaining height below the tab bar.
+	sectionHeight := max(height-lipgloss.Height(tabBar), 2)
+
+	return lipgloss.JoinVertical(lipgloss.Left, tabBar, panel.View(width, sectionHeight, focused))
+}
+
+// The numbers of the server's tabs that are opened with a letter rather
+// than a digit: b for Backups and R for Recipes.
+const (
+	backupsTab = 10
+	recipesTab = 11
+)
+
+// detailTab is a numbered section tab, its label in the tab bar and the
+// ID ui.disabled_tabs refers to it by (one of config.TabIDs).
+type detailTab struct {
+	num  int
+	name string
+	id   string
+}
+
+// siteTabs and serverTabs are the tabs shown with a site selected and
+// with only a server selected. Tab 0 is the site's or server's info view,
+// which can't be disabled.
+var (
+	siteTabs = []detailTab{
+		{0, "Info", ""}, {1, "Deploy", "deployments"}, {2, "Env", "env"}, {3, "DB", "databases"},
+		{4, "SSL", "ssl"}, {5, "Workers", "workers"}, {6, "Cmds", "commands"},
+		{7, "Logs", "logs"}, {8, "Git", "git"}, {9, "Domains", "domains"},
+	}
+	serverTabs = []detailTab{
+		{0, "Info", ""}, {1, "Events", "events"}, {2, "Nginx", "nginx"}, {3, "DB", "databases"}, {4, "SSL", "ssl"},
+		{5, "Circles", "circles"}, {6, "Daemons", "daemons"}, {7, "Firewall", "firewall"}, {8, "Jobs", "jobs"}, {9, "SSH Keys", "sshkeys"},
+		{backupsTab, "Backups", "backups"}, {recipesTab, "Recipes", "recipes"},
+	}
+)
+
+// label returns the tab's label in the tab bar, its key and name, e.g.
+// "1:Deploy".
+func (t detailTab) label() string {
+	switch t.num {
+	case backupsTab:
+		return "b:" + t.name
+	case recipesTab:
+		return "R:" + t.name
+	}
+	return fmt.Sprintf("%d:%s", t.num, t.name)
+}
+
+// SetReducedMotion shows a still mark instead of a spinner next to a
+// loading tab.
+func (d DetailController) SetReducedMotion(on bool) DetailController {
+	d.reducedMotion = on
+	return d
+}
+
+// Loading reports whether the active tab's panel, as ActivePanel picks
+// it, is still fetching its data.
+func (d DetailController) Loading(srv *forge.Server, site *forge.Site, screen Screen) bool {
+	l, ok := d.ActivePanel(srv, site, screen).(panels.Loader)
+	return ok && l.Loading() && !d.loadFailed
+}
+
+// loadingMark returns the spinner frame to show after the active tab's
+// label while panel loads, or "".
+func (d DetailController) loadingMark(panel panels.Panel) string {
+	if l, ok := panel.(panels.Loader); !ok || !l.Loading() || d.loadFailed {
+		return ""
+	}
+	if d.reducedMotion {
+		return "…"
+	}
+	return theme.SpinnerFrames[d.spinFrame%len(theme.SpinnerFrames)]
+}
+
+// SetDisabledTabs turns off the tabs with the given IDs (case-insensitive):
+// they are left out of the tab bars and never loaded.
+func (d DetailController) SetDisabledTabs(ids []string) DetailController {
+	d.disabled = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		d.disabled[strings.ToLower(strings.TrimSpace(id))] = true
+	}
+	return d
+}
+
+// TabEnabled reports whether tab num of a site's tabs, or a server's, is
+// shown. Numbers without a tab are reported as enabled.
+func (d DetailController) TabEnabled(num int, site bool) bool {
+	id := tabID(num, site)
+	return id == "" || !d.disabled[id]
+}
+
+// tabID returns the config.TabIDs ID of tab num of a site's tabs, or a
+// server's, or "" for the info view.
+func tabID(num int, site bool) string {
+	tabs := serverTabs
+	if site {
+		tabs = siteTabs
+	}
+	for _, t := range tabs {
+		if t.num == num {
+			return t.id
+		}
+	}
+	return ""
+}
+
+// tabName returns the tab bar label of the active tab, for a site's tabs
+// or a server's.
+func (d DetailController) tabName(site bool) string {
+	tabs := serverTabs
+	if site {
+		tabs = siteTabs
+	}
+	for _, t := range tabs {
+		if t.num == d.activeTab {
+			return t.name
+		}
+	}
+	return tabs[0].name
+}
+
+// renderTabBar renders the numbered section tabs at the top of the detail
+// panel, with mark after the active one's label.
+func (d DetailController) renderTabBar(width int, mark string) string {
+	// Tabs 6-9 change based on context (site selected vs server only).
+	var parts []string
+	for _, t := range siteTabs {
+		if d.disabled[t.id] {
+			continue
+		}
+		label := t.label()
+		if t.num == d.activeTab {
+			parts = append(parts, SelectedItemStyle.Render(label)+withMark(mark))
+		} else {
+			parts = append(parts, HelpBarStyle.Render(label))
+		}
+	}
+
+	bar := strings.Join(parts, "  ")
+	return theme.Truncate(bar, width)
+}
+
+// serverTabNums lists which activeTab values correspond to server-level panels.
+var serverTabNums = map[int]bool{1: true, 2: true, 3: true, 4: true, 5: true, 6: true, 7: true, 8: true, 9: true, backupsTab: true, recipesTab: true}
+
+// renderServerTabBar renders the server-level tab bar, with mark after the
+// active tab's label.
+func (d DetailController) renderServerTabBar(width int, mark string) string {
+	// If the active tab isn't a server-level tab, highlight Info.
+	activeForBar := d.activeTab
+	if !serverTabNums[activeForBar] {
+		activeForBar = 0
+	}
+
+	var parts []string
+	for _, t := range serverTabs {
+		if d.disabled[t.id] {
+			continue
+		}
+		label := t.label()
+		if t.num == activeForBar {
+			parts = append(parts, SelectedItemStyle.Render(label)+withMark(mark))
+		} else {
+			parts = append(parts, HelpBarStyle.Render(label))
+		}
+	}
+
+	bar := strings.Join(parts, "  ")
+	return theme.Truncate(bar, width)
+}
+
+// withMark returns mark with a leading space, or "" for no mark.
+func withMark(mark string) string {
+	if mark == "" {
+		return ""
+	}
+	return " " + HelpBarStyle.Render(mark)
+}
@@ -0,0 +1,174 @@
+package tui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// diagWindow is how many recent frames and updates the diagnostics
+// overlay averages over.
+const diagWindow = 60
+
+// teaPkg is the import path of bubbletea. Its messages are handed to the
+// model untouched since the runtime acts on some of them (batches,
+// sequences, quitting) before they reach Update.
+var teaPkg = reflect.TypeFor[tea.BatchMsg]().PkgPath()
+
+// timings keeps the last diagWindow durations of something.
+type timings struct {
+	buf  [diagWindow]time.Duration
+	next int
+	n    int
+}
+
+func (t *timings) add(d time.Duration) {
+	t.buf[t.next] = d
+	t.next = (t.next + 1) % diagWindow
+	if t.n < diagWindow {
+		t.n++
+	}
+}
+
+// stats returns the latest, mean and longest duration recorded.
+func (t *timings) stats() (last, avg, longest time.Duration) {
+	if t.n == 0 {
+		return 0, 0, 0
+	}
+	last = t.buf[(t.next+diagWindow-1)%diagWindow]
+	var sum time.Duration
+	for _, d := range t.buf[:t.n] {
+		sum += d
+		longest = max(longest, d)
+	}
+	return last, sum / time.Duration(t.n), longest
+}
+
+// diagnostics backs the F12 overlay that shows how long frames take to
+// render and messages to handle, and how much work is waiting. It is
+// shared by pointer so View, which gets a copy of the App, can record
+// into it too.
+type diagnostics struct {
+	enabled bool
+
+	renders timings
+	updates timings
+
+	// inflight counts tracked commands that are still running; queued
+	// counts messages they returned that Update hasn't received yet.
+	inflight atomic.Int64
+	queued   atomic.Int64
+
+	msgs    int
+	lastMsg string
+}
+
+// diagMsg wraps a message returned by a tracked command, so Update can
+// tell when it comes off the queue.
+type diagMsg struct {
+	msg tea.Msg
+}
+
+// track wraps cmd so the overlay can count it while it runs and the
+// message it returns until it is handled. The commands of a batch are
+// tracked one by one.
+func (d *diagnostics) track(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	d.inflight.Add(1)
+	return func() tea.Msg {
+		msg := cmd()
+		d.inflight.Add(-1)
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i, c := range batch {
+				batch[i] = d.track(c)
+			}
+			return batch
+		}
+		if msg == nil || msgPkg(msg) == teaPkg {
+			return msg
+		}
+		d.queued.Add(1)
+		return diagMsg{msg}
+	}
+}
+
+// msgPkg returns the import path of the package declaring msg's type.
+func msgPkg(msg tea.Msg) string {
+	t := reflect.TypeOf(msg)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.PkgPath()
+}
+
+// msgName returns msg's type name without its package path, e.g.
+// "tui.serversMsg".
+func msgName(msg tea.Msg) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", msg), "*")
+}
+
+// Update times m.update while the diagnostics overlay is open and
+// tracks the commands it returns. F12 opens and closes the overlay.
+func (m App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if w, ok := msg.(diagMsg); ok {
+		m.diag.queued.Add(-1)
+		msg = w.msg
+	}
+	if k, ok := msg.(tea.KeyPressMsg); ok && k.String() == "f12" {
+		m.diag.enabled = !m.diag.enabled
+		return m, nil
+	}
+	if !m.diag.enabled {
+		return m.updateAndPublish(msg)
+	}
+
+	start := time.Now()
+	model, cmd := m.updateAndPublish(msg)
+	m.diag.updates.add(time.Since(start))
+	m.diag.msgs++
+	m.diag.lastMsg = msgName(msg)
+	return model, m.diag.track(cmd)
+}
+
+// renderDiagnostics renders the diagnostics overlay.
+func (m App) renderDiagnostics() string {
+	d := m.diag
+	label := lipgloss.NewStyle().Foreground(theme.ColorSubtle)
+	row := func(name, value string) string {
+		return label.Render(fmt.Sprintf("%-9s", name)) + " " + value
+	}
+	timing := func(t *timings) string {
+		last, avg, longest := t.stats()
+		return fmt.Sprintf("%s  avg %s  max %s", ms(last), ms(avg), ms(longest))
+	}
+
+	lines := []string{
+		lipgloss.NewStyle().Bold(true).Foreground(theme.ColorPrimary).Render("Diagnostics") + label.Render("  F12: close"),
+		"",
+		row("Render", timing(&d.renders)),
+		row("Update", timing(&d.updates)),
+		row("Queued", fmt.Sprintf("%d messages", d.queued.Load())),
+		row("Running", fmt.Sprintf("%d commands", d.inflight.Load())),
+		row("Messages", fmt.Sprintf("%d, last %s", d.msgs, d.lastMsg)),
+		row("Output", fmt.Sprintf("%d lines", m.outputPanel.LineCount())),
+	}
+	return lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(theme.ColorPrimary).
+		Padding(0, 1).
+		Render(strings.Join(lines, "\n"))
+}
+
+// ms formats d in milliseconds with one decimal.
+func ms(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
@@ -0,0 +1,230 @@
+package tui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// DialogController owns the confirmation, input, text area, form and
+// picker modals. At most one of each is open at a time; results come back
+// as components.ConfirmResult, InputResult, FormResult and PickerResult
+// messages handled by the app.
+type DialogController struct {
+	confirm  *components.Confirm
+	input    *components.Input
+	textArea *components.TextArea
+	form     *components.Form
+	picker   *components.Picker
+
+	// history holds the values submitted to remembered inputs, by input
+	// ID, oldest first.
+	history map[string][]string
+}
+
+// maxInputHistory is how many values are remembered for each input.
+const maxInputHistory = 20
+
+// SetHistory sets the remembered input values, e.g. from the saved state.
+func (d DialogController) SetHistory(history map[string][]string) DialogController {
+	d.history = history
+	return d
+}
+
+// History returns the remembered input values, by input ID.
+func (d DialogController) History() map[string][]string {
+	return d.history
+}
+
+// remember adds value to the history of input id, moving it to the end if
+// it was there already.
+func (d DialogController) remember(id, value string) DialogController {
+	if value == "" {
+		return d
+	}
+	values := slices.DeleteFunc(slices.Clone(d.history[id]), func(v string) bool { return v == value })
+	values = append(values, value)
+	if len(values) > maxInputHistory {
+		values = values[len(values)-maxInputHistory:]
+	}
+	d.history = maps.Clone(d.history)
+	if d.history == nil {
+		d.history = make(map[string][]string)
+	}
+	d.history[id] = values
+	return d
+}
+
+// Confirm opens a yes/no dialog. id is echoed back in the ConfirmResult.
+func (d DialogController) Confirm(id, question string) DialogController {
+	c := components.NewConfirm(id, question)
+	d.confirm = &c
+	return d
+}
+
+// Prompt opens an input dialog built with components.NewInput or
+// components.NewInputWide, with the values remembered for its ID when it
+// is marked with Remember.
+func (d DialogController) Prompt(i components.Input) DialogController {
+	if i.Remembers() {
+		i = i.SetHistory(d.history[i.ID])
+	}
+	d.input = &i
+	return d
+}
+
+// Compose opens a multi-line input dialog built with
+// components.NewTextArea. Its value comes back as an InputResult.
+func (d DialogController) Compose(t components.TextArea) DialogController {
+	d.textArea = &t
+	return d
+}
+
+// Form opens a multi-field form built with components.NewForm.
+func (d DialogController) Form(f components.Form) DialogController {
+	d.form = &f
+	return d
+}
+
+// Pick opens a searchable list built with components.NewPicker.
+func (d DialogController) Pick(p components.Picker) DialogController {
+	d.picker = &p
+	return d
+}
+
+// Active returns whether any dialog is waiting for the user.
+func (d DialogController) Active() bool {
+	return d.InputActive() || d.TextAreaActive() || d.FormActive() || d.PickerActive() || d.ConfirmActive()
+}
+
+// PickerActive returns whether a picker is open.
+func (d DialogController) PickerActive() bool {
+	return d.picker != nil && d.picker.Active
+}
+
+// FormActive returns whether a form is open.
+func (d DialogController) FormActive() bool {
+	return d.form != nil && d.form.Active
+}
+
+// InputActive returns whether an input dialog is open.
+func (d DialogController) InputActive() bool {
+	return d.input != nil && d.input.Active
+}
+
+// TextAreaActive returns whether a multi-line input dialog is open.
+func (d DialogController) TextAreaActive() bool {
+	return d.textArea != nil && d.textArea.Active
+}
+
+// ConfirmActive returns whether a confirmation dialog is open.
+func (d DialogController) ConfirmActive() bool {
+	return d.confirm != nil && d.confirm.Active
+}
+
+// Update routes key events and pastes to the open dialog (input, text
+// area, form, picker, then confirm) and forgets a dialog once its result
+// or cancellation message arrives. It reports whether msg was for the
+// dialogs: a key or paste while one is open, or a result or cancellation,
+// which the caller then acts on.
+func (d DialogController) Update(msg tea.Msg) (DialogController, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		if d.InputActive() {
+			i, cmd := d.input.Update(msg)
+			d.input = &i
+			return d, cmd, true
+		}
+		if d.TextAreaActive() {
+			t, cmd := d.textArea.Update(msg)
+			d.textArea = &t
+			return d, cmd, true
+		}
+		if d.FormActive() {
+			f, cmd := d.form.Update(msg)
+			d.form = &f
+			return d, cmd, true
+		}
+		if d.PickerActive() {
+			p, cmd := d.picker.Update(msg)
+			d.picker = &p
+			return d, cmd, true
+		}
+		if d.ConfirmActive() {
+			c, cmd := d.confirm.Update(msg)
+			d.confirm = &c
+			return d, cmd, true
+		}
+		return d, nil, false
+	case tea.PasteMsg, components.InputPasted:
+		if d.InputActive() {
+			i, cmd := d.input.Update(msg)
+			d.input = &i
+			return d, cmd, true
+		}
+		if d.TextAreaActive() {
+			t, cmd := d.textArea.Update(msg)
+			d.textArea = &t
+			return d, cmd, true
+		}
+		if d.FormActive() {
+			f, cmd := d.form.Update(msg)
+			d.form = &f
+			return d, cmd, true
+		}
+		// Pickers and confirmations take no text, but still swallow it.
+		return d, nil, d.Active()
+	case components.InputResult:
+		if d.input != nil && d.input.Remembers() {
+			d = d.remember(msg.ID, strings.TrimSpace(msg.Value))
+		}
+		d.input = nil
+		d.textArea = nil
+	case components.InputCancelled:
+		d.input = nil
+		d.textArea = nil
+	case components.FormResult, components.FormCancelled:
+		d.form = nil
+	case components.PickerResult, components.PickerCancelled:
+		d.picker = nil
+	case components.ConfirmResult:
+		d.confirm = nil
+	default:
+		return d, nil, false
+	}
+	return d, nil, true
+}
+
+// View overlays the open dialogs, if any, on content.
+func (d DialogController) View(content string, width, height int) string {
+	if d.InputActive() {
+		if overlay := d.input.View(width, height); overlay != "" {
+			content = overlayCenter(overlay, content, width, height)
+		}
+	}
+	if d.TextAreaActive() {
+		if overlay := d.textArea.View(width, height); overlay != "" {
+			content = overlayCenter(overlay, content, width, height)
+		}
+	}
+	if d.FormActive() {
+		if overlay := d.form.View(width, height); overlay != "" {
+			content = overlayCenter(overlay, content, width, height)
+		}
+	}
+	if d.PickerActive() {
+		if overlay := d.picker.View(width, height); overlay != "" {
+			content = overlayCenter(overlay, content, width, height)
+		}
+	}
+	if d.ConfirmActive() {
+		if overlay := d.confirm.View(width, height); overlay != "" {
+			content = overlayCenter(overlay, content, width, height)
+		}
+	}
+	return content
+}
@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// submit presses keys in the open input dialog, then Enter, and returns
+// the controller once the result has come back, with the submitted value.
+func submit(t *testing.T, d DialogController, keys ...tea.Msg) (DialogController, string) {
+	t.Helper()
+	for _, k := range keys {
+		d, _, _ = d.Update(k)
+	}
+	d, cmd, _ := d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Enter didn't submit the input")
+	}
+	result, ok := cmd().(components.InputResult)
+	if !ok {
+		t.Fatal("Enter didn't produce an InputResult")
+	}
+	d, _, _ = d.Update(result)
+	return d, result.Value
+}
+
+func TestInputHistory(t *testing.T) {
+	var d DialogController
+	up := tea.KeyPressMsg{Code: tea.KeyUp}
+	down := tea.KeyPressMsg{Code: tea.KeyDown}
+	run := func() components.Input { return components.NewInput("run-command", "Command:", "").Remember() }
+
+	d = d.Prompt(run())
+	d, _ = submit(t, d, tea.PasteMsg{Content: "php artisan migrate"})
+	d = d.Prompt(run())
+	d, _ = submit(t, d, tea.PasteMsg{Content: "php artisan queue:restart"})
+
+	d = d.Prompt(run())
+	d, got := submit(t, d, up, up)
+	if got != "php artisan migrate" {
+		t.Errorf("up twice = %q, want the older command", got)
+	}
+	if h := d.History()["run-command"]; len(h) != 2 || h[1] != "php artisan migrate" {
+		t.Errorf("history after resubmitting = %q, want the recalled value moved last", h)
+	}
+
+	d = d.Prompt(run())
+	if _, got := submit(t, d, tea.PasteMsg{Content: "ls"}, up, down); got != "ls" {
+		t.Errorf("up then down = %q, want the draft back", got)
+	}
+
+	// Inputs that aren't remembered keep no history, e.g. for secrets.
+	d = d.Prompt(components.NewInput("settings-api-key", "API Key:", ""))
+	d, _ = submit(t, d, tea.PasteMsg{Content: "secret"})
+	if _, ok := d.History()["settings-api-key"]; ok {
+		t.Error("an input without Remember was added to the history")
+	}
+}
+
+func TestComposeKeepsNewlines(t *testing.T) {
+	d := DialogController{}.Compose(components.NewTextArea("install-cert", "Certificate:", ""))
+	pem := "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----"
+	d, _, _ = d.Update(tea.PasteMsg{Content: pem})
+	d, _, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	d, _, _ = d.Update(tea.PasteMsg{Content: "MIIC"})
+
+	d, cmd, _ := d.Update(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	if cmd == nil {
+		t.Fatal("ctrl+s didn't submit the text area")
+	}
+	result, ok := cmd().(components.InputResult)
+	if !ok {
+		t.Fatal("ctrl+s didn't produce an InputResult")
+	}
+	if want := pem + "\nMIIC"; result.Value != want {
+		t.Errorf("value = %q, want %q", result.Value, want)
+	}
+	d, _, _ = d.Update(result)
+	if d.Active() {
+		t.Error("the text area is still open after its result")
+	}
+}
+
+func TestWorkerFormDefaults(t *testing.T) {
+	d := DialogController{}.Form(workerForm("php83"))
+	var cmd tea.Cmd
+	for range 8 {
+		d, cmd, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	}
+	if cmd == nil {
+		t.Fatal("Enter on the last field didn't submit the form")
+	}
+	result, ok := cmd().(components.FormResult)
+	if !ok {
+		t.Fatal("submitting didn't produce a FormResult")
+	}
+	if result.Get("connection") != "redis" || result.Get("queue") != "default" ||
+		result.Int("processes", 0) != 1 || result.Int("timeout", 0) != 60 || result.Int("sleep", 0) != 3 {
+		t.Errorf("values = %v, want Forge's defaults", result.Values)
+	}
+	if result.Get("tries") != "" || result.Bool("force") || result.Get("php") != "" {
+		t.Errorf("values = %v, want tries, force and PHP version left blank", result.Values)
+	}
+}
+
+func TestValidatePHPVersion(t *testing.T) {
+	for _, s := range []string{"php84", "php74", "php"} {
+		err := validatePHPVersion(s)
+		if (err == nil) != (s != "php") {
+			t.Errorf("validatePHPVersion(%q) = %v", s, err)
+		}
+	}
+	if validatePHPVersion("8.4") == nil {
+		t.Error("validatePHPVersion accepted 8.4")
+	}
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"os"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// ApplyDisplay switches the theme, glyphs and timezone to match the
+// display settings in ui and returns the program options they need. A
+// non-empty NO_COLOR environment variable counts as ui.no_color. Call it
+// before the first program is started.
+func ApplyDisplay(ui config.UIConfig) []tea.ProgramOption {
+	if ui.Theme == config.ThemeHighContrast {
+		theme.UsePalette(theme.HighContrastPalette)
+	}
+	if ui.ASCII {
+		theme.UseASCII()
+	}
+	if ui.Timezone != "" {
+		// An unknown timezone was warned about when the config was loaded.
+		if loc, err := time.LoadLocation(ui.Timezone); err == nil {
+			panels.UseTimezone(loc)
+		}
+	}
+	if ui.NoColor || os.Getenv("NO_COLOR") != "" {
+		return []tea.ProgramOption{tea.WithColorProfile(colorprofile.Ascii)}
+	}
+	return nil
+}
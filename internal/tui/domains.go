@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/tui/panels"
+)
+
+// maxDiffLines caps how many lines of an alias diff the confirmation shows.
+const maxDiffLines = 15
+
+// previewBulkAliases parses pasted domains and asks for confirmation of
+// the resulting alias list, shown as a diff against the current one.
+func (m App) previewBulkAliases(text string) (tea.Model, tea.Cmd) {
+	domains, invalid := panels.ParseDomains(text)
+	merged, added := m.detail.domainsPanel.MergeAliases(domains)
+	if len(added) == 0 {
+		m.toast = "No new domain aliases to add"
+		if len(invalid) > 0 {
+			m.toast = fmt.Sprintf("Not domains: %s", strings.Join(invalid, ", "))
+		}
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+
+	// Additions come last, so a long diff loses its first lines.
+	diff := panels.AliasDiff(m.detail.domainsPanel.Aliases(), merged)
+	if len(diff) > maxDiffLines {
+		skipped := len(diff) - maxDiffLines + 1
+		diff = append([]string{fmt.Sprintf("  ... %d more", skipped)}, diff[skipped:]...)
+	}
+
+	// Pad the lines to one width so the dialog doesn't centre each one.
+	width := 0
+	for _, l := range diff {
+		width = max(width, lipgloss.Width(l))
+	}
+	for i, l := range diff {
+		diff[i] = l + strings.Repeat(" ", width-lipgloss.Width(l))
+	}
+
+	question := fmt.Sprintf("Add %d domain alias(es)?\n\n%s", len(added), strings.Join(diff, "\n"))
+	if len(invalid) > 0 {
+		question += fmt.Sprintf("\n\nSkipping (not domains): %s", strings.Join(invalid, ", "))
+	}
+	m.pendingInputValue = strings.Join(merged, "\n")
+	m.dialogs = m.dialogs.Confirm("bulk-domains", question)
+	return m, nil
+}
+
+// handleRedirectsKey handles keys specific to the redirect rules sub-view.
+func (m App) handleRedirectsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.dialogs = m.dialogs.Form(redirectForm())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if r := m.detail.redirectsPanel.SelectedRedirect(); r != nil {
+			m.dialogs = m.dialogs.Confirm("delete-redirect", fmt.Sprintf("Delete redirect from %s to %s?", r.From, r.To))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleSecurityKey handles keys specific to the security rules sub-view.
+func (m App) handleSecurityKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.dialogs = m.dialogs.Form(securityRuleForm())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if r := m.detail.securityPanel.SelectedRule(); r != nil {
+			m.dialogs = m.dialogs.Confirm("delete-security-rule", fmt.Sprintf("Delete security rule %s?\nIts path is open to anyone again.", r.Name))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
@@ -0,0 +1,266 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// envCopy is a copy of .env variables from one site to another in
+// progress: the source, the lines picked and, once chosen, the target.
+type envCopy struct {
+	from  string // "server/site" of the source
+	lines []envLine
+
+	targets []envCopyTarget
+	target  *envCopyTarget
+}
+
+// envCopyTarget is a site the variables can be copied to.
+type envCopyTarget struct {
+	server forge.Server
+	site   forge.Site
+}
+
+// envCopyTargetsMsg carries every site the variables can be copied to.
+type envCopyTargetsMsg struct {
+	targets []envCopyTarget
+	err     error
+}
+
+// envCopyEditedMsg carries the picked lines once the editor exits.
+type envCopyEditedMsg struct {
+	content string
+	err     error
+}
+
+// envCopiedMsg is sent once the target's .env file has been updated.
+type envCopiedMsg struct {
+	target         string
+	updated, added int
+	err            error
+}
+
+// startEnvCopy lists the variables of the site's .env file for picking.
+func (m App) startEnvCopy() (tea.Model, tea.Cmd) {
+	content, ok := m.detail.environmentPanel.Content()
+	if !ok || m.selectedSrv == nil || m.selectedSite == nil {
+		return m, nil
+	}
+	lines := parseEnvLines(content)
+	if len(lines) == 0 {
+		m.toast = "The .env file has no variables to copy"
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	m.pendingEnvCopy = &envCopy{
+		from:  m.selectedSrv.Name + "/" + m.selectedSite.Name,
+		lines: lines,
+	}
+	options := make([]components.PickerOption, len(lines))
+	for i, l := range lines {
+		options[i] = components.PickerOption{Label: l.key, Detail: l.value, Value: strconv.Itoa(i)}
+	}
+	m.dialogs = m.dialogs.Pick(components.NewMultiPicker("env-copy-keys", "Variables to copy:", options))
+	return m, nil
+}
+
+// pickedEnvCopyKeys keeps the picked lines and fetches the sites they can
+// be copied to.
+func (m App) pickedEnvCopyKeys(values []string) (tea.Model, tea.Cmd) {
+	c := m.pendingEnvCopy
+	if c == nil {
+		return m, nil
+	}
+	var picked []envLine
+	for _, v := range values {
+		if i, err := strconv.Atoi(v); err == nil && i < len(c.lines) {
+			picked = append(picked, c.lines[i])
+		}
+	}
+	c.lines = picked
+
+	client := m.forge
+	servers := m.treePanel.Servers()
+	sourceID := m.selectedSite.ID
+	m.toast = "Loading sites..."
+	m.toastIsErr = false
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		var targets []envCopyTarget
+		for _, srv := range servers {
+			sites, err := client.Sites.List(ctx, srv.ID)
+			if err != nil {
+				return envCopyTargetsMsg{err: err}
+			}
+			for _, site := range sites {
+				if site.ID != sourceID {
+					targets = append(targets, envCopyTarget{server: srv, site: site})
+				}
+			}
+		}
+		return envCopyTargetsMsg{targets: targets}
+	}
+}
+
+// handleEnvCopyTargets offers the sites to copy to.
+func (m App) handleEnvCopyTargets(msg envCopyTargetsMsg) (tea.Model, tea.Cmd) {
+	c := m.pendingEnvCopy
+	if c == nil {
+		return m, nil
+	}
+	if msg.err != nil || len(msg.targets) == 0 {
+		m.pendingEnvCopy = nil
+		m.toast = "No other sites to copy to"
+		if msg.err != nil {
+			m.toast = fmt.Sprintf("Loading sites failed: %v", msg.err)
+		}
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	m.toast = ""
+	c.targets = msg.targets
+	options := make([]components.PickerOption, len(msg.targets))
+	for i, t := range msg.targets {
+		options[i] = components.PickerOption{Label: t.site.Name, Detail: t.server.Name, Value: strconv.Itoa(i)}
+	}
+	title := fmt.Sprintf("Copy %d variable(s) to:", len(c.lines))
+	m.dialogs = m.dialogs.Pick(components.NewPicker("env-copy-site", title, options))
+	return m, nil
+}
+
+// pickedEnvCopySite opens the editor on the picked lines, so values can
+// be rewritten for the target before they are copied.
+func (m App) pickedEnvCopySite(value string) (tea.Model, tea.Cmd) {
+	c := m.pendingEnvCopy
+	i, err := strconv.Atoi(value)
+	if c == nil || err != nil || i >= len(c.targets) {
+		return m, nil
+	}
+	c.target = &c.targets[i]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Copying from %s to %s/%s.\n", c.from, c.target.server.Name, c.target.site.Name)
+	b.WriteString("# Edit values for the target; delete a line to skip it, or every line to cancel.\n")
+	for _, l := range c.lines {
+		b.WriteString(l.raw + "\n")
+	}
+
+	tmpFile, err := os.CreateTemp("", "phorge-env-copy-*.env")
+	if err != nil {
+		m.pendingEnvCopy = nil
+		return m, func() tea.Msg { return envCopyEditedMsg{err: err} }
+	}
+	_, err = tmpFile.WriteString(b.String())
+	tmpFile.Close()
+	path := tmpFile.Name()
+	if err != nil {
+		os.Remove(path)
+		return m, func() tea.Msg { return envCopyEditedMsg{err: err} }
+	}
+
+	editor := m.config.Editor.Command
+	if editor == "" {
+		editor = "vim"
+	}
+	return m, tea.ExecProcess(exec.Command(editor, path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return envCopyEditedMsg{err: err}
+		}
+		data, err := os.ReadFile(path)
+		return envCopyEditedMsg{content: string(data), err: err}
+	})
+}
+
+// handleEnvCopyEdited writes the edited lines into the target's .env file.
+func (m App) handleEnvCopyEdited(msg envCopyEditedMsg) (tea.Model, tea.Cmd) {
+	c := m.pendingEnvCopy
+	m.pendingEnvCopy = nil
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Copy failed: %v", msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	lines := parseEnvLines(msg.content)
+	if c == nil || c.target == nil || len(lines) == 0 {
+		m.toast = "Copy cancelled"
+		m.toastIsErr = false
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+
+	client := m.forge
+	target := *c.target
+	name := target.server.Name + "/" + target.site.Name
+	m.toast = fmt.Sprintf("Copying %d variable(s) to %s...", len(lines), name)
+	m.toastIsErr = false
+	return m, func() tea.Msg {
+		ctx := context.Background()
+		content, err := client.Environment.Get(ctx, target.server.ID, target.site.ID)
+		if err != nil {
+			return envCopiedMsg{target: name, err: err}
+		}
+		merged, updated, added := mergeEnvLines(content, lines)
+		err = client.Environment.Update(ctx, target.server.ID, target.site.ID, merged)
+		return envCopiedMsg{target: name, updated: updated, added: added, err: err}
+	}
+}
+
+// handleEnvCopied reports the result of a copy.
+func (m App) handleEnvCopied(msg envCopiedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Copy to %s failed: %v", msg.target, msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	m.toast = fmt.Sprintf("Copied to %s: %d updated, %d added", msg.target, msg.updated, msg.added)
+	m.toastIsErr = false
+	return m, m.clearToastAfter(3 * time.Second)
+}
+
+// mergeEnvLines sets lines in the .env file content: an assignment to the
+// same key is replaced in place, and keys the file lacks are appended.
+// It returns the new content and how many keys were replaced and added.
+func mergeEnvLines(content string, lines []envLine) (merged string, updated, added int) {
+	set := make(map[string]string, len(lines))
+	for _, l := range lines {
+		set[l.key] = l.raw
+	}
+
+	out := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	if content == "" {
+		out = nil
+	}
+	done := make(map[string]bool)
+	for i, line := range out {
+		parsed := parseEnvLines(line)
+		if len(parsed) == 0 {
+			continue
+		}
+		if raw, ok := set[parsed[0].key]; ok {
+			out[i] = raw
+			if !done[parsed[0].key] {
+				updated++
+			}
+			done[parsed[0].key] = true
+		}
+	}
+	for _, l := range lines {
+		if !done[l.key] {
+			out = append(out, l.raw)
+			added++
+		}
+		done[l.key] = true
+	}
+	return strings.Join(out, "\n") + "\n", updated, added
+}
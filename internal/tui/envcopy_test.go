@@ -0,0 +1,21 @@
+package tui
+
+import "testing"
+
+func TestMergeEnvLines(t *testing.T) {
+	content := "APP_NAME=Shop\n# Mail\nMAIL_HOST=smtp.example.com\nexport APP_ENV=production\n"
+	lines := parseEnvLines("APP_ENV=staging\nMAIL_HOST=mailpit\nSTRIPE_KEY=\"sk_test\"\n")
+
+	got, updated, added := mergeEnvLines(content, lines)
+	want := "APP_NAME=Shop\n# Mail\nMAIL_HOST=mailpit\nAPP_ENV=staging\nSTRIPE_KEY=\"sk_test\"\n"
+	if got != want {
+		t.Errorf("merged =\n%s\nwant\n%s", got, want)
+	}
+	if updated != 2 || added != 1 {
+		t.Errorf("updated, added = %d, %d, want 2, 1", updated, added)
+	}
+
+	if got, _, added := mergeEnvLines("", lines[:1]); got != "APP_ENV=staging\n" || added != 1 {
+		t.Errorf("merge into empty file = %q, %d added", got, added)
+	}
+}
@@ -1,7 +1,6 @@
 package tui
 
 import (
-	"context"
 	"fmt"
 	"net"
 	"os"
@@ -129,14 +128,11 @@ func (m App) databaseCmd() tea.Cmd {
 	user := m.config.SSHUserFor(srv.Name)
 
 	return func() tea.Msg {
-		// Fetch the .env file from the Forge API.
-		envContent, err := client.Environment.Get(context.Background(), srv.ID, site.ID)
+		// Fetch the .env file and parse the DB credentials from it.
+		dbCreds, err := fetchDBCreds(client, srv.ID, site.ID)
 		if err != nil {
-			return errMsg{fmt.Errorf("failed to fetch .env: %w", err)}
+			return errMsg{err}
 		}
-
-		// Parse the DB credentials from the .env content.
-		dbCreds := parseEnvVars(envContent)
 		if dbCreds["DB_HOST"] == "" {
 			return errMsg{fmt.Errorf("DB_HOST not found in .env")}
 		}
@@ -229,25 +225,77 @@ func (m App) handleDBReady(msg dbReadyMsg) (App, tea.Cmd) {
 }
 
 // parseEnvVars parses a .env file content into a map of key-value pairs.
-// It handles comments, empty lines, and quoted values.
+// It handles comments, empty lines, export prefixes and quoted values.
 func parseEnvVars(content string) map[string]string {
 	vars := make(map[string]string)
-	for _, line := range strings.Split(content, "\n") {
-		line = strings.TrimSpace(line)
+	for _, e := range parseEnvLines(content) {
+		vars[e.key] = e.value
+	}
+	return vars
+}
+
+// envLine is one assignment in a .env file: its key, its unquoted value
+// and the line as written.
+type envLine struct {
+	key, value, raw string
+}
+
+// parseEnvLines returns the assignments of a .env file in file order,
+// skipping comments, empty lines and lines without a key.
+func parseEnvLines(content string) []envLine {
+	var lines []envLine
+	for _, raw := range strings.Split(content, "\n") {
+		line := strings.TrimSpace(raw)
 		if line == "" || strings.HasPrefix(line, "#") {
 			continue
 		}
-		i := strings.IndexByte(line, '=')
-		if i < 0 {
+		if rest, ok := strings.CutPrefix(line, "export "); ok {
+			line = strings.TrimSpace(rest)
+		}
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
 			continue
 		}
-		key := strings.TrimSpace(line[:i])
-		value := strings.TrimSpace(line[i+1:])
-		// Remove surrounding quotes (single or double).
-		value = strings.Trim(value, `"'`)
-		vars[key] = value
+		lines = append(lines, envLine{key: key, value: envValue(strings.TrimSpace(value)), raw: strings.TrimSpace(raw)})
 	}
-	return vars
+	return lines
+}
+
+// envValue returns the value of a .env assignment: the text inside a pair
+// of quotes, with \" and \\ unescaped in double quotes, or an unquoted value
+// up to a # comment preceded by whitespace. An unclosed quote is dropped.
+func envValue(v string) string {
+	if v == "" {
+		return v
+	}
+	switch q := v[0]; q {
+	case '\'':
+		if end := strings.IndexByte(v[1:], q); end >= 0 {
+			return v[1 : end+1]
+		}
+		return v[1:]
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(v); i++ {
+			switch c := v[i]; {
+			case c == '"':
+				return b.String()
+			case c == '\\' && i+1 < len(v) && (v[i+1] == '"' || v[i+1] == '\\'):
+				i++
+				b.WriteByte(v[i])
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return b.String()
+	}
+	for i := 1; i < len(v); i++ {
+		if v[i] == '#' && (v[i-1] == ' ' || v[i-1] == '\t') {
+			return strings.TrimSpace(v[:i])
+		}
+	}
+	return v
 }
 
 // findFreePort asks the OS for an available TCP port.
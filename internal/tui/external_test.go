@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"maps"
+	"strings"
+	"testing"
+)
+
+func TestParseEnvVars(t *testing.T) {
+	content := strings.Join([]string{
+		"# Database",
+		"DB_CONNECTION=mysql",
+		"  DB_HOST = 127.0.0.1  ",
+		"",
+		`DB_PASSWORD="p4ss word"`,
+		`DB_USERNAME='forge'`,
+		`MIXED="it's"`,
+		`TRAILING="quoted'`,
+		`ESCAPED="say \"hi\" \\o/"`,
+		"export APP_ENV=production",
+		"APP_NAME=Shop # the storefront",
+		"HASH=abc#def",
+		`QUOTED_HASH="a # b" # comment`,
+		"EMPTY=",
+		"CRLF=value\r",
+		"no equals sign",
+		"=no key",
+	}, "\n")
+
+	want := map[string]string{
+		"DB_CONNECTION": "mysql",
+		"DB_HOST":       "127.0.0.1",
+		"DB_PASSWORD":   "p4ss word",
+		"DB_USERNAME":   "forge",
+		"MIXED":         "it's",
+		"TRAILING":      "quoted'",
+		"ESCAPED":       `say "hi" \o/`,
+		"APP_ENV":       "production",
+		"APP_NAME":      "Shop",
+		"HASH":          "abc#def",
+		"QUOTED_HASH":   "a # b",
+		"EMPTY":         "",
+		"CRLF":          "value",
+	}
+	if got := parseEnvVars(content); !maps.Equal(got, want) {
+		t.Errorf("parseEnvVars =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func FuzzParseEnvVars(f *testing.F) {
+	f.Add("KEY=value\n# comment\nOTHER=\"quoted\"")
+	f.Add(`A="unterminated`)
+	f.Add(`B="\`)
+	f.Add("export C='x' # y\r\n=\n")
+	f.Fuzz(func(t *testing.T, content string) {
+		for k, v := range parseEnvVars(content) {
+			if k == "" || k != strings.TrimSpace(k) || strings.ContainsAny(k, "=\n") {
+				t.Errorf("bad key %q", k)
+			}
+			if strings.Contains(v, "\n") {
+				t.Errorf("value of %q spans lines: %q", k, v)
+			}
+		}
+	})
+}
+
+// FuzzParseEnvVarsRoundTrip writes a value the ways a .env file can hold
+// it and checks each parses back to the same value.
+func FuzzParseEnvVarsRoundTrip(f *testing.F) {
+	f.Add("APP_KEY", "base64:abc=")
+	f.Add("DB_PASSWORD", `p"a's#s \ word`)
+	f.Add("NAME", " padded ")
+	f.Fuzz(func(t *testing.T, key, value string) {
+		validKey := key != "" && strings.Trim(key, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_.") == ""
+		if !validKey || strings.ContainsAny(value, "\r\n") {
+			t.Skip()
+		}
+		escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value)
+		lines := []string{key + `="` + escaped + `"`}
+		if !strings.Contains(value, "'") {
+			lines = append(lines, key+"='"+value+"'")
+		}
+		if value == strings.TrimSpace(value) && !strings.ContainsAny(value, `#"'`) {
+			lines = append(lines, key+"="+value)
+		}
+		for _, line := range lines {
+			if got := parseEnvVars(line)[key]; got != value {
+				t.Errorf("parseEnvVars(%q)[%q] = %q, want %q", line, key, got, value)
+			}
+		}
+	})
+}
@@ -0,0 +1,223 @@
+package tui
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// firewallRuleForm asks for the fields of a new firewall rule.
+func firewallRuleForm() components.Form {
+	return components.NewForm("create-firewall", "New firewall rule",
+		components.FormField{Key: "name", Label: "Name", Placeholder: "HTTP", Required: true},
+		components.FormField{Key: "port", Label: "Port or range (from:to)", Placeholder: "80", Required: true, Validate: validatePortSpec},
+		components.FormField{Key: "ip", Label: "From IP address (blank for any)", Placeholder: "203.0.113.7", Validate: validateIPOrCIDR},
+		components.FormField{Key: "type", Label: "Action", Kind: components.FieldSelect, Options: []string{"allow", "deny"}},
+	)
+}
+
+// daemonForm asks for the fields of a new daemon.
+func daemonForm() components.Form {
+	return components.NewForm("create-daemon", "New daemon",
+		components.FormField{Key: "command", Label: "Command", Placeholder: "php artisan queue:work", Required: true},
+		components.FormField{Key: "user", Label: "User", Value: "forge", Required: true},
+		components.FormField{Key: "directory", Label: "Directory (optional)", Placeholder: "/home/forge/example.com"},
+		components.FormField{Key: "processes", Label: "Processes", Value: "1", Required: true, Validate: components.IntRange(1, 100)},
+	)
+}
+
+// redirectForm asks for the fields of a new redirect rule.
+func redirectForm() components.Form {
+	return components.NewForm("create-redirect", "New redirect rule",
+		components.FormField{Key: "from", Label: "From path", Placeholder: "/old-page", Required: true, Validate: validateRedirectFrom},
+		components.FormField{Key: "to", Label: "To path or URL", Placeholder: "/new-page", Required: true},
+		components.FormField{Key: "type", Label: "Status code", Kind: components.FieldSelect, Options: []string{"301", "302"}},
+	)
+}
+
+// securityRuleForm asks for the fields of a new security rule and the
+// first user it lets through. A blank path protects the whole site.
+func securityRuleForm() components.Form {
+	return components.NewForm("create-security-rule", "New security rule",
+		components.FormField{Key: "name", Label: "Name", Placeholder: "Staging", Required: true},
+		components.FormField{Key: "path", Label: "Path (blank for the whole site)", Placeholder: "/admin", Validate: validateSecurityPath},
+		components.FormField{Key: "username", Label: "Username", Placeholder: "client", Required: true},
+		components.FormField{Key: "password", Label: "Password", Required: true, Secret: true},
+	)
+}
+
+// workerForm asks for the settings of a new queue worker, starting from
+// Forge's defaults. A blank PHP version runs the worker on the site's
+// version, sitePHP, which the field shows as its placeholder.
+func workerForm(sitePHP string) components.Form {
+	if sitePHP == "" {
+		sitePHP = "php84"
+	}
+	return components.NewForm("create-worker", "New queue worker",
+		components.FormField{Key: "connection", Label: "Connection", Value: "redis", Required: true},
+		components.FormField{Key: "queue", Label: "Queue", Value: "default", Required: true},
+		components.FormField{Key: "processes", Label: "Processes", Value: "1", Required: true, Validate: components.IntRange(1, 100)},
+		components.FormField{Key: "timeout", Label: "Timeout (seconds)", Value: "60", Required: true, Validate: components.IntRange(0, 86400)},
+		components.FormField{Key: "sleep", Label: "Sleep when idle (seconds)", Value: "3", Required: true, Validate: components.IntRange(0, 3600)},
+		components.FormField{Key: "tries", Label: "Tries (blank for unlimited)", Placeholder: "3", Validate: components.IntRange(1, 1000)},
+		components.FormField{Key: "force", Label: "Run in maintenance mode", Kind: components.FieldBool},
+		components.FormField{Key: "php", Label: "PHP version (blank for the site's)", Placeholder: sitePHP, Validate: validatePHPVersion},
+	)
+}
+
+// gitInstallForm asks for the repository to install on a blank site.
+func gitInstallForm() components.Form {
+	return components.NewForm("install-repo", "Install repository",
+		components.FormField{Key: "provider", Label: "Provider", Kind: components.FieldSelect, Options: forge.GitProviders},
+		components.FormField{Key: "repository", Label: "Repository", Placeholder: "acme/api", Required: true},
+		components.FormField{Key: "branch", Label: "Branch", Value: "main", Required: true},
+		components.FormField{Key: "composer", Label: "Install Composer dependencies", Kind: components.FieldBool, Value: "true"},
+	)
+}
+
+// dbUserForm asks for a new database user's name and password. A blank
+// password is generated.
+func dbUserForm() components.Form {
+	return components.NewForm("create-dbuser", "New database user",
+		components.FormField{Key: "name", Label: "Username", Placeholder: "forge_user", Required: true, Validate: validateDBIdentifier},
+		components.FormField{Key: "password", Label: "Password (blank to generate)", Placeholder: "auto-generate", Secret: true},
+	)
+}
+
+// handleFormResult dispatches a submitted form by ID.
+func (m App) handleFormResult(msg components.FormResult) (tea.Model, tea.Cmd) {
+	switch msg.ID {
+	case "create-firewall":
+		return m, m.detail.firewallPanel.CreateRule(forge.FirewallCreateOpts{
+			Name:      msg.Get("name"),
+			Port:      msg.Get("port"),
+			IPAddress: msg.Get("ip"),
+			Type:      msg.Get("type"),
+		})
+	case "create-redirect":
+		redirectType := forge.RedirectPermanent
+		if msg.Get("type") == "302" {
+			redirectType = forge.RedirectTemporary
+		}
+		return m, m.detail.redirectsPanel.CreateRedirect(forge.RedirectCreateOpts{
+			From: msg.Get("from"),
+			To:   msg.Get("to"),
+			Type: redirectType,
+		})
+	case "create-security-rule":
+		return m, m.detail.securityPanel.CreateRule(forge.SecurityRuleCreateOpts{
+			Name: msg.Get("name"),
+			Path: msg.Get("path"),
+			Credentials: []forge.SecurityCredentialInput{
+				{Username: msg.Get("username"), Password: msg.Values["password"]},
+			},
+		})
+	case "install-repo":
+		return m, m.installRepo(forge.GitInstallOpts{
+			Provider:   msg.Get("provider"),
+			Repository: msg.Get("repository"),
+			Branch:     msg.Get("branch"),
+			Composer:   msg.Bool("composer"),
+		})
+	case "create-dbuser":
+		return m, m.detail.dbUsersPanel.CreateUser(msg.Get("name"), msg.Values["password"])
+	case "create-worker":
+		return m, m.detail.workersPanel.CreateWorker(forge.WorkerCreateOpts{
+			Connection: msg.Get("connection"),
+			Queue:      msg.Get("queue"),
+			Processes:  msg.Int("processes", 1),
+			Timeout:    msg.Int("timeout", 60),
+			Sleep:      msg.Int("sleep", 3),
+			Tries:      msg.Int("tries", 0),
+			Daemon:     true,
+			Force:      msg.Bool("force"),
+			PHPVersion: msg.Get("php"),
+		})
+	case "create-daemon":
+		return m, m.detail.daemonsPanel.CreateDaemon(forge.DaemonCreateOpts{
+			Command:   msg.Get("command"),
+			User:      msg.Get("user"),
+			Directory: msg.Get("directory"),
+			Processes: msg.Int("processes", 1),
+			StartSecs: 1,
+		})
+	}
+	return m, nil
+}
+
+// validateRedirectFrom accepts a path on the site, or a URL for a redirect
+// from one of its domains.
+func validateRedirectFrom(s string) error {
+	if !strings.HasPrefix(s, "/") && !strings.Contains(s, "://") {
+		return fmt.Errorf("start with / or give a full URL")
+	}
+	return nil
+}
+
+// validateSecurityPath accepts a path on the site.
+func validateSecurityPath(s string) error {
+	if !strings.HasPrefix(s, "/") {
+		return fmt.Errorf("start with /")
+	}
+	return nil
+}
+
+// validatePortSpec accepts a port (80) or an inclusive range (8000:8010).
+func validatePortSpec(s string) error {
+	parts := strings.Split(s, ":")
+	if len(parts) > 2 {
+		return fmt.Errorf("use a port or a from:to range")
+	}
+	prev := 0
+	for _, p := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("ports are numbers from 1 to 65535")
+		}
+		if n < prev {
+			return fmt.Errorf("range must go from low to high")
+		}
+		prev = n
+	}
+	return nil
+}
+
+// validatePHPVersion accepts a Forge PHP version name such as php84.
+func validatePHPVersion(s string) error {
+	digits, ok := strings.CutPrefix(s, "php")
+	if !ok || len(digits) < 2 {
+		return fmt.Errorf("use a version such as php84")
+	}
+	if _, err := strconv.Atoi(digits); err != nil {
+		return fmt.Errorf("use a version such as php84")
+	}
+	return nil
+}
+
+// validateDBIdentifier accepts names made of letters, digits and
+// underscores, which MySQL and PostgreSQL both take unquoted.
+func validateDBIdentifier(s string) error {
+	for _, r := range s {
+		if r != '_' && (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return fmt.Errorf("use letters, digits and underscores only")
+		}
+	}
+	return nil
+}
+
+// validateIPOrCIDR accepts an IP address or a CIDR block.
+func validateIPOrCIDR(s string) error {
+	if net.ParseIP(s) != nil {
+		return nil
+	}
+	if _, _, err := net.ParseCIDR(s); err == nil {
+		return nil
+	}
+	return fmt.Errorf("not an IP address or CIDR block")
+}
@@ -0,0 +1,181 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// While Forge clones a repository the site's repository_status is
+// "installing"; the site is polled every gitPollInterval until it changes,
+// for at most gitMaxPolls times (10 minutes).
+const (
+	gitPollInterval = 5 * time.Second
+	gitMaxPolls     = 120
+)
+
+// The Git actions a gitSiteMsg reports on.
+const (
+	gitInstall = "install"
+	gitDetach  = "detach"
+	gitBranch  = "branch"
+)
+
+// gitSiteMsg carries a site as it is after a Git action, or at a poll of
+// its repository install. polls counts the polls so far.
+type gitSiteMsg struct {
+	action   string
+	serverID int64
+	site     *forge.Site
+	polls    int
+	err      error
+}
+
+// gitPollMsg is sent when it is time to poll a site's repository install
+// again.
+type gitPollMsg struct {
+	serverID int64
+	siteID   int64
+	polls    int
+}
+
+// handleGitKey handles keys specific to the site's Git tab.
+func (m App) handleGitKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	site := m.selectedSite
+	if site == nil || m.selectedSrv == nil {
+		return m, nil
+	}
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("i"))):
+		if site.Repository != "" {
+			m.toast = fmt.Sprintf("%s already has a repository; detach it first (x)", site.Name)
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		m.dialogs = m.dialogs.Form(gitInstallForm())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("b"))):
+		if site.Repository == "" {
+			return m, nil
+		}
+		placeholder := site.RepositoryBranch
+		if placeholder == "" {
+			placeholder = "main"
+		}
+		m.dialogs = m.dialogs.Prompt(components.NewInput("git-branch", "Deploy branch:", placeholder).Remember())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if site.Repository == "" {
+			return m, nil
+		}
+		m.dialogs = m.dialogs.Confirm("detach-repo", fmt.Sprintf("Detach %s from %s?\nThe site can't be deployed until a repository is installed again.",
+			site.Repository, site.Name))
+		return m, nil
+	}
+	return m, nil
+}
+
+// gitAction returns a command that runs a Git action on the selected site
+// and then fetches the site, to show its new repository details.
+func (m App) gitAction(action string, run func(ctx context.Context, client *forge.Client, serverID, siteID int64) error) tea.Cmd {
+	if m.selectedSrv == nil || m.selectedSite == nil {
+		return nil
+	}
+	client := m.forge
+	serverID, siteID := m.selectedSrv.ID, m.selectedSite.ID
+	return func() tea.Msg {
+		ctx := context.Background()
+		if err := run(ctx, client, serverID, siteID); err != nil {
+			return gitSiteMsg{action: action, err: err}
+		}
+		site, err := client.Sites.Get(ctx, serverID, siteID)
+		return gitSiteMsg{action: action, serverID: serverID, site: site, err: err}
+	}
+}
+
+// installRepo returns a command that installs a repository on the
+// selected site.
+func (m App) installRepo(opts forge.GitInstallOpts) tea.Cmd {
+	return m.gitAction(gitInstall, func(ctx context.Context, client *forge.Client, serverID, siteID int64) error {
+		return client.Git.Install(ctx, serverID, siteID, opts)
+	})
+}
+
+// detachRepo returns a command that removes the selected site's
+// repository.
+func (m App) detachRepo() tea.Cmd {
+	return m.gitAction(gitDetach, func(ctx context.Context, client *forge.Client, serverID, siteID int64) error {
+		return client.Git.Remove(ctx, serverID, siteID)
+	})
+}
+
+// changeBranch returns a command that changes the selected site's deployed
+// branch.
+func (m App) changeBranch(branch string) tea.Cmd {
+	return m.gitAction(gitBranch, func(ctx context.Context, client *forge.Client, serverID, siteID int64) error {
+		return client.Git.UpdateBranch(ctx, serverID, siteID, branch)
+	})
+}
+
+// pollGitInstall returns a command that fetches a site whose repository
+// is being installed.
+func (m App) pollGitInstall(msg gitPollMsg) tea.Cmd {
+	client := m.forge
+	return func() tea.Msg {
+		site, err := client.Sites.Get(context.Background(), msg.serverID, msg.siteID)
+		return gitSiteMsg{action: gitInstall, serverID: msg.serverID, site: site, polls: msg.polls, err: err}
+	}
+}
+
+// handleGitSite shows a site's repository as it is after a Git action, and
+// keeps polling while its install runs.
+func (m App) handleGitSite(msg gitSiteMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Git %s failed: %v", msg.action, msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	site := msg.site
+	m.treePanel = m.treePanel.UpdateSite(*site)
+	if m.selectedSite != nil && m.selectedSite.ID == site.ID {
+		m.selectedSite = site
+		m.detail.siteInfo = m.detail.siteInfo.SetSite(site)
+		m.detail.gitPanel = m.detail.gitPanel.SetSite(site)
+	}
+
+	installing := msg.action == gitInstall && site.RepositoryStatus == "installing"
+	if installing && msg.polls < gitMaxPolls {
+		poll := gitPollMsg{serverID: msg.serverID, siteID: site.ID, polls: msg.polls + 1}
+		cmd := tea.Tick(gitPollInterval, func(time.Time) tea.Msg { return poll })
+		if msg.polls > 0 {
+			return m, cmd
+		}
+		m.toast = fmt.Sprintf("Installing %s on %s...", site.Repository, site.Name)
+		m.toastIsErr = false
+		return m, tea.Batch(cmd, m.clearToastAfter(5*time.Second))
+	}
+
+	m.toastIsErr = false
+	switch {
+	case installing:
+		m.toast = fmt.Sprintf("%s is still installing on %s; see the Git tab later", site.Repository, site.Name)
+	case msg.action == gitInstall && site.RepositoryStatus != "installed":
+		m.toast = fmt.Sprintf("Installing the repository on %s ended as %q", site.Name, site.RepositoryStatus)
+		m.toastIsErr = true
+	case msg.action == gitInstall:
+		m.toast = fmt.Sprintf("Installed %s on %s", site.Repository, site.Name)
+	case msg.action == gitDetach:
+		m.toast = fmt.Sprintf("Detached the repository from %s", site.Name)
+	default:
+		m.toast = fmt.Sprintf("%s now deploys %s", site.Name, site.RepositoryBranch)
+	}
+	return m, m.clearToastAfter(5 * time.Second)
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// repoMatchMsg is sent when the working directory's git origin matches the
+// repository of a Forge site.
+type repoMatchMsg struct {
+	repo   string
+	server forge.Server
+	site   forge.Site
+}
+
+// gitOriginRepo returns the "owner/name" of the git origin remote of dir
+// ("" for the current directory), or "" when there is no repository or no
+// origin.
+func gitOriginRepo(dir string) string {
+	out, err := exec.Command("git", "-C", dir, "config", "--get", "remote.origin.url").Output()
+	if err != nil {
+		return ""
+	}
+	return normalizeRepo(string(out))
+}
+
+// normalizeRepo reduces a git remote URL or Forge repository field to a
+// lower-case "owner/name" so the two can be compared. It understands
+// scp-style (git@host:owner/name.git), ssh:// and https:// remotes.
+func normalizeRepo(s string) string {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "/")
+	s = strings.TrimSuffix(s, ".git")
+	if s == "" {
+		return ""
+	}
+
+	if i := strings.Index(s, "://"); i >= 0 {
+		// scheme://[user@]host[:port]/owner/name
+		s = s[i+3:]
+		if j := strings.Index(s, "/"); j >= 0 {
+			s = s[j+1:]
+		} else {
+			return ""
+		}
+	} else if i := strings.Index(s, ":"); i >= 0 && !strings.Contains(s[:i], "/") {
+		// scp-style: [user@]host:owner/name
+		s = s[i+1:]
+	}
+
+	parts := strings.Split(strings.Trim(s, "/"), "/")
+	if len(parts) < 2 {
+		return ""
+	}
+	return strings.ToLower(parts[len(parts)-2] + "/" + parts[len(parts)-1])
+}
+
+// detectRepoSite returns a command that, when the working directory is a
+// git checkout, searches every server for a site deploying the same
+// repository and reports the first match. It produces no message when
+// nothing matches — the suggestion is purely opportunistic.
+func (m App) detectRepoSite() tea.Cmd {
+	client := m.forge
+	return func() tea.Msg {
+		repo := gitOriginRepo("")
+		if repo == "" {
+			return nil
+		}
+		srv, site, ok := findSite(context.Background(), client, func(site forge.Site) bool {
+			return site.Repository != "" && normalizeRepo(site.Repository) == repo
+		})
+		if !ok {
+			return nil
+		}
+		return repoMatchMsg{repo: repo, server: srv, site: site}
+	}
+}
+
+// findSite searches every server for the first site match accepts.
+// Servers whose sites can't be listed are skipped.
+func findSite(ctx context.Context, client *forge.Client, match func(forge.Site) bool) (forge.Server, forge.Site, bool) {
+	servers, err := client.Servers.List(ctx)
+	if err != nil {
+		return forge.Server{}, forge.Site{}, false
+	}
+	for _, srv := range servers {
+		sites, err := client.Sites.List(ctx, srv.ID)
+		if err != nil {
+			continue
+		}
+		for _, site := range sites {
+			if match(site) {
+				return srv, site, true
+			}
+		}
+	}
+	return forge.Server{}, forge.Site{}, false
+}
@@ -8,6 +8,8 @@ import (
 	"charm.land/bubbles/v2/key"
 	lipgloss "charm.land/lipgloss/v2"
 
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -28,6 +30,9 @@ type HelpModal struct {
 	active  bool
 	scrollY int
 	height  int
+
+	// mostUsed are the user's most used actions, listed first.
+	mostUsed []config.ActionUse
 }
 
 // NewHelpModal creates a new (inactive) help modal.
@@ -44,6 +49,13 @@ func (h HelpModal) Toggle() HelpModal {
 	return h
 }
 
+// SetMostUsed sets the actions listed in the Most Used section, which is
+// left out when there are none.
+func (h HelpModal) SetMostUsed(uses []config.ActionUse) HelpModal {
+	h.mostUsed = uses
+	return h
+}
+
 // Active returns whether the help modal is currently visible.
 func (h HelpModal) Active() bool {
 	return h.active
@@ -60,6 +72,10 @@ func (h HelpModal) Update(msg tea.Msg) (HelpModal, tea.Cmd) {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "?", "q"))):
 			h.active = false
 			return h, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
+			// Close help and replay the onboarding tour.
+			h.active = false
+			return h, func() tea.Msg { return tourStartMsg{} }
 		case key.Matches(msg, key.NewBinding(key.WithKeys("j", "down"))):
 			h.scrollY++
 			return h, nil
@@ -99,6 +115,13 @@ func (h HelpModal) View(width, height int) string {
 	h.height = height
 
 	sections := helpSections()
+	if len(h.mostUsed) > 0 {
+		used := helpSection{title: "Most Used"}
+		for _, u := range h.mostUsed {
+			used.bindings = append(used.bindings, helpEntry{u.Key, fmt.Sprintf("%s (%d×)", u.Action, u.Count)})
+		}
+		sections = append([]helpSection{used}, sections...)
+	}
 
 	// Style definitions.
 	sectionStyle := lipgloss.NewStyle().
@@ -127,13 +150,7 @@ func (h HelpModal) View(width, height int) string {
 		Align(lipgloss.Center)
 
 	// Content width for the help box.
-	contentWidth := 44
-	if width < contentWidth+6 {
-		contentWidth = width - 6
-	}
-	if contentWidth < 20 {
-		contentWidth = 20
-	}
+	contentWidth := layout.Clamp(width-6, 20, 44)
 
 	// Build all lines.
 	var lines []string
@@ -147,9 +164,9 @@ func (h HelpModal) View(width, height int) string {
 		if dashCount < 2 {
 			dashCount = 2
 		}
-		header := separatorStyle.Render(strings.Repeat("─", 1)+" ") +
+		header := separatorStyle.Render(theme.GlyphRule+" ") +
 			sectionStyle.Render(section.title) +
-			separatorStyle.Render(" "+strings.Repeat("─", dashCount-1))
+			separatorStyle.Render(" "+strings.Repeat(theme.GlyphRule, dashCount-1))
 		lines = append(lines, header)
 
 		for _, entry := range section.bindings {
@@ -163,7 +180,7 @@ func (h HelpModal) View(width, height int) string {
 	}
 
 	lines = append(lines, "")
-	lines = append(lines, hintStyle.Width(contentWidth).Render("esc/? close  j/k scroll"))
+	lines = append(lines, hintStyle.Width(contentWidth).Render("esc/? close  j/k scroll  t tour"))
 
 	totalLines := len(lines)
 
@@ -205,7 +222,7 @@ func (h HelpModal) View(width, height int) string {
 	inner := strings.Join(visibleLines, "\n")
 
 	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.Border()).
 		BorderForeground(theme.ColorPrimary).
 		Padding(1, 2).
 		Background(theme.ColorBg).
@@ -224,7 +241,13 @@ func helpSections() []helpSection {
 				{"g/G", "Top/bottom"},
 				{"Enter", "Select → detail panel"},
 				{"Space", "Expand/collapse server"},
-				{"/", "Filter servers & sites"},
+				{"+/-", "Expand/collapse all servers"},
+				{"/", "Filter servers & sites (tag:<name> by tag, repo:<name> by repository)"},
+				{"B", "Bulk action on tagged or workspace servers"},
+				{"M", "Interleave the logs of several sites"},
+				{".", "Show/hide hidden servers"},
+				{"V", "Group sites by server/application"},
+				{"w", "Narrow to a workspace / show all"},
 				{"Esc", "Clear filter"},
 			},
 		},
@@ -241,6 +264,7 @@ func helpSections() []helpSection {
 			bindings: []helpEntry{
 				{"j/k", "Scroll up/down"},
 				{"g/G", "Top/bottom"},
+				{"F", "Follow live deploy output"},
 				{"Esc", "Back to detail"},
 			},
 		},
@@ -250,9 +274,18 @@ func helpSections() []helpSection {
 				{"Ctrl+S", "SSH to server"},
 				{"Ctrl+F", "SFTP via termscp"},
 				{"Ctrl+D", "Database tunnel"},
-				{"Ctrl+R", "Refresh"},
+				{"Ctrl+R", "Refresh servers and current tab"},
 				{"Ctrl+O", "Settings"},
+				{"A", "About / API status"},
+				{"!", "Alerts"},
+				{"P", "Provider credentials"},
+				{"E", "Next .phorge environment"},
+				{"T", "Scheduled actions"},
+				{":", "Run a user-defined action"},
+				{"z", "Undo delete (within 5s)"},
+				{"Ctrl+X", "Cancel queued deploy"},
 				{"?", "Toggle help"},
+				{"F12", "Toggle diagnostics overlay"},
 				{"q", "Quit"},
 			},
 		},
@@ -264,6 +297,7 @@ func helpSections() []helpSection {
 				{"r", "Reboot server"},
 				{"D", "Set/clear default"},
 				{"n", "Set/remove nickname"},
+				{"H", "Hide/unhide server"},
 			},
 		},
 		{
@@ -275,31 +309,61 @@ func helpSections() []helpSection {
 				{"n", "Set/remove nickname"},
 				{"l", "View logs"},
 			{"v", "Visit site in browser"},
+				{"*", "Toggle wildcard subdomains"},
 			},
 		},
 		{
 			title: "Section Tabs",
 			bindings: []helpEntry{
+				{"0", "Server/site info"},
 				{"1", "Deployments"},
-				{"2", "Environment"},
+				{"2", "Environment/Nginx"},
 				{"3", "Databases"},
-				{"4", "SSL"},
-				{"5", "Workers"},
+				{"4", "SSL/SSL Overview"},
+				{"5", "Workers/Circles"},
 				{"6", "Commands/Daemons"},
 				{"7", "Logs/Firewall"},
 				{"8", "Git/Jobs"},
 				{"9", "Domains/SSH Keys"},
+				{"b", "Backups (server)"},
+				{"R", "Recipes (server)"},
 			},
 		},
 		{
 			title: "Panel Actions",
 			bindings: []helpEntry{
 				{"c", "Create new"},
+				{"p", "Paste a public key (ssh keys)"},
+				{"i", "Install an existing certificate (ssl)"},
+				{"C", "Run a multi-line script (commands)"},
 				{"x", "Delete"},
 				{"a", "Add/activate"},
-				{"r", "Restart"},
+				{"p", "Paste domain aliases (domains)"},
+				{"C", "Copy env vars to another site (env)"},
+				{"r", "Restart / renew LE cert"},
 				{"u", "Users (databases)"},
+				{"r", "Redirect rules (domains)"},
+				{"s", "Security rules / basic auth (domains)"},
+				{"i", "Install a repository (git)"},
+				{"b", "Change the deployed branch (git)"},
+				{"x", "Detach the repository (git)"},
+				{"v", "Site DB credentials (databases)"},
 				{"S", "Deploy script"},
+				{"/", "Grep the log (logs)"},
+				{"s", "Save log to a file (logs)"},
+				{"@", "Schedule a deploy (deployments)"},
+				{"L", "Saved deploy logs (deployments)"},
+				{"Q", "Toggle quick deploy (deployments)"},
+				{"y/U", "Copy/regenerate trigger URL (deployments)"},
+				{"n/r", "Back up now/restore (backups)"},
+				{"r/c", "Run/create recipe (recipes)"},
+				{"f/m/t", "Failed/mine/last 24h (deployments)"},
+				{"s", "Sort (tables)"},
+				{"y/Y", "Copy firewall rule/all to server"},
+				{"t", "Apply firewall rule set"},
+				{"a", "Apply nginx template to site"},
+				{"u/o", "Max upload/OPcache (server info)"},
+				{"R/W", "Rename site/change web directory (site info)"},
 			},
 		},
 	}
@@ -0,0 +1,136 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/hooks"
+)
+
+// hookDoneMsg carries the outcome of a hook run.
+type hookDoneMsg struct {
+	result hooks.Result
+}
+
+// emit publishes ev on the event socket and runs the hooks subscribed to
+// it.
+func (m App) emit(ev hooks.Event) tea.Cmd {
+	ev.Time = time.Now()
+	if m.socket != nil {
+		m.socket.Publish(ev)
+	}
+	return m.fireHooks(ev)
+}
+
+// fireHooks returns a command that runs the hooks subscribed to ev in the
+// background, or nil when there are none.
+func (m App) fireHooks(ev hooks.Event) tea.Cmd {
+	subscribed := m.config.HooksFor(ev.Name)
+	if len(subscribed) == 0 {
+		return nil
+	}
+	client := m.forge
+	cmds := make([]tea.Cmd, len(subscribed))
+	for i, h := range subscribed {
+		cmds[i] = func() tea.Msg {
+			if h.Script != "" {
+				return hookDoneMsg{result: hooks.RunScript(context.Background(), h.Script, ev, client)}
+			}
+			return hookDoneMsg{result: hooks.Run(context.Background(), h.Command, ev)}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// firePanelLoaded emits panel_loaded for a tab being opened.
+func (m App) firePanelLoaded(tab int, serverID, siteID int64) tea.Cmd {
+	ev := hooks.Event{
+		Name:     config.HookPanelLoaded,
+		Server:   m.serverName(serverID),
+		ServerID: serverID,
+		SiteID:   siteID,
+		Tab:      tabID(tab, siteID != 0),
+	}
+	if m.selectedSite != nil && m.selectedSite.ID == siteID {
+		ev.Site = m.selectedSite.Name
+	}
+	return m.emit(ev)
+}
+
+// fireDeployFinished emits deploy_finished for a watched deployment that
+// finished or failed.
+func (m App) fireDeployFinished(w deployWatch, status string) tea.Cmd {
+	return m.emit(hooks.Event{
+		Name:         config.HookDeployFinished,
+		Server:       m.serverName(w.serverID),
+		ServerID:     w.serverID,
+		Site:         w.siteName,
+		SiteID:       w.siteID,
+		Status:       status,
+		DeploymentID: w.deployID,
+	})
+}
+
+// serverName returns the name of the server with the given ID, or "" if
+// it is not in the tree.
+func (m App) serverName(id int64) string {
+	if srv := m.treePanel.FindServerByID(id); srv != nil {
+		return srv.Name
+	}
+	return ""
+}
+
+// handleHookDone carries out the requests a hook made and reports a hook
+// that failed.
+func (m App) handleHookDone(msg hookDoneMsg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, r := range msg.result.Requests {
+		switch r.Action {
+		case hooks.ActionToast:
+			m.toast = r.Message
+			m.toastIsErr = r.Error
+			cmds = append(cmds, m.clearToastAfter(5*time.Second))
+		case hooks.ActionDeploy:
+			cmd, err := m.deployByName(r.Server, r.Site, "Hook deploy of "+r.Site)
+			if err != nil {
+				m.toast = "Hook deploy: " + err.Error()
+				m.toastIsErr = true
+				cmd = m.clearToastAfter(8 * time.Second)
+			}
+			cmds = append(cmds, cmd)
+		}
+	}
+	if err := msg.result.Err; err != nil {
+		m.toast = fmt.Sprintf("%s hook failed: %v", msg.result.Event, err)
+		m.toastIsErr = true
+		cmds = append(cmds, m.clearToastAfter(8*time.Second))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// deployByName starts a deploy of a site named by a hook or socket client,
+// described as what in the toast, unless the site is unknown or outside
+// its environment's maintenance windows: they can't be overridden this
+// way.
+func (m App) deployByName(server, site, what string) (tea.Cmd, error) {
+	srv := m.treePanel.FindServerByName(server)
+	if srv == nil {
+		return nil, fmt.Errorf("unknown server %q", server)
+	}
+	s := m.treePanel.FindSiteOnServer(srv.ID, site)
+	if s == nil {
+		return nil, fmt.Errorf("site %q not found on %s (are its sites loaded?)", site, srv.Name)
+	}
+	check, err := m.project.CheckMaintenanceWindow(srv.Name, s.Name, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("not deploying %s: %w", s.Name, err)
+	}
+	if check.Outside {
+		return nil, fmt.Errorf("not deploying %s outside the %s maintenance window (%s)", s.Name, check.Environment, check.Describe())
+	}
+	return startAutoDeploy(m.forge, srv.ID, s.ID, what), nil
+}
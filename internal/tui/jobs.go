@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// handleJobsKey handles keys specific to the scheduled jobs panel tab.
+func (m App) handleJobsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.pendingJob = nil
+		m.dialogs = m.dialogs.Prompt(components.NewInputWide("create-job-command", "Command:", "php /home/forge/example.com/artisan schedule:run").Remember())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if j := m.detail.jobsPanel.SelectedJob(); j != nil {
+			m.dialogs = m.dialogs.Confirm("delete-job", fmt.Sprintf("Delete job %q?", truncateStr(j.Command, 30)))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// createJobCommand is the first step of creating a job: it keeps the
+// command and asks who runs it.
+func (m App) createJobCommand(command string) (tea.Model, tea.Cmd) {
+	m.pendingJob = &forge.JobCreateOpts{Command: command}
+	m.dialogs = m.dialogs.Prompt(components.NewInput("create-job-user", "Run as user (empty for forge):", "forge").Remember())
+	return m, nil
+}
+
+// createJobUser keeps the job's user and asks for its schedule.
+func (m App) createJobUser(user string) (tea.Model, tea.Cmd) {
+	if m.pendingJob == nil {
+		return m, nil
+	}
+	m.pendingJob.User = user
+	m.dialogs = m.dialogs.Prompt(jobScheduleInput())
+	return m, nil
+}
+
+// jobScheduleInput asks for the schedule of the job being created.
+func jobScheduleInput() components.Input {
+	label := fmt.Sprintf("Frequency (%s) or cron expression:", strings.Join(forge.JobFrequencies, ", "))
+	return components.NewInputWide("create-job-schedule", label, "*/15 * * * *").Remember()
+}
+
+// createJobSchedule is the last step: it creates the job, or asks again
+// when the schedule isn't one Forge accepts.
+func (m App) createJobSchedule(schedule string) (tea.Model, tea.Cmd) {
+	if m.pendingJob == nil {
+		return m, nil
+	}
+	opts := *m.pendingJob
+	if err := opts.SetSchedule(schedule); err != nil {
+		m.toast = err.Error()
+		m.toastIsErr = true
+		m.dialogs = m.dialogs.Prompt(jobScheduleInput())
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	m.pendingJob = nil
+	return m, m.detail.jobsPanel.CreateJob(opts)
+}
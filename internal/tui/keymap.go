@@ -4,15 +4,21 @@ import "charm.land/bubbles/v2/key"
 
 // GlobalKeyMap contains keybindings available in every context.
 type GlobalKeyMap struct {
-	Quit     key.Binding
-	Refresh  key.Binding
-	SSH      key.Binding
-	SFTP     key.Binding
-	Database key.Binding
-	Help     key.Binding
-	Settings key.Binding
-	Tab      key.Binding
-	ShiftTab key.Binding
+	Quit      key.Binding
+	Refresh   key.Binding
+	SSH       key.Binding
+	SFTP      key.Binding
+	Database  key.Binding
+	Help      key.Binding
+	Settings  key.Binding
+	About     key.Binding
+	Alerts    key.Binding
+	Providers key.Binding
+	Scheduled key.Binding
+	Actions   key.Binding
+	Env       key.Binding
+	Tab       key.Binding
+	ShiftTab  key.Binding
 }
 
 // DefaultGlobalKeyMap returns the default global keybindings.
@@ -46,6 +52,30 @@ func DefaultGlobalKeyMap() GlobalKeyMap {
 			key.WithKeys("ctrl+o"),
 			key.WithHelp("ctrl+o", "settings"),
 		),
+		About: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "about"),
+		),
+		Alerts: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "alerts"),
+		),
+		Providers: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "provider credentials"),
+		),
+		Scheduled: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "scheduled actions"),
+		),
+		Actions: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "actions"),
+		),
+		Env: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "switch environment"),
+		),
 		Tab: key.NewBinding(
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "next panel"),
@@ -59,15 +89,15 @@ func DefaultGlobalKeyMap() GlobalKeyMap {
 
 // NavKeyMap contains keybindings for list navigation.
 type NavKeyMap struct {
-	Up        key.Binding
-	Down      key.Binding
-	Enter     key.Binding
-	Back      key.Binding
-	Search    key.Binding
-	Home      key.Binding
-	End       key.Binding
-	PageUp    key.Binding
-	PageDown  key.Binding
+	Up       key.Binding
+	Down     key.Binding
+	Enter    key.Binding
+	Back     key.Binding
+	Search   key.Binding
+	Home     key.Binding
+	End      key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
 }
 
 // DefaultNavKeyMap returns the default navigation keybindings.
@@ -112,8 +142,10 @@ func DefaultNavKeyMap() NavKeyMap {
 	}
 }
 
-// SectionKeyMap contains keybindings for switching detail panel tabs (1-9).
+// SectionKeyMap contains keybindings for switching detail panel tabs (0-9,
+// and b and R for the server's Backups and Recipes tabs).
 type SectionKeyMap struct {
+	Info        key.Binding // 0
 	Deployments key.Binding // 1
 	Environment key.Binding // 2
 	Databases   key.Binding // 3
@@ -123,11 +155,17 @@ type SectionKeyMap struct {
 	Firewall    key.Binding // 7
 	Jobs        key.Binding // 8
 	Domains     key.Binding // 9
+	Backups     key.Binding // b
+	Recipes     key.Binding // R
 }
 
 // DefaultSectionKeyMap returns the default section keybindings.
 func DefaultSectionKeyMap() SectionKeyMap {
 	return SectionKeyMap{
+		Info: key.NewBinding(
+			key.WithKeys("0"),
+			key.WithHelp("0", "info"),
+		),
 		Deployments: key.NewBinding(
 			key.WithKeys("1"),
 			key.WithHelp("1", "deployments"),
@@ -164,6 +202,14 @@ func DefaultSectionKeyMap() SectionKeyMap {
 			key.WithKeys("9"),
 			key.WithHelp("9", "domains"),
 		),
+		Backups: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "backups"),
+		),
+		Recipes: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "recipes"),
+		),
 	}
 }
 
@@ -200,6 +246,7 @@ type SiteActionKeyMap struct {
 	Database key.Binding
 	Logs     key.Binding
 	Visit    key.Binding
+	Wildcard key.Binding
 }
 
 // DefaultSiteActionKeyMap returns the default site action keybindings.
@@ -229,5 +276,9 @@ func DefaultSiteActionKeyMap() SiteActionKeyMap {
 			key.WithKeys("v"),
 			key.WithHelp("v", "visit site"),
 		),
+		Wildcard: key.NewBinding(
+			key.WithKeys("*"),
+			key.WithHelp("*", "toggle wildcards"),
+		),
 	}
 }
@@ -0,0 +1,56 @@
+// Package layout holds the small pieces of size arithmetic shared by the
+// TUI panels: clamping, splitting the screen between panels, sizing a
+// table's flexible column and padding rendered lines to a fixed height.
+package layout
+
+// Clamp returns v limited to the range [lo, hi]. When hi < lo, lo wins.
+func Clamp(v, lo, hi int) int {
+	return max(min(v, hi), lo)
+}
+
+// Inner returns the content size of a box drawn with a one-cell border,
+// never negative.
+func Inner(width, height int) (innerWidth, innerHeight int) {
+	return max(width-2, 0), max(height-2, 0)
+}
+
+// SplitVertical divides total rows between a top and a bottom pane. The
+// top pane gets topPct percent, then both are raised to their minimums;
+// if that overflows total, the bottom pane gives way first, but never
+// below minBottom.
+func SplitVertical(total, topPct, minTop, minBottom int) (top, bottom int) {
+	top = max(total*topPct/100, minTop)
+	bottom = max(total-total*topPct/100, minBottom)
+	if top+bottom > total {
+		bottom = total - top
+		if bottom < minBottom {
+			bottom = minBottom
+			top = total - bottom
+		}
+	}
+	return top, bottom
+}
+
+// Columns returns the width left for a table's flexible column once the
+// fixed columns and gaps (overhead) are taken from width, but at least
+// minWidth so the column stays readable on narrow terminals.
+func Columns(width, overhead, minWidth int) int {
+	return max(width-overhead, minWidth)
+}
+
+// ScrollStart returns the index of the first row to show so that cursor
+// stays inside a window of visible rows.
+func ScrollStart(cursor, visible int) int {
+	if cursor >= visible {
+		return cursor - visible + 1
+	}
+	return 0
+}
+
+// Pad appends empty lines until lines has at least n entries.
+func Pad(lines []string, n int) []string {
+	for len(lines) < n {
+		lines = append(lines, "")
+	}
+	return lines
+}
@@ -0,0 +1,81 @@
+package layout
+
+import "testing"
+
+func TestClamp(t *testing.T) {
+	tests := []struct {
+		v, lo, hi, want int
+	}{
+		{5, 0, 10, 5},
+		{-1, 0, 10, 0},
+		{11, 0, 10, 10},
+		{5, 8, 3, 8}, // lo wins when the range is empty
+	}
+	for _, tt := range tests {
+		if got := Clamp(tt.v, tt.lo, tt.hi); got != tt.want {
+			t.Errorf("Clamp(%d, %d, %d) = %d, want %d", tt.v, tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
+
+func TestInner(t *testing.T) {
+	if w, h := Inner(80, 24); w != 78 || h != 22 {
+		t.Errorf("Inner(80, 24) = %d, %d, want 78, 22", w, h)
+	}
+	if w, h := Inner(1, 0); w != 0 || h != 0 {
+		t.Errorf("Inner(1, 0) = %d, %d, want 0, 0", w, h)
+	}
+}
+
+func TestSplitVertical(t *testing.T) {
+	tests := []struct {
+		total, pct, minTop, minBottom int
+		top, bottom                   int
+	}{
+		{40, 60, 4, 3, 24, 16},
+		{40, 85, 4, 3, 34, 6},
+		{10, 85, 4, 3, 7, 3},   // bottom raised to its minimum
+		{5, 10, 4, 3, 2, 3},    // overflow: bottom keeps its minimum
+		{20, 100, 4, 3, 17, 3}, // top gives way when bottom would vanish
+	}
+	for _, tt := range tests {
+		top, bottom := SplitVertical(tt.total, tt.pct, tt.minTop, tt.minBottom)
+		if top != tt.top || bottom != tt.bottom {
+			t.Errorf("SplitVertical(%d, %d, %d, %d) = %d, %d, want %d, %d",
+				tt.total, tt.pct, tt.minTop, tt.minBottom, top, bottom, tt.top, tt.bottom)
+		}
+	}
+}
+
+func TestColumns(t *testing.T) {
+	if got := Columns(100, 60, 10); got != 40 {
+		t.Errorf("Columns(100, 60, 10) = %d, want 40", got)
+	}
+	if got := Columns(50, 60, 10); got != 10 {
+		t.Errorf("Columns(50, 60, 10) = %d, want 10", got)
+	}
+}
+
+func TestScrollStart(t *testing.T) {
+	tests := []struct{ cursor, visible, want int }{
+		{0, 5, 0},
+		{4, 5, 0},
+		{5, 5, 1},
+		{12, 5, 8},
+	}
+	for _, tt := range tests {
+		if got := ScrollStart(tt.cursor, tt.visible); got != tt.want {
+			t.Errorf("ScrollStart(%d, %d) = %d, want %d", tt.cursor, tt.visible, got, tt.want)
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	lines := Pad([]string{"a"}, 3)
+	if len(lines) != 3 || lines[0] != "a" || lines[2] != "" {
+		t.Errorf("Pad = %q, want [a  ]", lines)
+	}
+	if lines := Pad([]string{"a", "b"}, 1); len(lines) != 2 {
+		t.Errorf("Pad shrank lines to %d", len(lines))
+	}
+}
@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+)
+
+// handleLogsKey handles keys specific to the site logs panel tab.
+func (m App) handleLogsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, key.NewBinding(key.WithKeys("s"))) {
+		return m.saveLog()
+	}
+
+	return m.updateTab(msg)
+}
+
+// logsShowing reports whether the detail area shows the site Logs tab.
+func (m App) logsShowing() bool {
+	return m.detail.activeTab == 7 && m.selectedSite != nil && m.detail.TabEnabled(7, true)
+}
+
+// saveLog writes the log shown in the Logs tab to a file in the user's
+// Downloads directory, or the working directory when there is none, for
+// attaching to tickets.
+func (m App) saveLog() (tea.Model, tea.Cmd) {
+	content := m.detail.logsPanel.Content()
+	if m.selectedSite == nil || content == "" {
+		m.toast = "No log content to save"
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	path := filepath.Join(downloadsDir(), logFileName(m.selectedSite.Name, "site", time.Now()))
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		m.toast = fmt.Sprintf("Saving log: %v", err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	m.toast = "Log saved to " + path
+	m.toastIsErr = false
+	return m, m.clearToastAfter(5 * time.Second)
+}
+
+// logFileName names a saved log after where it came from, its kind and
+// when it was saved, e.g. example.com-site-log-20261016-150405.log.
+func logFileName(source, kind string, t time.Time) string {
+	source = strings.NewReplacer("/", "_", `\`, "_", " ", "_").Replace(source)
+	return fmt.Sprintf("%s-%s-log-%s.log", source, kind, t.Format("20060102-150405"))
+}
+
+// downloadsDir returns ~/Downloads when it exists, or else the working
+// directory.
+func downloadsDir() string {
+	if home, err := os.UserHomeDir(); err == nil {
+		dir := filepath.Join(home, "Downloads")
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return "."
+}
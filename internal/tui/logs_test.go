@@ -0,0 +1,16 @@
+package tui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLogFileName(t *testing.T) {
+	at := time.Date(2026, 10, 16, 15, 4, 5, 0, time.Local)
+	if got, want := logFileName("example.com", "site", at), "example.com-site-log-20261016-150405.log"; got != want {
+		t.Errorf("logFileName = %q, want %q", got, want)
+	}
+	if got, want := logFileName("a/b c", "site", at), "a_b_c-site-log-20261016-150405.log"; got != want {
+		t.Errorf("logFileName with separators = %q, want %q", got, want)
+	}
+}
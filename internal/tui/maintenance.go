@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// guardMaintenanceWindow checks a deploy of the selected site at the given
+// time against the maintenance windows of its .phorge environment. Outside
+// them it asks for an override reason under the input ID "override-"+action,
+// keeping value for when the reason is given, and reports true: the caller
+// must not deploy yet. A window that doesn't parse blocks the deploy too.
+func (m App) guardMaintenanceWindow(action, value string, at time.Time) (App, tea.Cmd, bool) {
+	if m.selectedSite == nil || m.selectedSrv == nil {
+		return m, nil, false
+	}
+	check, err := m.project.CheckMaintenanceWindow(m.selectedSrv.Name, m.selectedSite.Name, at)
+	if err != nil {
+		m.toast = fmt.Sprintf("Not deploying: %v", err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second), true
+	}
+	if !check.Outside {
+		return m, nil, false
+	}
+	m.pendingInputValue = value
+	label := fmt.Sprintf("Outside the %s maintenance window (%s). Reason to deploy anyway:",
+		check.Environment, check.Describe())
+	m.dialogs = m.dialogs.Prompt(components.NewInputWide("override-"+action, label, "hotfix for a broken checkout").Remember())
+	return m, nil, true
+}
+
+// recordOverride writes a deploy outside the selected site's maintenance
+// window to the audit log, reporting whether it was recorded. An override
+// that can't be recorded is not carried out.
+func (m App) recordOverride(reason, action string) (App, tea.Cmd, bool) {
+	if m.selectedSite == nil || m.selectedSrv == nil {
+		return m, nil, false
+	}
+	check, _ := m.project.CheckMaintenanceWindow(m.selectedSrv.Name, m.selectedSite.Name, time.Now())
+	err := config.AppendAudit(config.AuditEntry{
+		Action:      action,
+		Server:      m.selectedSrv.Name,
+		Site:        m.selectedSite.Name,
+		Environment: check.Environment,
+		Reason:      reason,
+		Via:         "tui",
+	})
+	if err != nil {
+		m.toast = fmt.Sprintf("Not deploying: recording the override failed: %v", err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second), false
+	}
+	return m, nil, true
+}
+
+// handleOverride carries out a deploy, queued deploy or scheduled deploy
+// held back by guardMaintenanceWindow, once its reason has been recorded.
+func (m App) handleOverride(id, reason string) (tea.Model, tea.Cmd) {
+	value := m.pendingInputValue
+	m.pendingInputValue = ""
+
+	action := "deploy outside maintenance window"
+	if id == "override-schedule-deploy" {
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return m, nil
+		}
+		action = fmt.Sprintf("schedule deploy outside maintenance window for %s", at.Format("Mon 15:04"))
+	}
+	m, cmd, ok := m.recordOverride(reason, action)
+	if !ok {
+		return m, cmd
+	}
+
+	switch id {
+	case "override-deploy":
+		return m.deployNow()
+	case "override-queue-deploy":
+		return m.queueDeploy()
+	case "override-schedule-deploy":
+		at, _ := time.Parse(time.RFC3339, value)
+		return m.addScheduledDeploy(at)
+	}
+	return m, nil
+}
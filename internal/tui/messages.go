@@ -1,6 +1,10 @@
 package tui
 
-import "github.com/hinkers/Phorge/internal/forge"
+import (
+	"time"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
 
 // serversLoadedMsg is sent when the server list has been fetched from the API.
 type serversLoadedMsg struct {
@@ -22,10 +26,27 @@ type rebootResultMsg struct {
 	err error
 }
 
-// toastMsg is sent to display a temporary notification.
+// toastMsg is sent to display a temporary notification. It is cleared
+// after duration, or 3 seconds when that is zero; a sticky toast stays up
+// until the next one replaces it.
 type toastMsg struct {
-	message string
-	isError bool
+	message  string
+	isError  bool
+	duration time.Duration
+	sticky   bool
+}
+
+// showOutputMsg is sent to open the output screen with content.
+type showOutputMsg struct {
+	title   string
+	content string
+}
+
+// generatedPasswordMsg is sent when Forge generated a password for a new
+// database user, which can only be shown this once.
+type generatedPasswordMsg struct {
+	user     string
+	password string
 }
 
 // externalExitMsg is sent after returning from an external process (ssh, sftp, etc.).
@@ -43,12 +64,31 @@ type setDefaultMsg struct {
 	err        error
 }
 
+// siteUpdatedMsg is sent after a site's name or web directory is changed.
+type siteUpdatedMsg struct {
+	serverID int64
+	oldName  string
+	site     *forge.Site
+	err      error
+}
+
+// phpSettingMsg is sent after a server's PHP settings are changed. server
+// is the server as it is once the change is made.
+type phpSettingMsg struct {
+	server forge.Server
+	what   string
+	err    error
+}
+
 // pollOutputTickMsg is sent by the output polling timer to trigger a refresh.
 type pollOutputTickMsg struct{}
 
 // pollSpinnerTickMsg is sent by the spinner animation timer.
 type pollSpinnerTickMsg struct{}
 
+// tabSpinnerTickMsg is sent by the tab bar's loading spinner timer.
+type tabSpinnerTickMsg struct{}
+
 // pollOutputResultMsg carries the result of a polled output fetch.
 type pollOutputResultMsg struct {
 	output   string
@@ -63,3 +103,8 @@ type pollFinalFetchMsg struct{}
 type pollFinalOutputMsg struct {
 	output string
 }
+
+// updateAvailableMsg is sent when a newer phorge release is found on GitHub.
+type updateAvailableMsg struct {
+	version string
+}
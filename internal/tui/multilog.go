@@ -0,0 +1,232 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// multiLogTail is how many entries from the end of each site's log are
+// interleaved.
+const multiLogTail = 200
+
+// logTimeLayout is the timestamp Laravel starts each log entry with,
+// e.g. "[2026-10-16 15:04:05] production.ERROR: ...".
+const logTimeLayout = "2006-01-02 15:04:05"
+
+// multiLogSite is a site whose log can be aggregated.
+type multiLogSite struct {
+	server forge.Server
+	site   forge.Site
+}
+
+// multiLogSitesMsg carries every site whose log can be aggregated.
+type multiLogSitesMsg struct {
+	sites []multiLogSite
+	err   error
+}
+
+// multiLogsMsg carries the logs of the picked sites, in the order picked.
+type multiLogsMsg struct {
+	names []string
+	logs  []string
+	errs  []error
+}
+
+// logEntry is one entry of a site's log: its first line and the lines
+// that follow it, such as a stack trace.
+type logEntry struct {
+	site  int // index into the aggregated sites
+	time  time.Time
+	lines []string
+}
+
+// startMultiLog fetches the sites on every server for picking.
+func (m App) startMultiLog() (tea.Model, tea.Cmd) {
+	client := m.forge
+	servers := m.treePanel.Servers()
+	m.toast = "Loading sites..."
+	m.toastIsErr = false
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		var sites []multiLogSite
+		for _, srv := range servers {
+			list, err := client.Sites.List(ctx, srv.ID)
+			if err != nil {
+				return multiLogSitesMsg{err: err}
+			}
+			for _, site := range list {
+				sites = append(sites, multiLogSite{server: srv, site: site})
+			}
+		}
+		return multiLogSitesMsg{sites: sites}
+	}
+}
+
+// handleMultiLogSites offers the sites to aggregate logs from.
+func (m App) handleMultiLogSites(msg multiLogSitesMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil || len(msg.sites) == 0 {
+		m.toast = "No sites to aggregate logs from"
+		if msg.err != nil {
+			m.toast = fmt.Sprintf("Loading sites failed: %v", msg.err)
+		}
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	m.toast = ""
+	m.pendingMultiLog = msg.sites
+	options := make([]components.PickerOption, len(msg.sites))
+	for i, s := range msg.sites {
+		options[i] = components.PickerOption{Label: s.site.Name, Detail: s.server.Name, Value: strconv.Itoa(i)}
+	}
+	m.dialogs = m.dialogs.Pick(components.NewMultiPicker("multi-log-sites", "Sites to aggregate logs from:", options))
+	return m, nil
+}
+
+// pickedMultiLogSites fetches the logs of the picked sites together.
+func (m App) pickedMultiLogSites(values []string) (tea.Model, tea.Cmd) {
+	sites := m.pendingMultiLog
+	m.pendingMultiLog = nil
+	var picked []multiLogSite
+	for _, v := range values {
+		if i, err := strconv.Atoi(v); err == nil && i < len(sites) {
+			picked = append(picked, sites[i])
+		}
+	}
+	if len(picked) == 0 {
+		return m, nil
+	}
+
+	client := m.forge
+	m.toast = fmt.Sprintf("Fetching %d logs...", len(picked))
+	m.toastIsErr = false
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		msg := multiLogsMsg{
+			names: make([]string, len(picked)),
+			logs:  make([]string, len(picked)),
+			errs:  make([]error, len(picked)),
+		}
+		var wg sync.WaitGroup
+		for i, s := range picked {
+			msg.names[i] = s.site.Name
+			wg.Go(func() {
+				msg.logs[i], msg.errs[i] = client.Logs.GetSiteLog(ctx, s.server.ID, s.site.ID)
+			})
+		}
+		wg.Wait()
+		return msg
+	}
+}
+
+// handleMultiLogs shows the fetched logs interleaved by timestamp in the
+// output panel.
+func (m App) handleMultiLogs(msg multiLogsMsg) (tea.Model, tea.Cmd) {
+	var logs [][]logEntry
+	var failed []string
+	for i, content := range msg.logs {
+		if msg.errs[i] != nil {
+			failed = append(failed, msg.names[i])
+			continue
+		}
+		entries := parseLogEntries(i, content)
+		if len(entries) > multiLogTail {
+			entries = entries[len(entries)-multiLogTail:]
+		}
+		logs = append(logs, entries)
+	}
+
+	m.outputPanel = m.outputPanel.SetContent(
+		fmt.Sprintf("Logs: %d sites", len(msg.names)),
+		renderMultiLog(msg.names, interleaveLogs(logs)),
+	)
+	m.nav = m.nav.Push(ScreenOutput)
+	m.toast = ""
+	if len(failed) > 0 {
+		m.toast = "Couldn't fetch the log of " + strings.Join(failed, ", ")
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	return m, nil
+}
+
+// parseLogEntries splits a Laravel log into entries, each starting at a
+// line with a timestamp. Lines before the first timestamp form an entry
+// without one.
+func parseLogEntries(site int, content string) []logEntry {
+	var entries []logEntry
+	for line := range strings.SplitSeq(strings.TrimRight(content, "\n"), "\n") {
+		if t, ok := logLineTime(line); ok || len(entries) == 0 {
+			entries = append(entries, logEntry{site: site, time: t})
+		}
+		e := &entries[len(entries)-1]
+		e.lines = append(e.lines, line)
+	}
+	return entries
+}
+
+// logLineTime returns the timestamp a log entry's first line starts with.
+// Both "[2026-10-16 15:04:05]" and Monolog's ISO form
+// "[2026-10-16T15:04:05.000000+00:00]" are read, ignoring the offset.
+func logLineTime(line string) (time.Time, bool) {
+	if len(line) < len(logTimeLayout)+2 || line[0] != '[' {
+		return time.Time{}, false
+	}
+	stamp := strings.Replace(line[1:len(logTimeLayout)+1], "T", " ", 1)
+	t, err := time.Parse(logTimeLayout, stamp)
+	return t, err == nil
+}
+
+// interleaveLogs merges the entries of several logs by timestamp, keeping
+// entries with equal timestamps in their log's order.
+func interleaveLogs(logs [][]logEntry) []logEntry {
+	var all []logEntry
+	for _, entries := range logs {
+		all = append(all, entries...)
+	}
+	slices.SortStableFunc(all, func(a, b logEntry) int {
+		return a.time.Compare(b.time)
+	})
+	return all
+}
+
+// multiLogColors are the colours the aggregated sites' tags cycle
+// through.
+var multiLogColors = []color.Color{
+	theme.ColorPrimary, theme.ColorSecondary, theme.ColorHighlight, theme.ColorError, theme.ColorFg,
+}
+
+// renderMultiLog renders interleaved entries, each line tagged with its
+// site's name in the site's colour.
+func renderMultiLog(names []string, entries []logEntry) string {
+	if len(entries) == 0 {
+		return "(the logs are empty)"
+	}
+	width := 0
+	for _, name := range names {
+		width = max(width, lipgloss.Width(name))
+	}
+	var b strings.Builder
+	for _, e := range entries {
+		style := lipgloss.NewStyle().Foreground(multiLogColors[e.site%len(multiLogColors)])
+		tag := style.Render(fmt.Sprintf("%-*s", width, names[e.site]))
+		for _, line := range e.lines {
+			b.WriteString(tag + " " + theme.GlyphSeparator + " " + line + "\n")
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestInterleaveLogs(t *testing.T) {
+	api := parseLogEntries(0, `#0 left over from a rotated entry
+[2026-10-16 10:00:00] production.INFO: api started
+[2026-10-16 10:00:05] production.ERROR: Connection refused
+#0 /var/www/api/vendor/Database.php(12)
+#1 {main}
+[2026-10-16 10:00:09] production.INFO: api retried
+`)
+	web := parseLogEntries(1, `[2026-10-16T10:00:05.000000+00:00] production.WARNING: slow query
+[2026-10-16T10:00:07.123456+00:00] production.ERROR: upstream failed
+`)
+	if len(api) != 4 || len(web) != 2 {
+		t.Fatalf("parsed %d and %d entries, want 4 and 2", len(api), len(web))
+	}
+	if got := len(api[2].lines); got != 3 {
+		t.Errorf("stack trace entry has %d lines, want 3", got)
+	}
+
+	var got []string
+	for _, e := range interleaveLogs([][]logEntry{api, web}) {
+		got = append(got, e.lines[0])
+	}
+	want := []string{
+		"#0 left over from a rotated entry",
+		"[2026-10-16 10:00:00] production.INFO: api started",
+		"[2026-10-16 10:00:05] production.ERROR: Connection refused",
+		"[2026-10-16T10:00:05.000000+00:00] production.WARNING: slow query",
+		"[2026-10-16T10:00:07.123456+00:00] production.ERROR: upstream failed",
+		"[2026-10-16 10:00:09] production.INFO: api retried",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("interleaved = %q, want %q", got, want)
+	}
+}
+
+func TestRenderMultiLog(t *testing.T) {
+	entries := interleaveLogs([][]logEntry{
+		parseLogEntries(0, "[2026-10-16 10:00:01] production.INFO: b"),
+		parseLogEntries(1, "[2026-10-16 10:00:00] production.INFO: a\n#0 trace"),
+	})
+	got := strings.Split(ansi.Strip(renderMultiLog([]string{"api.example.com", "web"}, entries)), "\n")
+	want := []string{
+		"web             │ [2026-10-16 10:00:00] production.INFO: a",
+		"web             │ #0 trace",
+		"api.example.com │ [2026-10-16 10:00:01] production.INFO: b",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("rendered = %q, want %q", got, want)
+	}
+}
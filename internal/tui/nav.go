@@ -0,0 +1,136 @@
+package tui
+
+// Screen is a place the user can navigate to and back from.
+type Screen int
+
+const (
+	ScreenTree Screen = iota
+	ScreenDetail
+	ScreenDeployScript // deploy script sub-view of the deployments tab
+	ScreenDBUsers      // database users sub-view of the databases tab
+	ScreenRedirects    // redirect rules sub-view of the domains tab
+	ScreenSecurity     // security rules sub-view of the domains tab
+	ScreenOutput
+)
+
+// Focus returns the panel that has keyboard focus while s is showing.
+func (s Screen) Focus() Focus {
+	switch s {
+	case ScreenTree:
+		return FocusTree
+	case ScreenOutput:
+		return FocusOutput
+	}
+	return FocusDetail
+}
+
+// NavStack records the screens the user has drilled into, most recent
+// last. Back pops the top screen, so it always returns to wherever the
+// user came from. The tree is always at the bottom and is never popped.
+type NavStack struct {
+	screens []Screen
+}
+
+// NewNavStack creates a stack holding only the tree.
+func NewNavStack() NavStack {
+	return NavStack{screens: []Screen{ScreenTree}}
+}
+
+// Top returns the screen currently showing.
+func (n NavStack) Top() Screen {
+	if len(n.screens) == 0 {
+		return ScreenTree
+	}
+	return n.screens[len(n.screens)-1]
+}
+
+// Focus returns the panel that has keyboard focus.
+func (n NavStack) Focus() Focus {
+	return n.Top().Focus()
+}
+
+// Contains reports whether s is anywhere on the stack.
+func (n NavStack) Contains(s Screen) bool {
+	return n.index(s) >= 0
+}
+
+// Push shows s on top of the current screen. Pushing a screen that is
+// already on the stack returns to it instead, so the stack never loops.
+func (n NavStack) Push(s Screen) NavStack {
+	if n.Contains(s) {
+		return n.PopTo(s)
+	}
+	screens := n.all()
+	// The capped slice makes append copy, so earlier App values sharing
+	// the backing array are left untouched.
+	n.screens = append(screens[:len(screens):len(screens)], s)
+	return n
+}
+
+// Pop returns to the previous screen. The tree is never popped.
+func (n NavStack) Pop() NavStack {
+	screens := n.all()
+	if len(screens) > 1 {
+		screens = screens[:len(screens)-1]
+	}
+	n.screens = screens
+	return n
+}
+
+// PopTo drops every screen above s. It does nothing when s isn't on the
+// stack.
+func (n NavStack) PopTo(s Screen) NavStack {
+	if i := n.index(s); i >= 0 {
+		n.screens = n.all()[:i+1]
+	}
+	return n
+}
+
+// FocusPanel moves focus to panel f as Tab cycling does: the tree drops
+// everything above it, the detail panel returns to its deepest open
+// sub-view, and the output panel is pushed if it isn't already open.
+func (n NavStack) FocusPanel(f Focus) NavStack {
+	switch f {
+	case FocusTree:
+		return NewNavStack()
+	case FocusOutput:
+		return n.Push(ScreenOutput)
+	}
+	screens := n.all()
+	for i := len(screens) - 1; i >= 0; i-- {
+		if screens[i].Focus() == FocusDetail {
+			n.screens = screens[:i+1]
+			return n
+		}
+	}
+	return NewNavStack().Push(ScreenDetail)
+}
+
+// DetailScreen returns the deepest detail-panel screen on the stack, which
+// decides whether the detail panel shows a tab or one of its sub-views.
+func (n NavStack) DetailScreen() Screen {
+	screens := n.all()
+	for i := len(screens) - 1; i >= 0; i-- {
+		if screens[i].Focus() == FocusDetail {
+			return screens[i]
+		}
+	}
+	return ScreenDetail
+}
+
+// all returns the screens, treating the zero NavStack as holding the tree.
+func (n NavStack) all() []Screen {
+	if len(n.screens) == 0 {
+		return []Screen{ScreenTree}
+	}
+	return n.screens
+}
+
+func (n NavStack) index(s Screen) int {
+	for i, v := range n.all() {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+)
+
+// navOf builds a stack by pushing screens onto a new one.
+func navOf(screens ...Screen) NavStack {
+	n := NewNavStack()
+	for _, s := range screens {
+		n = n.Push(s)
+	}
+	return n
+}
+
+func TestNavStackPushPop(t *testing.T) {
+	tests := []struct {
+		name string
+		nav  NavStack
+		want []Screen
+	}{
+		{"push", navOf(ScreenDetail, ScreenDeployScript), []Screen{ScreenTree, ScreenDetail, ScreenDeployScript}},
+		{"push a screen already on the stack", navOf(ScreenDetail, ScreenRedirects, ScreenOutput, ScreenDetail), []Screen{ScreenTree, ScreenDetail}},
+		{"push the tree", navOf(ScreenDetail, ScreenOutput, ScreenTree), []Screen{ScreenTree}},
+		{"pop", navOf(ScreenDetail, ScreenDBUsers).Pop(), []Screen{ScreenTree, ScreenDetail}},
+		{"pop at the tree", NewNavStack().Pop(), []Screen{ScreenTree}},
+		{"pop the zero stack", NavStack{}.Pop(), []Screen{ScreenTree}},
+		{"pop to", navOf(ScreenDetail, ScreenSecurity, ScreenOutput).PopTo(ScreenDetail), []Screen{ScreenTree, ScreenDetail}},
+		{"pop to a screen not on the stack", navOf(ScreenDetail).PopTo(ScreenOutput), []Screen{ScreenTree, ScreenDetail}},
+	}
+	for _, tt := range tests {
+		if got := tt.nav.all(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: stack = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestNavStackPushCopies checks pushing onto a stack leaves the stacks it
+// was made from as they were.
+func TestNavStackPushCopies(t *testing.T) {
+	base := navOf(ScreenDetail)
+	a := base.Push(ScreenDBUsers)
+	b := base.Push(ScreenRedirects)
+	if a.Top() != ScreenDBUsers || b.Top() != ScreenRedirects || base.Top() != ScreenDetail {
+		t.Errorf("tops = %v, %v, %v, want %v, %v, %v", a.Top(), b.Top(), base.Top(), ScreenDBUsers, ScreenRedirects, ScreenDetail)
+	}
+}
+
+func TestNavStackFocusPanel(t *testing.T) {
+	tests := []struct {
+		name  string
+		nav   NavStack
+		focus Focus
+		want  []Screen
+	}{
+		{"tree drops everything", navOf(ScreenDetail, ScreenDeployScript, ScreenOutput), FocusTree, []Screen{ScreenTree}},
+		{"detail from the tree", NewNavStack(), FocusDetail, []Screen{ScreenTree, ScreenDetail}},
+		{"detail returns to its sub-view", navOf(ScreenDetail, ScreenSecurity, ScreenOutput), FocusDetail, []Screen{ScreenTree, ScreenDetail, ScreenSecurity}},
+		{"detail already focused", navOf(ScreenDetail, ScreenDBUsers), FocusDetail, []Screen{ScreenTree, ScreenDetail, ScreenDBUsers}},
+		{"output is pushed", navOf(ScreenDetail, ScreenDeployScript), FocusOutput, []Screen{ScreenTree, ScreenDetail, ScreenDeployScript, ScreenOutput}},
+		{"output already open", navOf(ScreenOutput), FocusOutput, []Screen{ScreenTree, ScreenOutput}},
+	}
+	for _, tt := range tests {
+		got := tt.nav.FocusPanel(tt.focus)
+		if !slices.Equal(got.all(), tt.want) {
+			t.Errorf("%s: stack = %v, want %v", tt.name, got.all(), tt.want)
+		}
+		if got.Focus() != tt.focus {
+			t.Errorf("%s: focus = %v, want %v", tt.name, got.Focus(), tt.focus)
+		}
+	}
+}
+
+func TestNavStackDetailScreen(t *testing.T) {
+	tests := []struct {
+		name string
+		nav  NavStack
+		want Screen
+	}{
+		{"tree only", NewNavStack(), ScreenDetail},
+		{"zero stack", NavStack{}, ScreenDetail},
+		{"tab", navOf(ScreenDetail), ScreenDetail},
+		{"sub-view", navOf(ScreenDetail, ScreenRedirects), ScreenRedirects},
+		{"sub-view under the output", navOf(ScreenDetail, ScreenDeployScript, ScreenOutput), ScreenDeployScript},
+		{"output over the tree", navOf(ScreenOutput), ScreenDetail},
+	}
+	for _, tt := range tests {
+		if got := tt.nav.DetailScreen(); got != tt.want {
+			t.Errorf("%s: DetailScreen() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// handleNginxKey handles keys specific to the nginx templates tab.
+func (m App) handleNginxKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInput("nginx-template", "Template name:", "laravel"))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("e", "enter"))):
+		return m, m.detail.nginxPanel.EditTemplate()
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
+		t := m.detail.nginxPanel.SelectedTemplate()
+		if t == nil {
+			return m, nil
+		}
+		sites := m.detail.nginxPanel.Sites()
+		if len(sites) == 0 {
+			m.toast = "This server has no sites"
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		options := make([]components.PickerOption, len(sites))
+		for i, s := range sites {
+			options[i] = components.PickerOption{
+				Label: s.Name,
+				Value: strconv.FormatInt(s.ID, 10),
+			}
+		}
+		m.dialogs = m.dialogs.Pick(components.NewPicker("nginx-apply", fmt.Sprintf("Apply %s to:", t.Name), options))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if t := m.detail.nginxPanel.SelectedTemplate(); t != nil {
+			m.dialogs = m.dialogs.Confirm("delete-nginx-template", fmt.Sprintf("Delete nginx template %q?", t.Name))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// createNginxTemplate opens the editor on a new template called name.
+func (m App) createNginxTemplate(name string) (tea.Model, tea.Cmd) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return m, nil
+	}
+	return m, m.detail.nginxPanel.NewTemplate(name)
+}
+
+// confirmApplyNginx asks before overwriting the picked site's nginx
+// configuration with the selected template.
+func (m App) confirmApplyNginx(siteID string) (tea.Model, tea.Cmd) {
+	t := m.detail.nginxPanel.SelectedTemplate()
+	if t == nil {
+		return m, nil
+	}
+	for _, s := range m.detail.nginxPanel.Sites() {
+		if strconv.FormatInt(s.ID, 10) == siteID {
+			m.pendingInputValue = siteID
+			m.dialogs = m.dialogs.Confirm("nginx-apply", fmt.Sprintf("Replace the nginx config of %s with %s?", s.Name, t.Name))
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// applyNginxTemplate writes the selected template to the site confirmed
+// in confirmApplyNginx.
+func (m App) applyNginxTemplate() (tea.Model, tea.Cmd) {
+	siteID := m.pendingInputValue
+	m.pendingInputValue = ""
+	if m.selectedSrv == nil {
+		return m, nil
+	}
+	for _, s := range m.detail.nginxPanel.Sites() {
+		if strconv.FormatInt(s.ID, 10) == siteID {
+			m.toast = fmt.Sprintf("Applying template to %s...", s.Name)
+			m.toastIsErr = false
+			return m, m.detail.nginxPanel.ApplyToSite(*m.selectedSrv, s)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,460 @@
+package panels
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// --- Messages ---
+
+// BackupsLoadedMsg is sent when a server's backup configurations, with
+// their backups, have been fetched.
+type BackupsLoadedMsg struct {
+	Configs []forge.BackupConfig
+}
+
+// BackupRunMsg is sent when a backup has been started.
+type BackupRunMsg struct {
+	Config string
+}
+
+// BackupRestoredMsg is sent when the restore of a backup has been started.
+type BackupRestoredMsg struct {
+	Date string
+}
+
+// BackupConfigDeletedMsg is sent when a backup configuration has been
+// deleted.
+type BackupConfigDeletedMsg struct {
+	Config string
+}
+
+// backupRow is one line of the panel: a backup configuration, or one of
+// its backups when backup is set.
+type backupRow struct {
+	config forge.BackupConfig
+	backup *forge.Backup
+}
+
+// BackupsPanel lists a server's database backup configurations, each
+// followed by its backups.
+type BackupsPanel struct {
+	client   *forge.Client
+	serverID int64
+
+	configs []forge.BackupConfig
+	rows    []backupRow // as shown, rebuilt when configs or order change
+	cursor  int
+	loading bool
+	order   tableSort
+
+	// Keybindings
+	up   key.Binding
+	down key.Binding
+	home key.Binding
+	end  key.Binding
+	sort key.Binding
+}
+
+// backupsSort are the columns the backups of each configuration can be
+// sorted by; the configurations themselves keep the API's order.
+var backupsSort = []sortColumn[forge.Backup]{
+	{name: "status", cmp: func(a, b forge.Backup) int { return compareFold(a.Status, b.Status) }},
+	{name: "date", cmp: func(a, b forge.Backup) int { return compareTimestamps(a.Date, b.Date) }},
+	{name: "size", cmp: func(a, b forge.Backup) int {
+		sa, aok := a.Size.(float64)
+		sb, bok := b.Size.(float64)
+		return compareKnown(sa, aok, sb, bok)
+	}},
+}
+
+// NewBackupsPanel creates a new BackupsPanel.
+func NewBackupsPanel(client *forge.Client, serverID int64) BackupsPanel {
+	return BackupsPanel{
+		client:   client,
+		serverID: serverID,
+		loading:  true,
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		home: key.NewBinding(
+			key.WithKeys("g", "home"),
+			key.WithHelp("g", "top"),
+		),
+		end: key.NewBinding(
+			key.WithKeys("G", "end"),
+			key.WithHelp("G", "bottom"),
+		),
+		sort: sortKey,
+	}
+}
+
+// LoadBackups returns a tea.Cmd that fetches the server's backup
+// configurations.
+func (p BackupsPanel) LoadBackups() tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	return func() tea.Msg {
+		configs, err := client.Backups.ListConfigs(context.Background(), serverID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return BackupsLoadedMsg{Configs: configs}
+	}
+}
+
+// SelectedConfig returns the configuration of the selected row, or nil.
+func (p BackupsPanel) SelectedConfig() *forge.BackupConfig {
+	if p.cursor >= len(p.rows) {
+		return nil
+	}
+	c := p.rows[p.cursor].config
+	return &c
+}
+
+// SelectedBackup returns the selected backup, or nil when a configuration
+// (or nothing) is selected.
+func (p BackupsPanel) SelectedBackup() *forge.Backup {
+	if p.cursor >= len(p.rows) || p.rows[p.cursor].backup == nil {
+		return nil
+	}
+	b := *p.rows[p.cursor].backup
+	return &b
+}
+
+// RunBackup returns a tea.Cmd that starts a backup with the selected
+// configuration.
+func (p BackupsPanel) RunBackup() tea.Cmd {
+	c := p.SelectedConfig()
+	if c == nil {
+		return nil
+	}
+	client := p.client
+	serverID := p.serverID
+	return func() tea.Msg {
+		if err := client.Backups.RunBackup(context.Background(), serverID, c.ID); err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return BackupRunMsg{Config: BackupConfigName(*c)}
+	}
+}
+
+// RestoreBackup returns a tea.Cmd that restores the selected backup.
+func (p BackupsPanel) RestoreBackup() tea.Cmd {
+	b := p.SelectedBackup()
+	if b == nil {
+		return nil
+	}
+	client := p.client
+	serverID := p.serverID
+	return func() tea.Msg {
+		if err := client.Backups.RestoreBackup(context.Background(), serverID, b.BackupConfigurationID, b.ID); err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return BackupRestoredMsg{Date: backupDate(*b)}
+	}
+}
+
+// DeleteConfig returns a tea.Cmd that deletes the selected configuration.
+func (p BackupsPanel) DeleteConfig() tea.Cmd {
+	c := p.SelectedConfig()
+	if c == nil {
+		return nil
+	}
+	client := p.client
+	serverID := p.serverID
+	return func() tea.Msg {
+		if err := client.Backups.DeleteConfig(context.Background(), serverID, c.ID); err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return BackupConfigDeletedMsg{Config: BackupConfigName(*c)}
+	}
+}
+
+// Loading reports whether the panel's data is still being fetched.
+func (p BackupsPanel) Loading() bool {
+	return p.loading
+}
+
+// Update handles messages for the backups panel.
+func (p BackupsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case BackupsLoadedMsg:
+		rows := backupRows(msg.Configs, p.order)
+		p.cursor = keepCursor(p.rows, rows, p.cursor, backupRowID)
+		p.configs = msg.Configs
+		p.rows = rows
+		p.loading = false
+		return p, nil
+
+	case tea.KeyPressMsg:
+		return p.handleKey(msg)
+	}
+
+	return p, nil
+}
+
+// backupRows returns the rows listing configs: each configuration
+// followed by its backups, in the order s puts them in.
+func backupRows(configs []forge.BackupConfig, s tableSort) []backupRow {
+	var rows []backupRow
+	for _, c := range configs {
+		rows = append(rows, backupRow{config: c})
+		for _, b := range sortRows(c.Backups, backupsSort, s) {
+			if b.BackupConfigurationID == 0 {
+				b.BackupConfigurationID = c.ID
+			}
+			rows = append(rows, backupRow{config: c, backup: &b})
+		}
+	}
+	return rows
+}
+
+func backupRowID(r backupRow) [2]int64 {
+	if r.backup == nil {
+		return [2]int64{r.config.ID, 0}
+	}
+	return [2]int64{r.config.ID, r.backup.ID}
+}
+
+func (p BackupsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.sort):
+		p.order = p.order.next(len(backupsSort))
+		rows := backupRows(p.configs, p.order)
+		p.cursor = keepCursor(p.rows, rows, p.cursor, backupRowID)
+		p.rows = rows
+		return p, nil
+
+	case key.Matches(msg, p.down):
+		if len(p.rows) > 0 {
+			p.cursor = min(p.cursor+1, len(p.rows)-1)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.up):
+		if len(p.rows) > 0 {
+			p.cursor = max(p.cursor-1, 0)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.home):
+		p.cursor = 0
+		return p, nil
+
+	case key.Matches(msg, p.end):
+		if len(p.rows) > 0 {
+			p.cursor = len(p.rows) - 1
+		}
+		return p, nil
+
+	// 'n', 'r', 'x' are handled by the app layer.
+	}
+
+	return p, nil
+}
+
+// View renders the backups panel.
+func (p BackupsPanel) View(width, height int, focused bool) string {
+	style := theme.InactiveBorderStyle
+	titleColor := theme.ColorSubtle
+	if focused {
+		style = theme.ActiveBorderStyle
+		titleColor = theme.ColorPrimary
+	}
+
+	innerWidth, innerHeight := layout.Inner(width, height)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(titleColor).
+		Render(countTitle("Backups", len(p.configs), p.loading)) +
+		sortChip(backupsSort, p.order)
+
+	content := p.renderList(innerWidth, innerHeight-1)
+
+	return style.
+		Width(innerWidth).
+		Height(innerHeight).
+		Render(title + "\n" + content)
+}
+
+// backupColSizeWidth is the width of the size column.
+const backupColSizeWidth = 12
+
+const backupTableOverhead = 2 + colStatusWidth + 2 + 2 + backupColSizeWidth + 4
+
+func backupNameWidth(maxWidth int) int {
+	return layout.Columns(maxWidth, backupTableOverhead, 10)
+}
+
+func (p BackupsPanel) renderList(width, height int) string {
+	var lines []string
+
+	if p.loading && len(p.rows) == 0 {
+		lines = append(lines, theme.LoadingStyle.Render("Loading backups..."))
+	} else if len(p.rows) == 0 {
+		lines = append(lines, theme.NormalItemStyle.Render("No backup configurations on this server"))
+	} else {
+		lines = append(lines, p.renderBackupHeader(width))
+
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
+
+		for i := startIdx; i < len(p.rows) && len(lines)-1 < visibleHeight; i++ {
+			lines = append(lines, p.renderBackupLine(p.rows[i], i, width))
+		}
+	}
+
+	lines = layout.Pad(lines, height)
+
+	return strings.Join(lines, "\n")
+}
+
+func (p BackupsPanel) renderBackupHeader(maxWidth int) string {
+	nameW := backupNameWidth(maxWidth)
+	line := fmt.Sprintf("  %-*s  %-*s  %-*s",
+		colStatusWidth, "STATUS",
+		nameW, "BACKUP",
+		backupColSizeWidth, "SIZE",
+	)
+	return theme.Truncate(headerStyle.Render(line), maxWidth)
+}
+
+func (p BackupsPanel) renderBackupLine(row backupRow, idx, maxWidth int) string {
+	nameW := backupNameWidth(maxWidth)
+	statusPad := colStatusWidth - 2
+
+	var statusStr, name, size string
+	nameStyle := theme.NormalItemStyle
+	if row.backup == nil {
+		icon := lipgloss.NewStyle().Foreground(theme.ColorPrimary).Render(theme.GlyphDot)
+		statusStr = icon + " " + fmt.Sprintf("%-*s", statusPad, "config")
+		name = truncatePlain(BackupConfigName(row.config), nameW)
+		size = fmt.Sprintf("%d backup(s)", len(row.config.Backups))
+		nameStyle = lipgloss.NewStyle().Bold(true)
+	} else {
+		b := *row.backup
+		statusText := b.Status
+		if statusText == "" {
+			statusText = "unknown"
+		}
+		statusStr = backupStatusIcon(b.Status) + " " + fmt.Sprintf("%-*s", statusPad, truncatePlain(statusText, statusPad))
+		name = truncatePlain("  "+backupDate(b), nameW)
+		size = backupSize(b.Size)
+	}
+	sizeStr := fmt.Sprintf("%-*s", backupColSizeWidth, truncatePlain(size, backupColSizeWidth))
+
+	if idx == p.cursor {
+		line := theme.CursorStyle.Render("> ") +
+			statusStr +
+			"  " + theme.SelectedItemStyle.Render(fmt.Sprintf("%-*s", nameW, name)) +
+			"  " + theme.NormalItemStyle.Render(sizeStr)
+		return theme.Truncate(line, maxWidth)
+	}
+
+	line := "  " +
+		statusStr +
+		"  " + nameStyle.Render(fmt.Sprintf("%-*s", nameW, name)) +
+		"  " + theme.NormalItemStyle.Render(sizeStr)
+	return theme.Truncate(line, maxWidth)
+}
+
+// backupStatusIcon returns the icon for a backup's status. Forge reports
+// a successful backup as "success".
+func backupStatusIcon(status string) string {
+	switch strings.ToLower(status) {
+	case "success", "finished":
+		return statusIcon("finished")
+	case "failed", "error":
+		return statusIcon("failed")
+	case "running", "pending":
+		return statusIcon("deploying")
+	}
+	return statusIcon(status)
+}
+
+// BackupConfigName describes a backup configuration, e.g.
+// "s3 · daily at 03:00 · 2 database(s)".
+func BackupConfigName(c forge.BackupConfig) string {
+	parts := []string{c.Provider}
+	if parts[0] == "" {
+		parts[0] = fmt.Sprintf("#%d", c.ID)
+	}
+	schedule := c.Frequency
+	if c.DayOfWeek != nil && *c.DayOfWeek >= 0 && *c.DayOfWeek < 7 {
+		schedule += " on " + time.Weekday(*c.DayOfWeek).String()
+	}
+	if at := c.Time; at != "" {
+		schedule += " at " + at
+	}
+	if schedule = strings.TrimSpace(schedule); schedule != "" {
+		parts = append(parts, schedule)
+	}
+	if len(c.Databases) > 0 {
+		parts = append(parts, fmt.Sprintf("%d database(s)", len(c.Databases)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// backupDate returns when a backup was taken, as Forge reports it, or its
+// ID when the date is missing.
+func backupDate(b forge.Backup) string {
+	if b.Date != "" {
+		return b.Date
+	}
+	return fmt.Sprintf("#%d", b.ID)
+}
+
+// backupSize formats a backup's size, which Forge reports either as a
+// number of bytes or as text.
+func backupSize(v any) string {
+	switch v := v.(type) {
+	case float64:
+		units := []string{"B", "KB", "MB", "GB", "TB"}
+		i := 0
+		for v >= 1024 && i < len(units)-1 {
+			v /= 1024
+			i++
+		}
+		if i == 0 {
+			return fmt.Sprintf("%.0f %s", v, units[i])
+		}
+		return fmt.Sprintf("%.1f %s", v, units[i])
+	case string:
+		return v
+	case nil:
+		return "-"
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// HelpBindings returns the key hints for the backups panel.
+func (p BackupsPanel) HelpBindings() []HelpBinding {
+	return []HelpBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "n", Desc: "back up now"},
+		{Key: "r", Desc: "restore"},
+		{Key: "x", Desc: "delete config"},
+		{Key: "s", Desc: "sort"},
+		{Key: "g/G", Desc: "top/bottom"},
+		{Key: "esc", Desc: "back"},
+		{Key: "tab", Desc: "switch panel"},
+		{Key: "q", Desc: "quit"},
+	}
+}
@@ -0,0 +1,378 @@
+package panels
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// --- Messages ---
+
+// CirclesLoadedMsg is sent when the circles with access to the server
+// have been fetched.
+type CirclesLoadedMsg struct {
+	Circles []forge.Circle
+}
+
+// CircleInvitedMsg is sent when someone has been invited to a circle.
+type CircleInvitedMsg struct {
+	Circle string
+	Email  string
+}
+
+// CircleMemberRemovedMsg is sent when a member has been removed from a
+// circle.
+type CircleMemberRemovedMsg struct {
+	Circle string
+	Member string
+}
+
+// circleRow is one member of one circle, as listed by the panel.
+type circleRow struct {
+	circle forge.Circle
+	member forge.CircleMember
+}
+
+// CirclesPanel lists the members of every circle with access to a server,
+// so team changes don't need a trip to the Forge dashboard.
+type CirclesPanel struct {
+	client   *forge.Client
+	serverID int64
+
+	circles []forge.Circle
+	rows    []circleRow
+	cursor  int // index into shown()
+	loading bool
+	order   tableSort
+
+	// Keybindings
+	up   key.Binding
+	down key.Binding
+	home key.Binding
+	end  key.Binding
+	sort key.Binding
+}
+
+// circlesSort are the columns the circles table can be sorted by.
+var circlesSort = []sortColumn[circleRow]{
+	{name: "status", cmp: func(a, b circleRow) int { return compareFold(a.member.Status, b.member.Status) }},
+	{name: "name", cmp: func(a, b circleRow) int { return compareFold(memberName(a.member), memberName(b.member)) }},
+	{name: "email", cmp: func(a, b circleRow) int { return compareFold(a.member.Email, b.member.Email) }},
+	{name: "circle", cmp: func(a, b circleRow) int { return compareFold(a.circle.Name, b.circle.Name) }},
+}
+
+// NewCirclesPanel creates a new CirclesPanel.
+func NewCirclesPanel(client *forge.Client, serverID int64) CirclesPanel {
+	return CirclesPanel{
+		client:   client,
+		serverID: serverID,
+		loading:  true,
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		home: key.NewBinding(
+			key.WithKeys("g", "home"),
+			key.WithHelp("g", "top"),
+		),
+		end: key.NewBinding(
+			key.WithKeys("G", "end"),
+			key.WithHelp("G", "bottom"),
+		),
+		sort: sortKey,
+	}
+}
+
+// LoadCircles returns a tea.Cmd that fetches the circles with access to
+// the server.
+func (p CirclesPanel) LoadCircles() tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	return func() tea.Msg {
+		circles, err := client.Circles.ForServer(context.Background(), serverID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return CirclesLoadedMsg{Circles: circles}
+	}
+}
+
+// Circles returns the circles with access to the server.
+func (p CirclesPanel) Circles() []forge.Circle {
+	return p.circles
+}
+
+// shown returns the rows in the order the table shows them.
+func (p CirclesPanel) shown() []circleRow {
+	return sortRows(p.rows, circlesSort, p.order)
+}
+
+// SelectedCircle returns the circle of the selected row, or the only
+// circle when it has no members yet, or nil.
+func (p CirclesPanel) SelectedCircle() *forge.Circle {
+	if shown := p.shown(); p.cursor < len(shown) {
+		c := shown[p.cursor].circle
+		return &c
+	}
+	if len(p.circles) == 1 {
+		c := p.circles[0]
+		return &c
+	}
+	return nil
+}
+
+// SelectedMember returns the selected member, or nil.
+func (p CirclesPanel) SelectedMember() *forge.CircleMember {
+	shown := p.shown()
+	if p.cursor >= len(shown) {
+		return nil
+	}
+	m := shown[p.cursor].member
+	return &m
+}
+
+// Invite returns a tea.Cmd that invites email to the circle.
+func (p CirclesPanel) Invite(circle forge.Circle, email string) tea.Cmd {
+	client := p.client
+	return func() tea.Msg {
+		if _, err := client.Circles.Invite(context.Background(), circle.ID, email); err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return CircleInvitedMsg{Circle: circle.Name, Email: email}
+	}
+}
+
+// RemoveMember returns a tea.Cmd that removes the selected member from
+// their circle.
+func (p CirclesPanel) RemoveMember() tea.Cmd {
+	shown := p.shown()
+	if p.cursor >= len(shown) {
+		return nil
+	}
+	client := p.client
+	row := shown[p.cursor]
+	return func() tea.Msg {
+		if err := client.Circles.RemoveMember(context.Background(), row.circle.ID, row.member.ID); err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return CircleMemberRemovedMsg{Circle: row.circle.Name, Member: memberName(row.member)}
+	}
+}
+
+// Loading reports whether the panel's data is still being fetched.
+func (p CirclesPanel) Loading() bool {
+	return p.loading
+}
+
+// Update handles messages for the circles panel.
+func (p CirclesPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case CirclesLoadedMsg:
+		var rows []circleRow
+		for _, c := range msg.Circles {
+			for _, m := range c.Members {
+				rows = append(rows, circleRow{circle: c, member: m})
+			}
+		}
+		old := p.shown()
+		p.circles = msg.Circles
+		p.rows = rows
+		p.cursor = keepCursor(old, p.shown(), p.cursor, circleRowID)
+		p.loading = false
+		return p, nil
+
+	case tea.KeyPressMsg:
+		return p.handleKey(msg)
+	}
+
+	return p, nil
+}
+
+func circleRowID(r circleRow) [2]int64 { return [2]int64{r.circle.ID, r.member.ID} }
+
+func (p CirclesPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(circlesSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, circleRowID)
+		return p, nil
+
+	case key.Matches(msg, p.down):
+		if len(p.rows) > 0 {
+			p.cursor = min(p.cursor+1, len(p.rows)-1)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.up):
+		if len(p.rows) > 0 {
+			p.cursor = max(p.cursor-1, 0)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.home):
+		p.cursor = 0
+		return p, nil
+
+	case key.Matches(msg, p.end):
+		if len(p.rows) > 0 {
+			p.cursor = len(p.rows) - 1
+		}
+		return p, nil
+
+	// 'c', 'x' are handled by the app layer.
+	}
+
+	return p, nil
+}
+
+// View renders the circles panel.
+func (p CirclesPanel) View(width, height int, focused bool) string {
+	style := theme.InactiveBorderStyle
+	titleColor := theme.ColorSubtle
+	if focused {
+		style = theme.ActiveBorderStyle
+		titleColor = theme.ColorPrimary
+	}
+
+	innerWidth, innerHeight := layout.Inner(width, height)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(titleColor).
+		Render(countTitle("Circles", len(p.circles), p.loading)) +
+		sortChip(circlesSort, p.order)
+
+	content := p.renderList(innerWidth, innerHeight-1)
+
+	return style.
+		Width(innerWidth).
+		Height(innerHeight).
+		Render(title + "\n" + content)
+}
+
+// Column widths for the circles table.
+const (
+	circleColCircleWidth = 16
+	circleColEmailWidth  = 28
+)
+
+const circleTableOverhead = 2 + colStatusWidth + 2 + 2 + circleColEmailWidth + 2 + circleColCircleWidth + 4
+
+func circleNameWidth(maxWidth int) int {
+	return layout.Columns(maxWidth, circleTableOverhead, 10)
+}
+
+func (p CirclesPanel) renderList(width, height int) string {
+	var lines []string
+
+	switch {
+	case p.loading && len(p.rows) == 0:
+		lines = append(lines, theme.LoadingStyle.Render("Loading circles..."))
+	case len(p.circles) == 0:
+		lines = append(lines, theme.NormalItemStyle.Render("No circles have access to this server"))
+	case len(p.rows) == 0:
+		lines = append(lines, theme.NormalItemStyle.Render("No circle members yet (c to invite)"))
+	default:
+		lines = append(lines, p.renderCircleHeader(width))
+
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
+
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			lines = append(lines, p.renderCircleLine(shown[i], i, width))
+		}
+	}
+
+	lines = layout.Pad(lines, height)
+
+	return strings.Join(lines, "\n")
+}
+
+func (p CirclesPanel) renderCircleHeader(maxWidth int) string {
+	nameW := circleNameWidth(maxWidth)
+	line := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s",
+		colStatusWidth, "STATUS",
+		nameW, "NAME",
+		circleColEmailWidth, "EMAIL",
+		circleColCircleWidth, "CIRCLE",
+	)
+	return theme.Truncate(headerStyle.Render(line), maxWidth)
+}
+
+func (p CirclesPanel) renderCircleLine(row circleRow, idx, maxWidth int) string {
+	icon := lipgloss.NewStyle().Foreground(theme.ColorSecondary).Render(theme.GlyphOK)
+	statusText := "member"
+	if strings.EqualFold(row.member.Status, "pending") {
+		icon = lipgloss.NewStyle().Foreground(theme.ColorHighlight).Render(theme.GlyphDot)
+		statusText = "invited"
+	}
+
+	nameW := circleNameWidth(maxWidth)
+	name := truncatePlain(memberName(row.member), nameW)
+
+	email := row.member.Email
+	if email == "" {
+		email = "-"
+	}
+
+	statusPad := colStatusWidth - 2
+	statusStr := icon + " " + fmt.Sprintf("%-*s", statusPad, statusText)
+	emailStr := fmt.Sprintf("%-*s", circleColEmailWidth, truncatePlain(email, circleColEmailWidth))
+	circleStr := fmt.Sprintf("%-*s", circleColCircleWidth, truncatePlain(row.circle.Name, circleColCircleWidth))
+
+	if idx == p.cursor {
+		line := theme.CursorStyle.Render("> ") +
+			statusStr +
+			"  " + theme.SelectedItemStyle.Render(fmt.Sprintf("%-*s", nameW, name)) +
+			"  " + theme.NormalItemStyle.Render(emailStr) +
+			"  " + theme.NormalItemStyle.Render(circleStr)
+		return theme.Truncate(line, maxWidth)
+	}
+
+	line := "  " +
+		statusStr +
+		"  " + theme.NormalItemStyle.Render(fmt.Sprintf("%-*s", nameW, name)) +
+		"  " + theme.NormalItemStyle.Render(emailStr) +
+		"  " + theme.NormalItemStyle.Render(circleStr)
+	return theme.Truncate(line, maxWidth)
+}
+
+// memberName returns a member's name, falling back to their email for
+// invitations that haven't been accepted.
+func memberName(m forge.CircleMember) string {
+	switch {
+	case m.Name != "":
+		return m.Name
+	case m.Email != "":
+		return m.Email
+	}
+	return "-"
+}
+
+// HelpBindings returns the key hints for the circles panel.
+func (p CirclesPanel) HelpBindings() []HelpBinding {
+	return []HelpBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "c", Desc: "invite"},
+		{Key: "x", Desc: "remove"},
+		{Key: "s", Desc: "sort"},
+		{Key: "g/G", Desc: "top/bottom"},
+		{Key: "esc", Desc: "back"},
+		{Key: "tab", Desc: "switch panel"},
+		{Key: "q", Desc: "quit"},
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/bubbles/v2/key"
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -37,8 +39,9 @@ type CommandsPanel struct {
 	siteID   int64
 
 	commands []forge.SiteCommand
-	cursor   int
+	cursor   int // index into shown()
 	loading  bool
+	order    tableSort
 
 	// Detail sub-view state.
 	showDetail    bool
@@ -51,6 +54,19 @@ type CommandsPanel struct {
 	enter  key.Binding
 	home   key.Binding
 	end    key.Binding
+	sort   key.Binding
+}
+
+// commandsSort are the columns the commands table can be sorted by.
+var commandsSort = []sortColumn[forge.SiteCommand]{
+	{name: "date", cmp: func(a, b forge.SiteCommand) int { return compareTimestamps(a.CreatedAt, b.CreatedAt) }},
+	{name: "took", cmp: func(a, b forge.SiteCommand) int {
+		da, aok := a.Elapsed()
+		db, bok := b.Elapsed()
+		return compareKnown(da, aok, db, bok)
+	}},
+	{name: "user", cmp: func(a, b forge.SiteCommand) int { return compareFold(a.UserName, b.UserName) }},
+	{name: "status", cmp: func(a, b forge.SiteCommand) int { return compareFold(a.Status, b.Status) }},
 }
 
 // NewCommandsPanel creates a new CommandsPanel.
@@ -84,6 +100,7 @@ func NewCommandsPanel(client *forge.Client, serverID, siteID int64) CommandsPane
 			key.WithKeys("G", "end"),
 			key.WithHelp("G", "bottom"),
 		),
+		sort: sortKey,
 	}
 }
 
@@ -117,13 +134,14 @@ func (p CommandsPanel) CreateCommand(command string) tea.Cmd {
 
 // FetchCommandDetail returns a tea.Cmd that fetches a single command's details.
 func (p CommandsPanel) FetchCommandDetail() tea.Cmd {
-	if len(p.commands) == 0 || p.cursor >= len(p.commands) {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
 		return nil
 	}
 	client := p.client
 	serverID := p.serverID
 	siteID := p.siteID
-	cmdID := p.commands[p.cursor].ID
+	cmdID := shown[p.cursor].ID
 	return func() tea.Msg {
 		cmd, err := client.Commands.Get(context.Background(), serverID, siteID, cmdID)
 		if err != nil {
@@ -133,18 +151,29 @@ func (p CommandsPanel) FetchCommandDetail() tea.Cmd {
 	}
 }
 
+// shown returns the commands in the order the table shows them.
+func (p CommandsPanel) shown() []forge.SiteCommand {
+	return sortRows(p.commands, commandsSort, p.order)
+}
+
 // ShowingDetail reports whether the detail sub-view is active.
 func (p CommandsPanel) ShowingDetail() bool {
 	return p.showDetail
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p CommandsPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the commands panel.
 func (p CommandsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case CommandsLoadedMsg:
+		old := p.shown()
 		p.commands = msg.Commands
+		p.cursor = keepCursor(old, p.shown(), p.cursor, commandID)
 		p.loading = false
-		p.cursor = 0
 		return p, nil
 
 	case CommandDetailMsg:
@@ -159,6 +188,8 @@ func (p CommandsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	return p, nil
 }
 
+func commandID(c forge.SiteCommand) int64 { return c.ID }
+
 func (p CommandsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 	// If showing detail, Esc goes back to list.
 	if p.showDetail {
@@ -172,6 +203,12 @@ func (p CommandsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 	}
 
 	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(commandsSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, commandID)
+		return p, nil
+
 	case key.Matches(msg, p.down):
 		if len(p.commands) > 0 {
 			p.cursor = min(p.cursor+1, len(p.commands)-1)
@@ -212,19 +249,13 @@ func (p CommandsPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Commands ")
+		Render(countTitle("Commands", len(p.commands), p.loading)) +
+		sortChip(commandsSort, p.order)
 
 	var content string
 	if p.showDetail && p.detailCommand != nil {
@@ -244,37 +275,45 @@ func (p CommandsPanel) renderDetail(width, height int) string {
 	var lines []string
 
 	lines = append(lines, renderInfoKV("Command", cmd.Command, width))
-	lines = append(lines, renderInfoKV("Status", cmd.Status, width))
+	lines = append(lines, theme.Truncate(theme.LabelStyle.Render("Status:")+" "+
+		commandStatusStyle(cmd.Status).Render(cmd.Status), width))
 	lines = append(lines, renderInfoKV("User", cmd.UserName, width))
-	lines = append(lines, renderInfoKV("Created", cmd.CreatedAt, width))
-	if cmd.Duration != nil {
+	created := absoluteTime(cmd.CreatedAt)
+	if rel := relativeTime(cmd.CreatedAt); rel != "" && rel != cmd.CreatedAt {
+		created += " (" + rel + ")"
+	}
+	lines = append(lines, renderInfoKV("Created", created, width))
+	if d, ok := cmd.Elapsed(); ok {
+		lines = append(lines, renderInfoKV("Duration", humanDuration(d), width))
+	} else if cmd.Duration != nil {
 		lines = append(lines, renderInfoKV("Duration", fmt.Sprintf("%v", cmd.Duration), width))
 	}
+	if first, more := firstLine(cmd.Output); first != "" {
+		if more > 0 {
+			first += fmt.Sprintf(" (+%d lines)", more)
+		}
+		lines = append(lines, renderInfoKV("Output", first, width))
+	}
 
 	lines = append(lines, "")
 	lines = append(lines, theme.LabelStyle.Render("Press Esc to go back"))
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
 
 // Column widths for commands table.
 const (
-	cmdColUserWidth = 10
-	cmdColDateWidth = 12
+	cmdColUserWidth     = 10
+	cmdColDurationWidth = 8
+	cmdColDateWidth     = 12
 )
 
-const cmdTableOverhead = 2 + colStatusWidth + 2 + 2 + cmdColUserWidth + 2 + cmdColDateWidth + 4
+const cmdTableOverhead = 2 + colStatusWidth + 2 + 2 + cmdColUserWidth + 2 + cmdColDurationWidth + 2 + cmdColDateWidth + 4
 
 func cmdFlexWidth(maxWidth int) int {
-	w := maxWidth - cmdTableOverhead
-	if w < 10 {
-		w = 10
-	}
-	return w
+	return layout.Columns(maxWidth, cmdTableOverhead, 10)
 }
 
 func (p CommandsPanel) renderList(width, height int) string {
@@ -287,35 +326,29 @@ func (p CommandsPanel) renderList(width, height int) string {
 	} else {
 		lines = append(lines, p.renderCommandHeader(width))
 
-		visibleHeight := height - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
-		for i := startIdx; i < len(p.commands) && len(lines)-1 < visibleHeight; i++ {
-			cmd := p.commands[i]
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			cmd := shown[i]
 			line := p.renderCommandLine(cmd, i, width)
 			lines = append(lines, line)
 		}
 	}
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
 
 func (p CommandsPanel) renderCommandHeader(maxWidth int) string {
 	flexW := cmdFlexWidth(maxWidth)
-	line := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s",
+	line := fmt.Sprintf("  %-*s  %-*s  %-*s  %*s  %-*s",
 		colStatusWidth, "STATUS",
 		flexW, "COMMAND",
 		cmdColUserWidth, "USER",
+		cmdColDurationWidth, "TOOK",
 		cmdColDateWidth, "DATE",
 	)
 	return theme.Truncate(headerStyle.Render(line), maxWidth)
@@ -338,21 +371,24 @@ func (p CommandsPanel) renderCommandLine(cmd forge.SiteCommand, idx, maxWidth in
 		user = "-"
 	}
 
-	date := cmd.CreatedAt
+	took := "-"
+	if d, ok := cmd.Elapsed(); ok {
+		took = humanDuration(d)
+	}
+
+	date := localDate(cmd.CreatedAt)
 	if date == "" {
 		date = "-"
 	}
-	// Trim to date portion if it contains a time.
-	if len(date) > cmdColDateWidth {
-		date = date[:cmdColDateWidth]
-	}
 
 	flexW := cmdFlexWidth(maxWidth)
 	command = truncatePlain(command, flexW)
 
 	statusPad := colStatusWidth - 2
-	statusStr := icon + " " + fmt.Sprintf("%-*s", statusPad, truncatePlain(statusText, statusPad))
+	statusStr := icon + " " + commandStatusStyle(cmd.Status).Render(
+		fmt.Sprintf("%-*s", statusPad, truncatePlain(statusText, statusPad)))
 	userStr := fmt.Sprintf("%-*s", cmdColUserWidth, truncatePlain(user, cmdColUserWidth))
+	tookStr := fmt.Sprintf("%*s", cmdColDurationWidth, truncatePlain(took, cmdColDurationWidth))
 	dateStr := fmt.Sprintf("%-*s", cmdColDateWidth, truncatePlain(date, cmdColDateWidth))
 
 	if idx == p.cursor {
@@ -360,6 +396,7 @@ func (p CommandsPanel) renderCommandLine(cmd forge.SiteCommand, idx, maxWidth in
 			statusStr +
 			"  " + theme.SelectedItemStyle.Render(fmt.Sprintf("%-*s", flexW, command)) +
 			"  " + theme.NormalItemStyle.Render(userStr) +
+			"  " + theme.NormalItemStyle.Render(tookStr) +
 			"  " + theme.NormalItemStyle.Render(dateStr)
 		return theme.Truncate(line, maxWidth)
 	}
@@ -368,6 +405,7 @@ func (p CommandsPanel) renderCommandLine(cmd forge.SiteCommand, idx, maxWidth in
 		statusStr +
 		"  " + theme.NormalItemStyle.Render(fmt.Sprintf("%-*s", flexW, command)) +
 		"  " + theme.NormalItemStyle.Render(userStr) +
+		"  " + theme.NormalItemStyle.Render(tookStr) +
 		"  " + theme.NormalItemStyle.Render(dateStr)
 	return theme.Truncate(line, maxWidth)
 }
@@ -385,9 +423,47 @@ func (p CommandsPanel) HelpBindings() []HelpBinding {
 		{Key: "j/k", Desc: "navigate"},
 		{Key: "enter", Desc: "view details"},
 		{Key: "c", Desc: "run command"},
+		{Key: "C", Desc: "run script"},
+		{Key: "s", Desc: "sort"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
 		{Key: "q", Desc: "quit"},
 	}
 }
+
+// commandStatusStyle colours a command's status: green once finished, red
+// when failed and amber while waiting or running.
+func commandStatusStyle(status string) lipgloss.Style {
+	switch strings.ToLower(status) {
+	case "finished":
+		return lipgloss.NewStyle().Foreground(theme.ColorSecondary)
+	case "failed":
+		return lipgloss.NewStyle().Foreground(theme.ColorError)
+	case "waiting", "running":
+		return lipgloss.NewStyle().Foreground(theme.ColorHighlight)
+	default:
+		return lipgloss.NewStyle().Foreground(theme.ColorSubtle)
+	}
+}
+
+// humanDuration formats d compactly: "850ms", "12s", "3m05s", "1h02m".
+func humanDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+// firstLine returns the first non-blank line of s and how many lines
+// follow it.
+func firstLine(s string) (line string, more int) {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[0]), len(lines) - 1
+}
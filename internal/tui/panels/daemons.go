@@ -1,6 +1,7 @@
 package panels
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -38,8 +40,9 @@ type DaemonsPanel struct {
 	serverID int64
 
 	daemons []forge.Daemon
-	cursor  int
+	cursor  int // index into shown()
 	loading bool
+	order   tableSort
 
 	// Keybindings
 	up      key.Binding
@@ -49,6 +52,15 @@ type DaemonsPanel struct {
 	del     key.Binding
 	home    key.Binding
 	end     key.Binding
+	sort    key.Binding
+}
+
+// daemonsSort are the columns the daemons table can be sorted by.
+var daemonsSort = []sortColumn[forge.Daemon]{
+	{name: "status", cmp: func(a, b forge.Daemon) int { return compareFold(a.Status, b.Status) }},
+	{name: "command", cmp: func(a, b forge.Daemon) int { return strings.Compare(a.Command, b.Command) }},
+	{name: "user", cmp: func(a, b forge.Daemon) int { return strings.Compare(a.User, b.User) }},
+	{name: "procs", cmp: func(a, b forge.Daemon) int { return cmp.Compare(a.Processes, b.Processes) }},
 }
 
 // NewDaemonsPanel creates a new DaemonsPanel.
@@ -85,6 +97,7 @@ func NewDaemonsPanel(client *forge.Client, serverID int64) DaemonsPanel {
 			key.WithKeys("G", "end"),
 			key.WithHelp("G", "bottom"),
 		),
+		sort: sortKey,
 	}
 }
 
@@ -101,17 +114,15 @@ func (p DaemonsPanel) LoadDaemons() tea.Cmd {
 	}
 }
 
-// CreateDaemon returns a tea.Cmd that creates a new daemon with the given command.
-func (p DaemonsPanel) CreateDaemon(command string) tea.Cmd {
+// CreateDaemon returns a tea.Cmd that creates a new daemon.
+// An empty opts.User runs it as forge.
+func (p DaemonsPanel) CreateDaemon(opts forge.DaemonCreateOpts) tea.Cmd {
 	client := p.client
 	serverID := p.serverID
+	if opts.User == "" {
+		opts.User = "forge"
+	}
 	return func() tea.Msg {
-		opts := forge.DaemonCreateOpts{
-			Command:   command,
-			User:      "forge",
-			Processes: 1,
-			StartSecs: 1,
-		}
 		daemon, err := client.Daemons.Create(context.Background(), serverID, opts)
 		if err != nil {
 			return PanelErrMsg{Err: err}
@@ -122,12 +133,13 @@ func (p DaemonsPanel) CreateDaemon(command string) tea.Cmd {
 
 // RestartDaemon returns a tea.Cmd that restarts the currently selected daemon.
 func (p DaemonsPanel) RestartDaemon() tea.Cmd {
-	if len(p.daemons) == 0 || p.cursor >= len(p.daemons) {
+	d := p.SelectedDaemon()
+	if d == nil {
 		return nil
 	}
 	client := p.client
 	serverID := p.serverID
-	daemonID := p.daemons[p.cursor].ID
+	daemonID := d.ID
 	return func() tea.Msg {
 		err := client.Daemons.Restart(context.Background(), serverID, daemonID)
 		if err != nil {
@@ -139,12 +151,13 @@ func (p DaemonsPanel) RestartDaemon() tea.Cmd {
 
 // DeleteDaemon returns a tea.Cmd that deletes the currently selected daemon.
 func (p DaemonsPanel) DeleteDaemon() tea.Cmd {
-	if len(p.daemons) == 0 || p.cursor >= len(p.daemons) {
+	d := p.SelectedDaemon()
+	if d == nil {
 		return nil
 	}
 	client := p.client
 	serverID := p.serverID
-	daemonID := p.daemons[p.cursor].ID
+	daemonID := d.ID
 	return func() tea.Msg {
 		err := client.Daemons.Delete(context.Background(), serverID, daemonID)
 		if err != nil {
@@ -154,22 +167,34 @@ func (p DaemonsPanel) DeleteDaemon() tea.Cmd {
 	}
 }
 
+// shown returns the daemons in the order the table shows them.
+func (p DaemonsPanel) shown() []forge.Daemon {
+	return sortRows(p.daemons, daemonsSort, p.order)
+}
+
 // SelectedDaemon returns the currently selected daemon, or nil.
 func (p DaemonsPanel) SelectedDaemon() *forge.Daemon {
-	if len(p.daemons) == 0 || p.cursor >= len(p.daemons) {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
 		return nil
 	}
-	d := p.daemons[p.cursor]
+	d := shown[p.cursor]
 	return &d
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p DaemonsPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the daemons panel.
 func (p DaemonsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case DaemonsLoadedMsg:
+		old := p.shown()
 		p.daemons = msg.Daemons
+		p.cursor = keepCursor(old, p.shown(), p.cursor, daemonID)
 		p.loading = false
-		p.cursor = 0
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -179,8 +204,16 @@ func (p DaemonsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	return p, nil
 }
 
+func daemonID(d forge.Daemon) int64 { return d.ID }
+
 func (p DaemonsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(daemonsSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, daemonID)
+		return p, nil
+
 	case key.Matches(msg, p.down):
 		if len(p.daemons) > 0 {
 			p.cursor = min(p.cursor+1, len(p.daemons)-1)
@@ -218,19 +251,13 @@ func (p DaemonsPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Daemons ")
+		Render(countTitle("Daemons", len(p.daemons), p.loading)) +
+		sortChip(daemonsSort, p.order)
 
 	content := p.renderList(innerWidth, innerHeight-1)
 
@@ -249,11 +276,7 @@ const (
 const daemonTableOverhead = 2 + colStatusWidth + 2 + 2 + daemonColUserWidth + 2 + daemonColProcsWidth + 4
 
 func daemonCmdWidth(maxWidth int) int {
-	w := maxWidth - daemonTableOverhead
-	if w < 10 {
-		w = 10
-	}
-	return w
+	return layout.Columns(maxWidth, daemonTableOverhead, 10)
 }
 
 func (p DaemonsPanel) renderList(width, height int) string {
@@ -266,25 +289,18 @@ func (p DaemonsPanel) renderList(width, height int) string {
 	} else {
 		lines = append(lines, p.renderDaemonHeader(width))
 
-		visibleHeight := height - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
-		for i := startIdx; i < len(p.daemons) && len(lines)-1 < visibleHeight; i++ {
-			d := p.daemons[i]
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			d := shown[i]
 			line := p.renderDaemonLine(d, i, width)
 			lines = append(lines, line)
 		}
 	}
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
@@ -351,6 +367,7 @@ func (p DaemonsPanel) HelpBindings() []HelpBinding {
 		{Key: "c", Desc: "create"},
 		{Key: "r", Desc: "restart"},
 		{Key: "x", Desc: "delete"},
+		{Key: "s", Desc: "sort"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
@@ -2,6 +2,7 @@ package panels
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -21,8 +23,10 @@ type DBUsersLoadedMsg struct {
 }
 
 // DBUserCreatedMsg is sent when a database user has been created.
+// Password is set only when it was generated, so it can be shown once.
 type DBUserCreatedMsg struct {
-	User *forge.DatabaseUser
+	User     *forge.DatabaseUser
+	Password string
 }
 
 // DBUserDeletedMsg is sent when a database user has been deleted.
@@ -103,12 +107,41 @@ func (p DBUsersPanel) CreateUser(name, password string) tea.Cmd {
 	client := p.client
 	serverID := p.serverID
 	return func() tea.Msg {
+		generated := ""
+		if password == "" {
+			var err error
+			if generated, err = GeneratePassword(dbPasswordLength); err != nil {
+				return PanelErrMsg{Err: err}
+			}
+			password = generated
+		}
 		user, err := client.Databases.CreateUser(context.Background(), serverID, name, password, nil)
 		if err != nil {
 			return PanelErrMsg{Err: err}
 		}
-		return DBUserCreatedMsg{User: user}
+		return DBUserCreatedMsg{User: user, Password: generated}
+	}
+}
+
+// dbPasswordLength is the length of generated database user passwords.
+const dbPasswordLength = 24
+
+// passwordAlphabet avoids characters that need quoting in .env files and
+// shells.
+const passwordAlphabet = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// GeneratePassword returns a random password of n characters.
+func GeneratePassword(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate password: %w", err)
 	}
+	for i, b := range buf {
+		// 256 is not a multiple of the alphabet size; the slight bias is
+		// irrelevant at this length.
+		buf[i] = passwordAlphabet[int(b)%len(passwordAlphabet)]
+	}
+	return string(buf), nil
 }
 
 // DeleteUser returns a tea.Cmd that deletes the currently selected database user.
@@ -137,13 +170,18 @@ func (p DBUsersPanel) SelectedUser() *forge.DatabaseUser {
 	return &u
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p DBUsersPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the database users panel.
 func (p DBUsersPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case DBUsersLoadedMsg:
+		p.cursor = keepCursor(p.users, msg.Users, p.cursor, func(x forge.DatabaseUser) int64 { return x.ID })
 		p.users = msg.Users
 		p.loading = false
-		p.cursor = 0
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -192,19 +230,12 @@ func (p DBUsersPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Database Users ")
+		Render(countTitle("Database Users", len(p.users), p.loading))
 
 	content := p.renderList(innerWidth, innerHeight-1)
 
@@ -222,14 +253,8 @@ func (p DBUsersPanel) renderList(width, height int) string {
 	} else if len(p.users) == 0 {
 		lines = append(lines, theme.NormalItemStyle.Render("No database users found"))
 	} else {
-		visibleHeight := height - 1
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-1, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
 		for i := startIdx; i < len(p.users) && len(lines) < visibleHeight; i++ {
 			user := p.users[i]
@@ -238,9 +263,7 @@ func (p DBUsersPanel) renderList(width, height int) string {
 		}
 	}
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
@@ -10,6 +10,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -136,13 +137,18 @@ func (p DatabasesPanel) SelectedDatabase() *forge.Database {
 	return &db
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p DatabasesPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the databases panel.
 func (p DatabasesPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case DatabasesLoadedMsg:
+		p.cursor = keepCursor(p.databases, msg.Databases, p.cursor, func(x forge.Database) int64 { return x.ID })
 		p.databases = msg.Databases
 		p.loading = false
-		p.cursor = 0
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -191,19 +197,12 @@ func (p DatabasesPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Databases ")
+		Render(countTitle("Databases", len(p.databases), p.loading))
 
 	content := p.renderList(innerWidth, innerHeight-1)
 
@@ -221,14 +220,8 @@ func (p DatabasesPanel) renderList(width, height int) string {
 	} else if len(p.databases) == 0 {
 		lines = append(lines, theme.NormalItemStyle.Render("No databases found"))
 	} else {
-		visibleHeight := height - 1
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-1, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
 		for i := startIdx; i < len(p.databases) && len(lines) < visibleHeight; i++ {
 			db := p.databases[i]
@@ -237,9 +230,7 @@ func (p DatabasesPanel) renderList(width, height int) string {
 		}
 	}
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
@@ -280,6 +271,7 @@ func (p DatabasesPanel) HelpBindings() []HelpBinding {
 		{Key: "c", Desc: "create"},
 		{Key: "x", Desc: "delete"},
 		{Key: "u", Desc: "users"},
+		{Key: "v", Desc: "credentials"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
@@ -11,6 +11,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -120,6 +121,11 @@ func (p DeployScriptPanel) saveScript(content string) tea.Cmd {
 	}
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p DeployScriptPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the deploy script panel.
 func (p DeployScriptPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -235,14 +241,7 @@ func (p DeployScriptPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
@@ -281,13 +280,7 @@ func (p DeployScriptPanel) renderContent(width, height int) string {
 	allLines := strings.Split(p.content, "\n")
 
 	// Clamp scroll offset.
-	maxScroll := len(allLines) - height
-	if maxScroll < 0 {
-		maxScroll = 0
-	}
-	if p.scrollY > maxScroll {
-		p.scrollY = maxScroll
-	}
+	p.scrollY = layout.Clamp(p.scrollY, 0, len(allLines)-height)
 
 	var lines []string
 	for i := p.scrollY; i < len(allLines) && len(lines) < height; i++ {
@@ -296,9 +289,7 @@ func (p DeployScriptPanel) renderContent(width, height int) string {
 	}
 
 	// Pad remaining height.
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
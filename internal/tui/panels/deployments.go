@@ -11,6 +11,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -47,9 +48,22 @@ type DeploymentsPanel struct {
 	siteID   int64
 
 	deployments []forge.Deployment
-	cursor      int
+	cursor      int // index into shown()
 	loading     bool
 
+	// Quick filters, shown as chips in the title. author is the user's
+	// commit author name (ui.author), matched by onlyMine.
+	onlyFailed bool
+	onlyMine   bool
+	onlyRecent bool
+	author     string
+	order      tableSort
+
+	// The site's quick deploy setting, shown in the title, and its
+	// deployment trigger URL, shown under it.
+	quickDeploy bool
+	triggerURL  string
+
 	// Keybindings
 	up     key.Binding
 	down   key.Binding
@@ -59,6 +73,35 @@ type DeploymentsPanel struct {
 	back   key.Binding
 	home   key.Binding
 	end    key.Binding
+	failed key.Binding
+	mine   key.Binding
+	recent key.Binding
+	sort   key.Binding
+}
+
+// deploymentsSort are the columns the deployments table can be sorted by.
+var deploymentsSort = []sortColumn[forge.Deployment]{
+	{name: "time", cmp: func(a, b forge.Deployment) int { return compareTimestamps(a.StartedAt, b.StartedAt) }},
+	{name: "duration", cmp: func(a, b forge.Deployment) int {
+		da, aok := deployDuration(a)
+		db, bok := deployDuration(b)
+		return compareKnown(da, aok, db, bok)
+	}},
+	{name: "author", cmp: func(a, b forge.Deployment) int { return compareFold(a.CommitAuthor, b.CommitAuthor) }},
+	{name: "status", cmp: func(a, b forge.Deployment) int { return compareFold(a.Status, b.Status) }},
+}
+
+// deployDuration returns how long a finished deployment took.
+func deployDuration(d forge.Deployment) (time.Duration, bool) {
+	start, ok := parseTimestamp(d.StartedAt)
+	if !ok {
+		return 0, false
+	}
+	end, ok := parseTimestamp(d.EndedAt)
+	if !ok {
+		return 0, false
+	}
+	return end.Sub(start), true
 }
 
 // NewDeploymentsPanel creates a new DeploymentsPanel. Call LoadDeployments()
@@ -101,9 +144,87 @@ func NewDeploymentsPanel(client *forge.Client, serverID, siteID int64) Deploymen
 			key.WithKeys("G", "end"),
 			key.WithHelp("G", "bottom"),
 		),
+		failed: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "only failed"),
+		),
+		mine: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "only mine"),
+		),
+		recent: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "last 24h"),
+		),
+		sort: sortKey,
 	}
 }
 
+// SetAuthor sets the commit author name the "mine" filter matches.
+func (p DeploymentsPanel) SetAuthor(author string) DeploymentsPanel {
+	p.author = strings.TrimSpace(author)
+	return p
+}
+
+// shown returns the deployments that pass the active quick filters, in
+// the order the table shows them.
+func (p DeploymentsPanel) shown() []forge.Deployment {
+	return sortRows(p.filtered(), deploymentsSort, p.order)
+}
+
+// filtered returns the deployments that pass the active quick filters.
+func (p DeploymentsPanel) filtered() []forge.Deployment {
+	if !p.onlyFailed && !p.onlyMine && !p.onlyRecent {
+		return p.deployments
+	}
+	var out []forge.Deployment
+	for _, d := range p.deployments {
+		if p.onlyFailed && !strings.EqualFold(d.Status, "failed") {
+			continue
+		}
+		if p.onlyMine && !strings.EqualFold(strings.TrimSpace(d.CommitAuthor), p.author) {
+			continue
+		}
+		if p.onlyRecent {
+			t, ok := parseTimestamp(d.StartedAt)
+			if !ok || time.Since(t) > 24*time.Hour {
+				continue
+			}
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// SetSite sets the site whose quick deploy state and trigger URL are
+// shown.
+func (p DeploymentsPanel) SetSite(site *forge.Site) DeploymentsPanel {
+	p.quickDeploy = site.QuickDeploy
+	p.triggerURL = site.DeploymentURL
+	return p
+}
+
+// TriggerURL returns the site's deployment trigger URL, or "" when it is
+// not known.
+func (p DeploymentsPanel) TriggerURL() string {
+	return p.triggerURL
+}
+
+// chips returns the labels of the active quick filters.
+func (p DeploymentsPanel) chips() []string {
+	var chips []string
+	if p.onlyFailed {
+		chips = append(chips, "failed")
+	}
+	if p.onlyMine {
+		chips = append(chips, "mine")
+	}
+	if p.onlyRecent {
+		chips = append(chips, "24h")
+	}
+	return chips
+}
+
 // LoadDeployments returns a tea.Cmd that fetches the deployment history.
 func (p DeploymentsPanel) LoadDeployments() tea.Cmd {
 	client := p.client
@@ -123,13 +244,39 @@ func (p DeploymentsPanel) TriggerDeploy() tea.Cmd {
 	client := p.client
 	serverID := p.serverID
 	siteID := p.siteID
+	prevID := p.LatestID()
 	return func() tea.Msg {
 		err := client.Deployments.Deploy(context.Background(), serverID, siteID)
 		if err != nil {
 			return PanelErrMsg{Err: err}
 		}
-		return DeployTriggerMsg{}
+		return DeployTriggerMsg{ServerID: serverID, SiteID: siteID, PrevID: prevID}
+	}
+}
+
+// LatestID returns the ID of the most recent loaded deployment, or 0.
+func (p DeploymentsPanel) LatestID() int64 {
+	var latest int64
+	for _, d := range p.deployments {
+		latest = max(latest, d.ID)
+	}
+	return latest
+}
+
+// Running returns the most recent loaded deployment if it is still
+// deploying or queued, or nil.
+func (p DeploymentsPanel) Running() *forge.Deployment {
+	var latest *forge.Deployment
+	for i := range p.deployments {
+		if latest == nil || p.deployments[i].ID > latest.ID {
+			latest = &p.deployments[i]
+		}
 	}
+	if latest == nil || !forge.DeploymentRunning(latest.Status) {
+		return nil
+	}
+	d := *latest
+	return &d
 }
 
 // LoadOutput returns a tea.Cmd that fetches the output for a deployment.
@@ -158,8 +305,14 @@ func (p DeploymentsPanel) ResetDeployStatus() tea.Cmd {
 }
 
 // DeployTriggerMsg is sent when a deploy has been successfully triggered.
-// The app layer handles showing a toast and refreshing the list.
-type DeployTriggerMsg struct{}
+// The app layer handles showing a toast and refreshing the list. PrevID is
+// the newest deployment known before triggering, so the new one can be
+// told apart once it appears.
+type DeployTriggerMsg struct {
+	ServerID int64
+	SiteID   int64
+	PrevID   int64
+}
 
 // PanelErrMsg is sent when a panel API call fails.
 // The app layer should catch this and display the error.
@@ -167,13 +320,19 @@ type PanelErrMsg struct {
 	Err error
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p DeploymentsPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the deployments panel.
 func (p DeploymentsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case DeploymentsLoadedMsg:
+		old := p.shown()
 		p.deployments = msg.Deployments
+		p.cursor = keepCursor(old, p.shown(), p.cursor, deploymentID)
 		p.loading = false
-		p.cursor = 0
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -183,17 +342,20 @@ func (p DeploymentsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	return p, nil
 }
 
+func deploymentID(d forge.Deployment) int64 { return d.ID }
+
 // handleListKey processes key events when viewing the deployment list.
 func (p DeploymentsPanel) handleListKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	shown := p.shown()
 	switch {
 	case key.Matches(msg, p.down):
-		if len(p.deployments) > 0 {
-			p.cursor = min(p.cursor+1, len(p.deployments)-1)
+		if len(shown) > 0 {
+			p.cursor = min(p.cursor+1, len(shown)-1)
 		}
 		return p, nil
 
 	case key.Matches(msg, p.up):
-		if len(p.deployments) > 0 {
+		if len(shown) > 0 {
 			p.cursor = max(p.cursor-1, 0)
 		}
 		return p, nil
@@ -203,14 +365,39 @@ func (p DeploymentsPanel) handleListKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 		return p, nil
 
 	case key.Matches(msg, p.end):
-		if len(p.deployments) > 0 {
-			p.cursor = len(p.deployments) - 1
+		if len(shown) > 0 {
+			p.cursor = len(shown) - 1
+		}
+		return p, nil
+
+	case key.Matches(msg, p.failed):
+		p.onlyFailed = !p.onlyFailed
+		p.cursor = keepCursor(shown, p.shown(), p.cursor, deploymentID)
+		return p, nil
+
+	case key.Matches(msg, p.mine):
+		if p.author == "" && !p.onlyMine {
+			return p, func() tea.Msg {
+				return PanelErrMsg{Err: fmt.Errorf("set ui.author in config.toml to filter your deployments")}
+			}
 		}
+		p.onlyMine = !p.onlyMine
+		p.cursor = keepCursor(shown, p.shown(), p.cursor, deploymentID)
+		return p, nil
+
+	case key.Matches(msg, p.recent):
+		p.onlyRecent = !p.onlyRecent
+		p.cursor = keepCursor(shown, p.shown(), p.cursor, deploymentID)
+		return p, nil
+
+	case key.Matches(msg, p.sort):
+		p.order = p.order.next(len(deploymentsSort))
+		p.cursor = keepCursor(shown, p.shown(), p.cursor, deploymentID)
 		return p, nil
 
 	case key.Matches(msg, p.enter):
-		if len(p.deployments) > 0 {
-			dep := p.deployments[p.cursor]
+		if len(shown) > 0 {
+			dep := shown[p.cursor]
 			serverID := p.serverID
 			siteID := p.siteID
 			deployID := dep.ID
@@ -224,7 +411,7 @@ func (p DeploymentsPanel) handleListKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 		}
 		return p, nil
 
-	// 'd' and 'r' are handled by the app layer which shows the confirm dialog.
+	// 'd', 'r', 'Q', 'y' and 'U' are handled by the app layer which shows the confirm dialog.
 	// We just return nil here; the app inspects the key before delegating.
 	}
 
@@ -240,25 +427,40 @@ func (p DeploymentsPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Deployments ")
-	content := p.renderList(innerWidth, innerHeight-1)
+		Render(countTitle("Deployments", len(p.shown()), p.loading))
+	quick := lipgloss.NewStyle().Foreground(theme.ColorSubtle)
+	if p.quickDeploy {
+		quick = theme.ActiveStatusStyle
+	}
+	title += quick.Render("quick deploy "+boolToOnOff(p.quickDeploy)) + " "
+	for _, chip := range p.chips() {
+		title += theme.FilterIndicatorStyle.Render("["+chip+"]") + " "
+	}
+	title += sortChip(deploymentsSort, p.order)
+	if shown := p.shown(); p.cursor < len(shown) {
+		dep := shown[p.cursor]
+		ts := dep.EndedAt
+		if ts == "" {
+			ts = dep.StartedAt
+		}
+		title += selectedTime(ts)
+	}
+	title = theme.Truncate(title, innerWidth)
+	header := title
+	if p.triggerURL != "" {
+		header += "\n" + theme.Truncate(theme.LabelStyle.Render("Trigger URL:")+theme.ValueStyle.Render(p.triggerURL), innerWidth)
+	}
+	content := p.renderList(innerWidth, innerHeight-lipgloss.Height(header))
 
 	return style.
 		Width(innerWidth).
 		Height(innerHeight).
-		Render(title + "\n" + content)
+		Render(header + "\n" + content)
 }
 
 // Column widths for the deployments table.
@@ -277,36 +479,31 @@ var headerStyle = lipgloss.NewStyle().
 func (p DeploymentsPanel) renderList(width, height int) string {
 	var lines []string
 
+	shown := p.shown()
 	if p.loading && len(p.deployments) == 0 {
 		lines = append(lines, theme.LoadingStyle.Render("Loading deployments..."))
 	} else if len(p.deployments) == 0 {
 		lines = append(lines, theme.NormalItemStyle.Render("No deployments found"))
+	} else if len(shown) == 0 {
+		lines = append(lines, theme.NormalItemStyle.Render("No deployments match the filters"))
 	} else {
 		// Render table header.
 		lines = append(lines, p.renderHeader(width))
 
 		// Calculate visible range with scrolling.
 		// Reserve 1 for the header row.
-		visibleHeight := height - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
-		for i := startIdx; i < len(p.deployments) && len(lines)-1 < visibleHeight; i++ {
-			dep := p.deployments[i]
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			dep := shown[i]
 			line := p.renderDeploymentLine(dep, i, width)
 			lines = append(lines, line)
 		}
 	}
 
 	// Pad to fill the panel height.
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
@@ -317,11 +514,7 @@ const tableOverhead = 2 + colStatusWidth + 2 + 2 + colAuthorWidth + 2 + colTimeW
 
 // commitWidth returns the space available for the commit message column.
 func commitWidth(maxWidth int) int {
-	w := maxWidth - tableOverhead
-	if w < 10 {
-		w = 10
-	}
-	return w
+	return layout.Columns(maxWidth, tableOverhead, 10)
 }
 
 // renderHeader renders the column header row.
@@ -404,8 +597,14 @@ func (p DeploymentsPanel) HelpBindings() []HelpBinding {
 		{Key: "j/k", Desc: "navigate"},
 		{Key: "enter", Desc: "output"},
 		{Key: "d", Desc: "deploy"},
+		{Key: "@", Desc: "deploy at"},
 		{Key: "S", Desc: "script"},
+		{Key: "L", Desc: "saved logs"},
 		{Key: "r", Desc: "reset status"},
+		{Key: "Q", Desc: "quick deploy"},
+		{Key: "y/U", Desc: "copy/reset trigger URL"},
+		{Key: "f/m/t", Desc: "failed/mine/24h"},
+		{Key: "s", Desc: "sort"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "next panel"},
@@ -418,56 +617,16 @@ func (p DeploymentsPanel) HelpBindings() []HelpBinding {
 func statusIcon(status string) string {
 	switch strings.ToLower(status) {
 	case "finished":
-		return lipgloss.NewStyle().Foreground(theme.ColorSecondary).Render("✓")
+		return lipgloss.NewStyle().Foreground(theme.ColorSecondary).Render(theme.GlyphOK)
 	case "failed":
-		return lipgloss.NewStyle().Foreground(theme.ColorError).Render("✗")
-	case "deploying":
-		return lipgloss.NewStyle().Foreground(theme.ColorHighlight).Render("●")
+		return lipgloss.NewStyle().Foreground(theme.ColorError).Render(theme.GlyphFail)
+	case "deploying", "queued":
+		return lipgloss.NewStyle().Foreground(theme.ColorHighlight).Render(theme.GlyphDot)
 	default:
 		return lipgloss.NewStyle().Foreground(theme.ColorSubtle).Render("?")
 	}
 }
 
-// relativeTime converts a Forge timestamp string into a human-readable
-// relative duration like "2m ago", "1h ago", etc.
-func relativeTime(ts string) string {
-	if ts == "" {
-		return ""
-	}
-
-	// Forge timestamps are typically in ISO 8601 / RFC 3339 format.
-	layouts := []string{
-		time.RFC3339,
-		"2006-01-02T15:04:05.000000Z",
-		"2006-01-02 15:04:05",
-	}
-
-	var t time.Time
-	var err error
-	for _, layout := range layouts {
-		t, err = time.Parse(layout, ts)
-		if err == nil {
-			break
-		}
-	}
-	if err != nil {
-		return ts // fall back to raw string
-	}
-
-	d := time.Since(t)
-	switch {
-	case d < time.Minute:
-		return "now"
-	case d < time.Hour:
-		return fmt.Sprintf("%dm ago", int(d.Minutes()))
-	case d < 24*time.Hour:
-		return fmt.Sprintf("%dh ago", int(d.Hours()))
-	default:
-		days := int(d.Hours()) / 24
-		return fmt.Sprintf("%dd ago", days)
-	}
-}
-
 // truncatePlain truncates a plain (no ANSI) string to the given width.
 func truncatePlain(s string, maxWidth int) string {
 	if maxWidth <= 0 {
@@ -0,0 +1,32 @@
+package panels
+
+import (
+	"testing"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+func TestDeploymentsRunning(t *testing.T) {
+	tests := []struct {
+		status string
+		want   bool
+	}{
+		{"deploying", true},
+		{"queued", true},
+		{"finished", false},
+		{"failed", false},
+	}
+	for _, tt := range tests {
+		p, _ := NewDeploymentsPanel(nil, 1, 10).Update(DeploymentsLoadedMsg{Deployments: []forge.Deployment{
+			{ID: 7, Status: tt.status},
+			{ID: 6, Status: "finished"},
+		}})
+		got := p.(DeploymentsPanel).Running()
+		if (got != nil) != tt.want {
+			t.Errorf("Running() with the latest deployment %s = %+v, want running %v", tt.status, got, tt.want)
+		}
+		if got != nil && got.ID != 7 {
+			t.Errorf("Running() = #%d, want #7", got.ID)
+		}
+	}
+}
@@ -2,13 +2,17 @@ package panels
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strings"
+	"unicode"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/bubbles/v2/key"
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -24,16 +28,32 @@ type DomainsSavedMsg struct {
 	Err error
 }
 
-// DomainsPanel shows the domain aliases for a site with add/remove actions.
+// DomainsCertMsg carries the site's active certificate, or nil when it has
+// none, for showing which domains it covers.
+type DomainsCertMsg struct {
+	Cert *forge.Certificate
+}
+
+// DomainsRedirectMsg is sent when a www redirect rule has been created.
+type DomainsRedirectMsg struct {
+	From, To string
+}
+
+// DomainsPanel shows a site's primary domain and aliases, with add/remove
+// actions for the aliases and whether the active certificate covers each.
 type DomainsPanel struct {
 	client   *forge.Client
 	serverID int64
 	siteID   int64
 
+	primary string // the site name; listed first and not removable
 	aliases []string
-	cursor  int
+	cursor  int // row index; row 0 is the primary domain
 	loading bool
 
+	cert       *forge.Certificate
+	certLoaded bool
+
 	// Keybindings
 	up     key.Binding
 	down   key.Binding
@@ -43,12 +63,14 @@ type DomainsPanel struct {
 	end    key.Binding
 }
 
-// NewDomainsPanel creates a new DomainsPanel.
-func NewDomainsPanel(client *forge.Client, serverID, siteID int64, aliases []string) DomainsPanel {
+// NewDomainsPanel creates a new DomainsPanel. Call LoadCert to fetch the
+// certificate coverage.
+func NewDomainsPanel(client *forge.Client, serverID, siteID int64, primary string, aliases []string) DomainsPanel {
 	return DomainsPanel{
 		client:   client,
 		serverID: serverID,
 		siteID:   siteID,
+		primary:  primary,
 		aliases:  aliases,
 		loading:  false,
 		up: key.NewBinding(
@@ -78,17 +100,40 @@ func NewDomainsPanel(client *forge.Client, serverID, siteID int64, aliases []str
 	}
 }
 
+// rows returns the domains listed: the primary domain, then the aliases.
+func (p DomainsPanel) rows() []string {
+	return append([]string{p.primary}, p.aliases...)
+}
+
+// LoadCert returns a tea.Cmd that fetches the site's active certificate.
+func (p DomainsPanel) LoadCert() tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	return func() tea.Msg {
+		cert, err := client.Certificates.Active(context.Background(), serverID, siteID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return DomainsCertMsg{Cert: cert}
+	}
+}
+
 // AddAlias adds a new alias and saves the full list via the API.
 func (p DomainsPanel) AddAlias(alias string) tea.Cmd {
 	newAliases := make([]string, len(p.aliases))
 	copy(newAliases, p.aliases)
 	newAliases = append(newAliases, alias)
+	return p.SetAliases(newAliases)
+}
 
+// SetAliases saves aliases as the site's full alias list via the API.
+func (p DomainsPanel) SetAliases(aliases []string) tea.Cmd {
 	client := p.client
 	serverID := p.serverID
 	siteID := p.siteID
 	return func() tea.Msg {
-		_, err := client.Sites.UpdateAliases(context.Background(), serverID, siteID, newAliases)
+		_, err := client.Sites.UpdateAliases(context.Background(), serverID, siteID, aliases)
 		if err != nil {
 			return PanelErrMsg{Err: err}
 		}
@@ -96,37 +141,182 @@ func (p DomainsPanel) AddAlias(alias string) tea.Cmd {
 	}
 }
 
-// RemoveAlias removes the currently selected alias and saves the full list via the API.
-func (p DomainsPanel) RemoveAlias() tea.Cmd {
-	if len(p.aliases) == 0 || p.cursor >= len(p.aliases) {
-		return nil
+// MergeAliases returns the site's aliases with domains appended, skipping
+// those the site already has, and which of domains were added.
+func (p DomainsPanel) MergeAliases(domains []string) (merged, added []string) {
+	merged = slices.Clone(p.aliases)
+	for _, d := range domains {
+		if p.HasAlias(d) {
+			continue
+		}
+		merged = append(merged, d)
+		added = append(added, d)
+	}
+	return merged, added
+}
+
+// ParseDomains splits pasted text into domains, separated by commas,
+// semicolons or whitespace (newlines included). Domains are lowercased
+// and listed once each; anything that doesn't look like a domain is
+// returned in invalid instead.
+func ParseDomains(text string) (domains, invalid []string) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ',' || r == ';' || unicode.IsSpace(r)
+	})
+	for _, f := range fields {
+		d := strings.TrimSuffix(strings.ToLower(f), ".")
+		switch {
+		case !validDomain(d):
+			invalid = append(invalid, f)
+		case !slices.Contains(domains, d):
+			domains = append(domains, d)
+		}
 	}
+	return domains, invalid
+}
 
-	newAliases := make([]string, 0, len(p.aliases)-1)
-	for i, a := range p.aliases {
-		if i != p.cursor {
-			newAliases = append(newAliases, a)
+// validDomain reports whether d is a hostname with at least two labels,
+// allowing a leading wildcard label.
+func validDomain(d string) bool {
+	labels := strings.Split(strings.TrimPrefix(d, "*."), ".")
+	if len(labels) < 2 || len(d) > 253 {
+		return false
+	}
+	for _, l := range labels {
+		if l == "" || len(l) > 63 || l[0] == '-' || l[len(l)-1] == '-' {
+			return false
+		}
+		for _, r := range l {
+			if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// AliasDiff renders the change from the aliases before to after for a
+// preview, one alias per line: "+ " for added, "- " for removed and "  "
+// for kept.
+func AliasDiff(before, after []string) []string {
+	var lines []string
+	for _, a := range before {
+		if slices.Contains(after, a) {
+			lines = append(lines, "  "+a)
+		} else {
+			lines = append(lines, "- "+a)
+		}
+	}
+	for _, a := range after {
+		if !slices.Contains(before, a) {
+			lines = append(lines, "+ "+a)
 		}
 	}
+	return lines
+}
+
+// Aliases returns the site's aliases.
+func (p DomainsPanel) Aliases() []string {
+	return p.aliases
+}
 
+// RemoveAlias returns a tea.Cmd that removes alias from the site. The
+// alias list is fetched when the command runs, not when it is made, so a
+// delete held back for its grace period keeps aliases added meanwhile.
+func (p DomainsPanel) RemoveAlias(alias string) tea.Cmd {
+	if alias == "" {
+		return nil
+	}
 	client := p.client
 	serverID := p.serverID
 	siteID := p.siteID
 	return func() tea.Msg {
-		_, err := client.Sites.UpdateAliases(context.Background(), serverID, siteID, newAliases)
+		site, err := client.Sites.Get(context.Background(), serverID, siteID)
 		if err != nil {
 			return PanelErrMsg{Err: err}
 		}
-		return DomainsSavedMsg{Err: nil}
+		aliases := slices.DeleteFunc(slices.Clone(site.Aliases), func(a string) bool {
+			return strings.EqualFold(a, alias)
+		})
+		if len(aliases) == len(site.Aliases) {
+			// Already gone.
+			return DomainsSavedMsg{}
+		}
+		if _, err := client.Sites.UpdateAliases(context.Background(), serverID, siteID, aliases); err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return DomainsSavedMsg{}
 	}
 }
 
-// SelectedAlias returns the currently selected alias, or empty string.
+// WWWRedirect returns the standard redirect between the www and bare
+// forms of the primary domain: from the alias to the primary. Both must be
+// among the site's domains.
+func (p DomainsPanel) WWWRedirect() (from, to string, err error) {
+	to = strings.ToLower(p.primary)
+	if bare, ok := strings.CutPrefix(to, "www."); ok {
+		from = bare
+	} else {
+		from = "www." + to
+	}
+	if !p.HasAlias(from) {
+		return "", "", fmt.Errorf("add %s as an alias first", from)
+	}
+	return from, to, nil
+}
+
+// CreateWWWRedirect returns a tea.Cmd that adds a permanent redirect rule
+// from one domain to the other, unless the site already has one from it.
+func (p DomainsPanel) CreateWWWRedirect(from, to string) tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	return func() tea.Msg {
+		ctx := context.Background()
+		rules, err := client.Redirects.List(ctx, serverID, siteID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		source := "https://" + from
+		for _, r := range rules {
+			if strings.EqualFold(strings.TrimSuffix(r.From, "/"), source) {
+				return PanelErrMsg{Err: fmt.Errorf("%s already redirects to %s", from, r.To)}
+			}
+		}
+		_, err = client.Redirects.Create(ctx, serverID, siteID, forge.RedirectCreateOpts{
+			From: source,
+			To:   "https://" + to,
+			Type: forge.RedirectPermanent,
+		})
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return DomainsRedirectMsg{From: from, To: to}
+	}
+}
+
+// HasAlias reports whether alias is already one of the site's domains,
+// the primary one included. Domains are compared case-insensitively.
+func (p DomainsPanel) HasAlias(alias string) bool {
+	alias = strings.TrimSpace(alias)
+	return slices.ContainsFunc(p.rows(), func(a string) bool {
+		return strings.EqualFold(a, alias)
+	})
+}
+
+// SelectedAlias returns the currently selected alias, or empty string when
+// the cursor is on the primary domain.
 func (p DomainsPanel) SelectedAlias() string {
-	if len(p.aliases) == 0 || p.cursor >= len(p.aliases) {
+	idx := p.cursor - 1
+	if idx < 0 || idx >= len(p.aliases) {
 		return ""
 	}
-	return p.aliases[p.cursor]
+	return p.aliases[idx]
+}
+
+// OnPrimary reports whether the cursor is on the primary domain.
+func (p DomainsPanel) OnPrimary() bool {
+	return p.cursor == 0
 }
 
 // RefreshAliases returns a tea.Cmd that fetches the latest site data to update aliases.
@@ -143,15 +333,24 @@ func (p DomainsPanel) RefreshAliases() tea.Cmd {
 	}
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p DomainsPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the domains panel.
 func (p DomainsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case DomainsLoadedMsg:
+		old := p.rows()
 		p.aliases = msg.Aliases
+		p.cursor = keepCursor(old, p.rows(), p.cursor, func(a string) string { return a })
 		p.loading = false
-		if p.cursor >= len(p.aliases) {
-			p.cursor = max(len(p.aliases)-1, 0)
-		}
+		return p, nil
+
+	case DomainsCertMsg:
+		p.cert = msg.Cert
+		p.certLoaded = true
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -162,17 +361,14 @@ func (p DomainsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 }
 
 func (p DomainsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	rows := len(p.rows())
 	switch {
 	case key.Matches(msg, p.down):
-		if len(p.aliases) > 0 {
-			p.cursor = min(p.cursor+1, len(p.aliases)-1)
-		}
+		p.cursor = min(p.cursor+1, rows-1)
 		return p, nil
 
 	case key.Matches(msg, p.up):
-		if len(p.aliases) > 0 {
-			p.cursor = max(p.cursor-1, 0)
-		}
+		p.cursor = max(p.cursor-1, 0)
 		return p, nil
 
 	case key.Matches(msg, p.home):
@@ -180,12 +376,10 @@ func (p DomainsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 		return p, nil
 
 	case key.Matches(msg, p.end):
-		if len(p.aliases) > 0 {
-			p.cursor = len(p.aliases) - 1
-		}
+		p.cursor = rows - 1
 		return p, nil
 
-	// 'a', 'x' are handled by the app layer.
+	// 'a', 'A', 'x' are handled by the app layer.
 	}
 
 	return p, nil
@@ -200,19 +394,12 @@ func (p DomainsPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Domains ")
+		Render(countTitle("Domains", len(p.rows()), p.loading))
 
 	content := p.renderList(innerWidth, innerHeight-1)
 
@@ -227,47 +414,65 @@ func (p DomainsPanel) renderList(width, height int) string {
 
 	if p.loading {
 		lines = append(lines, theme.LoadingStyle.Render("Loading domains..."))
-	} else if len(p.aliases) == 0 {
-		lines = append(lines, theme.NormalItemStyle.Render("No domain aliases"))
 	} else {
-		visibleHeight := height - 1
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
+		if p.certLoaded {
+			lines = append(lines, p.renderCertSummary(width), "")
 		}
+		rows := p.rows()
+		visibleHeight := max(height-1-len(lines), 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
-		for i := startIdx; i < len(p.aliases) && len(lines) < visibleHeight; i++ {
-			alias := p.aliases[i]
-			line := p.renderAliasLine(alias, i, width)
-			lines = append(lines, line)
+		for i := startIdx; i < len(rows) && i-startIdx < visibleHeight; i++ {
+			lines = append(lines, p.renderDomainLine(rows[i], i, width))
+		}
+		if len(p.aliases) == 0 {
+			lines = append(lines, theme.NormalItemStyle.Render("  No domain aliases"))
 		}
 	}
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
 
-func (p DomainsPanel) renderAliasLine(alias string, idx, maxWidth int) string {
-	nameWidth := maxWidth - 6
-	if nameWidth < 10 {
-		nameWidth = 10
+// renderCertSummary names the domains of the active certificate.
+func (p DomainsPanel) renderCertSummary(maxWidth int) string {
+	if p.cert == nil {
+		return theme.Truncate(theme.ErrorStatusStyle.Render("No active certificate"), maxWidth)
+	}
+	return renderInfoKV("Certificate", strings.Join(p.cert.Domains(), ", "), maxWidth)
+}
+
+// Column width of the primary/coverage markers after the domain name.
+const domainTagWidth = 9 // "primary" plus padding
+
+func (p DomainsPanel) renderDomainLine(domain string, idx, maxWidth int) string {
+	nameWidth := max(maxWidth-6-domainTagWidth-14, 10)
+	name := fmt.Sprintf("%-*s", nameWidth, truncatePlain(domain, nameWidth))
+
+	tag := ""
+	if idx == 0 {
+		tag = "primary"
+	}
+	tagStr := theme.NormalItemStyle.Render(fmt.Sprintf("%-*s", domainTagWidth, tag))
+
+	coverage := ""
+	if p.cert != nil {
+		if p.cert.Covers(domain) {
+			coverage = theme.ActiveStatusStyle.Render(theme.GlyphOK + " covered")
+		} else {
+			coverage = theme.ErrorStatusStyle.Render(theme.GlyphFail + " not covered")
+		}
 	}
-	alias = truncatePlain(alias, nameWidth)
 
 	if idx == p.cursor {
 		line := theme.CursorStyle.Render("> ") +
-			theme.SelectedItemStyle.Render(alias)
+			theme.SelectedItemStyle.Render(name) + "  " + tagStr + coverage
 		return theme.Truncate(line, maxWidth)
 	}
 
 	line := "  " +
-		theme.NormalItemStyle.Render(alias)
+		theme.NormalItemStyle.Render(name) + "  " + tagStr + coverage
 	return theme.Truncate(line, maxWidth)
 }
 
@@ -276,7 +481,11 @@ func (p DomainsPanel) HelpBindings() []HelpBinding {
 	return []HelpBinding{
 		{Key: "j/k", Desc: "navigate"},
 		{Key: "a", Desc: "add alias"},
+		{Key: "p", Desc: "paste aliases"},
 		{Key: "x", Desc: "remove"},
+		{Key: "w", Desc: "www redirect"},
+		{Key: "r", Desc: "redirect rules"},
+		{Key: "s", Desc: "security rules"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
@@ -0,0 +1,36 @@
+package panels
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseDomains(t *testing.T) {
+	domains, invalid := ParseDomains("a.example.com, B.example.com\nc.example.com.;a.example.com  *.example.com\tnot_a_domain localhost")
+	want := []string{"a.example.com", "b.example.com", "c.example.com", "*.example.com"}
+	if !slices.Equal(domains, want) {
+		t.Errorf("domains = %q, want %q", domains, want)
+	}
+	if !slices.Equal(invalid, []string{"not_a_domain", "localhost"}) {
+		t.Errorf("invalid = %q", invalid)
+	}
+}
+
+func TestMergeAliases(t *testing.T) {
+	p := NewDomainsPanel(nil, 1, 10, "example.com", []string{"www.example.com"})
+	merged, added := p.MergeAliases([]string{"example.com", "WWW.example.com", "api.example.com"})
+	if !slices.Equal(merged, []string{"www.example.com", "api.example.com"}) {
+		t.Errorf("merged = %q", merged)
+	}
+	if !slices.Equal(added, []string{"api.example.com"}) {
+		t.Errorf("added = %q", added)
+	}
+}
+
+func TestAliasDiff(t *testing.T) {
+	got := AliasDiff([]string{"a.example.com", "b.example.com"}, []string{"b.example.com", "c.example.com"})
+	want := []string{"- a.example.com", "  b.example.com", "+ c.example.com"}
+	if !slices.Equal(got, want) {
+		t.Errorf("AliasDiff = %q, want %q", got, want)
+	}
+}
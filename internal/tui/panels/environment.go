@@ -11,6 +11,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -109,6 +110,11 @@ func (p EnvironmentPanel) LoadEnv() tea.Cmd {
 	}
 }
 
+// Content returns the loaded .env file, and false while it is loading.
+func (p EnvironmentPanel) Content() (string, bool) {
+	return p.content, !p.loading
+}
+
 // saveEnv returns a tea.Cmd that uploads the environment file.
 func (p EnvironmentPanel) saveEnv(content string) tea.Cmd {
 	client := p.client
@@ -120,6 +126,11 @@ func (p EnvironmentPanel) saveEnv(content string) tea.Cmd {
 	}
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p EnvironmentPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the environment panel.
 func (p EnvironmentPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -235,14 +246,7 @@ func (p EnvironmentPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
@@ -281,13 +285,7 @@ func (p EnvironmentPanel) renderContent(width, height int) string {
 	allLines := strings.Split(p.content, "\n")
 
 	// Clamp scroll offset.
-	maxScroll := len(allLines) - height
-	if maxScroll < 0 {
-		maxScroll = 0
-	}
-	if p.scrollY > maxScroll {
-		p.scrollY = maxScroll
-	}
+	p.scrollY = layout.Clamp(p.scrollY, 0, len(allLines)-height)
 
 	var lines []string
 	for i := p.scrollY; i < len(allLines) && len(lines) < height; i++ {
@@ -296,9 +294,7 @@ func (p EnvironmentPanel) renderContent(width, height int) string {
 	}
 
 	// Pad remaining height.
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
@@ -307,6 +303,7 @@ func (p EnvironmentPanel) renderContent(width, height int) string {
 func (p EnvironmentPanel) HelpBindings() []HelpBinding {
 	return []HelpBinding{
 		{Key: "e", Desc: "edit"},
+		{Key: "C", Desc: "copy vars to site"},
 		{Key: "j/k", Desc: "scroll"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
@@ -10,6 +10,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -26,8 +27,9 @@ type EventsPanel struct {
 	serverID int64
 
 	events  []forge.Event
-	cursor  int
+	cursor  int // index into shown()
 	loading bool
+	order   tableSort
 
 	// Keybindings
 	up   key.Binding
@@ -35,6 +37,14 @@ type EventsPanel struct {
 	back key.Binding
 	home key.Binding
 	end  key.Binding
+	sort key.Binding
+}
+
+// eventsSort are the columns the events table can be sorted by.
+var eventsSort = []sortColumn[forge.Event]{
+	{name: "time", cmp: func(a, b forge.Event) int { return compareTimestamps(a.CreatedAt, b.CreatedAt) }},
+	{name: "user", cmp: func(a, b forge.Event) int { return strings.Compare(a.RanAs, b.RanAs) }},
+	{name: "description", cmp: func(a, b forge.Event) int { return compareFold(a.Description, b.Description) }},
 }
 
 // NewEventsPanel creates a new EventsPanel. Call LoadEvents() to fetch data.
@@ -63,6 +73,7 @@ func NewEventsPanel(client *forge.Client, serverID int64) EventsPanel {
 			key.WithKeys("G", "end"),
 			key.WithHelp("G", "bottom"),
 		),
+		sort: sortKey,
 	}
 }
 
@@ -79,13 +90,24 @@ func (p EventsPanel) LoadEvents() tea.Cmd {
 	}
 }
 
+// shown returns the events in the order the table shows them.
+func (p EventsPanel) shown() []forge.Event {
+	return sortRows(p.events, eventsSort, p.order)
+}
+
+// Loading reports whether the panel's data is still being fetched.
+func (p EventsPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the events panel.
 func (p EventsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case EventsLoadedMsg:
+		old := p.shown()
 		p.events = msg.Events
+		p.cursor = keepCursor(old, p.shown(), p.cursor, eventID)
 		p.loading = false
-		p.cursor = 0
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -96,8 +118,16 @@ func (p EventsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 }
 
 // handleKey processes key events for the events panel.
+func eventID(x forge.Event) int64 { return x.ID }
+
 func (p EventsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(eventsSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, eventID)
+		return p, nil
+
 	case key.Matches(msg, p.down):
 		if len(p.events) > 0 {
 			p.cursor = min(p.cursor+1, len(p.events)-1)
@@ -133,19 +163,16 @@ func (p EventsPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Events ")
+		Render(countTitle("Events", len(p.events), p.loading)) +
+		sortChip(eventsSort, p.order)
+	if shown := p.shown(); p.cursor < len(shown) {
+		title = theme.Truncate(title+selectedTime(shown[p.cursor].CreatedAt), innerWidth)
+	}
 	content := p.renderList(innerWidth, innerHeight-1)
 
 	return style.
@@ -166,11 +193,7 @@ const eventTableOverhead = 2 + colEventTimeWidth + 2 + colEventUserWidth + 2 + 4
 
 // eventDescWidth returns the space available for the description column.
 func eventDescWidth(maxWidth int) int {
-	w := maxWidth - eventTableOverhead
-	if w < 10 {
-		w = 10
-	}
-	return w
+	return layout.Columns(maxWidth, eventTableOverhead, 10)
 }
 
 // renderList renders the event list view.
@@ -187,26 +210,19 @@ func (p EventsPanel) renderList(width, height int) string {
 
 		// Calculate visible range with scrolling.
 		// Reserve 1 for the header row.
-		visibleHeight := height - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
-		for i := startIdx; i < len(p.events) && len(lines)-1 < visibleHeight; i++ {
-			evt := p.events[i]
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			evt := shown[i]
 			line := p.renderEventLine(evt, i, width)
 			lines = append(lines, line)
 		}
 	}
 
 	// Pad to fill the panel height.
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
@@ -269,6 +285,7 @@ func (p EventsPanel) renderEventLine(evt forge.Event, idx, maxWidth int) string
 func (p EventsPanel) HelpBindings() []HelpBinding {
 	return []HelpBinding{
 		{Key: "j/k", Desc: "navigate"},
+		{Key: "s", Desc: "sort"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "next panel"},
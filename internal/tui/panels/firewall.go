@@ -3,6 +3,7 @@ package panels
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
@@ -10,6 +11,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -28,6 +30,17 @@ type FirewallCreatedMsg struct {
 // FirewallDeletedMsg is sent when a firewall rule has been deleted.
 type FirewallDeletedMsg struct{}
 
+// FirewallCopiedMsg is sent when rules have been copied to another server
+// or a firewall set has been applied. Skipped counts rules the target
+// already had. Set is the applied set's name, empty for a copy.
+type FirewallCopiedMsg struct {
+	Target  string
+	Set     string
+	Created int
+	Skipped int
+	Err     error
+}
+
 // FirewallPanel shows the firewall rules on a server with CRUD actions.
 // Firewall rules are server-level resources.
 type FirewallPanel struct {
@@ -35,8 +48,9 @@ type FirewallPanel struct {
 	serverID int64
 
 	rules   []forge.FirewallRule
-	cursor  int
+	cursor  int // index into shown()
 	loading bool
+	order   tableSort
 
 	// Keybindings
 	up     key.Binding
@@ -45,6 +59,39 @@ type FirewallPanel struct {
 	del    key.Binding
 	home   key.Binding
 	end    key.Binding
+	sort   key.Binding
+}
+
+// firewallSort are the columns the firewall table can be sorted by.
+var firewallSort = []sortColumn[forge.FirewallRule]{
+	{name: "port", cmp: func(a, b forge.FirewallRule) int { return comparePorts(a.Port, b.Port) }},
+	{name: "name", cmp: func(a, b forge.FirewallRule) int { return compareFold(a.Name, b.Name) }},
+	{name: "ip", cmp: func(a, b forge.FirewallRule) int { return strings.Compare(a.IPAddress, b.IPAddress) }},
+}
+
+// comparePorts orders firewall ports by their (first) port number; a rule's
+// port is a number, a string such as "443" or a range such as "8000:8010".
+func comparePorts(a, b any) int {
+	pa, aok := firstPort(a)
+	pb, bok := firstPort(b)
+	return compareKnown(pa, aok, pb, bok)
+}
+
+// firstPort returns the number a rule's port starts with.
+func firstPort(port any) (int, bool) {
+	switch v := port.(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	case string:
+		if i := strings.IndexAny(v, ":-"); i >= 0 {
+			v = v[:i]
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		return n, err == nil
+	}
+	return 0, false
 }
 
 // NewFirewallPanel creates a new FirewallPanel.
@@ -77,6 +124,7 @@ func NewFirewallPanel(client *forge.Client, serverID int64) FirewallPanel {
 			key.WithKeys("G", "end"),
 			key.WithHelp("G", "bottom"),
 		),
+		sort: sortKey,
 	}
 }
 
@@ -94,15 +142,14 @@ func (p FirewallPanel) LoadRules() tea.Cmd {
 }
 
 // CreateRule returns a tea.Cmd that creates a new firewall rule.
-func (p FirewallPanel) CreateRule(name string, port string) tea.Cmd {
+// An empty opts.Type creates an allow rule.
+func (p FirewallPanel) CreateRule(opts forge.FirewallCreateOpts) tea.Cmd {
 	client := p.client
 	serverID := p.serverID
+	if opts.Type == "" {
+		opts.Type = "allow"
+	}
 	return func() tea.Msg {
-		opts := forge.FirewallCreateOpts{
-			Name: name,
-			Port: port,
-			Type: "allow",
-		}
 		rule, err := client.Firewall.Create(context.Background(), serverID, opts)
 		if err != nil {
 			return PanelErrMsg{Err: err}
@@ -113,12 +160,13 @@ func (p FirewallPanel) CreateRule(name string, port string) tea.Cmd {
 
 // DeleteRule returns a tea.Cmd that deletes the currently selected firewall rule.
 func (p FirewallPanel) DeleteRule() tea.Cmd {
-	if len(p.rules) == 0 || p.cursor >= len(p.rules) {
+	r := p.SelectedRule()
+	if r == nil {
 		return nil
 	}
 	client := p.client
 	serverID := p.serverID
-	ruleID := p.rules[p.cursor].ID
+	ruleID := r.ID
 	return func() tea.Msg {
 		err := client.Firewall.Delete(context.Background(), serverID, ruleID)
 		if err != nil {
@@ -128,22 +176,73 @@ func (p FirewallPanel) DeleteRule() tea.Cmd {
 	}
 }
 
+// Rules returns all loaded firewall rules.
+func (p FirewallPanel) Rules() []forge.FirewallRule {
+	return p.rules
+}
+
+// CopyRules returns a tea.Cmd that creates rules on the target server,
+// skipping any the target already has (same port, IP and type).
+func (p FirewallPanel) CopyRules(rules []forge.FirewallRule, target forge.Server) tea.Cmd {
+	client := p.client
+	return func() tea.Msg {
+		created, skipped, err := client.Firewall.CreateMissing(context.Background(), target.ID, rules)
+		return FirewallCopiedMsg{Target: target.Name, Created: created, Skipped: skipped, Err: err}
+	}
+}
+
+// ApplySet returns a tea.Cmd that creates an allow rule on target for each
+// port in the named set that the server does not already allow.
+func (p FirewallPanel) ApplySet(name string, ports []int, target forge.Server) tea.Cmd {
+	copyCmd := p.CopyRules(FirewallSetRules(name, ports), target)
+	return func() tea.Msg {
+		msg := copyCmd().(FirewallCopiedMsg)
+		msg.Set = name
+		return msg
+	}
+}
+
+// FirewallSetRules expands a named firewall set into one allow rule per port.
+func FirewallSetRules(name string, ports []int) []forge.FirewallRule {
+	rules := make([]forge.FirewallRule, 0, len(ports))
+	for _, port := range ports {
+		rules = append(rules, forge.FirewallRule{
+			Name: fmt.Sprintf("%s %d", name, port),
+			Port: strconv.Itoa(port),
+			Type: "allow",
+		})
+	}
+	return rules
+}
+
+// shown returns the rules in the order the table shows them.
+func (p FirewallPanel) shown() []forge.FirewallRule {
+	return sortRows(p.rules, firewallSort, p.order)
+}
+
 // SelectedRule returns the currently selected firewall rule, or nil.
 func (p FirewallPanel) SelectedRule() *forge.FirewallRule {
-	if len(p.rules) == 0 || p.cursor >= len(p.rules) {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
 		return nil
 	}
-	r := p.rules[p.cursor]
+	r := shown[p.cursor]
 	return &r
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p FirewallPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the firewall panel.
 func (p FirewallPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case FirewallLoadedMsg:
+		old := p.shown()
 		p.rules = msg.Rules
+		p.cursor = keepCursor(old, p.shown(), p.cursor, firewallRuleID)
 		p.loading = false
-		p.cursor = 0
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -153,8 +252,16 @@ func (p FirewallPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	return p, nil
 }
 
+func firewallRuleID(r forge.FirewallRule) int64 { return r.ID }
+
 func (p FirewallPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(firewallSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, firewallRuleID)
+		return p, nil
+
 	case key.Matches(msg, p.down):
 		if len(p.rules) > 0 {
 			p.cursor = min(p.cursor+1, len(p.rules)-1)
@@ -192,19 +299,13 @@ func (p FirewallPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Firewall Rules ")
+		Render(countTitle("Firewall Rules", len(p.rules), p.loading)) +
+		sortChip(firewallSort, p.order)
 
 	content := p.renderList(innerWidth, innerHeight-1)
 
@@ -224,11 +325,7 @@ const (
 const fwTableOverhead = 2 + colStatusWidth + 2 + 2 + fwColPortWidth + 2 + fwColIPWidth + 2 + fwColTypeWidth + 4
 
 func fwNameWidth(maxWidth int) int {
-	w := maxWidth - fwTableOverhead
-	if w < 8 {
-		w = 8
-	}
-	return w
+	return layout.Columns(maxWidth, fwTableOverhead, 8)
 }
 
 func (p FirewallPanel) renderList(width, height int) string {
@@ -241,25 +338,18 @@ func (p FirewallPanel) renderList(width, height int) string {
 	} else {
 		lines = append(lines, p.renderRuleHeader(width))
 
-		visibleHeight := height - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
-		for i := startIdx; i < len(p.rules) && len(lines)-1 < visibleHeight; i++ {
-			r := p.rules[i]
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			r := shown[i]
 			line := p.renderRuleLine(r, i, width)
 			lines = append(lines, line)
 		}
 	}
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
@@ -331,6 +421,9 @@ func (p FirewallPanel) HelpBindings() []HelpBinding {
 		{Key: "j/k", Desc: "navigate"},
 		{Key: "c", Desc: "create rule"},
 		{Key: "x", Desc: "delete"},
+		{Key: "y/Y", Desc: "copy rule/all to server"},
+		{Key: "t", Desc: "apply rule set"},
+		{Key: "s", Desc: "sort"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
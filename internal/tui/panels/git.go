@@ -7,12 +7,13 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
 // GitPanel shows repository information for a site as key-value pairs.
-// This is a read-only info panel -- no API calls needed, data comes from the
-// selected site.
+// No API calls needed, data comes from the selected site; the install,
+// branch and detach actions are handled by the app layer.
 type GitPanel struct {
 	site *forge.Site
 }
@@ -28,7 +29,7 @@ func (p GitPanel) SetSite(site *forge.Site) GitPanel {
 	return p
 }
 
-// Update handles messages. GitPanel is display-only.
+// Update handles messages. GitPanel has no state of its own to update.
 func (p GitPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	return p, nil
 }
@@ -42,14 +43,7 @@ func (p GitPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
@@ -71,7 +65,7 @@ func (p GitPanel) View(width, height int, focused bool) string {
 
 		repo := site.Repository
 		if repo == "" {
-			repo = "-"
+			repo = "- (i to install one)"
 		}
 		lines = append(lines, renderInfoKV("Repository", repo, innerWidth))
 
@@ -96,9 +90,7 @@ func (p GitPanel) View(width, height int, focused bool) string {
 	}
 
 	// Pad to fill the panel height.
-	for len(lines) < innerHeight-1 {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, innerHeight-1)
 
 	content := strings.Join(lines, "\n")
 
@@ -111,6 +103,9 @@ func (p GitPanel) View(width, height int, focused bool) string {
 // HelpBindings returns the key hints for the git panel.
 func (p GitPanel) HelpBindings() []HelpBinding {
 	return []HelpBinding{
+		{Key: "i", Desc: "install repo"},
+		{Key: "b", Desc: "branch"},
+		{Key: "x", Desc: "detach"},
 		{Key: "1-9", Desc: "sections"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
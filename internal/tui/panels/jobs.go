@@ -9,7 +9,9 @@ import (
 	"charm.land/bubbles/v2/key"
 	lipgloss "charm.land/lipgloss/v2"
 
+	"github.com/hinkers/Phorge/internal/cron"
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -20,21 +22,47 @@ type JobsLoadedMsg struct {
 	Jobs []forge.ScheduledJob
 }
 
-// JobsPanel shows the scheduled jobs on a server (read-only list).
+// JobCreatedMsg is sent when a scheduled job has been created.
+type JobCreatedMsg struct {
+	Job *forge.ScheduledJob
+}
+
+// JobDeletedMsg is sent when a scheduled job has been deleted.
+type JobDeletedMsg struct{}
+
+// JobOutputMsg carries the output of a job's latest run, fetched when the
+// user presses Enter on it.
+type JobOutputMsg struct {
+	Command string
+	Output  string
+}
+
+// JobsPanel shows the scheduled jobs on a server, with their latest output.
 // Jobs are server-level resources.
 type JobsPanel struct {
 	client   *forge.Client
 	serverID int64
 
 	jobs    []forge.ScheduledJob
-	cursor  int
+	cursor  int // index into shown()
 	loading bool
+	order   tableSort
 
 	// Keybindings
-	up   key.Binding
-	down key.Binding
-	home key.Binding
-	end  key.Binding
+	up    key.Binding
+	down  key.Binding
+	home  key.Binding
+	end   key.Binding
+	sort  key.Binding
+	enter key.Binding
+}
+
+// jobsSort are the columns the scheduled jobs table can be sorted by.
+var jobsSort = []sortColumn[forge.ScheduledJob]{
+	{name: "status", cmp: func(a, b forge.ScheduledJob) int { return compareFold(a.Status, b.Status) }},
+	{name: "command", cmp: func(a, b forge.ScheduledJob) int { return strings.Compare(a.Command, b.Command) }},
+	{name: "schedule", cmp: func(a, b forge.ScheduledJob) int { return strings.Compare(a.Frequency, b.Frequency) }},
+	{name: "user", cmp: func(a, b forge.ScheduledJob) int { return strings.Compare(a.User, b.User) }},
 }
 
 // NewJobsPanel creates a new JobsPanel.
@@ -59,6 +87,11 @@ func NewJobsPanel(client *forge.Client, serverID int64) JobsPanel {
 			key.WithKeys("G", "end"),
 			key.WithHelp("G", "bottom"),
 		),
+		enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "view output"),
+		),
+		sort: sortKey,
 	}
 }
 
@@ -75,13 +108,88 @@ func (p JobsPanel) LoadJobs() tea.Cmd {
 	}
 }
 
+// CreateJob returns a tea.Cmd that creates a scheduled job.
+// An empty opts.User runs it as forge.
+func (p JobsPanel) CreateJob(opts forge.JobCreateOpts) tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	if opts.User == "" {
+		opts.User = "forge"
+	}
+	return func() tea.Msg {
+		job, err := client.Jobs.Create(context.Background(), serverID, opts)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return JobCreatedMsg{Job: job}
+	}
+}
+
+// DeleteJob returns a tea.Cmd that deletes the currently selected job.
+func (p JobsPanel) DeleteJob() tea.Cmd {
+	job := p.SelectedJob()
+	if job == nil {
+		return nil
+	}
+	client := p.client
+	serverID := p.serverID
+	jobID := job.ID
+	return func() tea.Msg {
+		err := client.Jobs.Delete(context.Background(), serverID, jobID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return JobDeletedMsg{}
+	}
+}
+
+// LoadOutput returns a tea.Cmd that fetches the output of the selected
+// job's latest run.
+func (p JobsPanel) LoadOutput() tea.Cmd {
+	job := p.SelectedJob()
+	if job == nil {
+		return nil
+	}
+	client := p.client
+	serverID := p.serverID
+	jobID, command := job.ID, job.Command
+	return func() tea.Msg {
+		output, err := client.Jobs.GetOutput(context.Background(), serverID, jobID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return JobOutputMsg{Command: command, Output: output}
+	}
+}
+
+// shown returns the jobs in the order the table shows them.
+func (p JobsPanel) shown() []forge.ScheduledJob {
+	return sortRows(p.jobs, jobsSort, p.order)
+}
+
+// SelectedJob returns the currently selected job, or nil.
+func (p JobsPanel) SelectedJob() *forge.ScheduledJob {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
+		return nil
+	}
+	j := shown[p.cursor]
+	return &j
+}
+
+// Loading reports whether the panel's data is still being fetched.
+func (p JobsPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the jobs panel.
 func (p JobsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case JobsLoadedMsg:
+		old := p.shown()
 		p.jobs = msg.Jobs
+		p.cursor = keepCursor(old, p.shown(), p.cursor, jobID)
 		p.loading = false
-		p.cursor = 0
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -91,8 +199,16 @@ func (p JobsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	return p, nil
 }
 
+func jobID(x forge.ScheduledJob) int64 { return x.ID }
+
 func (p JobsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(jobsSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, jobID)
+		return p, nil
+
 	case key.Matches(msg, p.down):
 		if len(p.jobs) > 0 {
 			p.cursor = min(p.cursor+1, len(p.jobs)-1)
@@ -114,6 +230,9 @@ func (p JobsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 			p.cursor = len(p.jobs) - 1
 		}
 		return p, nil
+
+	case key.Matches(msg, p.enter):
+		return p, p.LoadOutput()
 	}
 
 	return p, nil
@@ -128,19 +247,13 @@ func (p JobsPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Scheduled Jobs ")
+		Render(countTitle("Scheduled Jobs", len(p.jobs), p.loading)) +
+		sortChip(jobsSort, p.order)
 
 	content := p.renderList(innerWidth, innerHeight-1)
 
@@ -152,18 +265,18 @@ func (p JobsPanel) View(width, height int, focused bool) string {
 
 // Column widths for jobs table.
 const (
-	jobColSchedWidth = 14
+	jobColSchedWidth = 22
+	jobColCronWidth  = 14
 	jobColUserWidth  = 8
 )
 
-const jobTableOverhead = 2 + colStatusWidth + 2 + 2 + jobColSchedWidth + 2 + jobColUserWidth + 4
+// cronStyle dims the raw cron expression next to its description.
+var cronStyle = lipgloss.NewStyle().Foreground(theme.ColorMuted)
+
+const jobTableOverhead = 2 + colStatusWidth + 2 + 2 + jobColSchedWidth + 2 + jobColCronWidth + 2 + jobColUserWidth + 4
 
 func jobCmdWidth(maxWidth int) int {
-	w := maxWidth - jobTableOverhead
-	if w < 10 {
-		w = 10
-	}
-	return w
+	return layout.Columns(maxWidth, jobTableOverhead, 10)
 }
 
 func (p JobsPanel) renderList(width, height int) string {
@@ -176,35 +289,29 @@ func (p JobsPanel) renderList(width, height int) string {
 	} else {
 		lines = append(lines, p.renderJobHeader(width))
 
-		visibleHeight := height - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
-		for i := startIdx; i < len(p.jobs) && len(lines)-1 < visibleHeight; i++ {
-			job := p.jobs[i]
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			job := shown[i]
 			line := p.renderJobLine(job, i, width)
 			lines = append(lines, line)
 		}
 	}
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
 
 func (p JobsPanel) renderJobHeader(maxWidth int) string {
 	cmdWidth := jobCmdWidth(maxWidth)
-	line := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s",
+	line := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s  %-*s",
 		colStatusWidth, "STATUS",
 		cmdWidth, "COMMAND",
 		jobColSchedWidth, "SCHEDULE",
+		jobColCronWidth, "CRON",
 		jobColUserWidth, "USER",
 	)
 	return theme.Truncate(headerStyle.Render(line), maxWidth)
@@ -222,13 +329,19 @@ func (p JobsPanel) renderJobLine(job forge.ScheduledJob, idx, maxWidth int) stri
 		command = "-"
 	}
 
-	freq := job.Cron
-	if freq == "" {
+	// Describe the cron expression when possible, falling back to Forge's
+	// frequency name ("Nightly", "Custom", ...).
+	freq, ok := cron.Describe(job.Cron)
+	if !ok {
 		freq = job.Frequency
 	}
 	if freq == "" {
 		freq = "-"
 	}
+	expr := job.Cron
+	if expr == "" {
+		expr = "-"
+	}
 
 	user := job.User
 	if user == "" {
@@ -241,6 +354,7 @@ func (p JobsPanel) renderJobLine(job forge.ScheduledJob, idx, maxWidth int) stri
 	statusPad := colStatusWidth - 2
 	statusStr := icon + " " + fmt.Sprintf("%-*s", statusPad, truncatePlain(statusText, statusPad))
 	freqStr := fmt.Sprintf("%-*s", jobColSchedWidth, truncatePlain(freq, jobColSchedWidth))
+	exprStr := fmt.Sprintf("%-*s", jobColCronWidth, truncatePlain(expr, jobColCronWidth))
 	userStr := fmt.Sprintf("%-*s", jobColUserWidth, truncatePlain(user, jobColUserWidth))
 
 	if idx == p.cursor {
@@ -248,6 +362,7 @@ func (p JobsPanel) renderJobLine(job forge.ScheduledJob, idx, maxWidth int) stri
 			statusStr +
 			"  " + theme.SelectedItemStyle.Render(fmt.Sprintf("%-*s", cmdWidth, command)) +
 			"  " + theme.NormalItemStyle.Render(freqStr) +
+			"  " + cronStyle.Render(exprStr) +
 			"  " + theme.NormalItemStyle.Render(userStr)
 		return theme.Truncate(line, maxWidth)
 	}
@@ -256,6 +371,7 @@ func (p JobsPanel) renderJobLine(job forge.ScheduledJob, idx, maxWidth int) stri
 		statusStr +
 		"  " + theme.NormalItemStyle.Render(fmt.Sprintf("%-*s", cmdWidth, command)) +
 		"  " + theme.NormalItemStyle.Render(freqStr) +
+		"  " + cronStyle.Render(exprStr) +
 		"  " + theme.NormalItemStyle.Render(userStr)
 	return theme.Truncate(line, maxWidth)
 }
@@ -264,6 +380,10 @@ func (p JobsPanel) renderJobLine(job forge.ScheduledJob, idx, maxWidth int) stri
 func (p JobsPanel) HelpBindings() []HelpBinding {
 	return []HelpBinding{
 		{Key: "j/k", Desc: "navigate"},
+		{Key: "enter", Desc: "output"},
+		{Key: "c", Desc: "create"},
+		{Key: "x", Desc: "delete"},
+		{Key: "s", Desc: "sort"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
@@ -0,0 +1,465 @@
+package panels
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/panels/testutil"
+)
+
+// listPanel describes one list panel for the table-driven tests below:
+// the fixtures it loads, how to build it, its table header and what its
+// cursor is.
+type listPanel struct {
+	name   string
+	header string // first column of the table's header row, if it has one
+	routes testutil.Routes
+	load   func(c *forge.Client) (Panel, tea.Cmd)
+	items  func(msg tea.Msg) (n int, ok bool) // length of the Loaded message
+	cursor func(p Panel) int
+}
+
+var listPanels = []listPanel{
+	{
+		name:   "backups",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/backup-configs": "backups"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewBackupsPanel(c, 1)
+			return p, p.LoadBackups()
+		},
+		items: func(msg tea.Msg) (int, bool) {
+			m, ok := msg.(BackupsLoadedMsg)
+			n := len(m.Configs)
+			for _, c := range m.Configs {
+				n += len(c.Backups)
+			}
+			return n, ok
+		},
+		cursor: func(p Panel) int { return p.(BackupsPanel).cursor },
+	},
+	{
+		name:   "circles",
+		header: "STATUS",
+		routes: testutil.Routes{"/circles": "circles"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewCirclesPanel(c, 1)
+			return p, p.LoadCircles()
+		},
+		items: func(msg tea.Msg) (int, bool) {
+			m, ok := msg.(CirclesLoadedMsg)
+			n := 0
+			for _, c := range m.Circles {
+				n += len(c.Members)
+			}
+			return n, ok
+		},
+		cursor: func(p Panel) int { return p.(CirclesPanel).cursor },
+	},
+	{
+		name:   "commands",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/sites/10/commands": "commands"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewCommandsPanel(c, 1, 10)
+			return p, p.LoadCommands()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(CommandsLoadedMsg); return len(m.Commands), ok },
+		cursor: func(p Panel) int { return p.(CommandsPanel).cursor },
+	},
+	{
+		name:   "daemons",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/daemons": "daemons"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewDaemonsPanel(c, 1)
+			return p, p.LoadDaemons()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(DaemonsLoadedMsg); return len(m.Daemons), ok },
+		cursor: func(p Panel) int { return p.(DaemonsPanel).cursor },
+	},
+	{
+		name:   "database users",
+		routes: testutil.Routes{"/servers/1/database-users": "database_users"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewDBUsersPanel(c, 1)
+			return p, p.LoadUsers()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(DBUsersLoadedMsg); return len(m.Users), ok },
+		cursor: func(p Panel) int { return p.(DBUsersPanel).cursor },
+	},
+	{
+		name:   "databases",
+		routes: testutil.Routes{"/servers/1/databases": "databases"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewDatabasesPanel(c, 1)
+			return p, p.LoadDatabases()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(DatabasesLoadedMsg); return len(m.Databases), ok },
+		cursor: func(p Panel) int { return p.(DatabasesPanel).cursor },
+	},
+	{
+		name:   "deployments",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/sites/10/deployment-history": "deployments"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewDeploymentsPanel(c, 1, 10)
+			return p, p.LoadDeployments()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(DeploymentsLoadedMsg); return len(m.Deployments), ok },
+		cursor: func(p Panel) int { return p.(DeploymentsPanel).cursor },
+	},
+	{
+		name:   "events",
+		header: "TIME",
+		routes: testutil.Routes{"/servers/1/events": "events"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewEventsPanel(c, 1)
+			return p, p.LoadEvents()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(EventsLoadedMsg); return len(m.Events), ok },
+		cursor: func(p Panel) int { return p.(EventsPanel).cursor },
+	},
+	{
+		name:   "firewall",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/firewall-rules": "firewall"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewFirewallPanel(c, 1)
+			return p, p.LoadRules()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(FirewallLoadedMsg); return len(m.Rules), ok },
+		cursor: func(p Panel) int { return p.(FirewallPanel).cursor },
+	},
+	{
+		name:   "jobs",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/jobs": "jobs"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewJobsPanel(c, 1)
+			return p, p.LoadJobs()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(JobsLoadedMsg); return len(m.Jobs), ok },
+		cursor: func(p Panel) int { return p.(JobsPanel).cursor },
+	},
+	{
+		name:   "nginx templates",
+		header: "ID",
+		routes: testutil.Routes{
+			"/servers/1/nginx/templates": "nginx_templates",
+			"/servers/1/sites":           "sites",
+		},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewNginxTemplatesPanel(c, 1, "")
+			return p, p.LoadTemplates()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(NginxTemplatesLoadedMsg); return len(m.Templates), ok },
+		cursor: func(p Panel) int { return p.(NginxTemplatesPanel).cursor },
+	},
+	{
+		name:   "redirects",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/sites/10/redirect-rules": "redirects"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewRedirectsPanel(c, 1, 10)
+			return p, p.LoadRedirects()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(RedirectsLoadedMsg); return len(m.Rules), ok },
+		cursor: func(p Panel) int { return p.(RedirectsPanel).cursor },
+	},
+	{
+		name:   "security rules",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/sites/10/security-rules": "security_rules"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewSecurityRulesPanel(c, 1, 10)
+			return p, p.LoadRules()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(SecurityRulesLoadedMsg); return len(m.Rules), ok },
+		cursor: func(p Panel) int { return p.(SecurityRulesPanel).cursor },
+	},
+	{
+		name:   "recipes",
+		header: "NAME",
+		routes: testutil.Routes{"/recipes": "recipes"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewRecipesPanel(c)
+			return p, p.LoadRecipes()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(RecipesLoadedMsg); return len(m.Recipes), ok },
+		cursor: func(p Panel) int { return p.(RecipesPanel).cursor },
+	},
+	{
+		name:   "ssh keys",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/keys": "ssh_keys"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewSSHKeysPanel(c, 1)
+			return p, p.LoadKeys()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(SSHKeysLoadedMsg); return len(m.Keys), ok },
+		cursor: func(p Panel) int { return p.(SSHKeysPanel).cursor },
+	},
+	{
+		name:   "certificates",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/sites/10/certificates": "certificates"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewSSLPanel(c, 1, 10)
+			return p, p.LoadCerts()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(CertsLoadedMsg); return len(m.Certificates), ok },
+		cursor: func(p Panel) int { return p.(SSLPanel).cursor },
+	},
+	{
+		name:   "ssl overview",
+		header: "SITE",
+		routes: testutil.Routes{
+			"/servers/1/sites":                   "sites",
+			"/servers/1/sites/10/certificates":   "certificates",
+			"/servers/1/sites/10/certificates/1": "certificate",
+		},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewSSLOverviewPanel(c, 1)
+			return p, p.LoadOverview()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(SSLOverviewLoadedMsg); return len(m.Rows), ok },
+		cursor: func(p Panel) int { return p.(SSLOverviewPanel).cursor },
+	},
+	{
+		name:   "workers",
+		header: "STATUS",
+		routes: testutil.Routes{"/servers/1/sites/10/workers": "workers"},
+		load: func(c *forge.Client) (Panel, tea.Cmd) {
+			p := NewWorkersPanel(c, 1, 10)
+			return p, p.LoadWorkers()
+		},
+		items:  func(msg tea.Msg) (int, bool) { m, ok := msg.(WorkersLoadedMsg); return len(m.Workers), ok },
+		cursor: func(p Panel) int { return p.(WorkersPanel).cursor },
+	},
+}
+
+// fixtureItems is how many items every list fixture holds.
+const fixtureItems = 3
+
+// loaded builds lp against the fake API and feeds it its Loaded message.
+func loaded(t *testing.T, lp listPanel) Panel {
+	t.Helper()
+	p, load := lp.load(testutil.NewClient(t, lp.routes))
+	msg := testutil.Run(t, load)
+	if n, ok := lp.items(msg); !ok || n != fixtureItems {
+		t.Fatalf("%s: load returned %T with %d items, want the Loaded message with %d", lp.name, msg, n, fixtureItems)
+	}
+	p, _ = p.Update(msg)
+	return p
+}
+
+func TestListPanelsLoad(t *testing.T) {
+	for _, lp := range listPanels {
+		t.Run(lp.name, func(t *testing.T) {
+			loaded(t, lp)
+		})
+	}
+}
+
+func TestListPanelsLoadError(t *testing.T) {
+	for _, lp := range listPanels {
+		t.Run(lp.name, func(t *testing.T) {
+			_, load := lp.load(testutil.NewClient(t, nil))
+			msg := testutil.Run(t, load)
+			if _, ok := msg.(PanelErrMsg); !ok {
+				t.Errorf("load returned %T, want PanelErrMsg", msg)
+			}
+		})
+	}
+}
+
+func TestListPanelsCursor(t *testing.T) {
+	tests := []struct {
+		keys string
+		want int
+	}{
+		{"", 0},
+		{"j", 1},
+		{"down down", 2},
+		{"j j j j j", fixtureItems - 1}, // stops at the bottom
+		{"k", 0},                        // stops at the top
+		{"G", fixtureItems - 1},
+		{"end k", fixtureItems - 2},
+		{"G g", 0},
+		{"j j home", 0},
+	}
+	for _, lp := range listPanels {
+		t.Run(lp.name, func(t *testing.T) {
+			base := loaded(t, lp)
+			for _, tt := range tests {
+				p := base
+				for _, k := range strings.Fields(tt.keys) {
+					p, _ = p.Update(testutil.Key(k))
+				}
+				if got := lp.cursor(p); got != tt.want {
+					t.Errorf("after %q cursor = %d, want %d", tt.keys, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestListPanelsKeepCursorOnReload(t *testing.T) {
+	for _, lp := range listPanels {
+		t.Run(lp.name, func(t *testing.T) {
+			p := loaded(t, lp)
+			p, _ = p.Update(testutil.Key("G"))
+
+			_, load := lp.load(testutil.NewClient(t, lp.routes))
+			p, _ = p.Update(testutil.Run(t, load))
+			if got := lp.cursor(p); got != fixtureItems-1 {
+				t.Errorf("cursor after reload = %d, want %d", got, fixtureItems-1)
+			}
+		})
+	}
+}
+
+// TestListPanelsFit renders every list panel, whose last fixture item is
+// far too long for its columns, and checks the result fits the space
+// given and still shows the selected row.
+func TestListPanelsFit(t *testing.T) {
+	sizes := []struct{ width, height int }{
+		{80, 6},
+		{80, 12},
+		{120, 20},
+		{200, 30},
+	}
+	for _, lp := range listPanels {
+		t.Run(lp.name, func(t *testing.T) {
+			p := loaded(t, lp)
+			p, _ = p.Update(testutil.Key("G"))
+			for _, sz := range sizes {
+				view := p.View(sz.width, sz.height, true)
+				lines := strings.Split(view, "\n")
+				if len(lines) != sz.height {
+					t.Errorf("%dx%d: rendered %d lines", sz.width, sz.height, len(lines))
+				}
+				for i, line := range lines {
+					if w := lipgloss.Width(line); w > sz.width {
+						t.Errorf("%dx%d: line %d is %d wide: %q", sz.width, sz.height, i, w, line)
+					}
+				}
+				if !strings.Contains(view, "> ") {
+					t.Errorf("%dx%d: selected row scrolled out of view", sz.width, sz.height)
+				}
+			}
+		})
+	}
+}
+
+// padRows returns a copy of the Loaded message msg with the items in its
+// first slice field grown to n by repeating all but the first, so only row
+// 0 shows the first item.
+func padRows(msg tea.Msg, n int) tea.Msg {
+	v := reflect.New(reflect.TypeOf(msg)).Elem()
+	v.Set(reflect.ValueOf(msg))
+	for i := range v.NumField() {
+		f := v.Field(i)
+		if f.Kind() != reflect.Slice || f.Len() < 2 {
+			continue
+		}
+		rows := reflect.MakeSlice(f.Type(), n, n)
+		rows.Index(0).Set(f.Index(0))
+		for r := 1; r < n; r++ {
+			rows.Index(r).Set(f.Index(1 + (r-1)%(f.Len()-1)))
+		}
+		f.Set(rows)
+		break
+	}
+	return v.Interface()
+}
+
+// TestListPanelsStickyHeader scrolls every table panel to the last of
+// more rows than it has room for and checks the header row stays on the
+// line it started on while row 0 scrolls out of view.
+func TestListPanelsStickyHeader(t *testing.T) {
+	const rows, height = 30, 8
+	for _, lp := range listPanels {
+		if lp.header == "" {
+			continue
+		}
+		t.Run(lp.name, func(t *testing.T) {
+			p, load := lp.load(testutil.NewClient(t, lp.routes))
+			msg := padRows(testutil.Run(t, load), rows)
+			if n, ok := lp.items(msg); !ok || n < rows {
+				t.Fatalf("load returned %T with %d items, want at least %d", msg, n, rows)
+			}
+			p, _ = p.Update(msg)
+
+			lines := strings.Split(ansi.Strip(p.View(120, height, true)), "\n")
+			header := slices.IndexFunc(lines, func(l string) bool { return strings.Contains(l, lp.header) })
+			if header < 0 || header+1 >= len(lines) || !strings.Contains(lines[header+1], "> ") {
+				t.Fatalf("header %q not directly above the selected row 0:\n%s", lp.header, strings.Join(lines, "\n"))
+			}
+			row0 := strings.Replace(lines[header+1], "> ", "  ", 1)
+
+			p, _ = p.Update(testutil.Key("G"))
+			scrolled := strings.Split(ansi.Strip(p.View(120, height, true)), "\n")
+			if got := slices.IndexFunc(scrolled, func(l string) bool { return strings.Contains(l, lp.header) }); got != header {
+				t.Errorf("header %q on line %d after scrolling to the end, want line %d:\n%s", lp.header, got, header, strings.Join(scrolled, "\n"))
+			}
+			if slices.Contains(scrolled, row0) {
+				t.Errorf("row 0 still shown after scrolling to the end:\n%s", strings.Join(scrolled, "\n"))
+			}
+			if !slices.ContainsFunc(scrolled, func(l string) bool { return strings.Contains(l, "> ") }) {
+				t.Errorf("selected last row not shown:\n%s", strings.Join(scrolled, "\n"))
+			}
+		})
+	}
+}
+
+func TestKeepCursor(t *testing.T) {
+	id := func(n int) int { return n }
+	tests := []struct {
+		name     string
+		old, new []int
+		cursor   int
+		want     int
+	}{
+		{"same list", []int{1, 2, 3}, []int{1, 2, 3}, 1, 1},
+		{"item moved", []int{1, 2, 3}, []int{0, 1, 2, 3}, 1, 2},
+		{"item gone", []int{1, 2, 3}, []int{1, 3}, 1, 1},
+		{"list shrank", []int{1, 2, 3}, []int{4}, 2, 0},
+		{"list emptied", []int{1, 2, 3}, nil, 2, 0},
+		{"first load", nil, []int{1, 2, 3}, 0, 0},
+		{"cursor out of range", []int{1}, []int{1, 2}, 5, 1},
+	}
+	for _, tt := range tests {
+		if got := keepCursor(tt.old, tt.new, tt.cursor, id); got != tt.want {
+			t.Errorf("%s: keepCursor(%v, %v, %d) = %d, want %d", tt.name, tt.old, tt.new, tt.cursor, got, tt.want)
+		}
+	}
+}
+
+func TestTruncatePlain(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 5, "hello"},
+		{"hello world", 8, "hello..."},
+		{"hello", 3, "hel"},
+		{"hello", 0, ""},
+		{"héllo wörld", 6, "hél..."},
+	}
+	for _, tt := range tests {
+		if got := truncatePlain(tt.s, tt.width); got != tt.want {
+			t.Errorf("truncatePlain(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
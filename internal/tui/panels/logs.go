@@ -4,13 +4,16 @@ import (
 	"context"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -26,6 +29,10 @@ type LogEditorDoneMsg struct {
 	Err error
 }
 
+// logGrepContext is how many lines around each match the grep filter
+// keeps, like grep -C2.
+const logGrepContext = 2
+
 // LogsPanel shows log content in a scrollable viewport.
 // If siteID > 0 it shows site logs, otherwise server logs.
 type LogsPanel struct {
@@ -39,6 +46,15 @@ type LogsPanel struct {
 	pendingEdit bool   // true if user pressed 'e' while loading
 	editor      string // editor command from config
 
+	// The grep filter: a regex typed after /, applied to the fetched
+	// content. lines holds what is shown, the filtered lines when a
+	// filter is applied.
+	filterInput  textinput.Model
+	filterActive bool
+	filter       *regexp.Regexp
+	filterErr    error
+	lines        []LogLine
+
 	// Keybindings
 	up      key.Binding
 	down    key.Binding
@@ -53,12 +69,18 @@ func NewLogsPanel(client *forge.Client, serverID, siteID int64, editor string) L
 	if editor == "" {
 		editor = "vim"
 	}
+	ti := textinput.New()
+	ti.Prompt = "/ "
+	ti.Placeholder = "regex..."
+	ti.CharLimit = 256
+
 	return LogsPanel{
-		client:   client,
-		serverID: serverID,
-		siteID:   siteID,
-		loading:  true,
-		editor:   editor,
+		client:      client,
+		serverID:    serverID,
+		siteID:      siteID,
+		loading:     true,
+		editor:      editor,
+		filterInput: ti,
 		up: key.NewBinding(
 			key.WithKeys("k", "up"),
 			key.WithHelp("k/up", "scroll up"),
@@ -106,6 +128,95 @@ func (p LogsPanel) LoadLogs() tea.Cmd {
 	}
 }
 
+// Content returns the log content shown: the lines the grep filter keeps
+// when one is applied, or else everything fetched.
+func (p LogsPanel) Content() string {
+	if p.filter == nil {
+		return p.content
+	}
+	text := make([]string, len(p.lines))
+	for i, l := range p.lines {
+		text[i] = l.Text
+		if l.Separator {
+			text[i] = "--"
+		}
+	}
+	return strings.Join(text, "\n")
+}
+
+// FilterActive reports whether the grep filter input is open.
+func (p LogsPanel) FilterActive() bool {
+	return p.filterActive
+}
+
+// Filtered reports whether a grep filter is applied.
+func (p LogsPanel) Filtered() bool {
+	return p.filter != nil
+}
+
+// LogLine is a line shown by the logs panel. With a grep filter applied,
+// Match marks the lines that matched and Separator the "--" between
+// groups of lines that aren't adjacent in the log.
+type LogLine struct {
+	Text      string
+	Match     bool
+	Separator bool
+}
+
+// GrepLines returns the lines matching re with context lines around each
+// match, like grep -C: groups that aren't adjacent are divided by a
+// separator line.
+func GrepLines(lines []string, re *regexp.Regexp, context int) []LogLine {
+	keep := make([]bool, len(lines))
+	match := make([]bool, len(lines))
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		match[i] = true
+		for j := max(i-context, 0); j <= min(i+context, len(lines)-1); j++ {
+			keep[j] = true
+		}
+	}
+	var out []LogLine
+	last := -1
+	for i, line := range lines {
+		if !keep[i] {
+			continue
+		}
+		if last >= 0 && i > last+1 {
+			out = append(out, LogLine{Separator: true})
+		}
+		out = append(out, LogLine{Text: line, Match: match[i]})
+		last = i
+	}
+	return out
+}
+
+// applyFilter recomputes the shown lines from the content and the filter
+// typed so far. An invalid regex leaves the log unfiltered.
+func (p LogsPanel) applyFilter() LogsPanel {
+	p.filter, p.filterErr = nil, nil
+	if expr := p.filterInput.Value(); expr != "" {
+		p.filter, p.filterErr = regexp.Compile(expr)
+	}
+	all := strings.Split(p.content, "\n")
+	if p.filter == nil {
+		p.lines = make([]LogLine, len(all))
+		for i, line := range all {
+			p.lines[i] = LogLine{Text: line}
+		}
+		return p
+	}
+	p.lines = GrepLines(all, p.filter, logGrepContext)
+	return p
+}
+
+// Loading reports whether the panel's data is still being fetched.
+func (p LogsPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the logs panel.
 func (p LogsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -113,6 +224,7 @@ func (p LogsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 		p.content = msg.Content
 		p.loading = false
 		p.scrollY = 0
+		p = p.applyFilter()
 		if p.pendingEdit {
 			p.pendingEdit = false
 			return p.openEditor()
@@ -128,14 +240,52 @@ func (p LogsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 		return p, nil
 
 	case tea.KeyPressMsg:
+		if p.filterActive {
+			return p.handleFilterKey(msg)
+		}
 		return p.handleKey(msg)
 	}
 
 	return p, nil
 }
 
+// handleFilterKey processes keys while the grep filter input is open. The
+// filter applies as it is typed; Enter closes the input and keeps it, Esc
+// clears it.
+func (p LogsPanel) handleFilterKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+		p.filterActive = false
+		p.filterInput.Blur()
+		return p, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
+		p.filterActive = false
+		p.filterInput.Blur()
+		p.filterInput.SetValue("")
+		p.scrollY = 0
+		return p.applyFilter(), nil
+	}
+
+	var cmd tea.Cmd
+	p.filterInput, cmd = p.filterInput.Update(msg)
+	p.scrollY = 0
+	return p.applyFilter(), cmd
+}
+
 func (p LogsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("/"))):
+		p.filterActive = true
+		p.filterInput.Focus()
+		return p, textinput.Blink
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))) && p.filter != nil:
+		// Esc clears an applied filter without reopening the input.
+		p.filterInput.SetValue("")
+		p.scrollY = 0
+		return p.applyFilter(), nil
+
 	case key.Matches(msg, p.down):
 		p.scrollY++
 		return p, nil
@@ -151,8 +301,7 @@ func (p LogsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 		return p, nil
 
 	case key.Matches(msg, p.end):
-		lines := strings.Split(p.content, "\n")
-		p.scrollY = len(lines) // will be clamped during render
+		p.scrollY = len(p.lines) // will be clamped during render
 		return p, nil
 
 	case key.Matches(msg, p.refresh):
@@ -207,14 +356,7 @@ func (p LogsPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	titleText := " Logs "
 	if p.siteID > 0 {
@@ -228,14 +370,33 @@ func (p LogsPanel) View(width, height int, focused bool) string {
 		Foreground(titleColor).
 		Render(titleText)
 
-	content := p.renderContent(innerWidth, innerHeight-1) // -1 for title line
+	// The filter input, or the applied filter, takes a line below the title.
+	var filterLine string
+	switch {
+	case p.filterActive:
+		filterLine = theme.Truncate(p.filterInput.View(), innerWidth) + "\n"
+	case p.filter != nil:
+		filterLine = theme.Truncate(theme.FilterIndicatorStyle.Render("grep: "+p.filter.String()), innerWidth) + "\n"
+	}
+	if p.filterErr != nil {
+		filterLine = theme.Truncate(theme.ErrorStatusStyle.Render("invalid regex: "+p.filterErr.Error()), innerWidth) + "\n"
+		if p.filterActive {
+			filterLine = theme.Truncate(p.filterInput.View(), innerWidth) + "\n" + filterLine
+		}
+	}
+	contentHeight := innerHeight - 1 - strings.Count(filterLine, "\n") // -1 for title line
+
+	content := p.renderContent(innerWidth, contentHeight)
 
 	return style.
 		Width(innerWidth).
 		Height(innerHeight).
-		Render(title + "\n" + content)
+		Render(title + "\n" + filterLine + content)
 }
 
+// logContextStyle dims the context lines around grep matches.
+var logContextStyle = lipgloss.NewStyle().Foreground(theme.ColorMuted)
+
 // renderContent renders the log content with scrolling.
 func (p LogsPanel) renderContent(width, height int) string {
 	if height < 1 {
@@ -252,28 +413,28 @@ func (p LogsPanel) renderContent(width, height int) string {
 	if p.content == "" {
 		return theme.NormalItemStyle.Render("No log content available")
 	}
-
-	allLines := strings.Split(p.content, "\n")
+	if p.filter != nil && len(p.lines) == 0 {
+		return theme.NormalItemStyle.Render("No matching lines")
+	}
 
 	// Clamp scroll offset.
-	maxScroll := len(allLines) - height
-	if maxScroll < 0 {
-		maxScroll = 0
-	}
-	if p.scrollY > maxScroll {
-		p.scrollY = maxScroll
-	}
+	p.scrollY = layout.Clamp(p.scrollY, 0, len(p.lines)-height)
 
 	var lines []string
-	for i := p.scrollY; i < len(allLines) && len(lines) < height; i++ {
-		line := theme.Truncate(allLines[i], width)
-		lines = append(lines, theme.NormalItemStyle.Render(line))
+	for i := p.scrollY; i < len(p.lines) && len(lines) < height; i++ {
+		l := p.lines[i]
+		switch {
+		case l.Separator:
+			lines = append(lines, logContextStyle.Render("--"))
+		case p.filter != nil && !l.Match:
+			lines = append(lines, logContextStyle.Render(theme.Truncate(l.Text, width)))
+		default:
+			lines = append(lines, theme.NormalItemStyle.Render(theme.Truncate(l.Text, width)))
+		}
 	}
 
 	// Pad remaining height.
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
@@ -282,6 +443,8 @@ func (p LogsPanel) renderContent(width, height int) string {
 func (p LogsPanel) HelpBindings() []HelpBinding {
 	return []HelpBinding{
 		{Key: "e", Desc: "open in editor"},
+		{Key: "/", Desc: "grep"},
+		{Key: "s", Desc: "save to file"},
 		{Key: "j/k", Desc: "scroll"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "r", Desc: "refresh"},
@@ -0,0 +1,41 @@
+package panels
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGrepLines(t *testing.T) {
+	lines := strings.Split("a\nb\nERROR one\nc\nd\ne\nf\ng\nERROR two\nh", "\n")
+	got := GrepLines(lines, regexp.MustCompile(`ERROR`), 2)
+
+	var text []string
+	for _, l := range got {
+		switch {
+		case l.Separator:
+			text = append(text, "--")
+		case l.Match:
+			text = append(text, "*"+l.Text)
+		default:
+			text = append(text, l.Text)
+		}
+	}
+	want := "a b *ERROR one c d -- f g *ERROR two h"
+	if strings.Join(text, " ") != want {
+		t.Errorf("GrepLines = %q, want %q", strings.Join(text, " "), want)
+	}
+}
+
+func TestGrepLinesAdjacentGroups(t *testing.T) {
+	lines := strings.Split("x1\nx2\nx3\nx4\nx5\nx6", "\n")
+	got := GrepLines(lines, regexp.MustCompile(`x[16]`), 2)
+	for _, l := range got {
+		if l.Separator {
+			t.Fatalf("GrepLines split groups that touch: %+v", got)
+		}
+	}
+	if len(got) != 6 {
+		t.Errorf("GrepLines kept %d lines, want all 6", len(got))
+	}
+}
@@ -0,0 +1,490 @@
+package panels
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// --- Messages ---
+
+// NginxTemplatesLoadedMsg is sent when the server's nginx templates, and
+// the sites they can be applied to, have been fetched.
+type NginxTemplatesLoadedMsg struct {
+	Templates []forge.NginxTemplate
+	Sites     []forge.Site
+}
+
+// NginxEditorDoneMsg is sent after the external editor exits for a
+// template. TemplateID is 0 for a template that doesn't exist yet.
+type NginxEditorDoneMsg struct {
+	TemplateID int64
+	Name       string
+	NewContent string
+	Changed    bool
+	Err        error
+}
+
+// NginxTemplateSavedMsg is sent after a template has been created or
+// updated.
+type NginxTemplateSavedMsg struct {
+	Name    string
+	Created bool
+	Err     error
+}
+
+// NginxTemplateDeletedMsg is sent after a template has been deleted.
+type NginxTemplateDeletedMsg struct {
+	Name string
+}
+
+// NginxTemplateAppliedMsg is sent after a template has been written as a
+// site's nginx configuration.
+type NginxTemplateAppliedMsg struct {
+	Template string
+	Site     string
+}
+
+// nginxStarter is the content a new template starts from in the editor.
+const nginxStarter = `server {
+    listen {{PORT}};
+    listen {{PORT_V6}};
+    server_name {{DOMAINS}};
+    root {{PATH}};
+
+    index index.html index.htm index.php;
+
+    location / {
+        try_files $uri $uri/ /index.php?$query_string;
+    }
+}
+`
+
+// NginxTemplatesPanel lists a server's nginx templates, so standard server
+// blocks can be kept in one place, edited, and applied to sites.
+type NginxTemplatesPanel struct {
+	client   *forge.Client
+	serverID int64
+	editor   string // editor command from config
+
+	templates []forge.NginxTemplate
+	sites     []forge.Site
+	cursor    int // index into shown()
+	loading   bool
+	order     tableSort
+	saving    bool
+
+	// Keybindings
+	up   key.Binding
+	down key.Binding
+	home key.Binding
+	end  key.Binding
+	sort key.Binding
+}
+
+// nginxSort are the columns the nginx templates table can be sorted by.
+var nginxSort = []sortColumn[forge.NginxTemplate]{
+	{name: "id", cmp: func(a, b forge.NginxTemplate) int { return cmp.Compare(a.ID, b.ID) }},
+	{name: "name", cmp: func(a, b forge.NginxTemplate) int { return compareFold(a.Name, b.Name) }},
+	{name: "lines", cmp: func(a, b forge.NginxTemplate) int {
+		la, aok := templateLines(a)
+		lb, bok := templateLines(b)
+		return compareKnown(la, aok, lb, bok)
+	}},
+}
+
+// templateLines returns the number of lines in t's content, or false if
+// the API didn't return its content.
+func templateLines(t forge.NginxTemplate) (int, bool) {
+	if t.Content == "" {
+		return 0, false
+	}
+	return strings.Count(strings.TrimRight(t.Content, "\n"), "\n") + 1, true
+}
+
+// NewNginxTemplatesPanel creates a new NginxTemplatesPanel.
+func NewNginxTemplatesPanel(client *forge.Client, serverID int64, editor string) NginxTemplatesPanel {
+	if editor == "" {
+		editor = "vim"
+	}
+	return NginxTemplatesPanel{
+		client:   client,
+		serverID: serverID,
+		editor:   editor,
+		loading:  true,
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		home: key.NewBinding(
+			key.WithKeys("g", "home"),
+			key.WithHelp("g", "top"),
+		),
+		end: key.NewBinding(
+			key.WithKeys("G", "end"),
+			key.WithHelp("G", "bottom"),
+		),
+		sort: sortKey,
+	}
+}
+
+// LoadTemplates returns a tea.Cmd that fetches the server's nginx
+// templates and sites.
+func (p NginxTemplatesPanel) LoadTemplates() tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	return func() tea.Msg {
+		ctx := context.Background()
+		templates, err := client.Nginx.ListTemplates(ctx, serverID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		sites, err := client.Sites.List(ctx, serverID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return NginxTemplatesLoadedMsg{Templates: templates, Sites: sites}
+	}
+}
+
+// shown returns the templates in the order the table shows them.
+func (p NginxTemplatesPanel) shown() []forge.NginxTemplate {
+	return sortRows(p.templates, nginxSort, p.order)
+}
+
+// SelectedTemplate returns the selected template, or nil.
+func (p NginxTemplatesPanel) SelectedTemplate() *forge.NginxTemplate {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
+		return nil
+	}
+	t := shown[p.cursor]
+	return &t
+}
+
+// Sites returns the server's sites, which templates can be applied to.
+func (p NginxTemplatesPanel) Sites() []forge.Site {
+	return p.sites
+}
+
+// NewTemplate opens the editor on a starter server block for a new
+// template called name; it is created once the editor exits.
+func (p NginxTemplatesPanel) NewTemplate(name string) tea.Cmd {
+	return p.openEditor(forge.NginxTemplate{Name: name, Content: nginxStarter}, true)
+}
+
+// EditTemplate opens the editor on the selected template.
+func (p NginxTemplatesPanel) EditTemplate() tea.Cmd {
+	t := p.SelectedTemplate()
+	if t == nil {
+		return nil
+	}
+	return p.openEditor(*t, false)
+}
+
+// openEditor writes the template to a temp file and opens the external
+// editor. A new template counts as changed even if saved untouched.
+func (p NginxTemplatesPanel) openEditor(t forge.NginxTemplate, isNew bool) tea.Cmd {
+	tmpFile, err := os.CreateTemp("", "phorge-nginx-*.conf")
+	if err != nil {
+		return func() tea.Msg {
+			return PanelErrMsg{Err: err}
+		}
+	}
+	if _, err := tmpFile.WriteString(t.Content); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return func() tea.Msg {
+			return PanelErrMsg{Err: err}
+		}
+	}
+	tmpFile.Close()
+	path := tmpFile.Name()
+
+	c := exec.Command(p.editor, path)
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return NginxEditorDoneMsg{Err: err}
+		}
+		newContent, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return NginxEditorDoneMsg{Err: readErr}
+		}
+		return NginxEditorDoneMsg{
+			TemplateID: t.ID,
+			Name:       t.Name,
+			NewContent: string(newContent),
+			Changed:    isNew || string(newContent) != t.Content,
+		}
+	})
+}
+
+// saveTemplate returns a tea.Cmd that creates the template when id is 0
+// and updates it otherwise.
+func (p NginxTemplatesPanel) saveTemplate(id int64, name, content string) tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	return func() tea.Msg {
+		ctx := context.Background()
+		if id == 0 {
+			_, err := client.Nginx.CreateTemplate(ctx, serverID, name, content)
+			return NginxTemplateSavedMsg{Name: name, Created: true, Err: err}
+		}
+		err := client.Nginx.UpdateTemplate(ctx, serverID, id, name, content)
+		return NginxTemplateSavedMsg{Name: name, Err: err}
+	}
+}
+
+// DeleteTemplate returns a tea.Cmd that deletes the selected template.
+func (p NginxTemplatesPanel) DeleteTemplate() tea.Cmd {
+	t := p.SelectedTemplate()
+	if t == nil {
+		return nil
+	}
+	client := p.client
+	serverID := p.serverID
+	tmpl := *t
+	return func() tea.Msg {
+		if err := client.Nginx.DeleteTemplate(context.Background(), serverID, tmpl.ID); err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return NginxTemplateDeletedMsg{Name: tmpl.Name}
+	}
+}
+
+// ApplyToSite returns a tea.Cmd that renders the selected template for
+// site on srv and writes it as the site's nginx configuration.
+func (p NginxTemplatesPanel) ApplyToSite(srv forge.Server, site forge.Site) tea.Cmd {
+	t := p.SelectedTemplate()
+	if t == nil {
+		return nil
+	}
+	client := p.client
+	tmpl := *t
+	return func() tea.Msg {
+		ctx := context.Background()
+		// The list may leave content out; fetch it when it does.
+		if tmpl.Content == "" {
+			full, err := client.Nginx.GetTemplate(ctx, srv.ID, tmpl.ID)
+			if err != nil {
+				return PanelErrMsg{Err: err}
+			}
+			tmpl.Content = full.Content
+		}
+		content := forge.RenderTemplate(tmpl.Content, srv, site)
+		if err := client.Nginx.UpdateSiteConfig(ctx, srv.ID, site.ID, content); err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return NginxTemplateAppliedMsg{Template: tmpl.Name, Site: site.Name}
+	}
+}
+
+// Loading reports whether the panel's data is still being fetched.
+func (p NginxTemplatesPanel) Loading() bool {
+	return p.loading
+}
+
+// Update handles messages for the nginx templates panel.
+func (p NginxTemplatesPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case NginxTemplatesLoadedMsg:
+		old := p.shown()
+		p.templates = msg.Templates
+		p.cursor = keepCursor(old, p.shown(), p.cursor, nginxTemplateID)
+		p.sites = msg.Sites
+		p.loading = false
+		p.saving = false
+		return p, nil
+
+	case NginxEditorDoneMsg:
+		if msg.Err != nil {
+			return p, func() tea.Msg {
+				return PanelErrMsg{Err: msg.Err}
+			}
+		}
+		if !msg.Changed {
+			return p, nil
+		}
+		p.saving = true
+		return p, p.saveTemplate(msg.TemplateID, msg.Name, msg.NewContent)
+
+	case NginxTemplateSavedMsg:
+		p.saving = false
+		return p, nil
+
+	case tea.KeyPressMsg:
+		return p.handleKey(msg)
+	}
+
+	return p, nil
+}
+
+func nginxTemplateID(x forge.NginxTemplate) int64 { return x.ID }
+
+func (p NginxTemplatesPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(nginxSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, nginxTemplateID)
+		return p, nil
+
+	case key.Matches(msg, p.down):
+		if len(p.templates) > 0 {
+			p.cursor = min(p.cursor+1, len(p.templates)-1)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.up):
+		if len(p.templates) > 0 {
+			p.cursor = max(p.cursor-1, 0)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.home):
+		p.cursor = 0
+		return p, nil
+
+	case key.Matches(msg, p.end):
+		if len(p.templates) > 0 {
+			p.cursor = len(p.templates) - 1
+		}
+		return p, nil
+
+	// 'c', 'e', 'a', 'x' are handled by the app layer.
+	}
+
+	return p, nil
+}
+
+// View renders the nginx templates panel.
+func (p NginxTemplatesPanel) View(width, height int, focused bool) string {
+	style := theme.InactiveBorderStyle
+	titleColor := theme.ColorSubtle
+	if focused {
+		style = theme.ActiveBorderStyle
+		titleColor = theme.ColorPrimary
+	}
+
+	innerWidth, innerHeight := layout.Inner(width, height)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(titleColor).
+		Render(countTitle("Nginx Templates", len(p.templates), p.loading)) +
+		sortChip(nginxSort, p.order)
+
+	content := p.renderList(innerWidth, innerHeight-1)
+
+	return style.
+		Width(innerWidth).
+		Height(innerHeight).
+		Render(title + "\n" + content)
+}
+
+// Column widths for the nginx templates table.
+const (
+	nginxColIDWidth    = 8
+	nginxColLinesWidth = 6
+)
+
+const nginxTableOverhead = 2 + nginxColIDWidth + 2 + 2 + nginxColLinesWidth + 2
+
+func nginxNameWidth(maxWidth int) int {
+	return layout.Columns(maxWidth, nginxTableOverhead, 10)
+}
+
+func (p NginxTemplatesPanel) renderList(width, height int) string {
+	var lines []string
+
+	switch {
+	case p.saving:
+		lines = append(lines, theme.LoadingStyle.Render("Saving nginx template..."))
+	case p.loading && len(p.templates) == 0:
+		lines = append(lines, theme.LoadingStyle.Render("Loading nginx templates..."))
+	case len(p.templates) == 0:
+		lines = append(lines, theme.NormalItemStyle.Render("No nginx templates (c to create)"))
+	default:
+		lines = append(lines, p.renderTemplateHeader(width))
+
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
+
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			lines = append(lines, p.renderTemplateLine(shown[i], i, width))
+		}
+	}
+
+	lines = layout.Pad(lines, height)
+
+	return strings.Join(lines, "\n")
+}
+
+func (p NginxTemplatesPanel) renderTemplateHeader(maxWidth int) string {
+	nameW := nginxNameWidth(maxWidth)
+	line := fmt.Sprintf("  %-*s  %-*s  %*s",
+		nginxColIDWidth, "ID",
+		nameW, "NAME",
+		nginxColLinesWidth, "LINES",
+	)
+	return theme.Truncate(headerStyle.Render(line), maxWidth)
+}
+
+func (p NginxTemplatesPanel) renderTemplateLine(t forge.NginxTemplate, idx, maxWidth int) string {
+	nameW := nginxNameWidth(maxWidth)
+	name := truncatePlain(t.Name, nameW)
+
+	lineCount := "-"
+	if n, ok := templateLines(t); ok {
+		lineCount = fmt.Sprintf("%d", n)
+	}
+
+	idStr := fmt.Sprintf("%-*d", nginxColIDWidth, t.ID)
+	linesStr := fmt.Sprintf("%*s", nginxColLinesWidth, lineCount)
+
+	if idx == p.cursor {
+		line := theme.CursorStyle.Render("> ") +
+			theme.NormalItemStyle.Render(idStr) +
+			"  " + theme.SelectedItemStyle.Render(fmt.Sprintf("%-*s", nameW, name)) +
+			"  " + theme.NormalItemStyle.Render(linesStr)
+		return theme.Truncate(line, maxWidth)
+	}
+
+	line := "  " +
+		theme.NormalItemStyle.Render(idStr) +
+		"  " + theme.NormalItemStyle.Render(fmt.Sprintf("%-*s", nameW, name)) +
+		"  " + theme.NormalItemStyle.Render(linesStr)
+	return theme.Truncate(line, maxWidth)
+}
+
+// HelpBindings returns the key hints for the nginx templates panel.
+func (p NginxTemplatesPanel) HelpBindings() []HelpBinding {
+	return []HelpBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "c", Desc: "create"},
+		{Key: "e", Desc: "edit"},
+		{Key: "a", Desc: "apply to site"},
+		{Key: "x", Desc: "delete"},
+		{Key: "s", Desc: "sort"},
+		{Key: "esc", Desc: "back"},
+		{Key: "tab", Desc: "switch panel"},
+		{Key: "q", Desc: "quit"},
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"charm.land/bubbles/v2/key"
 	lipgloss "charm.land/lipgloss/v2"
 
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -15,14 +16,25 @@ import (
 type OutputPanel struct {
 	title   string
 	content string
+	lines   []string // content split into lines, kept in step with content
 	scroll  int
 
+	// height is the panel's last rendered height, used to clamp scrolling.
+	height int
+
+	// live is set while the content is being refreshed by polling; follow
+	// keeps the view pinned to the newest output while live.
+	live   bool
+	follow bool
+
 	// Keybindings
 	up   key.Binding
 	down key.Binding
 	home key.Binding
 	end  key.Binding
 	back key.Binding
+
+	followKey key.Binding
 }
 
 // NewOutputPanel creates a new, empty output panel.
@@ -48,6 +60,10 @@ func NewOutputPanel() OutputPanel {
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "back"),
 		),
+		followKey: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "follow"),
+		),
 	}
 }
 
@@ -55,11 +71,82 @@ func NewOutputPanel() OutputPanel {
 // View() clamps the scroll to the valid max, so 999999 just means "end".
 func (o OutputPanel) SetContent(title, content string) OutputPanel {
 	o.title = title
-	o.content = content
+	o = o.setText(content)
 	o.scroll = 999999
+	o.live = false
+	o.follow = false
+	return o
+}
+
+// StartStream prepares the panel for content that will be refreshed by
+// polling, following the newest output until the user scrolls away.
+func (o OutputPanel) StartStream() OutputPanel {
+	o.live = true
+	o.follow = true
+	o.scroll = 999999
+	return o
+}
+
+// StreamContent replaces the content of a live stream. The view stays on
+// the newest output while following and keeps the user's position otherwise.
+func (o OutputPanel) StreamContent(title, content string) OutputPanel {
+	o.title = title
+	o = o.setText(content)
+	if o.follow {
+		o.scroll = 999999
+	}
+	return o
+}
+
+// EndStream marks the content as no longer being refreshed.
+func (o OutputPanel) EndStream() OutputPanel {
+	o.live = false
+	o.follow = false
+	return o
+}
+
+// SetHeight records the panel height the app will render it at, so scroll
+// keys can be clamped to the content.
+func (o OutputPanel) SetHeight(height int) OutputPanel {
+	o.height = height
+	return o
+}
+
+// LineCount returns the number of lines of output held.
+func (o OutputPanel) LineCount() int {
+	return len(o.lines)
+}
+
+// Following reports whether the panel is pinned to the newest live output.
+func (o OutputPanel) Following() bool {
+	return o.live && o.follow
+}
+
+// setText replaces the content and its line slice. Live output usually
+// only grows, so when the new content extends the old only the appended
+// part is split.
+func (o OutputPanel) setText(content string) OutputPanel {
+	if n := len(o.lines); n > 0 && len(content) > len(o.content) && strings.HasPrefix(content, o.content) {
+		// The old last line may have been incomplete, so re-split it with
+		// the tail. The capped slice makes append copy rather than write
+		// into an array shared with earlier copies of the panel.
+		more := strings.Split(o.lines[n-1]+content[len(o.content):], "\n")
+		o.lines = append(o.lines[:n-1:n-1], more...)
+	} else if content == "" {
+		o.lines = nil
+	} else {
+		o.lines = strings.Split(content, "\n")
+	}
+	o.content = content
 	return o
 }
 
+// maxScroll returns the largest useful scroll offset for the content.
+func (o OutputPanel) maxScroll() int {
+	visible := max(o.height-3, 1)
+	return max(len(o.lines)-visible, 0)
+}
+
 // SetTitle updates only the panel title without changing the content or scroll.
 func (o OutputPanel) SetTitle(title string) OutputPanel {
 	o.title = title
@@ -70,6 +157,7 @@ func (o OutputPanel) SetTitle(title string) OutputPanel {
 func (o OutputPanel) Clear() OutputPanel {
 	o.title = ""
 	o.content = ""
+	o.lines = nil
 	o.scroll = 0
 	return o
 }
@@ -88,24 +176,37 @@ func (o OutputPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 }
 
 func (o OutputPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	// Resolve the "end" sentinel so relative moves start from what is shown.
+	o.scroll = min(o.scroll, o.maxScroll())
+
 	switch {
 	case key.Matches(msg, o.down):
-		o.scroll++
+		o.scroll = min(o.scroll+1, o.maxScroll())
 		return o, nil
 
 	case key.Matches(msg, o.up):
 		if o.scroll > 0 {
 			o.scroll--
 		}
+		o.follow = false
 		return o, nil
 
 	case key.Matches(msg, o.home):
 		o.scroll = 0
+		o.follow = false
 		return o, nil
 
 	case key.Matches(msg, o.end):
 		// Set to a large value; View will clamp it.
 		o.scroll = 999999
+		o.follow = o.live
+		return o, nil
+
+	case key.Matches(msg, o.followKey):
+		o.follow = !o.follow
+		if o.follow {
+			o.scroll = 999999
+		}
 		return o, nil
 	}
 
@@ -125,36 +226,28 @@ func (o OutputPanel) View(width, height int, focused bool) string {
 	if o.title != "" {
 		panelTitle = o.title
 	}
+	if o.Following() {
+		panelTitle += " (following)"
+	}
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
 		Render(" " + panelTitle + " ")
 
-	innerWidth := width - 2
-	innerHeight := height - 3
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	// Leave a row for the title.
+	innerWidth, innerHeight := layout.Inner(width, height-1)
 
 	var lines []string
 
 	if o.content == "" {
 		lines = append(lines, theme.NormalItemStyle.Render("No output"))
 	} else {
-		allLines := strings.Split(o.content, "\n")
+		// Only the visible window is rendered; the split is done once in
+		// setText rather than on every frame.
+		allLines := o.lines
 
 		// Clamp scroll.
-		maxScroll := len(allLines) - innerHeight
-		if maxScroll < 0 {
-			maxScroll = 0
-		}
-		scroll := o.scroll
-		if scroll > maxScroll {
-			scroll = maxScroll
-		}
+		scroll := layout.Clamp(o.scroll, 0, len(allLines)-innerHeight)
 
 		for i := scroll; i < len(allLines) && len(lines) < innerHeight; i++ {
 			line := theme.Truncate(allLines[i], innerWidth)
@@ -163,13 +256,8 @@ func (o OutputPanel) View(width, height int, focused bool) string {
 	}
 
 	// Pad to fill.
-	totalHeight := height - 2
-	if totalHeight < 0 {
-		totalHeight = 0
-	}
-	for len(lines) < totalHeight-1 {
-		lines = append(lines, "")
-	}
+	totalHeight := max(height-2, 0)
+	lines = layout.Pad(lines, totalHeight-1)
 
 	content := strings.Join(lines, "\n")
 
@@ -184,6 +272,7 @@ func (o OutputPanel) HelpBindings() []HelpBinding {
 	return []HelpBinding{
 		{Key: "j/k", Desc: "scroll"},
 		{Key: "g/G", Desc: "top/bottom"},
+		{Key: "F", Desc: "follow"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "next panel"},
 	}
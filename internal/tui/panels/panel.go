@@ -2,7 +2,11 @@
 // implementations for the three-panel TUI layout.
 package panels
 
-import tea "charm.land/bubbletea/v2"
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+)
 
 // Panel is the interface all detail/context panels implement.
 type Panel interface {
@@ -19,6 +23,21 @@ type Panel interface {
 	HelpBindings() []HelpBinding
 }
 
+// Loader is implemented by panels that fetch their data, so the tab bar
+// can show that the active tab is still loading.
+type Loader interface {
+	Loading() bool
+}
+
+// countTitle returns a list panel's title text with its number of items,
+// e.g. " Deployments (37) ", or just its name while the list loads.
+func countTitle(name string, n int, loading bool) string {
+	if loading && n == 0 {
+		return " " + name + " "
+	}
+	return fmt.Sprintf(" %s (%d) ", name, n)
+}
+
 // HelpBinding pairs a key label with a short description for the help bar.
 type HelpBinding struct {
 	Key  string
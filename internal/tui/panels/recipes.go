@@ -0,0 +1,327 @@
+package panels
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// --- Messages ---
+
+// RecipesLoadedMsg is sent when the recipe list has been fetched.
+type RecipesLoadedMsg struct {
+	Recipes []forge.Recipe
+}
+
+// RecipeCreatedMsg is sent when a recipe has been created.
+type RecipeCreatedMsg struct {
+	Recipe *forge.Recipe
+}
+
+// RecipeScriptMsg carries a recipe's script, fetched when the user presses
+// Enter on it.
+type RecipeScriptMsg struct {
+	Name   string
+	User   string
+	Script string
+}
+
+// RecipesPanel shows the account's recipes. Recipes belong to the account
+// rather than a server; the tab lists them from any server.
+type RecipesPanel struct {
+	client *forge.Client
+
+	recipes []forge.Recipe
+	cursor  int // index into shown()
+	loading bool
+	order   tableSort
+
+	// Keybindings
+	up    key.Binding
+	down  key.Binding
+	home  key.Binding
+	end   key.Binding
+	sort  key.Binding
+	enter key.Binding
+}
+
+// recipesSort are the columns the recipes table can be sorted by.
+var recipesSort = []sortColumn[forge.Recipe]{
+	{name: "name", cmp: func(a, b forge.Recipe) int { return compareFold(a.Name, b.Name) }},
+	{name: "user", cmp: func(a, b forge.Recipe) int { return strings.Compare(a.User, b.User) }},
+	{name: "created", cmp: func(a, b forge.Recipe) int { return compareTimestamps(a.CreatedAt, b.CreatedAt) }},
+}
+
+// NewRecipesPanel creates a new RecipesPanel.
+func NewRecipesPanel(client *forge.Client) RecipesPanel {
+	return RecipesPanel{
+		client:  client,
+		loading: true,
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		home: key.NewBinding(
+			key.WithKeys("g", "home"),
+			key.WithHelp("g", "top"),
+		),
+		end: key.NewBinding(
+			key.WithKeys("G", "end"),
+			key.WithHelp("G", "bottom"),
+		),
+		enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "view script"),
+		),
+		sort: sortKey,
+	}
+}
+
+// LoadRecipes returns a tea.Cmd that fetches the recipe list.
+func (p RecipesPanel) LoadRecipes() tea.Cmd {
+	client := p.client
+	return func() tea.Msg {
+		recipes, err := client.Recipes.List(context.Background())
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return RecipesLoadedMsg{Recipes: recipes}
+	}
+}
+
+// CreateRecipe returns a tea.Cmd that creates a recipe.
+func (p RecipesPanel) CreateRecipe(opts forge.RecipeCreateOpts) tea.Cmd {
+	client := p.client
+	return func() tea.Msg {
+		recipe, err := client.Recipes.Create(context.Background(), opts)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return RecipeCreatedMsg{Recipe: recipe}
+	}
+}
+
+// LoadScript returns a tea.Cmd that fetches the selected recipe's script.
+func (p RecipesPanel) LoadScript() tea.Cmd {
+	recipe := p.SelectedRecipe()
+	if recipe == nil {
+		return nil
+	}
+	client := p.client
+	recipeID := recipe.ID
+	return func() tea.Msg {
+		r, err := client.Recipes.Get(context.Background(), recipeID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return RecipeScriptMsg{Name: r.Name, User: r.User, Script: r.Script}
+	}
+}
+
+// shown returns the recipes in the order the table shows them.
+func (p RecipesPanel) shown() []forge.Recipe {
+	return sortRows(p.recipes, recipesSort, p.order)
+}
+
+// SelectedRecipe returns the currently selected recipe, or nil.
+func (p RecipesPanel) SelectedRecipe() *forge.Recipe {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
+		return nil
+	}
+	r := shown[p.cursor]
+	return &r
+}
+
+// Loading reports whether the panel's data is still being fetched.
+func (p RecipesPanel) Loading() bool {
+	return p.loading
+}
+
+// Update handles messages for the recipes panel.
+func (p RecipesPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case RecipesLoadedMsg:
+		old := p.shown()
+		p.recipes = msg.Recipes
+		p.cursor = keepCursor(old, p.shown(), p.cursor, recipeID)
+		p.loading = false
+		return p, nil
+
+	case tea.KeyPressMsg:
+		return p.handleKey(msg)
+	}
+
+	return p, nil
+}
+
+func recipeID(x forge.Recipe) int64 { return x.ID }
+
+func (p RecipesPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(recipesSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, recipeID)
+		return p, nil
+
+	case key.Matches(msg, p.down):
+		if len(p.recipes) > 0 {
+			p.cursor = min(p.cursor+1, len(p.recipes)-1)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.up):
+		if len(p.recipes) > 0 {
+			p.cursor = max(p.cursor-1, 0)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.home):
+		p.cursor = 0
+		return p, nil
+
+	case key.Matches(msg, p.end):
+		if len(p.recipes) > 0 {
+			p.cursor = len(p.recipes) - 1
+		}
+		return p, nil
+
+	case key.Matches(msg, p.enter):
+		return p, p.LoadScript()
+	}
+
+	return p, nil
+}
+
+// View renders the recipes panel.
+func (p RecipesPanel) View(width, height int, focused bool) string {
+	style := theme.InactiveBorderStyle
+	titleColor := theme.ColorSubtle
+	if focused {
+		style = theme.ActiveBorderStyle
+		titleColor = theme.ColorPrimary
+	}
+
+	innerWidth, innerHeight := layout.Inner(width, height)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(titleColor).
+		Render(countTitle("Recipes", len(p.recipes), p.loading)) +
+		sortChip(recipesSort, p.order)
+
+	content := p.renderList(innerWidth, innerHeight-1)
+
+	return style.
+		Width(innerWidth).
+		Height(innerHeight).
+		Render(title + "\n" + content)
+}
+
+// Column widths for the recipes table.
+const (
+	recipeColUserWidth    = 8
+	recipeColCreatedWidth = 12
+)
+
+const recipeTableOverhead = 2 + 2 + recipeColUserWidth + 2 + recipeColCreatedWidth + 4
+
+func recipeNameWidth(maxWidth int) int {
+	return layout.Columns(maxWidth, recipeTableOverhead, 10)
+}
+
+func (p RecipesPanel) renderList(width, height int) string {
+	var lines []string
+
+	if p.loading && len(p.recipes) == 0 {
+		lines = append(lines, theme.LoadingStyle.Render("Loading recipes..."))
+	} else if len(p.recipes) == 0 {
+		lines = append(lines, theme.NormalItemStyle.Render("No recipes found"))
+	} else {
+		lines = append(lines, p.renderRecipeHeader(width))
+
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
+
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			lines = append(lines, p.renderRecipeLine(shown[i], i, width))
+		}
+	}
+
+	lines = layout.Pad(lines, height)
+
+	return strings.Join(lines, "\n")
+}
+
+func (p RecipesPanel) renderRecipeHeader(maxWidth int) string {
+	nameWidth := recipeNameWidth(maxWidth)
+	line := fmt.Sprintf("  %-*s  %-*s  %-*s",
+		nameWidth, "NAME",
+		recipeColUserWidth, "USER",
+		recipeColCreatedWidth, "CREATED",
+	)
+	return theme.Truncate(headerStyle.Render(line), maxWidth)
+}
+
+func (p RecipesPanel) renderRecipeLine(recipe forge.Recipe, idx, maxWidth int) string {
+	name := recipe.Name
+	if name == "" {
+		name = "-"
+	}
+	user := recipe.User
+	if user == "" {
+		user = "root"
+	}
+	created := localDate(recipe.CreatedAt)
+	if created == "" {
+		created = "-"
+	}
+
+	nameWidth := recipeNameWidth(maxWidth)
+	nameStr := fmt.Sprintf("%-*s", nameWidth, truncatePlain(name, nameWidth))
+	userStr := fmt.Sprintf("%-*s", recipeColUserWidth, truncatePlain(user, recipeColUserWidth))
+	createdStr := fmt.Sprintf("%-*s", recipeColCreatedWidth, truncatePlain(created, recipeColCreatedWidth))
+
+	if idx == p.cursor {
+		line := theme.CursorStyle.Render("> ") +
+			theme.SelectedItemStyle.Render(nameStr) +
+			"  " + theme.NormalItemStyle.Render(userStr) +
+			"  " + theme.NormalItemStyle.Render(createdStr)
+		return theme.Truncate(line, maxWidth)
+	}
+
+	line := "  " +
+		theme.NormalItemStyle.Render(nameStr) +
+		"  " + theme.NormalItemStyle.Render(userStr) +
+		"  " + theme.NormalItemStyle.Render(createdStr)
+	return theme.Truncate(line, maxWidth)
+}
+
+// HelpBindings returns the key hints for the recipes panel.
+func (p RecipesPanel) HelpBindings() []HelpBinding {
+	return []HelpBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "enter", Desc: "script"},
+		{Key: "r", Desc: "run"},
+		{Key: "c", Desc: "create"},
+		{Key: "s", Desc: "sort"},
+		{Key: "g/G", Desc: "top/bottom"},
+		{Key: "esc", Desc: "back"},
+		{Key: "tab", Desc: "switch panel"},
+		{Key: "q", Desc: "quit"},
+	}
+}
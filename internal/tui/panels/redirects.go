@@ -0,0 +1,344 @@
+package panels
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// --- Messages ---
+
+// RedirectsLoadedMsg is sent when the redirect rule list has been fetched.
+type RedirectsLoadedMsg struct {
+	Rules []forge.RedirectRule
+}
+
+// RedirectCreatedMsg is sent when a redirect rule has been created.
+type RedirectCreatedMsg struct {
+	Rule *forge.RedirectRule
+}
+
+// RedirectDeletedMsg is sent when a redirect rule has been deleted.
+type RedirectDeletedMsg struct{}
+
+// RedirectsPanel shows a site's redirect rules with create and delete
+// actions. It is a sub-view of the Domains tab.
+type RedirectsPanel struct {
+	client   *forge.Client
+	serverID int64
+	siteID   int64
+
+	rules   []forge.RedirectRule
+	cursor  int // index into shown()
+	loading bool
+	order   tableSort
+
+	// Keybindings
+	up   key.Binding
+	down key.Binding
+	home key.Binding
+	end  key.Binding
+	sort key.Binding
+}
+
+// redirectsSort are the columns the redirects table can be sorted by.
+var redirectsSort = []sortColumn[forge.RedirectRule]{
+	{name: "status", cmp: func(a, b forge.RedirectRule) int { return compareFold(a.Status, b.Status) }},
+	{name: "from", cmp: func(a, b forge.RedirectRule) int { return strings.Compare(a.From, b.From) }},
+	{name: "to", cmp: func(a, b forge.RedirectRule) int { return strings.Compare(a.To, b.To) }},
+	{name: "type", cmp: func(a, b forge.RedirectRule) int { return strings.Compare(a.Type, b.Type) }},
+}
+
+// NewRedirectsPanel creates a new RedirectsPanel.
+func NewRedirectsPanel(client *forge.Client, serverID, siteID int64) RedirectsPanel {
+	return RedirectsPanel{
+		client:   client,
+		serverID: serverID,
+		siteID:   siteID,
+		loading:  true,
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		home: key.NewBinding(
+			key.WithKeys("g", "home"),
+			key.WithHelp("g", "top"),
+		),
+		end: key.NewBinding(
+			key.WithKeys("G", "end"),
+			key.WithHelp("G", "bottom"),
+		),
+		sort: sortKey,
+	}
+}
+
+// LoadRedirects returns a tea.Cmd that fetches the redirect rule list.
+func (p RedirectsPanel) LoadRedirects() tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	return func() tea.Msg {
+		rules, err := client.Redirects.List(context.Background(), serverID, siteID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return RedirectsLoadedMsg{Rules: rules}
+	}
+}
+
+// CreateRedirect returns a tea.Cmd that creates a redirect rule.
+// An empty opts.Type creates a permanent (301) redirect.
+func (p RedirectsPanel) CreateRedirect(opts forge.RedirectCreateOpts) tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	if opts.Type == "" {
+		opts.Type = forge.RedirectPermanent
+	}
+	return func() tea.Msg {
+		rule, err := client.Redirects.Create(context.Background(), serverID, siteID, opts)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return RedirectCreatedMsg{Rule: rule}
+	}
+}
+
+// DeleteRedirect returns a tea.Cmd that deletes the currently selected
+// redirect rule.
+func (p RedirectsPanel) DeleteRedirect() tea.Cmd {
+	r := p.SelectedRedirect()
+	if r == nil {
+		return nil
+	}
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	ruleID := r.ID
+	return func() tea.Msg {
+		err := client.Redirects.Delete(context.Background(), serverID, siteID, ruleID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return RedirectDeletedMsg{}
+	}
+}
+
+// shown returns the redirect rules in the order the table shows them.
+func (p RedirectsPanel) shown() []forge.RedirectRule {
+	return sortRows(p.rules, redirectsSort, p.order)
+}
+
+// SelectedRedirect returns the currently selected redirect rule, or nil.
+func (p RedirectsPanel) SelectedRedirect() *forge.RedirectRule {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
+		return nil
+	}
+	r := shown[p.cursor]
+	return &r
+}
+
+// Loading reports whether the panel's data is still being fetched.
+func (p RedirectsPanel) Loading() bool {
+	return p.loading
+}
+
+// Update handles messages for the redirects panel.
+func (p RedirectsPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case RedirectsLoadedMsg:
+		old := p.shown()
+		p.rules = msg.Rules
+		p.cursor = keepCursor(old, p.shown(), p.cursor, redirectID)
+		p.loading = false
+		return p, nil
+
+	case tea.KeyPressMsg:
+		return p.handleKey(msg)
+	}
+
+	return p, nil
+}
+
+func redirectID(x forge.RedirectRule) int64 { return x.ID }
+
+func (p RedirectsPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(redirectsSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, redirectID)
+		return p, nil
+
+	case key.Matches(msg, p.down):
+		if len(p.rules) > 0 {
+			p.cursor = min(p.cursor+1, len(p.rules)-1)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.up):
+		if len(p.rules) > 0 {
+			p.cursor = max(p.cursor-1, 0)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.home):
+		p.cursor = 0
+		return p, nil
+
+	case key.Matches(msg, p.end):
+		if len(p.rules) > 0 {
+			p.cursor = len(p.rules) - 1
+		}
+		return p, nil
+
+	// 'c', 'x' are handled by the app layer.
+	}
+
+	return p, nil
+}
+
+// View renders the redirects panel.
+func (p RedirectsPanel) View(width, height int, focused bool) string {
+	style := theme.InactiveBorderStyle
+	titleColor := theme.ColorSubtle
+	if focused {
+		style = theme.ActiveBorderStyle
+		titleColor = theme.ColorPrimary
+	}
+
+	innerWidth, innerHeight := layout.Inner(width, height)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(titleColor).
+		Render(countTitle("Redirect Rules", len(p.rules), p.loading)) +
+		sortChip(redirectsSort, p.order)
+
+	content := p.renderList(innerWidth, innerHeight-1)
+
+	return style.
+		Width(innerWidth).
+		Height(innerHeight).
+		Render(title + "\n" + content)
+}
+
+// redirectColTypeWidth is the width of the redirects table's TYPE column.
+const redirectColTypeWidth = 9
+
+const redirectTableOverhead = 2 + colStatusWidth + 2 + 2 + 2 + redirectColTypeWidth + 4
+
+// redirectURLWidth returns the width of each of the FROM and TO columns,
+// which share what the other columns leave.
+func redirectURLWidth(maxWidth int) int {
+	return layout.Columns(maxWidth, redirectTableOverhead, 20) / 2
+}
+
+// redirectTypeLabel describes a rule type with its status code, e.g.
+// "301 perm".
+func redirectTypeLabel(t string) string {
+	switch t {
+	case forge.RedirectPermanent:
+		return "301 perm"
+	case forge.RedirectTemporary:
+		return "302 temp"
+	}
+	return t
+}
+
+func (p RedirectsPanel) renderList(width, height int) string {
+	var lines []string
+
+	if p.loading && len(p.rules) == 0 {
+		lines = append(lines, theme.LoadingStyle.Render("Loading redirect rules..."))
+	} else if len(p.rules) == 0 {
+		lines = append(lines, theme.NormalItemStyle.Render("No redirect rules found"))
+	} else {
+		lines = append(lines, p.renderRedirectHeader(width))
+
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
+
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			lines = append(lines, p.renderRedirectLine(shown[i], i, width))
+		}
+	}
+
+	lines = layout.Pad(lines, height)
+
+	return strings.Join(lines, "\n")
+}
+
+func (p RedirectsPanel) renderRedirectHeader(maxWidth int) string {
+	urlW := redirectURLWidth(maxWidth)
+	line := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s",
+		colStatusWidth, "STATUS",
+		urlW, "FROM",
+		urlW, "TO",
+		redirectColTypeWidth, "TYPE",
+	)
+	return theme.Truncate(headerStyle.Render(line), maxWidth)
+}
+
+func (p RedirectsPanel) renderRedirectLine(r forge.RedirectRule, idx, maxWidth int) string {
+	icon := statusIcon(r.Status)
+	statusText := r.Status
+	if statusText == "" {
+		statusText = "unknown"
+	}
+	to := r.To
+	if to == "" {
+		to = "-"
+	}
+
+	urlW := redirectURLWidth(maxWidth)
+	statusPad := colStatusWidth - 2
+	statusStr := icon + " " + fmt.Sprintf("%-*s", statusPad, truncatePlain(statusText, statusPad))
+	fromStr := fmt.Sprintf("%-*s", urlW, truncatePlain(r.From, urlW))
+	toStr := fmt.Sprintf("%-*s", urlW, truncatePlain(to, urlW))
+	typeStr := fmt.Sprintf("%-*s", redirectColTypeWidth, truncatePlain(redirectTypeLabel(r.Type), redirectColTypeWidth))
+
+	if idx == p.cursor {
+		line := theme.CursorStyle.Render("> ") +
+			statusStr +
+			"  " + theme.SelectedItemStyle.Render(fromStr) +
+			"  " + theme.NormalItemStyle.Render(toStr) +
+			"  " + theme.NormalItemStyle.Render(typeStr)
+		return theme.Truncate(line, maxWidth)
+	}
+
+	line := "  " +
+		statusStr +
+		"  " + theme.NormalItemStyle.Render(fromStr) +
+		"  " + theme.NormalItemStyle.Render(toStr) +
+		"  " + theme.NormalItemStyle.Render(typeStr)
+	return theme.Truncate(line, maxWidth)
+}
+
+// HelpBindings returns the key hints for the redirects panel.
+func (p RedirectsPanel) HelpBindings() []HelpBinding {
+	return []HelpBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "c", Desc: "create redirect"},
+		{Key: "x", Desc: "delete"},
+		{Key: "s", Desc: "sort"},
+		{Key: "g/G", Desc: "top/bottom"},
+		{Key: "esc", Desc: "back"},
+		{Key: "tab", Desc: "switch panel"},
+		{Key: "q", Desc: "quit"},
+	}
+}
@@ -0,0 +1,345 @@
+package panels
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// --- Messages ---
+
+// SecurityRulesLoadedMsg is sent when the security rule list has been
+// fetched.
+type SecurityRulesLoadedMsg struct {
+	Rules []forge.SecurityRule
+}
+
+// SecurityRuleCreatedMsg is sent when a security rule has been created.
+type SecurityRuleCreatedMsg struct {
+	Rule *forge.SecurityRule
+}
+
+// SecurityRuleDeletedMsg is sent when a security rule has been deleted.
+type SecurityRuleDeletedMsg struct{}
+
+// SecurityRulesPanel shows a site's security rules, the paths it guards
+// with HTTP basic authentication, with create and delete actions. It is a
+// sub-view of the Domains tab.
+type SecurityRulesPanel struct {
+	client   *forge.Client
+	serverID int64
+	siteID   int64
+
+	rules   []forge.SecurityRule
+	cursor  int // index into shown()
+	loading bool
+	order   tableSort
+
+	// Keybindings
+	up   key.Binding
+	down key.Binding
+	home key.Binding
+	end  key.Binding
+	sort key.Binding
+}
+
+// securitySort are the columns the security rules table can be sorted by.
+var securitySort = []sortColumn[forge.SecurityRule]{
+	{name: "status", cmp: func(a, b forge.SecurityRule) int { return compareFold(a.Status, b.Status) }},
+	{name: "name", cmp: func(a, b forge.SecurityRule) int { return compareFold(a.Name, b.Name) }},
+	{name: "path", cmp: func(a, b forge.SecurityRule) int { return strings.Compare(a.Path, b.Path) }},
+	{name: "users", cmp: func(a, b forge.SecurityRule) int { return cmp.Compare(len(a.Credentials), len(b.Credentials)) }},
+}
+
+// NewSecurityRulesPanel creates a new SecurityRulesPanel.
+func NewSecurityRulesPanel(client *forge.Client, serverID, siteID int64) SecurityRulesPanel {
+	return SecurityRulesPanel{
+		client:   client,
+		serverID: serverID,
+		siteID:   siteID,
+		loading:  true,
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		home: key.NewBinding(
+			key.WithKeys("g", "home"),
+			key.WithHelp("g", "top"),
+		),
+		end: key.NewBinding(
+			key.WithKeys("G", "end"),
+			key.WithHelp("G", "bottom"),
+		),
+		sort: sortKey,
+	}
+}
+
+// LoadRules returns a tea.Cmd that fetches the security rule list.
+func (p SecurityRulesPanel) LoadRules() tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	return func() tea.Msg {
+		rules, err := client.Security.List(context.Background(), serverID, siteID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return SecurityRulesLoadedMsg{Rules: rules}
+	}
+}
+
+// CreateRule returns a tea.Cmd that creates a security rule.
+func (p SecurityRulesPanel) CreateRule(opts forge.SecurityRuleCreateOpts) tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	return func() tea.Msg {
+		rule, err := client.Security.Create(context.Background(), serverID, siteID, opts)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return SecurityRuleCreatedMsg{Rule: rule}
+	}
+}
+
+// DeleteRule returns a tea.Cmd that deletes the currently selected
+// security rule.
+func (p SecurityRulesPanel) DeleteRule() tea.Cmd {
+	r := p.SelectedRule()
+	if r == nil {
+		return nil
+	}
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	ruleID := r.ID
+	return func() tea.Msg {
+		err := client.Security.Delete(context.Background(), serverID, siteID, ruleID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return SecurityRuleDeletedMsg{}
+	}
+}
+
+// shown returns the security rules in the order the table shows them.
+func (p SecurityRulesPanel) shown() []forge.SecurityRule {
+	return sortRows(p.rules, securitySort, p.order)
+}
+
+// SelectedRule returns the currently selected security rule, or nil.
+func (p SecurityRulesPanel) SelectedRule() *forge.SecurityRule {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
+		return nil
+	}
+	r := shown[p.cursor]
+	return &r
+}
+
+// Loading reports whether the panel's data is still being fetched.
+func (p SecurityRulesPanel) Loading() bool {
+	return p.loading
+}
+
+// Update handles messages for the security rules panel.
+func (p SecurityRulesPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SecurityRulesLoadedMsg:
+		old := p.shown()
+		p.rules = msg.Rules
+		p.cursor = keepCursor(old, p.shown(), p.cursor, securityRuleID)
+		p.loading = false
+		return p, nil
+
+	case tea.KeyPressMsg:
+		return p.handleKey(msg)
+	}
+
+	return p, nil
+}
+
+func securityRuleID(x forge.SecurityRule) int64 { return x.ID }
+
+func (p SecurityRulesPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(securitySort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, securityRuleID)
+		return p, nil
+
+	case key.Matches(msg, p.down):
+		if len(p.rules) > 0 {
+			p.cursor = min(p.cursor+1, len(p.rules)-1)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.up):
+		if len(p.rules) > 0 {
+			p.cursor = max(p.cursor-1, 0)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.home):
+		p.cursor = 0
+		return p, nil
+
+	case key.Matches(msg, p.end):
+		if len(p.rules) > 0 {
+			p.cursor = len(p.rules) - 1
+		}
+		return p, nil
+
+	// 'c', 'x' are handled by the app layer.
+	}
+
+	return p, nil
+}
+
+// View renders the security rules panel.
+func (p SecurityRulesPanel) View(width, height int, focused bool) string {
+	style := theme.InactiveBorderStyle
+	titleColor := theme.ColorSubtle
+	if focused {
+		style = theme.ActiveBorderStyle
+		titleColor = theme.ColorPrimary
+	}
+
+	innerWidth, innerHeight := layout.Inner(width, height)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(titleColor).
+		Render(countTitle("Security Rules", len(p.rules), p.loading)) +
+		sortChip(securitySort, p.order)
+
+	content := p.renderList(innerWidth, innerHeight-1)
+
+	return style.
+		Width(innerWidth).
+		Height(innerHeight).
+		Render(title + "\n" + content)
+}
+
+// Column widths for the security rules table.
+const (
+	securityColNameWidth = 16
+	securityColPathWidth = 20
+)
+
+const securityTableOverhead = 2 + colStatusWidth + 2 + securityColNameWidth + 2 + securityColPathWidth + 2 + 4
+
+func securityUsersWidth(maxWidth int) int {
+	return layout.Columns(maxWidth, securityTableOverhead, 10)
+}
+
+// securityUsers lists the usernames allowed through a rule.
+func securityUsers(r forge.SecurityRule) string {
+	names := make([]string, len(r.Credentials))
+	for i, c := range r.Credentials {
+		names[i] = c.Username
+	}
+	return strings.Join(names, ", ")
+}
+
+func (p SecurityRulesPanel) renderList(width, height int) string {
+	var lines []string
+
+	if p.loading && len(p.rules) == 0 {
+		lines = append(lines, theme.LoadingStyle.Render("Loading security rules..."))
+	} else if len(p.rules) == 0 {
+		lines = append(lines, theme.NormalItemStyle.Render("No security rules found"))
+	} else {
+		lines = append(lines, p.renderRuleHeader(width))
+
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
+
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			lines = append(lines, p.renderRuleLine(shown[i], i, width))
+		}
+	}
+
+	lines = layout.Pad(lines, height)
+
+	return strings.Join(lines, "\n")
+}
+
+func (p SecurityRulesPanel) renderRuleHeader(maxWidth int) string {
+	line := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s",
+		colStatusWidth, "STATUS",
+		securityColNameWidth, "NAME",
+		securityColPathWidth, "PATH",
+		securityUsersWidth(maxWidth), "USERS",
+	)
+	return theme.Truncate(headerStyle.Render(line), maxWidth)
+}
+
+func (p SecurityRulesPanel) renderRuleLine(r forge.SecurityRule, idx, maxWidth int) string {
+	icon := statusIcon(r.Status)
+	statusText := r.Status
+	if statusText == "" {
+		statusText = "unknown"
+	}
+	// A rule without a path guards the whole site.
+	path := r.Path
+	if path == "" {
+		path = "/"
+	}
+	users := securityUsers(r)
+	if users == "" {
+		users = "-"
+	}
+
+	usersW := securityUsersWidth(maxWidth)
+	statusPad := colStatusWidth - 2
+	statusStr := icon + " " + fmt.Sprintf("%-*s", statusPad, truncatePlain(statusText, statusPad))
+	nameStr := fmt.Sprintf("%-*s", securityColNameWidth, truncatePlain(r.Name, securityColNameWidth))
+	pathStr := fmt.Sprintf("%-*s", securityColPathWidth, truncatePlain(path, securityColPathWidth))
+	usersStr := fmt.Sprintf("%-*s", usersW, truncatePlain(users, usersW))
+
+	if idx == p.cursor {
+		line := theme.CursorStyle.Render("> ") +
+			statusStr +
+			"  " + theme.SelectedItemStyle.Render(nameStr) +
+			"  " + theme.NormalItemStyle.Render(pathStr) +
+			"  " + theme.NormalItemStyle.Render(usersStr)
+		return theme.Truncate(line, maxWidth)
+	}
+
+	line := "  " +
+		statusStr +
+		"  " + theme.NormalItemStyle.Render(nameStr) +
+		"  " + theme.NormalItemStyle.Render(pathStr) +
+		"  " + theme.NormalItemStyle.Render(usersStr)
+	return theme.Truncate(line, maxWidth)
+}
+
+// HelpBindings returns the key hints for the security rules panel.
+func (p SecurityRulesPanel) HelpBindings() []HelpBinding {
+	return []HelpBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "c", Desc: "create rule"},
+		{Key: "x", Desc: "delete"},
+		{Key: "s", Desc: "sort"},
+		{Key: "g/G", Desc: "top/bottom"},
+		{Key: "esc", Desc: "back"},
+		{Key: "tab", Desc: "switch panel"},
+		{Key: "q", Desc: "quit"},
+	}
+}
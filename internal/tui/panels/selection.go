@@ -0,0 +1,16 @@
+package panels
+
+// keepCursor returns the cursor position to use after a list is reloaded from
+// old to items: the new index of the item that was selected, matched by
+// key, or the old position clamped to the new list when that item is gone.
+func keepCursor[T any, K comparable](old, items []T, cursor int, key func(T) K) int {
+	if cursor >= 0 && cursor < len(old) {
+		want := key(old[cursor])
+		for i, item := range items {
+			if key(item) == want {
+				return i
+			}
+		}
+	}
+	return max(min(cursor, len(items)-1), 0)
+}
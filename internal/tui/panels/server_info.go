@@ -8,6 +8,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -46,14 +47,7 @@ func (s ServerInfo) View(width, height int, focused bool) string {
 		Foreground(titleColor).
 		Render(" Server ")
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	var lines []string
 
@@ -67,6 +61,7 @@ func (s ServerInfo) View(width, height int, focused bool) string {
 		lines = append(lines, renderInfoKV("Provider", srv.Provider, innerWidth))
 		lines = append(lines, renderInfoKV("Region", srv.Region, innerWidth))
 		lines = append(lines, renderInfoKV("PHP", srv.PHPVersion, innerWidth))
+		lines = append(lines, renderInfoKV("OPcache", srv.OPcacheStatus, innerWidth))
 		lines = append(lines, renderInfoKV("Ubuntu", srv.UbuntuVersion, innerWidth))
 		lines = append(lines, renderInfoKV("DB Type", srv.DatabaseType, innerWidth))
 		lines = append(lines, renderInfoKV("DB Status", srv.DBStatus, innerWidth))
@@ -76,9 +71,7 @@ func (s ServerInfo) View(width, height int, focused bool) string {
 	}
 
 	// Pad to fill the panel height.
-	for len(lines) < innerHeight-1 {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, innerHeight-1)
 
 	content := strings.Join(lines, "\n")
 
@@ -88,9 +81,12 @@ func (s ServerInfo) View(width, height int, focused bool) string {
 		Render(title + "\n" + content)
 }
 
-// HelpBindings returns the key hints for the server info panel.
+// HelpBindings returns the key hints for the server info panel. u and o
+// are handled by the app layer.
 func (s ServerInfo) HelpBindings() []HelpBinding {
 	return []HelpBinding{
+		{Key: "u", Desc: "max upload"},
+		{Key: "o", Desc: "toggle OPcache"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
 		{Key: "q", Desc: "quit"},
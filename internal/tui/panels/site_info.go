@@ -7,6 +7,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -45,14 +46,7 @@ func (s SiteInfo) View(width, height int, focused bool) string {
 		Foreground(titleColor).
 		Render(" Site ")
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	var lines []string
 
@@ -71,6 +65,7 @@ func (s SiteInfo) View(width, height int, focused bool) string {
 		lines = append(lines, renderStatusKV("Status", site.Status, innerWidth))
 		lines = append(lines, renderInfoKV("Quick Deploy", boolToOnOff(site.QuickDeploy), innerWidth))
 		lines = append(lines, renderInfoKV("SSL", sslStatus(site.IsSecured), innerWidth))
+		lines = append(lines, renderInfoKV("Wildcards", boolToOnOff(site.Wildcards), innerWidth))
 
 		// Show aliases if any.
 		if len(site.Aliases) > 0 {
@@ -84,9 +79,7 @@ func (s SiteInfo) View(width, height int, focused bool) string {
 	}
 
 	// Pad to fill the panel height.
-	for len(lines) < innerHeight-1 {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, innerHeight-1)
 
 	content := strings.Join(lines, "\n")
 
@@ -96,9 +89,12 @@ func (s SiteInfo) View(width, height int, focused bool) string {
 		Render(title + "\n" + content)
 }
 
-// HelpBindings returns the key hints for the site info panel.
+// HelpBindings returns the key hints for the site info panel. R and W
+// are handled by the app layer.
 func (s SiteInfo) HelpBindings() []HelpBinding {
 	return []HelpBinding{
+		{Key: "R", Desc: "rename"},
+		{Key: "W", Desc: "web dir"},
 		{Key: "1-9", Desc: "sections"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
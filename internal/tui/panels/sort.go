@@ -0,0 +1,102 @@
+package panels
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// sortColumn is a column a table panel can be sorted by. cmp orders two
+// rows ascending, like the functions slices.SortFunc takes.
+type sortColumn[T any] struct {
+	name string
+	cmp  func(a, b T) int
+}
+
+// tableSort is the order a table panel shows its rows in. col is a
+// 1-based index into the panel's sort columns; the zero value keeps the
+// order the API returned them in.
+type tableSort struct {
+	col  int
+	desc bool
+}
+
+// sortKey is the binding that cycles a table panel's sort.
+var sortKey = key.NewBinding(
+	key.WithKeys("s"),
+	key.WithHelp("s", "sort"),
+)
+
+// next returns the sort that follows s among n columns: each column
+// ascending, then descending, then back to the API's order.
+func (s tableSort) next(n int) tableSort {
+	switch {
+	case s.col == 0:
+		return tableSort{col: 1}
+	case !s.desc:
+		return tableSort{col: s.col, desc: true}
+	case s.col < n:
+		return tableSort{col: s.col + 1}
+	}
+	return tableSort{}
+}
+
+// sortRows returns rows in the order s puts them in. Rows that compare
+// equal keep their order, and rows itself is left as it is.
+func sortRows[T any](rows []T, cols []sortColumn[T], s tableSort) []T {
+	if s.col < 1 || s.col > len(cols) {
+		return rows
+	}
+	compare := cols[s.col-1].cmp
+	out := slices.Clone(rows)
+	slices.SortStableFunc(out, func(a, b T) int {
+		if s.desc {
+			return compare(b, a)
+		}
+		return compare(a, b)
+	})
+	return out
+}
+
+// sortChip returns the title chip naming the sort, e.g. "[port ↑] ", or ""
+// for the API's order.
+func sortChip[T any](cols []sortColumn[T], s tableSort) string {
+	if s.col < 1 || s.col > len(cols) {
+		return ""
+	}
+	arrow := theme.GlyphAsc
+	if s.desc {
+		arrow = theme.GlyphDesc
+	}
+	return theme.FilterIndicatorStyle.Render("["+cols[s.col-1].name+" "+arrow+"]") + " "
+}
+
+// compareKnown orders a and b like cmp.Compare, with values that could not
+// be worked out (ok is false) after all the others.
+func compareKnown[T cmp.Ordered](a T, aok bool, b T, bok bool) int {
+	switch {
+	case aok && bok:
+		return cmp.Compare(a, b)
+	case aok:
+		return -1
+	case bok:
+		return 1
+	}
+	return 0
+}
+
+// compareTimestamps orders two API timestamps oldest first.
+func compareTimestamps(a, b string) int {
+	ta, aok := parseTimestamp(a)
+	tb, bok := parseTimestamp(b)
+	return compareKnown(ta.UnixNano(), aok, tb.UnixNano(), bok)
+}
+
+// compareFold orders two strings ignoring case.
+func compareFold(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
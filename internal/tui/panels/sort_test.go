@@ -0,0 +1,94 @@
+package panels
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/panels/testutil"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+func TestTableSortCycle(t *testing.T) {
+	want := []tableSort{
+		{col: 1},
+		{col: 1, desc: true},
+		{col: 2},
+		{col: 2, desc: true},
+		{},
+	}
+	var s tableSort
+	for i, w := range want {
+		s = s.next(2)
+		if s != w {
+			t.Fatalf("after %d presses sort = %+v, want %+v", i+1, s, w)
+		}
+	}
+}
+
+func TestFirewallSortByPort(t *testing.T) {
+	rules := []forge.FirewallRule{
+		{ID: 1, Port: "8000:8010"},
+		{ID: 2, Port: float64(22)},
+		{ID: 3, Port: nil},
+		{ID: 4, Port: "443"},
+	}
+	ids := func(rules []forge.FirewallRule) []int64 {
+		out := make([]int64, len(rules))
+		for i, r := range rules {
+			out[i] = r.ID
+		}
+		return out
+	}
+
+	if got := ids(sortRows(rules, firewallSort, tableSort{col: 1})); !slices.Equal(got, []int64{2, 4, 1, 3}) {
+		t.Errorf("by port ascending = %v, want [2 4 1 3]", got)
+	}
+	if got := ids(sortRows(rules, firewallSort, tableSort{col: 1, desc: true})); !slices.Equal(got, []int64{3, 1, 4, 2}) {
+		t.Errorf("by port descending = %v, want [3 1 4 2]", got)
+	}
+	if got := ids(sortRows(rules, firewallSort, tableSort{})); !slices.Equal(got, []int64{1, 2, 3, 4}) {
+		t.Errorf("unsorted = %v, want the API's order", got)
+	}
+}
+
+// TestSortKeepsSelection sorts every table panel and checks the sort is
+// named in its title and the selected row stays selected as the sort
+// changes under it.
+func TestSortKeepsSelection(t *testing.T) {
+	for _, lp := range listPanels {
+		if lp.header == "" {
+			continue
+		}
+		t.Run(lp.name, func(t *testing.T) {
+			p := loaded(t, lp)
+			p, _ = p.Update(testutil.Key("j"))
+			before := selectedRow(p)
+			if before == "" {
+				t.Fatal("no row selected")
+			}
+			for i := range 4 {
+				p, _ = p.Update(testutil.Key("s"))
+				if got := selectedRow(p); got != before {
+					t.Fatalf("selected %q after sorting, want %q", got, before)
+				}
+				if i == 0 && !strings.Contains(ansi.Strip(p.View(200, 20, true)), theme.GlyphAsc+"]") {
+					t.Errorf("sort not shown in the title:\n%s", ansi.Strip(p.View(200, 20, true)))
+				}
+			}
+		})
+	}
+}
+
+// selectedRow returns the text of the row p shows as selected.
+func selectedRow(p Panel) string {
+	for _, line := range strings.Split(ansi.Strip(p.View(200, 20, true)), "\n") {
+		if _, row, ok := strings.Cut(line, "> "); ok {
+			return strings.TrimSpace(row)
+		}
+	}
+	return ""
+}
@@ -10,6 +10,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -34,8 +35,9 @@ type SSHKeysPanel struct {
 	serverID int64
 
 	keys    []forge.SSHKey
-	cursor  int
+	cursor  int // index into shown()
 	loading bool
+	order   tableSort
 
 	// Keybindings
 	up     key.Binding
@@ -44,6 +46,13 @@ type SSHKeysPanel struct {
 	del    key.Binding
 	home   key.Binding
 	end    key.Binding
+	sort   key.Binding
+}
+
+// sshKeysSort are the columns the SSH keys table can be sorted by.
+var sshKeysSort = []sortColumn[forge.SSHKey]{
+	{name: "status", cmp: func(a, b forge.SSHKey) int { return compareFold(a.Status, b.Status) }},
+	{name: "name", cmp: func(a, b forge.SSHKey) int { return compareFold(a.Name, b.Name) }},
 }
 
 // NewSSHKeysPanel creates a new SSHKeysPanel.
@@ -76,6 +85,7 @@ func NewSSHKeysPanel(client *forge.Client, serverID int64) SSHKeysPanel {
 			key.WithKeys("G", "end"),
 			key.WithHelp("G", "bottom"),
 		),
+		sort: sortKey,
 	}
 }
 
@@ -107,12 +117,13 @@ func (p SSHKeysPanel) CreateKey(name, keyContent, username string) tea.Cmd {
 
 // DeleteKey returns a tea.Cmd that deletes the currently selected SSH key.
 func (p SSHKeysPanel) DeleteKey() tea.Cmd {
-	if len(p.keys) == 0 || p.cursor >= len(p.keys) {
+	k := p.SelectedKey()
+	if k == nil {
 		return nil
 	}
 	client := p.client
 	serverID := p.serverID
-	keyID := p.keys[p.cursor].ID
+	keyID := k.ID
 	return func() tea.Msg {
 		err := client.SSHKeys.Delete(context.Background(), serverID, keyID)
 		if err != nil {
@@ -122,22 +133,34 @@ func (p SSHKeysPanel) DeleteKey() tea.Cmd {
 	}
 }
 
+// shown returns the SSH keys in the order the table shows them.
+func (p SSHKeysPanel) shown() []forge.SSHKey {
+	return sortRows(p.keys, sshKeysSort, p.order)
+}
+
 // SelectedKey returns the currently selected SSH key, or nil.
 func (p SSHKeysPanel) SelectedKey() *forge.SSHKey {
-	if len(p.keys) == 0 || p.cursor >= len(p.keys) {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
 		return nil
 	}
-	k := p.keys[p.cursor]
+	k := shown[p.cursor]
 	return &k
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p SSHKeysPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the SSH keys panel.
 func (p SSHKeysPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case SSHKeysLoadedMsg:
+		old := p.shown()
 		p.keys = msg.Keys
+		p.cursor = keepCursor(old, p.shown(), p.cursor, sshKeyID)
 		p.loading = false
-		p.cursor = 0
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -147,8 +170,16 @@ func (p SSHKeysPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	return p, nil
 }
 
+func sshKeyID(x forge.SSHKey) int64 { return x.ID }
+
 func (p SSHKeysPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(sshKeysSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, sshKeyID)
+		return p, nil
+
 	case key.Matches(msg, p.down):
 		if len(p.keys) > 0 {
 			p.cursor = min(p.cursor+1, len(p.keys)-1)
@@ -186,19 +217,13 @@ func (p SSHKeysPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" SSH Keys ")
+		Render(countTitle("SSH Keys", len(p.keys), p.loading)) +
+		sortChip(sshKeysSort, p.order)
 
 	content := p.renderList(innerWidth, innerHeight-1)
 
@@ -211,11 +236,7 @@ func (p SSHKeysPanel) View(width, height int, focused bool) string {
 const sshKeyTableOverhead = 2 + colStatusWidth + 2 + 4
 
 func sshKeyNameWidth(maxWidth int) int {
-	w := maxWidth - sshKeyTableOverhead
-	if w < 10 {
-		w = 10
-	}
-	return w
+	return layout.Columns(maxWidth, sshKeyTableOverhead, 10)
 }
 
 func (p SSHKeysPanel) renderList(width, height int) string {
@@ -228,25 +249,18 @@ func (p SSHKeysPanel) renderList(width, height int) string {
 	} else {
 		lines = append(lines, p.renderKeyHeader(width))
 
-		visibleHeight := height - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
-		for i := startIdx; i < len(p.keys) && len(lines)-1 < visibleHeight; i++ {
-			k := p.keys[i]
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			k := shown[i]
 			line := p.renderKeyLine(k, i, width)
 			lines = append(lines, line)
 		}
 	}
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
@@ -296,8 +310,10 @@ func (p SSHKeysPanel) HelpBindings() []HelpBinding {
 	return []HelpBinding{
 		{Key: "j/k", Desc: "navigate"},
 		{Key: "c", Desc: "create"},
+		{Key: "p", Desc: "paste key"},
 		{Key: "x", Desc: "delete"},
 		{Key: "i", Desc: "install default key"},
+		{Key: "s", Desc: "sort"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
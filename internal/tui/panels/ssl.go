@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/bubbles/v2/key"
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -31,6 +33,18 @@ type CertActivatedMsg struct{}
 // CertDeletedMsg is sent when a certificate has been deleted.
 type CertDeletedMsg struct{}
 
+// CertRenewedMsg is sent when a certificate renewal has been requested.
+type CertRenewedMsg struct{}
+
+// CertExpiryMsg carries certificate expiry timestamps keyed by certificate
+// ID, fetched individually since the list endpoint omits them.
+type CertExpiryMsg struct {
+	Expiry map[int64]string
+}
+
+// certExpiryWarning is how close to expiry a certificate is highlighted.
+const certExpiryWarning = 30 * 24 * time.Hour
+
 // SSLPanel shows the SSL certificates for a site with CRUD actions.
 type SSLPanel struct {
 	client   *forge.Client
@@ -38,8 +52,10 @@ type SSLPanel struct {
 	siteID   int64
 
 	certificates []forge.Certificate
-	cursor       int
+	expiry       map[int64]string
+	cursor       int // index into shown()
 	loading      bool
+	order        tableSort
 
 	// Keybindings
 	up       key.Binding
@@ -49,6 +65,21 @@ type SSLPanel struct {
 	del      key.Binding
 	home     key.Binding
 	end      key.Binding
+	sort     key.Binding
+}
+
+// sortColumns returns the columns the certificates table can be sorted
+// by. Expiry dates are fetched after the certificates, so the expires
+// column reads them from p.
+func (p SSLPanel) sortColumns() []sortColumn[forge.Certificate] {
+	return []sortColumn[forge.Certificate]{
+		{name: "status", cmp: func(a, b forge.Certificate) int { return compareFold(a.Status, b.Status) }},
+		{name: "domain", cmp: func(a, b forge.Certificate) int { return strings.Compare(a.Domain, b.Domain) }},
+		{name: "type", cmp: func(a, b forge.Certificate) int { return strings.Compare(a.Type, b.Type) }},
+		{name: "expires", cmp: func(a, b forge.Certificate) int {
+			return compareTimestamps(p.expiry[a.ID], p.expiry[b.ID])
+		}},
+	}
 }
 
 // NewSSLPanel creates a new SSLPanel.
@@ -86,6 +117,7 @@ func NewSSLPanel(client *forge.Client, serverID, siteID int64) SSLPanel {
 			key.WithKeys("G", "end"),
 			key.WithHelp("G", "bottom"),
 		),
+		sort: sortKey,
 	}
 }
 
@@ -103,6 +135,48 @@ func (p SSLPanel) LoadCerts() tea.Cmd {
 	}
 }
 
+// LoadExpiry returns a tea.Cmd that fetches each certificate individually
+// to learn its expiry date.
+func (p SSLPanel) LoadExpiry() tea.Cmd {
+	if len(p.certificates) == 0 {
+		return nil
+	}
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	certs := p.certificates
+	return func() tea.Msg {
+		expiry := make(map[int64]string, len(certs))
+		for _, c := range certs {
+			cert, err := client.Certificates.Get(context.Background(), serverID, siteID, c.ID)
+			if err != nil {
+				continue
+			}
+			expiry[c.ID] = cert.ExpiresAt
+		}
+		return CertExpiryMsg{Expiry: expiry}
+	}
+}
+
+// RenewCert returns a tea.Cmd that renews the currently selected certificate.
+func (p SSLPanel) RenewCert() tea.Cmd {
+	cert := p.SelectedCert()
+	if cert == nil {
+		return nil
+	}
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	certID := cert.ID
+	return func() tea.Msg {
+		err := client.Certificates.Renew(context.Background(), serverID, siteID, certID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return CertRenewedMsg{}
+	}
+}
+
 // CreateLetsEncrypt returns a tea.Cmd that creates a Let's Encrypt certificate.
 func (p SSLPanel) CreateLetsEncrypt(domains []string) tea.Cmd {
 	client := p.client
@@ -117,15 +191,31 @@ func (p SSLPanel) CreateLetsEncrypt(domains []string) tea.Cmd {
 	}
 }
 
+// InstallCert returns a tea.Cmd that installs an existing certificate
+// from its PEM-encoded certificate and private key.
+func (p SSLPanel) InstallCert(certificate, key string) tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	siteID := p.siteID
+	return func() tea.Msg {
+		cert, err := client.Certificates.Install(context.Background(), serverID, siteID, certificate, key)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+		return CertCreatedMsg{Certificate: cert}
+	}
+}
+
 // ActivateCert returns a tea.Cmd that activates the currently selected certificate.
 func (p SSLPanel) ActivateCert() tea.Cmd {
-	if len(p.certificates) == 0 || p.cursor >= len(p.certificates) {
+	cert := p.SelectedCert()
+	if cert == nil {
 		return nil
 	}
 	client := p.client
 	serverID := p.serverID
 	siteID := p.siteID
-	certID := p.certificates[p.cursor].ID
+	certID := cert.ID
 	return func() tea.Msg {
 		err := client.Certificates.Activate(context.Background(), serverID, siteID, certID)
 		if err != nil {
@@ -137,13 +227,14 @@ func (p SSLPanel) ActivateCert() tea.Cmd {
 
 // DeleteCert returns a tea.Cmd that deletes the currently selected certificate.
 func (p SSLPanel) DeleteCert() tea.Cmd {
-	if len(p.certificates) == 0 || p.cursor >= len(p.certificates) {
+	cert := p.SelectedCert()
+	if cert == nil {
 		return nil
 	}
 	client := p.client
 	serverID := p.serverID
 	siteID := p.siteID
-	certID := p.certificates[p.cursor].ID
+	certID := cert.ID
 	return func() tea.Msg {
 		err := client.Certificates.Delete(context.Background(), serverID, siteID, certID)
 		if err != nil {
@@ -153,22 +244,50 @@ func (p SSLPanel) DeleteCert() tea.Cmd {
 	}
 }
 
+// ExpiresIn returns how long until the certificate expires. ok is false
+// when the expiry date is not (yet) known.
+func (p SSLPanel) ExpiresIn(certID int64) (d time.Duration, ok bool) {
+	t, ok := parseTimestamp(p.expiry[certID])
+	if !ok {
+		return 0, false
+	}
+	return time.Until(t), true
+}
+
+// shown returns the certificates in the order the table shows them.
+func (p SSLPanel) shown() []forge.Certificate {
+	return sortRows(p.certificates, p.sortColumns(), p.order)
+}
+
 // SelectedCert returns the currently selected certificate, or nil.
 func (p SSLPanel) SelectedCert() *forge.Certificate {
-	if len(p.certificates) == 0 || p.cursor >= len(p.certificates) {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
 		return nil
 	}
-	cert := p.certificates[p.cursor]
+	cert := shown[p.cursor]
 	return &cert
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p SSLPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the SSL panel.
 func (p SSLPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case CertsLoadedMsg:
+		old := p.shown()
 		p.certificates = msg.Certificates
+		p.cursor = keepCursor(old, p.shown(), p.cursor, certificateID)
 		p.loading = false
-		p.cursor = 0
+		return p, p.LoadExpiry()
+
+	case CertExpiryMsg:
+		old := p.shown()
+		p.expiry = msg.Expiry
+		p.cursor = keepCursor(old, p.shown(), p.cursor, certificateID)
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -178,8 +297,16 @@ func (p SSLPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	return p, nil
 }
 
+func certificateID(x forge.Certificate) int64 { return x.ID }
+
 func (p SSLPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(p.sortColumns()))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, certificateID)
+		return p, nil
+
 	case key.Matches(msg, p.down):
 		if len(p.certificates) > 0 {
 			p.cursor = min(p.cursor+1, len(p.certificates)-1)
@@ -217,19 +344,13 @@ func (p SSLPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" SSL Certificates ")
+		Render(countTitle("SSL Certificates", len(p.certificates), p.loading)) +
+		sortChip(p.sortColumns(), p.order)
 
 	content := p.renderList(innerWidth, innerHeight-1)
 
@@ -242,18 +363,15 @@ func (p SSLPanel) View(width, height int, focused bool) string {
 // Column widths for SSL table.
 // sslColStatusWidth is wider to accommodate the active indicator (* ✓ status).
 const (
-	sslColStatusWidth = 14
-	sslColTypeWidth   = 12
+	sslColStatusWidth  = 14
+	sslColTypeWidth    = 12
+	sslColExpiresWidth = 10
 )
 
-const sslTableOverhead = 2 + sslColStatusWidth + 2 + 2 + sslColTypeWidth + 4
+const sslTableOverhead = 2 + sslColStatusWidth + 2 + 2 + sslColTypeWidth + 2 + sslColExpiresWidth + 4
 
 func sslDomainWidth(maxWidth int) int {
-	w := maxWidth - sslTableOverhead
-	if w < 10 {
-		w = 10
-	}
-	return w
+	return layout.Columns(maxWidth, sslTableOverhead, 10)
 }
 
 func (p SSLPanel) renderList(width, height int) string {
@@ -266,35 +384,29 @@ func (p SSLPanel) renderList(width, height int) string {
 	} else {
 		lines = append(lines, p.renderCertHeader(width))
 
-		visibleHeight := height - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
-		for i := startIdx; i < len(p.certificates) && len(lines)-1 < visibleHeight; i++ {
-			cert := p.certificates[i]
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			cert := shown[i]
 			line := p.renderCertLine(cert, i, width)
 			lines = append(lines, line)
 		}
 	}
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
 
 func (p SSLPanel) renderCertHeader(maxWidth int) string {
 	domainW := sslDomainWidth(maxWidth)
-	line := fmt.Sprintf("  %-*s  %-*s  %-*s",
+	line := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s",
 		sslColStatusWidth, "STATUS",
 		domainW, "DOMAIN",
 		sslColTypeWidth, "TYPE",
+		sslColExpiresWidth, "EXPIRES",
 	)
 	return theme.Truncate(headerStyle.Render(line), maxWidth)
 }
@@ -330,29 +442,62 @@ func (p SSLPanel) renderCertLine(cert forge.Certificate, idx, maxWidth int) stri
 	statusPad := sslColStatusWidth - 4 // active(1) + space(1) + icon(1) + space(1)
 	statusStr := activePrefix + icon + " " + fmt.Sprintf("%-*s", statusPad, truncatePlain(statusText, statusPad))
 	typeStr := fmt.Sprintf("%-*s", sslColTypeWidth, truncatePlain(certType, sslColTypeWidth))
+	expiresStr := renderExpiry(p.expiry[cert.ID])
 
 	if idx == p.cursor {
 		line := theme.CursorStyle.Render("> ") +
 			statusStr +
 			"  " + theme.SelectedItemStyle.Render(fmt.Sprintf("%-*s", domainW, domain)) +
-			"  " + theme.NormalItemStyle.Render(typeStr)
+			"  " + theme.NormalItemStyle.Render(typeStr) +
+			"  " + expiresStr
 		return theme.Truncate(line, maxWidth)
 	}
 
 	line := "  " +
 		statusStr +
 		"  " + theme.NormalItemStyle.Render(fmt.Sprintf("%-*s", domainW, domain)) +
-		"  " + theme.NormalItemStyle.Render(typeStr)
+		"  " + theme.NormalItemStyle.Render(typeStr) +
+		"  " + expiresStr
 	return theme.Truncate(line, maxWidth)
 }
 
+// renderExpiry renders the time left until the expiry timestamp ts,
+// highlighted when within certExpiryWarning and red once expired.
+func renderExpiry(ts string) string {
+	t, ok := parseTimestamp(ts)
+	if !ok {
+		return theme.NormalItemStyle.Render(fmt.Sprintf("%-*s", sslColExpiresWidth, "-"))
+	}
+	d := time.Until(t)
+
+	style := theme.NormalItemStyle
+	var text string
+	switch {
+	case d <= 0:
+		style = lipgloss.NewStyle().Foreground(theme.ColorError)
+		text = "expired"
+	case d < 24*time.Hour:
+		style = lipgloss.NewStyle().Foreground(theme.ColorError)
+		text = fmt.Sprintf("in %dh", int(d.Hours()))
+	default:
+		if d < certExpiryWarning {
+			style = lipgloss.NewStyle().Foreground(theme.ColorHighlight)
+		}
+		text = fmt.Sprintf("in %dd", int(d.Hours())/24)
+	}
+	return style.Render(fmt.Sprintf("%-*s", sslColExpiresWidth, text))
+}
+
 // HelpBindings returns the key hints for the SSL panel.
 func (p SSLPanel) HelpBindings() []HelpBinding {
 	return []HelpBinding{
 		{Key: "j/k", Desc: "navigate"},
 		{Key: "c", Desc: "create LE cert"},
+		{Key: "i", Desc: "install existing"},
 		{Key: "a", Desc: "activate"},
+		{Key: "r", Desc: "renew LE cert"},
 		{Key: "x", Desc: "delete"},
+		{Key: "s", Desc: "sort"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
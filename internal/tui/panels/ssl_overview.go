@@ -0,0 +1,360 @@
+package panels
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// --- Messages ---
+
+// SSLOverviewLoadedMsg is sent when every site's active certificate on a
+// server has been fetched.
+type SSLOverviewLoadedMsg struct {
+	Rows []SiteCertRow
+}
+
+// SiteCertRow is one site's entry in the SSL overview. Cert is nil when
+// the site has no active certificate.
+type SiteCertRow struct {
+	Site      forge.Site
+	Cert      *forge.Certificate
+	ExpiresAt string
+}
+
+// sslOverviewConcurrency bounds the number of sites queried at once.
+const sslOverviewConcurrency = 4
+
+// SSLOverviewPanel lists the active certificate and expiry of every site
+// on a server (read-only), soonest expiry first.
+type SSLOverviewPanel struct {
+	client   *forge.Client
+	serverID int64
+
+	rows    []SiteCertRow
+	cursor  int // index into shown()
+	loading bool
+	order   tableSort
+
+	// Keybindings
+	up   key.Binding
+	down key.Binding
+	home key.Binding
+	end  key.Binding
+	sort key.Binding
+}
+
+// sslOverviewSort are the columns the SSL overview can be sorted by.
+var sslOverviewSort = []sortColumn[SiteCertRow]{
+	{name: "site", cmp: func(a, b SiteCertRow) int { return compareFold(a.Site.Name, b.Site.Name) }},
+	{name: "certificate", cmp: func(a, b SiteCertRow) int {
+		return compareCerts(a, b, func(c *forge.Certificate) string { return c.Domain })
+	}},
+	{name: "type", cmp: func(a, b SiteCertRow) int {
+		return compareCerts(a, b, func(c *forge.Certificate) string { return c.Type })
+	}},
+	{name: "expires", cmp: func(a, b SiteCertRow) int { return compareTimestamps(a.ExpiresAt, b.ExpiresAt) }},
+}
+
+// compareCerts orders two rows by a field of their certificates, with
+// sites that have no active certificate after the others.
+func compareCerts(a, b SiteCertRow, field func(*forge.Certificate) string) int {
+	if a.Cert == nil || b.Cert == nil {
+		return compareKnown(0, a.Cert != nil, 0, b.Cert != nil)
+	}
+	return strings.Compare(field(a.Cert), field(b.Cert))
+}
+
+// NewSSLOverviewPanel creates a new SSLOverviewPanel.
+func NewSSLOverviewPanel(client *forge.Client, serverID int64) SSLOverviewPanel {
+	return SSLOverviewPanel{
+		client:   client,
+		serverID: serverID,
+		loading:  true,
+		up: key.NewBinding(
+			key.WithKeys("k", "up"),
+			key.WithHelp("k/up", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("j", "down"),
+			key.WithHelp("j/down", "down"),
+		),
+		home: key.NewBinding(
+			key.WithKeys("g", "home"),
+			key.WithHelp("g", "top"),
+		),
+		end: key.NewBinding(
+			key.WithKeys("G", "end"),
+			key.WithHelp("G", "bottom"),
+		),
+		sort: sortKey,
+	}
+}
+
+// LoadOverview returns a tea.Cmd that fetches every site on the server and
+// its active certificate, including the expiry date.
+func (p SSLOverviewPanel) LoadOverview() tea.Cmd {
+	client := p.client
+	serverID := p.serverID
+	return func() tea.Msg {
+		ctx := context.Background()
+		sites, err := client.Sites.List(ctx, serverID)
+		if err != nil {
+			return PanelErrMsg{Err: err}
+		}
+
+		rows := make([]SiteCertRow, len(sites))
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, sslOverviewConcurrency)
+		for i, site := range sites {
+			rows[i].Site = site
+			wg.Add(1)
+			go func(row *SiteCertRow) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				certs, err := client.Certificates.List(ctx, serverID, row.Site.ID)
+				if err != nil {
+					return
+				}
+				for _, c := range certs {
+					if !c.Active {
+						continue
+					}
+					cert := c
+					row.Cert = &cert
+					if full, err := client.Certificates.Get(ctx, serverID, row.Site.ID, c.ID); err == nil {
+						row.ExpiresAt = full.ExpiresAt
+					}
+					return
+				}
+			}(&rows[i])
+		}
+		wg.Wait()
+
+		sortByExpiry(rows)
+		return SSLOverviewLoadedMsg{Rows: rows}
+	}
+}
+
+// sortByExpiry orders rows soonest expiry first, then sites with a
+// certificate of unknown expiry, then sites without a certificate.
+func sortByExpiry(rows []SiteCertRow) {
+	rank := func(r SiteCertRow) int {
+		switch {
+		case r.Cert == nil:
+			return 2
+		case r.ExpiresAt == "":
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		ri, rj := rank(rows[i]), rank(rows[j])
+		if ri != rj {
+			return ri < rj
+		}
+		if ri == 0 {
+			ti, _ := parseTimestamp(rows[i].ExpiresAt)
+			tj, _ := parseTimestamp(rows[j].ExpiresAt)
+			return ti.Before(tj)
+		}
+		return false
+	})
+}
+
+// shown returns the rows in the order the table shows them.
+func (p SSLOverviewPanel) shown() []SiteCertRow {
+	return sortRows(p.rows, sslOverviewSort, p.order)
+}
+
+// Loading reports whether the panel's data is still being fetched.
+func (p SSLOverviewPanel) Loading() bool {
+	return p.loading
+}
+
+// Update handles messages for the SSL overview panel.
+func (p SSLOverviewPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SSLOverviewLoadedMsg:
+		old := p.shown()
+		p.rows = msg.Rows
+		p.cursor = keepCursor(old, p.shown(), p.cursor, siteCertRowID)
+		p.loading = false
+		return p, nil
+
+	case tea.KeyPressMsg:
+		return p.handleKey(msg)
+	}
+
+	return p, nil
+}
+
+func siteCertRowID(r SiteCertRow) int64 { return r.Site.ID }
+
+func (p SSLOverviewPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
+	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(sslOverviewSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, siteCertRowID)
+		return p, nil
+
+	case key.Matches(msg, p.down):
+		if len(p.rows) > 0 {
+			p.cursor = min(p.cursor+1, len(p.rows)-1)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.up):
+		if len(p.rows) > 0 {
+			p.cursor = max(p.cursor-1, 0)
+		}
+		return p, nil
+
+	case key.Matches(msg, p.home):
+		p.cursor = 0
+		return p, nil
+
+	case key.Matches(msg, p.end):
+		if len(p.rows) > 0 {
+			p.cursor = len(p.rows) - 1
+		}
+		return p, nil
+	}
+
+	return p, nil
+}
+
+// View renders the SSL overview panel.
+func (p SSLOverviewPanel) View(width, height int, focused bool) string {
+	style := theme.InactiveBorderStyle
+	titleColor := theme.ColorSubtle
+	if focused {
+		style = theme.ActiveBorderStyle
+		titleColor = theme.ColorPrimary
+	}
+
+	innerWidth, innerHeight := layout.Inner(width, height)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(titleColor).
+		Render(countTitle("SSL Overview", len(p.rows), p.loading)) +
+		sortChip(sslOverviewSort, p.order)
+
+	content := p.renderList(innerWidth, innerHeight-1)
+
+	return style.
+		Width(innerWidth).
+		Height(innerHeight).
+		Render(title + "\n" + content)
+}
+
+// Column widths for the SSL overview table.
+const sslOverviewSiteWidth = 28
+
+const sslOverviewOverhead = 2 + sslOverviewSiteWidth + 2 + 2 + sslColTypeWidth + 2 + sslColExpiresWidth + 4
+
+func sslOverviewDomainWidth(maxWidth int) int {
+	return layout.Columns(maxWidth, sslOverviewOverhead, 10)
+}
+
+func (p SSLOverviewPanel) renderList(width, height int) string {
+	var lines []string
+
+	if p.loading && len(p.rows) == 0 {
+		lines = append(lines, theme.LoadingStyle.Render("Loading certificates for all sites..."))
+	} else if len(p.rows) == 0 {
+		lines = append(lines, theme.NormalItemStyle.Render("No sites found"))
+	} else {
+		lines = append(lines, p.renderHeader(width))
+
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
+
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			lines = append(lines, p.renderRow(shown[i], i, width))
+		}
+	}
+
+	lines = layout.Pad(lines, height)
+
+	return strings.Join(lines, "\n")
+}
+
+func (p SSLOverviewPanel) renderHeader(maxWidth int) string {
+	domainW := sslOverviewDomainWidth(maxWidth)
+	line := fmt.Sprintf("  %-*s  %-*s  %-*s  %-*s",
+		sslOverviewSiteWidth, "SITE",
+		domainW, "CERTIFICATE",
+		sslColTypeWidth, "TYPE",
+		sslColExpiresWidth, "EXPIRES",
+	)
+	return theme.Truncate(headerStyle.Render(line), maxWidth)
+}
+
+func (p SSLOverviewPanel) renderRow(row SiteCertRow, idx, maxWidth int) string {
+	domainW := sslOverviewDomainWidth(maxWidth)
+	siteStr := fmt.Sprintf("%-*s", sslOverviewSiteWidth, truncatePlain(row.Site.Name, sslOverviewSiteWidth))
+
+	var domainStr, typeStr, expiresStr string
+	if row.Cert == nil {
+		none := lipgloss.NewStyle().Foreground(theme.ColorError)
+		domainStr = none.Render(fmt.Sprintf("%-*s", domainW, truncatePlain("no active certificate", domainW)))
+		typeStr = fmt.Sprintf("%-*s", sslColTypeWidth, "-")
+		expiresStr = fmt.Sprintf("%-*s", sslColExpiresWidth, "-")
+	} else {
+		domain := row.Cert.Domain
+		if domain == "" {
+			domain = "-"
+		}
+		certType := row.Cert.Type
+		if certType == "" {
+			certType = "unknown"
+		}
+		domainStr = theme.NormalItemStyle.Render(fmt.Sprintf("%-*s", domainW, truncatePlain(domain, domainW)))
+		typeStr = fmt.Sprintf("%-*s", sslColTypeWidth, truncatePlain(certType, sslColTypeWidth))
+		expiresStr = renderExpiry(row.ExpiresAt)
+	}
+
+	if idx == p.cursor {
+		line := theme.CursorStyle.Render("> ") +
+			theme.SelectedItemStyle.Render(siteStr) +
+			"  " + domainStr +
+			"  " + theme.NormalItemStyle.Render(typeStr) +
+			"  " + expiresStr
+		return theme.Truncate(line, maxWidth)
+	}
+
+	line := "  " +
+		theme.NormalItemStyle.Render(siteStr) +
+		"  " + domainStr +
+		"  " + theme.NormalItemStyle.Render(typeStr) +
+		"  " + expiresStr
+	return theme.Truncate(line, maxWidth)
+}
+
+// HelpBindings returns the key hints for the SSL overview panel.
+func (p SSLOverviewPanel) HelpBindings() []HelpBinding {
+	return []HelpBinding{
+		{Key: "j/k", Desc: "navigate"},
+		{Key: "s", Desc: "sort"},
+		{Key: "g/G", Desc: "top/bottom"},
+		{Key: "esc", Desc: "back"},
+		{Key: "tab", Desc: "switch panel"},
+		{Key: "q", Desc: "quit"},
+	}
+}
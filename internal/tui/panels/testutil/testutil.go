@@ -0,0 +1,99 @@
+// Package testutil provides a fake Forge API and canned responses for
+// testing panels.
+//
+// The fixtures directory holds one JSON response per list endpoint, named
+// after the panel whose Loaded message it produces (daemons.json for
+// DaemonsLoadedMsg and so on). Each has three items, the last with values
+// far too long for any column, so cursor and truncation tests have
+// something to work with.
+package testutil
+
+import (
+	"embed"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Fixture returns the fixture called name, e.g. "daemons".
+func Fixture(t testing.TB, name string) []byte {
+	t.Helper()
+	data, err := fixtures.ReadFile(path.Join("fixtures", name+".json"))
+	if err != nil {
+		t.Fatalf("fixture %q: %v", name, err)
+	}
+	return data
+}
+
+// Routes maps request paths, e.g. "/servers/1/daemons", to the name of the
+// fixture served for them.
+type Routes map[string]string
+
+// NewClient returns a Client talking to a fake Forge API that answers GET
+// requests for routes with their fixture and anything else with a 404.
+// The server is shut down when the test ends.
+func NewClient(t testing.TB, routes Routes) *forge.Client {
+	t.Helper()
+	bodies := make(map[string][]byte, len(routes))
+	for p, name := range routes {
+		bodies[p] = Fixture(t, name)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := bodies[r.URL.Path]
+		if !ok || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not found."}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := forge.NewClient("test-token")
+	c.BaseURL = srv.URL
+	return c
+}
+
+// Run runs cmd and returns its message, failing the test if there is no
+// command to run.
+func Run(t testing.TB, cmd tea.Cmd) tea.Msg {
+	t.Helper()
+	if cmd == nil {
+		t.Fatal("command is nil")
+	}
+	return cmd()
+}
+
+// Key returns the key press for k, written the way key bindings spell it:
+// "j", "G", "down", "enter".
+func Key(k string) tea.KeyPressMsg {
+	if code, ok := namedKeys[k]; ok {
+		return tea.KeyPressMsg{Code: code}
+	}
+	r := []rune(k)
+	if len(r) != 1 {
+		panic("testutil: unknown key " + k)
+	}
+	return tea.KeyPressMsg{Code: r[0], Text: k}
+}
+
+var namedKeys = map[string]rune{
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEscape,
+	"tab":       tea.KeyTab,
+	"backspace": tea.KeyBackspace,
+}
@@ -0,0 +1,99 @@
+package panels
+
+import (
+	"fmt"
+	"time"
+
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// absoluteTimeLayout is how absolute times are shown, in the display
+// timezone.
+const absoluteTimeLayout = "2006-01-02 15:04 MST"
+
+// displayLocation is the timezone times are shown in; see UseTimezone.
+var displayLocation = time.Local
+
+// UseTimezone shows every absolute time in loc instead of the local
+// timezone. Call it before the UI is first drawn.
+func UseTimezone(loc *time.Location) {
+	displayLocation = loc
+}
+
+// parseTimestamp parses a Forge timestamp into UTC. Forge timestamps are
+// typically in ISO 8601 / RFC 3339 format, but older endpoints use a plain
+// layout without an offset, which is in UTC.
+func parseTimestamp(ts string) (time.Time, bool) {
+	if ts == "" {
+		return time.Time{}, false
+	}
+	layouts := []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05.000000Z",
+		"2006-01-02 15:04:05",
+	}
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, ts); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// relativeTime converts a Forge timestamp string into a human-readable
+// relative duration like "2m ago", "1h ago", etc.
+func relativeTime(ts string) string {
+	if ts == "" {
+		return ""
+	}
+
+	t, ok := parseTimestamp(ts)
+	if !ok {
+		return ts // fall back to raw string
+	}
+
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		days := int(d.Hours()) / 24
+		return fmt.Sprintf("%dd ago", days)
+	}
+}
+
+// absoluteTime formats a Forge timestamp as a date and time in the display
+// timezone, e.g. "2026-10-16 15:04 AEDT", or returns it as it is when it
+// can't be parsed.
+func absoluteTime(ts string) string {
+	t, ok := parseTimestamp(ts)
+	if !ok {
+		return ts
+	}
+	return t.In(displayLocation).Format(absoluteTimeLayout)
+}
+
+// localDate formats a Forge timestamp as a date in the display timezone.
+func localDate(ts string) string {
+	t, ok := parseTimestamp(ts)
+	if !ok {
+		return ts
+	}
+	return t.In(displayLocation).Format("2006-01-02")
+}
+
+// selectedTime renders the absolute time of a list's selected row for the
+// panel's title, so the exact time behind "2h ago" is shown as the cursor
+// moves. It is empty when ts can't be parsed.
+func selectedTime(ts string) string {
+	if _, ok := parseTimestamp(ts); !ok {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(theme.ColorSubtle).Render(absoluteTime(ts)) + " "
+}
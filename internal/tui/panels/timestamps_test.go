@@ -0,0 +1,28 @@
+package panels
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAbsoluteTime(t *testing.T) {
+	defer UseTimezone(displayLocation)
+	UseTimezone(time.FixedZone("AEDT", 11*60*60))
+
+	for _, ts := range []string{
+		"2026-10-16T04:04:05Z",
+		"2026-10-16T06:04:05+02:00",
+		"2026-10-16T04:04:05.000000Z",
+		"2026-10-16 04:04:05", // no offset: UTC
+	} {
+		if got, want := absoluteTime(ts), "2026-10-16 15:04 AEDT"; got != want {
+			t.Errorf("absoluteTime(%q) = %q, want %q", ts, got, want)
+		}
+	}
+	if got, want := localDate("2026-10-16T20:00:00Z"), "2026-10-17"; got != want {
+		t.Errorf("localDate late in the UTC day = %q, want %q", got, want)
+	}
+	if got := absoluteTime("yesterday"); got != "yesterday" {
+		t.Errorf("absoluteTime of an unparseable value = %q, want it unchanged", got)
+	}
+}
@@ -1,6 +1,7 @@
 package panels
 
 import (
+	"slices"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
@@ -9,6 +10,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -24,20 +26,33 @@ type TreeFetchSitesMsg struct {
 	ServerID int64
 }
 
-// TreeNodeKind distinguishes server nodes from site nodes.
+// TreeNodeKind distinguishes server, site and application nodes.
 type TreeNodeKind int
 
 const (
 	NodeServer TreeNodeKind = iota
 	NodeSite
+	NodeApp // an application grouping sites across servers
 )
 
+// RecentSite identifies a recently visited site shown in the Recent group.
+type RecentSite struct {
+	ServerID int64
+	SiteID   int64
+}
+
 // TreeNode is a single entry in the flattened visible list.
 type TreeNode struct {
 	Kind   TreeNodeKind
 	Server forge.Server
 	Site   *forge.Site // non-nil only for NodeSite
 	IsLast bool        // true when this is the last site under its server
+	Recent bool        // true for entries in the Recent group
+
+	// App is the application a node belongs to in application mode, and
+	// Envs the servers it runs on (NodeApp only).
+	App  string
+	Envs []string
 }
 
 // TreePanel is a lazygit-style tree that combines servers and their sites
@@ -63,6 +78,36 @@ type TreePanel struct {
 	// Nicknames maps "server\nsite" to nickname for display.
 	nicknames map[string]string
 
+	// deployStatus maps site ID to its latest deployment status.
+	deployStatus map[int64]string
+
+	// recent lists recently visited sites, shown as a group at the top.
+	recent []RecentSite
+
+	// hidden reports whether a server is on the ignore list. Hidden
+	// servers are left out of the tree unless showHidden is set.
+	hidden     func(forge.Server) bool
+	showHidden bool
+
+	// workspace narrows the tree to the servers and sites of the
+	// workspace named workspaceName, when one is selected.
+	workspace     func(srv forge.Server, site *forge.Site) bool
+	workspaceName string
+
+	// byApp groups sites by application instead of by server;
+	// appExpanded holds the expanded applications by lowercased name.
+	byApp       bool
+	appExpanded map[string]bool
+
+	// reachable maps server ID to the result of the last SSH port check.
+	// Servers that haven't been checked are absent.
+	reachable map[int64]bool
+
+	// pendingSite is the site that was selected before a reload, restored
+	// once its server's sites are loaded again (if the cursor is still on
+	// that server by then).
+	pendingSite *TreeNode
+
 	// Keybindings
 	up    key.Binding
 	down  key.Binding
@@ -85,6 +130,9 @@ func NewTreePanel() TreePanel {
 		expanded:      make(map[int64]bool),
 		sitesLoaded:   make(map[int64]bool),
 		sitesLoading:  make(map[int64]bool),
+		deployStatus:  make(map[int64]string),
+		reachable:     make(map[int64]bool),
+		appExpanded:   make(map[string]bool),
 		filterInput:   ti,
 		up: key.NewBinding(
 			key.WithKeys("k", "up"),
@@ -117,27 +165,191 @@ func NewTreePanel() TreePanel {
 	}
 }
 
-// SetServers replaces the server list and resets state.
+// SetServers replaces the server list and resets state, keeping the
+// selection on the same server or site when it still exists. The filter
+// is kept, so a refresh does not lose it.
 func (t TreePanel) SetServers(servers []forge.Server) TreePanel {
+	prev, hadSelection := t.selectedNode()
 	t.servers = servers
 	t.loading = false
 	t.cursor = 0
-	t.filterActive = false
-	t.filterText = ""
-	t.filterInput.SetValue("")
 	// Reset expansion state for a fresh load.
 	t.expanded = make(map[int64]bool)
 	t.sitesByServer = make(map[int64][]forge.Site)
 	t.sitesLoaded = make(map[int64]bool)
 	t.sitesLoading = make(map[int64]bool)
+	t.deployStatus = make(map[int64]string)
+	t.pendingSite = nil
+
+	if hadSelection {
+		// Sites aren't loaded yet: select the server for now and come
+		// back to the site in SetSites.
+		if prev.Kind == NodeSite {
+			site := prev
+			t.pendingSite = &site
+			prev = TreeNode{Kind: NodeServer, Server: prev.Server}
+		}
+		t = t.reselect(prev)
+	}
 	return t
 }
 
 // SetSites stores the fetched sites for a server.
 func (t TreePanel) SetSites(serverID int64, sites []forge.Site) TreePanel {
+	prev, ok := t.selectedNode()
 	t.sitesByServer[serverID] = sites
 	t.sitesLoaded[serverID] = true
 	t.sitesLoading[serverID] = false
+	if p := t.pendingSite; p != nil && p.Server.ID == serverID {
+		t.pendingSite = nil
+		if ok && prev.Kind == NodeServer && prev.Server.ID == serverID {
+			prev = *p
+		}
+	}
+	if ok {
+		t = t.reselect(prev)
+	}
+	return t
+}
+
+// SetDeployStatuses records the latest deployment status for each site ID
+// in statuses. Sites with a known status show a badge next to their name.
+func (t TreePanel) SetDeployStatuses(statuses map[int64]string) TreePanel {
+	for id, status := range statuses {
+		t.deployStatus[id] = status
+	}
+	return t
+}
+
+// SetRecent sets the sites shown in the Recent group, most recent first.
+// Entries whose sites haven't been loaded yet are hidden until they are.
+func (t TreePanel) SetRecent(recent []RecentSite) TreePanel {
+	prev, ok := t.selectedNode()
+	t.recent = recent
+	if ok {
+		t = t.reselect(prev)
+	}
+	return t
+}
+
+// LoadRecentSites returns commands fetching the sites of servers referenced
+// by the Recent group, without expanding them.
+func (t TreePanel) LoadRecentSites() (TreePanel, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, r := range t.recent {
+		serverID := r.ServerID
+		if t.sitesLoaded[serverID] || t.sitesLoading[serverID] || t.FindServerByID(serverID) == nil {
+			continue
+		}
+		t.sitesLoading[serverID] = true
+		cmds = append(cmds, func() tea.Msg {
+			return TreeFetchSitesMsg{ServerID: serverID}
+		})
+	}
+	return t, tea.Batch(cmds...)
+}
+
+// SetReachability records whether each server's SSH port answered.
+func (t TreePanel) SetReachability(reachable map[int64]bool) TreePanel {
+	t.reachable = reachable
+	return t
+}
+
+// SetHidden sets the predicate deciding which servers are hidden. If the
+// selected node is hidden by it, the cursor stays where it was, clamped to
+// the shorter tree.
+func (t TreePanel) SetHidden(hidden func(forge.Server) bool) TreePanel {
+	prev, ok := t.selectedNode()
+	t.hidden = hidden
+	if ok {
+		t = t.reselect(prev)
+	}
+	t.cursor = max(min(t.cursor, len(t.visibleNodes())-1), 0)
+	return t
+}
+
+// ShowingHidden reports whether hidden servers are currently shown.
+func (t TreePanel) ShowingHidden() bool {
+	return t.showHidden
+}
+
+// IsHidden reports whether srv is on the ignore list, whether or not
+// hidden servers are currently shown.
+func (t TreePanel) IsHidden(srv forge.Server) bool {
+	return t.hidden != nil && t.hidden(srv)
+}
+
+// skip reports whether srv is left out of the tree, being hidden or
+// outside the selected workspace.
+func (t TreePanel) skip(srv forge.Server) bool {
+	return (!t.showHidden && t.IsHidden(srv)) || !t.inWorkspace(srv, nil)
+}
+
+// SetWorkspace narrows the tree to a workspace: in reports whether a
+// server, or a site on it when site is non-nil, belongs to it. An empty
+// name shows everything again.
+func (t TreePanel) SetWorkspace(name string, in func(srv forge.Server, site *forge.Site) bool) TreePanel {
+	prev, ok := t.selectedNode()
+	t.workspaceName = name
+	t.workspace = in
+	if name == "" {
+		t.workspace = nil
+	}
+	t.cursor = 0
+	if ok {
+		t = t.reselect(prev)
+	}
+	return t
+}
+
+// Workspace returns the name of the selected workspace, or "".
+func (t TreePanel) Workspace() string {
+	return t.workspaceName
+}
+
+// WorkspaceServers returns the shown servers in the selected workspace.
+func (t TreePanel) WorkspaceServers() []forge.Server {
+	var out []forge.Server
+	for _, srv := range t.servers {
+		if !t.skip(srv) {
+			out = append(out, srv)
+		}
+	}
+	return out
+}
+
+// inWorkspace reports whether srv, or site on it when site is non-nil,
+// belongs to the selected workspace. Everything does when none is.
+func (t TreePanel) inWorkspace(srv forge.Server, site *forge.Site) bool {
+	return t.workspace == nil || t.workspace(srv, site)
+}
+
+// ExpandedServers returns the IDs of all expanded server nodes in tree
+// order.
+func (t TreePanel) ExpandedServers() []int64 {
+	var ids []int64
+	for _, srv := range t.servers {
+		if t.expanded[srv.ID] {
+			ids = append(ids, srv.ID)
+		}
+	}
+	return ids
+}
+
+// UpdateSite replaces a loaded site's data (e.g. after a rename) so its
+// label reflects the change.
+func (t TreePanel) UpdateSite(site forge.Site) TreePanel {
+	for serverID, sites := range t.sitesByServer {
+		for i := range sites {
+			if sites[i].ID == site.ID {
+				updated := make([]forge.Site, len(sites))
+				copy(updated, sites)
+				updated[i] = site
+				t.sitesByServer[serverID] = updated
+				return t
+			}
+		}
+	}
 	return t
 }
 
@@ -171,6 +383,54 @@ func (t TreePanel) ExpandServer(serverID int64) (TreePanel, tea.Cmd) {
 	return t, nil
 }
 
+// ExpandAll expands every server node, or every application in
+// application mode. Servers whose sites haven't been loaded yet each get a
+// TreeFetchSitesMsg command.
+func (t TreePanel) ExpandAll() (TreePanel, tea.Cmd) {
+	if t.byApp {
+		for _, node := range t.visibleNodes() {
+			if node.Kind == NodeApp {
+				t.appExpanded[strings.ToLower(node.App)] = true
+			}
+		}
+		return t, nil
+	}
+	var cmds []tea.Cmd
+	for _, srv := range t.servers {
+		if t.skip(srv) {
+			continue
+		}
+		var cmd tea.Cmd
+		t, cmd = t.ExpandServer(srv.ID)
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return t, tea.Batch(cmds...)
+}
+
+// CollapseAll collapses every server node, or every application in
+// application mode. If the cursor was on a site, it moves to that site's
+// server or application.
+func (t TreePanel) CollapseAll() TreePanel {
+	if t.byApp {
+		prev, _ := t.selectedNode()
+		clear(t.appExpanded)
+		t.cursor = appIndex(t.visibleNodes(), prev.App)
+		return t
+	}
+	srv, _ := t.Selected()
+	for id := range t.expanded {
+		t.expanded[id] = false
+	}
+	var found bool
+	t, found = t.SetCursorToServer(srv.ID)
+	if !found {
+		t.cursor = 0
+	}
+	return t
+}
+
 // SetCursorToServer moves the cursor to the server node with the given ID.
 // Returns true if the server was found.
 func (t TreePanel) SetCursorToServer(serverID int64) (TreePanel, bool) {
@@ -196,6 +456,43 @@ func (t TreePanel) FindServerByName(name string) *forge.Server {
 	return nil
 }
 
+// Servers returns all loaded servers.
+func (t TreePanel) Servers() []forge.Server {
+	return t.servers
+}
+
+// TagFilter returns the tag named by a "tag:<name>" filter, or "" when the
+// filter is not a tag filter.
+func (t TreePanel) TagFilter() string {
+	tag, ok := strings.CutPrefix(strings.TrimSpace(t.filterText), "tag:")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(tag)
+}
+
+// RepoFilter returns the repository named by a "repo:<name>" filter, or ""
+// when the filter is not a repository filter.
+func (t TreePanel) RepoFilter() string {
+	repo, ok := strings.CutPrefix(strings.TrimSpace(t.filterText), "repo:")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(repo)
+}
+
+// ServersWithTag returns the loaded servers carrying the given tag,
+// leaving out hidden ones unless they are shown.
+func (t TreePanel) ServersWithTag(tag string) []forge.Server {
+	var out []forge.Server
+	for _, srv := range t.servers {
+		if srv.HasTag(tag) && !t.skip(srv) {
+			out = append(out, srv)
+		}
+	}
+	return out
+}
+
 // FindServerByID returns the server with the given ID, or nil if not found.
 func (t TreePanel) FindServerByID(id int64) *forge.Server {
 	for _, srv := range t.servers {
@@ -240,12 +537,32 @@ func (t TreePanel) FindSiteByName(siteName string) (*forge.Server, *forge.Site)
 	return nil, nil
 }
 
+// FindSiteOnServer returns the site with the given name on a specific
+// server, or nil if it is not found or the server's sites are not loaded.
+func (t TreePanel) FindSiteOnServer(serverID int64, siteName string) *forge.Site {
+	for _, site := range t.sitesByServer[serverID] {
+		if strings.EqualFold(site.Name, siteName) {
+			s := site
+			return &s
+		}
+	}
+	return nil
+}
+
 // SetCursorToSite moves the cursor to the site node with the given ID.
 // Returns true if the site was found.
 func (t TreePanel) SetCursorToSite(siteID int64) (TreePanel, bool) {
+	if t.byApp {
+		// Open the site's application so the site is in the tree.
+		for _, sites := range t.sitesByServer {
+			if i := slices.IndexFunc(sites, func(s forge.Site) bool { return s.ID == siteID }); i >= 0 {
+				t.appExpanded[strings.ToLower(AppName(sites[i]))] = true
+			}
+		}
+	}
 	nodes := t.visibleNodes()
 	for i, node := range nodes {
-		if node.Kind == NodeSite && node.Site != nil && node.Site.ID == siteID {
+		if node.Kind == NodeSite && !node.Recent && node.Site != nil && node.Site.ID == siteID {
 			t.cursor = i
 			return t, true
 		}
@@ -267,6 +584,20 @@ func (t TreePanel) FilterActive() bool {
 	return t.filterActive
 }
 
+// FilterText returns the applied filter, or "" when the tree is unfiltered.
+func (t TreePanel) FilterText() string {
+	return t.filterText
+}
+
+// SetFilter applies a filter without opening the filter input, e.g. to
+// restore the one from the previous session.
+func (t TreePanel) SetFilter(text string) TreePanel {
+	t.filterText = text
+	t.filterInput.SetValue(text)
+	t.cursor = 0
+	return t
+}
+
 // Selected returns the server and optional site at the current cursor position.
 func (t TreePanel) Selected() (forge.Server, *forge.Site) {
 	nodes := t.visibleNodes()
@@ -280,17 +611,45 @@ func (t TreePanel) Selected() (forge.Server, *forge.Site) {
 // visibleNodes builds the flat list of visible tree nodes.
 func (t TreePanel) visibleNodes() []TreeNode {
 	filterLower := strings.ToLower(t.filterText)
+	tag := t.TagFilter()
+	repo := strings.ToLower(t.RepoFilter())
 	var nodes []TreeNode
 
+	if filterLower == "" {
+		nodes = t.recentNodes()
+	}
+	if t.byApp {
+		return append(nodes, t.appNodes(filterLower, tag, repo)...)
+	}
+
 	for _, srv := range t.servers {
+		if t.skip(srv) {
+			continue
+		}
 		srvMatches := filterLower == "" || strings.Contains(strings.ToLower(srv.Name), filterLower)
+		if tag != "" {
+			// "tag:<name>" matches servers by tag only, never by site name.
+			srvMatches = srv.HasTag(tag)
+		}
+		if repo != "" {
+			// "repo:<name>" matches the sites deploying a repository, on
+			// any server, and never a server itself.
+			srvMatches = false
+		}
 
 		sites := t.sitesByServer[srv.ID]
 
 		// Collect sites that match the filter (used when expanded or auto-expanding).
 		var matchingSites []forge.Site
 		for _, site := range sites {
-			if filterLower == "" || strings.Contains(strings.ToLower(site.Name), filterLower) || srvMatches {
+			if !t.inWorkspace(srv, &site) {
+				continue
+			}
+			match := filterLower == "" || strings.Contains(strings.ToLower(site.Name), filterLower) || srvMatches
+			if repo != "" {
+				match = strings.Contains(strings.ToLower(site.Repository), repo)
+			}
+			if match {
 				matchingSites = append(matchingSites, site)
 			}
 		}
@@ -328,6 +687,61 @@ func (t TreePanel) visibleNodes() []TreeNode {
 	return nodes
 }
 
+// selectedNode returns the node under the cursor, if any.
+func (t TreePanel) selectedNode() (TreeNode, bool) {
+	nodes := t.visibleNodes()
+	if t.cursor >= len(nodes) {
+		return TreeNode{}, false
+	}
+	return nodes[t.cursor], true
+}
+
+// reselect moves the cursor back onto prev after nodes were added or
+// removed above it, so the selection doesn't silently change.
+func (t TreePanel) reselect(prev TreeNode) TreePanel {
+	for i, node := range t.visibleNodes() {
+		if node.Kind != prev.Kind || node.Server.ID != prev.Server.ID || node.Recent != prev.Recent {
+			continue
+		}
+		if node.Kind == NodeApp && node.App != prev.App {
+			continue
+		}
+		if node.Kind == NodeSite && node.Site.ID != prev.Site.ID {
+			continue
+		}
+		t.cursor = i
+		return t
+	}
+	return t
+}
+
+// recentNodes returns the Recent group's nodes for sites that are loaded.
+func (t TreePanel) recentNodes() []TreeNode {
+	var nodes []TreeNode
+	for _, r := range t.recent {
+		srv := t.FindServerByID(r.ServerID)
+		if srv == nil || t.skip(*srv) {
+			continue
+		}
+		for _, site := range t.sitesByServer[r.ServerID] {
+			if site.ID == r.SiteID && t.inWorkspace(*srv, &site) {
+				s := site
+				nodes = append(nodes, TreeNode{
+					Kind:   NodeSite,
+					Server: *srv,
+					Site:   &s,
+					Recent: true,
+				})
+				break
+			}
+		}
+	}
+	if len(nodes) > 0 {
+		nodes[len(nodes)-1].IsLast = true
+	}
+	return nodes
+}
+
 // Update handles key events for the tree panel.
 func (t TreePanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -347,6 +761,12 @@ func (t TreePanel) handleFilterKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 		t.filterActive = false
 		t.filterText = t.filterInput.Value()
 		t.cursor = 0
+		if t.RepoFilter() != "" {
+			// A repository can be deployed on any server, so search them all.
+			var load tea.Cmd
+			t, load = t.LoadAllSites()
+			return t, tea.Batch(t.emitSelected(), load)
+		}
 		return t, t.emitSelected()
 
 	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))):
@@ -375,6 +795,17 @@ func (t TreePanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 		t.filterInput.Focus()
 		return t, textinput.Blink
 
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc"))) && t.filterText != "":
+		// Esc clears an applied filter without reopening the input.
+		prev, ok := t.selectedNode()
+		t.filterText = ""
+		t.filterInput.SetValue("")
+		t.cursor = 0
+		if ok {
+			t = t.reselect(prev)
+		}
+		return t, t.emitSelected()
+
 	case key.Matches(msg, t.down):
 		if len(nodes) > 0 {
 			t.cursor = min(t.cursor+1, len(nodes)-1)
@@ -408,9 +839,49 @@ func (t TreePanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 				// First expand the server so sites load.
 				return t.toggleServer(node.Server)
 			}
+			if node.Kind == NodeApp {
+				return t.toggleApp(node.App)
+			}
 			return t, t.emitSelected()
 		}
 
+	case key.Matches(msg, key.NewBinding(key.WithKeys("+", "="))):
+		// Expand every server.
+		var cmd tea.Cmd
+		t, cmd = t.ExpandAll()
+		return t, tea.Batch(cmd, t.emitSelected())
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("-"))):
+		// Collapse every server.
+		t = t.CollapseAll()
+		return t, t.emitSelected()
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("."))):
+		// Show or hide the servers on the ignore list. If the selected
+		// server disappears, fall back to the top of the tree.
+		prev, ok := t.selectedNode()
+		t.showHidden = !t.showHidden
+		t.cursor = 0
+		if ok {
+			t = t.reselect(prev)
+		}
+		if t.byApp {
+			var cmd tea.Cmd
+			t, cmd = t.LoadAllSites()
+			return t, tea.Batch(cmd, t.emitSelected())
+		}
+		return t, t.emitSelected()
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("V"))):
+		// Switch between grouping sites by server and by application.
+		t = t.SetGroupByApp(!t.byApp)
+		if t.byApp {
+			var cmd tea.Cmd
+			t, cmd = t.LoadAllSites()
+			return t, tea.Batch(cmd, t.emitSelected())
+		}
+		return t, t.emitSelected()
+
 	case key.Matches(msg, key.NewBinding(key.WithKeys(" "))):
 		// Space: toggle expand/collapse for servers.
 		if t.cursor < len(nodes) {
@@ -418,6 +889,9 @@ func (t TreePanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 			if node.Kind == NodeServer {
 				return t.toggleServer(node.Server)
 			}
+			if node.Kind == NodeApp {
+				return t.toggleApp(node.App)
+			}
 		}
 
 	case key.Matches(msg, t.right):
@@ -435,6 +909,15 @@ func (t TreePanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 					return t, t.emitSelected()
 				}
 			}
+			if node.Kind == NodeApp {
+				if !t.appExpanded[strings.ToLower(node.App)] {
+					return t.toggleApp(node.App)
+				}
+				if t.cursor+1 < len(nodes) && nodes[t.cursor+1].Kind == NodeSite {
+					t.cursor++
+					return t, t.emitSelected()
+				}
+			}
 			// On a site node, l is handled by app.go (focus detail panel).
 		}
 
@@ -442,6 +925,13 @@ func (t TreePanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 		// h / left: collapse server or move to parent server from site.
 		if t.cursor < len(nodes) {
 			node := nodes[t.cursor]
+			if node.App != "" {
+				// Application mode: collapse the application, moving to
+				// its header from one of its sites.
+				t.appExpanded[strings.ToLower(node.App)] = false
+				t.cursor = appIndex(t.visibleNodes(), node.App)
+				return t, t.emitSelected()
+			}
 			if node.Kind == NodeServer {
 				// Collapse the server if expanded.
 				if t.expanded[node.Server.ID] {
@@ -525,19 +1015,20 @@ func (t TreePanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	title := lipgloss.NewStyle().
+	title := " Servers "
+	if t.byApp {
+		title = " Applications "
+	}
+	if t.workspaceName != "" {
+		title += "[" + t.workspaceName + "] "
+	}
+	title = lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Servers ")
+		Render(title)
 
-	innerWidth := width - 2
-	innerHeight := height - 3
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	// Leave a row for the title.
+	innerWidth, innerHeight := layout.Inner(width, height-1)
 
 	var lines []string
 
@@ -561,36 +1052,46 @@ func (t TreePanel) View(width, height int, focused bool) string {
 		lines = append(lines, theme.NormalItemStyle.Render("No servers found"))
 	} else if len(nodes) == 0 && t.filterText != "" {
 		lines = append(lines, theme.NormalItemStyle.Render("No matches"))
+	} else if len(nodes) == 0 && t.byApp && t.loadingSites() {
+		lines = append(lines, theme.LoadingStyle.Render("Loading sites..."))
+	} else if len(nodes) == 0 && t.byApp && slices.ContainsFunc(t.servers, func(srv forge.Server) bool { return !t.skip(srv) }) {
+		lines = append(lines, theme.NormalItemStyle.Render("No sites found"))
+	} else if len(nodes) == 0 && t.workspaceName != "" {
+		lines = append(lines, theme.NormalItemStyle.Render("Nothing in this workspace"))
+	} else if len(nodes) == 0 {
+		lines = append(lines, theme.NormalItemStyle.Render("All servers hidden (. to show)"))
 	} else {
 		filterLines := 0
 		if t.filterActive || t.filterText != "" {
 			filterLines = 1
 		}
 
-		visibleHeight := innerHeight
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if t.cursor >= visibleHeight {
-			startIdx = t.cursor - visibleHeight + 1
+		visibleHeight := max(innerHeight, 1)
+		startIdx := layout.ScrollStart(t.cursor, visibleHeight)
+
+		// The Recent group's heading takes a line while scrolled to the
+		// top; scroll past it if the cursor would otherwise be hidden.
+		hasRecent := len(nodes) > 0 && nodes[0].Recent
+		if hasRecent && startIdx == 0 && t.cursor >= visibleHeight-1 {
+			startIdx = 1
 		}
 
 		for i := startIdx; i < len(nodes) && len(lines)-filterLines < visibleHeight; i++ {
 			node := nodes[i]
+			if i == 0 && hasRecent {
+				lines = append(lines, "  "+theme.LabelStyle.Render(theme.GlyphStar+" Recent"))
+				if len(lines)-filterLines >= visibleHeight {
+					break
+				}
+			}
 			line := t.renderNode(node, i, innerWidth)
 			lines = append(lines, line)
 		}
 	}
 
 	// Pad to fill.
-	totalHeight := height - 2
-	if totalHeight < 0 {
-		totalHeight = 0
-	}
-	for len(lines) < totalHeight-1 {
-		lines = append(lines, "")
-	}
+	totalHeight := max(height-2, 0)
+	lines = layout.Pad(lines, totalHeight-1)
 
 	content := strings.Join(lines, "\n")
 
@@ -604,10 +1105,23 @@ func (t TreePanel) View(width, height int, focused bool) string {
 func (t TreePanel) renderNode(node TreeNode, idx, maxWidth int) string {
 	isCursor := idx == t.cursor
 
+	if node.Kind == NodeApp {
+		// "acme/api: production-1, staging-1"
+		icon := theme.GlyphCollapsed
+		if t.appExpanded[strings.ToLower(node.App)] {
+			icon = theme.GlyphExpanded
+		}
+		name := theme.Truncate(node.App+": "+strings.Join(node.Envs, ", "), maxWidth-6)
+		if isCursor {
+			return theme.CursorStyle.Render("> ") + theme.SelectedItemStyle.Render(icon+" "+name)
+		}
+		return "  " + theme.NormalItemStyle.Render(icon+" "+name)
+	}
+
 	if node.Kind == NodeServer {
-		icon := "▶"
+		icon := theme.GlyphCollapsed
 		if t.expanded[node.Server.ID] {
-			icon = "▼"
+			icon = theme.GlyphExpanded
 		}
 
 		// Show * next to the default server, and nickname if set.
@@ -618,19 +1132,38 @@ func (t TreePanel) renderNode(node TreeNode, idx, maxWidth int) string {
 		if nick, ok := t.nicknames[node.Server.Name+"\n"]; ok {
 			suffix += " [" + nick + "]"
 		}
+		hidden := t.IsHidden(node.Server)
+		if hidden {
+			suffix += " (hidden)"
+		}
 
-		name := theme.Truncate(node.Server.Name+suffix, maxWidth-6)
+		// Online/offline dot from the last reachability check.
+		dot := ""
+		nameWidth := maxWidth - 6
+		if up, ok := t.reachable[node.Server.ID]; ok {
+			color := theme.ColorError
+			if up {
+				color = theme.ColorSecondary
+			}
+			dot = " " + lipgloss.NewStyle().Foreground(color).Render(theme.GlyphDot)
+			nameWidth -= 2
+		}
+
+		name := theme.Truncate(node.Server.Name+suffix, nameWidth)
 		if isCursor {
 			return theme.CursorStyle.Render("> ") +
-				theme.SelectedItemStyle.Render(icon+" "+name)
+				theme.SelectedItemStyle.Render(icon+" "+name) + dot
 		}
-		return "  " + theme.NormalItemStyle.Render(icon+" "+name)
+		if hidden {
+			return "  " + lipgloss.NewStyle().Foreground(theme.ColorSubtle).Render(icon+" "+name) + dot
+		}
+		return "  " + theme.NormalItemStyle.Render(icon+" "+name) + dot
 	}
 
 	// Site node.
-	prefix := "├ "
+	prefix := theme.GlyphBranch + " "
 	if node.IsLast {
-		prefix = "└ "
+		prefix = theme.GlyphLastBranch + " "
 	}
 
 	siteName := ""
@@ -638,8 +1171,12 @@ func (t TreePanel) renderNode(node TreeNode, idx, maxWidth int) string {
 		siteName = node.Site.Name
 	}
 
-	// Show * next to the default site, and nickname if set.
+	// Show * next to the default site, and nickname if set. Recent entries
+	// also name their server.
 	siteSuffix := ""
+	if node.Recent || node.App != "" {
+		siteSuffix = " (" + node.Server.Name + ")"
+	}
 	if t.defaultSite != "" && strings.EqualFold(siteName, t.defaultSite) {
 		siteSuffix = " *"
 	}
@@ -647,13 +1184,23 @@ func (t TreePanel) renderNode(node TreeNode, idx, maxWidth int) string {
 		siteSuffix += " [" + nick + "]"
 	}
 
-	name := theme.Truncate(siteName+siteSuffix, maxWidth-8)
+	// Latest deployment badge (✓/✗/●), once fetched.
+	badge := ""
+	nameWidth := maxWidth - 8
+	if node.Site != nil {
+		if status := t.deployStatus[node.Site.ID]; status != "" {
+			badge = " " + statusIcon(status)
+			nameWidth -= 2
+		}
+	}
+
+	name := theme.Truncate(siteName+siteSuffix, nameWidth)
 
 	if isCursor {
 		return theme.CursorStyle.Render("> ") +
-			"  " + theme.SelectedItemStyle.Render(prefix+name)
+			"  " + theme.SelectedItemStyle.Render(prefix+name) + badge
 	}
-	return "    " + theme.NormalItemStyle.Render(prefix+name)
+	return "    " + theme.NormalItemStyle.Render(prefix+name) + badge
 }
 
 // HelpBindings returns the key hints for the tree panel.
@@ -669,6 +1216,15 @@ func (t TreePanel) HelpBindings() []HelpBinding {
 	bindings := []HelpBinding{
 		{Key: "j/k", Desc: "navigate"},
 		{Key: "h/l", Desc: "collapse/expand"},
+		{Key: "+/-", Desc: "expand/collapse all"},
+		{Key: "B", Desc: "bulk action by tag"},
+		{Key: ".", Desc: "show/hide hidden"},
+		{Key: "V", Desc: "group by server/app"},
+		{Key: "w", Desc: "workspace"},
+	}
+
+	if node, ok := t.selectedNode(); ok && node.Kind == NodeApp {
+		return append(bindings, HelpBinding{Key: "enter/space", Desc: "expand/collapse"})
 	}
 
 	if t.CursorOnServer() {
@@ -679,6 +1235,7 @@ func (t TreePanel) HelpBindings() []HelpBinding {
 			HelpBinding{Key: "r", Desc: "reboot"},
 			HelpBinding{Key: "D", Desc: "set default"},
 			HelpBinding{Key: "n", Desc: "nickname"},
+			HelpBinding{Key: "H", Desc: "hide/unhide"},
 		)
 	} else {
 		bindings = append(bindings,
@@ -686,13 +1243,15 @@ func (t TreePanel) HelpBindings() []HelpBinding {
 			HelpBinding{Key: "s", Desc: "SSH"},
 			HelpBinding{Key: "D", Desc: "set default"},
 			HelpBinding{Key: "n", Desc: "nickname"},
+			HelpBinding{Key: "*", Desc: "wildcards"},
 		)
 	}
 
-	bindings = append(bindings,
-		HelpBinding{Key: "/", Desc: "filter"},
-		HelpBinding{Key: "tab", Desc: "next panel"},
-	)
+	bindings = append(bindings, HelpBinding{Key: "/", Desc: "filter"})
+	if t.filterText != "" {
+		bindings = append(bindings, HelpBinding{Key: "esc", Desc: "clear filter"})
+	}
+	bindings = append(bindings, HelpBinding{Key: "tab", Desc: "next panel"})
 
 	return bindings
 }
@@ -0,0 +1,155 @@
+package panels
+
+import (
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// The tree's application mode (V) groups sites from every server by the
+// application they run, so "acme/api" lists its production and staging
+// sites together instead of under separate servers.
+
+// AppName returns the application a site belongs to: its repository when
+// one is installed, otherwise the site's name.
+func AppName(site forge.Site) string {
+	if site.Repository != "" {
+		return site.Repository
+	}
+	return site.Name
+}
+
+// SetGroupByApp switches between grouping sites by server and by
+// application, keeping a selected site in view by expanding its server or
+// application. Grouping by application needs every server's sites, which
+// LoadAllSites fetches.
+func (t TreePanel) SetGroupByApp(on bool) TreePanel {
+	prev, ok := t.selectedNode()
+	t.byApp = on
+	t.cursor = 0
+	if !ok {
+		return t
+	}
+	if prev.Kind == NodeSite && !prev.Recent {
+		if on {
+			t.appExpanded[strings.ToLower(AppName(*prev.Site))] = true
+		} else {
+			t.expanded[prev.Server.ID] = true
+		}
+	}
+	return t.reselect(prev)
+}
+
+// GroupingByApp reports whether sites are grouped by application.
+func (t TreePanel) GroupingByApp() bool {
+	return t.byApp
+}
+
+// LoadAllSites returns commands fetching the sites of every shown server
+// whose sites aren't loaded yet, without expanding them.
+func (t TreePanel) LoadAllSites() (TreePanel, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, srv := range t.servers {
+		serverID := srv.ID
+		if t.skip(srv) || t.sitesLoaded[serverID] || t.sitesLoading[serverID] {
+			continue
+		}
+		t.sitesLoading[serverID] = true
+		cmds = append(cmds, func() tea.Msg {
+			return TreeFetchSitesMsg{ServerID: serverID}
+		})
+	}
+	return t, tea.Batch(cmds...)
+}
+
+// loadingSites reports whether any server's sites are being fetched.
+func (t TreePanel) loadingSites() bool {
+	for _, loading := range t.sitesLoading {
+		if loading {
+			return true
+		}
+	}
+	return false
+}
+
+// appNodes builds the visible nodes in application mode: a header per
+// application, sorted by name, followed by its sites when it is expanded
+// or the filter matches some of them. repo is the lower-cased repository
+// of a "repo:<name>" filter, or "".
+func (t TreePanel) appNodes(filterLower, tag, repo string) []TreeNode {
+	type group struct {
+		header TreeNode
+		sites  []TreeNode
+	}
+	var groups []*group
+	byName := make(map[string]*group)
+
+	for _, srv := range t.servers {
+		if t.skip(srv) || (tag != "" && !srv.HasTag(tag)) {
+			continue
+		}
+		for _, site := range t.sitesByServer[srv.ID] {
+			if !t.inWorkspace(srv, &site) {
+				continue
+			}
+			app := AppName(site)
+			if repo != "" && !strings.Contains(strings.ToLower(site.Repository), repo) {
+				continue
+			}
+			if tag == "" && repo == "" && filterLower != "" &&
+				!strings.Contains(strings.ToLower(app), filterLower) &&
+				!strings.Contains(strings.ToLower(site.Name), filterLower) &&
+				!strings.Contains(strings.ToLower(srv.Name), filterLower) {
+				continue
+			}
+			g := byName[strings.ToLower(app)]
+			if g == nil {
+				g = &group{header: TreeNode{Kind: NodeApp, App: app}}
+				byName[strings.ToLower(app)] = g
+				groups = append(groups, g)
+			}
+			if !slices.Contains(g.header.Envs, srv.Name) {
+				g.header.Envs = append(g.header.Envs, srv.Name)
+			}
+			s := site
+			g.sites = append(g.sites, TreeNode{Kind: NodeSite, Server: srv, Site: &s, App: g.header.App})
+		}
+	}
+
+	slices.SortStableFunc(groups, func(a, b *group) int {
+		return strings.Compare(strings.ToLower(a.header.App), strings.ToLower(b.header.App))
+	})
+
+	var nodes []TreeNode
+	for _, g := range groups {
+		nodes = append(nodes, g.header)
+		if t.appExpanded[strings.ToLower(g.header.App)] || filterLower != "" {
+			g.sites[len(g.sites)-1].IsLast = true
+			nodes = append(nodes, g.sites...)
+		}
+	}
+	return nodes
+}
+
+// toggleApp expands or collapses an application node.
+func (t TreePanel) toggleApp(app string) (Panel, tea.Cmd) {
+	k := strings.ToLower(app)
+	t.appExpanded[k] = !t.appExpanded[k]
+	if nodes := t.visibleNodes(); t.cursor >= len(nodes) && len(nodes) > 0 {
+		t.cursor = len(nodes) - 1
+	}
+	return t, t.emitSelected()
+}
+
+// appIndex returns the index of the header of application app in nodes.
+func appIndex(nodes []TreeNode, app string) int {
+	for i, n := range nodes {
+		if n.Kind == NodeApp && n.App == app {
+			return i
+		}
+	}
+	return 0
+}
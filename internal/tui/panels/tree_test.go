@@ -0,0 +1,165 @@
+package panels
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/panels/testutil"
+)
+
+func TestTreeHiddenServers(t *testing.T) {
+	servers := []forge.Server{{ID: 1, Name: "web"}, {ID: 2, Name: "old"}, {ID: 3, Name: "db"}}
+	tree := NewTreePanel().SetServers(servers)
+	tree, _ = tree.SetCursorToServer(3)
+	tree = tree.SetHidden(func(srv forge.Server) bool { return srv.Name == "old" })
+
+	names := func(tree TreePanel) []string {
+		var out []string
+		for _, n := range tree.visibleNodes() {
+			out = append(out, n.Server.Name)
+		}
+		return out
+	}
+	if got := names(tree); len(got) != 2 || got[0] != "web" || got[1] != "db" {
+		t.Fatalf("visible servers = %q, want [web db]", got)
+	}
+	if srv, _ := tree.Selected(); srv.Name != "db" {
+		t.Errorf("selected %q after hiding, want db", srv.Name)
+	}
+
+	p, _ := tree.Update(testutil.Key("."))
+	tree = p.(TreePanel)
+	if !tree.ShowingHidden() || len(names(tree)) != 3 {
+		t.Errorf("after '.' visible servers = %q, want all three", names(tree))
+	}
+	if srv, _ := tree.Selected(); srv.Name != "db" {
+		t.Errorf("selected %q after showing hidden, want db", srv.Name)
+	}
+
+	p, _ = tree.Update(testutil.Key("."))
+	tree = p.(TreePanel)
+	if len(names(tree)) != 2 {
+		t.Errorf("after second '.' visible servers = %q, want two", names(tree))
+	}
+}
+
+func TestTreeGroupByApp(t *testing.T) {
+	servers := []forge.Server{{ID: 1, Name: "production"}, {ID: 2, Name: "staging"}}
+	tree := NewTreePanel().SetServers(servers).SetGroupByApp(true)
+	tree, _ = tree.LoadAllSites()
+	tree = tree.SetSites(1, []forge.Site{
+		{ID: 10, Name: "api.example.com", Repository: "acme/api"},
+		{ID: 11, Name: "blog.example.com"},
+	})
+	tree = tree.SetSites(2, []forge.Site{{ID: 20, Name: "staging.api.example.com", Repository: "Acme/API"}})
+
+	nodes := tree.visibleNodes()
+	if len(nodes) != 2 || nodes[0].App != "acme/api" || nodes[1].App != "blog.example.com" {
+		t.Fatalf("collapsed nodes = %+v, want the two applications", nodes)
+	}
+	if want := []string{"production", "staging"}; !slices.Equal(nodes[0].Envs, want) {
+		t.Errorf("acme/api runs on %q, want %q", nodes[0].Envs, want)
+	}
+
+	p, _ := tree.Update(testutil.Key("enter"))
+	tree = p.(TreePanel)
+	nodes = tree.visibleNodes()
+	if len(nodes) != 4 || nodes[1].Site.ID != 10 || nodes[2].Site.ID != 20 || !nodes[2].IsLast {
+		t.Fatalf("expanded nodes = %+v, want acme/api's two sites under it", nodes)
+	}
+
+	p, _ = tree.Update(testutil.Key("j"))
+	p, _ = p.Update(testutil.Key("j"))
+	p, _ = p.Update(testutil.Key("V"))
+	tree = p.(TreePanel)
+	if srv, site := tree.Selected(); srv.ID != 2 || site == nil || site.ID != 20 {
+		t.Errorf("selected %v, %v after leaving application mode, want staging's site", srv, site)
+	}
+
+	tree = tree.SetGroupByApp(true).CollapseAll()
+	if tree, ok := tree.SetCursorToSite(11); !ok {
+		t.Error("SetCursorToSite did not open the site's application")
+	} else if _, site := tree.Selected(); site == nil || site.ID != 11 {
+		t.Errorf("selected %v, want site 11", site)
+	}
+}
+
+func TestTreeWorkspace(t *testing.T) {
+	servers := []forge.Server{{ID: 1, Name: "production"}, {ID: 2, Name: "staging"}, {ID: 3, Name: "worker"}}
+	tree := NewTreePanel().SetServers(servers)
+	tree = tree.SetSites(1, []forge.Site{{ID: 10, Name: "api.example.com"}, {ID: 11, Name: "blog.example.com"}})
+	tree = tree.SetWorkspace("api", func(srv forge.Server, site *forge.Site) bool {
+		switch srv.Name {
+		case "production":
+			return site == nil || site.Name == "api.example.com"
+		case "worker":
+			return true
+		}
+		return false
+	})
+	tree, _ = tree.ExpandAll()
+
+	var got []string
+	for _, n := range tree.visibleNodes() {
+		if n.Site != nil {
+			got = append(got, n.Site.Name)
+		} else {
+			got = append(got, n.Server.Name)
+		}
+	}
+	if want := []string{"production", "api.example.com", "worker"}; !slices.Equal(got, want) {
+		t.Errorf("workspace nodes = %q, want %q", got, want)
+	}
+	if n := len(tree.WorkspaceServers()); n != 2 {
+		t.Errorf("WorkspaceServers returned %d servers, want 2", n)
+	}
+
+	tree = tree.SetWorkspace("", nil)
+	if n := len(tree.WorkspaceServers()); n != 3 {
+		t.Errorf("without a workspace WorkspaceServers returned %d servers, want 3", n)
+	}
+}
+
+func TestTreeRepoFilter(t *testing.T) {
+	servers := []forge.Server{{ID: 1, Name: "production"}, {ID: 2, Name: "staging"}, {ID: 3, Name: "acme-api"}}
+	tree := NewTreePanel().SetServers(servers)
+	tree = tree.SetSites(1, []forge.Site{
+		{ID: 10, Name: "api.example.com", Repository: "acme/api"},
+		{ID: 11, Name: "blog.example.com", Repository: "acme/blog"},
+	})
+	tree = tree.SetSites(2, []forge.Site{{ID: 20, Name: "staging.example.com", Repository: "Acme/API"}})
+
+	for _, k := range []string{"/", "r", "e", "p", "o", ":", "a", "c", "m", "e", "/", "a", "p", "i", "enter"} {
+		p, _ := tree.Update(testutil.Key(k))
+		tree = p.(TreePanel)
+	}
+	if got := tree.RepoFilter(); got != "acme/api" {
+		t.Fatalf("RepoFilter() = %q, want acme/api", got)
+	}
+	var got []int64
+	for _, n := range tree.visibleNodes() {
+		if n.Site != nil {
+			got = append(got, n.Site.ID)
+		}
+	}
+	if !slices.Equal(got, []int64{10, 20}) {
+		t.Errorf("sites shown = %v, want both deployments of acme/api", got)
+	}
+	for _, n := range tree.visibleNodes() {
+		if n.Kind == NodeServer && n.Server.Name == "acme-api" {
+			t.Error("a repo: filter matched a server by name")
+		}
+	}
+
+	tree = tree.SetGroupByApp(true)
+	got = got[:0]
+	for _, n := range tree.visibleNodes() {
+		if n.Site != nil {
+			got = append(got, n.Site.ID)
+		}
+	}
+	if !slices.Equal(got, []int64{10, 20}) {
+		t.Errorf("sites shown by application = %v, want both deployments of acme/api", got)
+	}
+}
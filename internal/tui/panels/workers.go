@@ -1,6 +1,7 @@
 package panels
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -38,8 +40,9 @@ type WorkersPanel struct {
 	siteID   int64
 
 	workers []forge.Worker
-	cursor  int
+	cursor  int // index into shown()
 	loading bool
+	order   tableSort
 
 	// Keybindings
 	up      key.Binding
@@ -49,6 +52,16 @@ type WorkersPanel struct {
 	del     key.Binding
 	home    key.Binding
 	end     key.Binding
+	sort    key.Binding
+}
+
+// workersSort are the columns the workers table can be sorted by.
+var workersSort = []sortColumn[forge.Worker]{
+	{name: "status", cmp: func(a, b forge.Worker) int { return compareFold(a.Status, b.Status) }},
+	{name: "connection", cmp: func(a, b forge.Worker) int {
+		return compareFold(a.Connection+":"+a.Queue, b.Connection+":"+b.Queue)
+	}},
+	{name: "procs", cmp: func(a, b forge.Worker) int { return cmp.Compare(a.Processes, b.Processes) }},
 }
 
 // NewWorkersPanel creates a new WorkersPanel.
@@ -86,6 +99,7 @@ func NewWorkersPanel(client *forge.Client, serverID, siteID int64) WorkersPanel
 			key.WithKeys("G", "end"),
 			key.WithHelp("G", "bottom"),
 		),
+		sort: sortKey,
 	}
 }
 
@@ -103,20 +117,12 @@ func (p WorkersPanel) LoadWorkers() tea.Cmd {
 	}
 }
 
-// CreateWorker returns a tea.Cmd that creates a new worker with default settings.
-func (p WorkersPanel) CreateWorker() tea.Cmd {
+// CreateWorker returns a tea.Cmd that creates a new worker.
+func (p WorkersPanel) CreateWorker(opts forge.WorkerCreateOpts) tea.Cmd {
 	client := p.client
 	serverID := p.serverID
 	siteID := p.siteID
 	return func() tea.Msg {
-		opts := forge.WorkerCreateOpts{
-			Connection: "redis",
-			Queue:      "default",
-			Timeout:    60,
-			Sleep:      3,
-			Processes:  1,
-			Daemon:     true,
-		}
 		worker, err := client.Workers.Create(context.Background(), serverID, siteID, opts)
 		if err != nil {
 			return PanelErrMsg{Err: err}
@@ -127,13 +133,14 @@ func (p WorkersPanel) CreateWorker() tea.Cmd {
 
 // RestartWorker returns a tea.Cmd that restarts the currently selected worker.
 func (p WorkersPanel) RestartWorker() tea.Cmd {
-	if len(p.workers) == 0 || p.cursor >= len(p.workers) {
+	w := p.SelectedWorker()
+	if w == nil {
 		return nil
 	}
 	client := p.client
 	serverID := p.serverID
 	siteID := p.siteID
-	workerID := p.workers[p.cursor].ID
+	workerID := w.ID
 	return func() tea.Msg {
 		err := client.Workers.Restart(context.Background(), serverID, siteID, workerID)
 		if err != nil {
@@ -145,13 +152,14 @@ func (p WorkersPanel) RestartWorker() tea.Cmd {
 
 // DeleteWorker returns a tea.Cmd that deletes the currently selected worker.
 func (p WorkersPanel) DeleteWorker() tea.Cmd {
-	if len(p.workers) == 0 || p.cursor >= len(p.workers) {
+	w := p.SelectedWorker()
+	if w == nil {
 		return nil
 	}
 	client := p.client
 	serverID := p.serverID
 	siteID := p.siteID
-	workerID := p.workers[p.cursor].ID
+	workerID := w.ID
 	return func() tea.Msg {
 		err := client.Workers.Delete(context.Background(), serverID, siteID, workerID)
 		if err != nil {
@@ -161,22 +169,34 @@ func (p WorkersPanel) DeleteWorker() tea.Cmd {
 	}
 }
 
+// shown returns the workers in the order the table shows them.
+func (p WorkersPanel) shown() []forge.Worker {
+	return sortRows(p.workers, workersSort, p.order)
+}
+
 // SelectedWorker returns the currently selected worker, or nil.
 func (p WorkersPanel) SelectedWorker() *forge.Worker {
-	if len(p.workers) == 0 || p.cursor >= len(p.workers) {
+	shown := p.shown()
+	if len(shown) == 0 || p.cursor >= len(shown) {
 		return nil
 	}
-	w := p.workers[p.cursor]
+	w := shown[p.cursor]
 	return &w
 }
 
+// Loading reports whether the panel's data is still being fetched.
+func (p WorkersPanel) Loading() bool {
+	return p.loading
+}
+
 // Update handles messages for the workers panel.
 func (p WorkersPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case WorkersLoadedMsg:
+		old := p.shown()
 		p.workers = msg.Workers
+		p.cursor = keepCursor(old, p.shown(), p.cursor, workerID)
 		p.loading = false
-		p.cursor = 0
 		return p, nil
 
 	case tea.KeyPressMsg:
@@ -186,8 +206,16 @@ func (p WorkersPanel) Update(msg tea.Msg) (Panel, tea.Cmd) {
 	return p, nil
 }
 
+func workerID(w forge.Worker) int64 { return w.ID }
+
 func (p WorkersPanel) handleKey(msg tea.KeyPressMsg) (Panel, tea.Cmd) {
 	switch {
+	case key.Matches(msg, p.sort):
+		old := p.shown()
+		p.order = p.order.next(len(workersSort))
+		p.cursor = keepCursor(old, p.shown(), p.cursor, workerID)
+		return p, nil
+
 	case key.Matches(msg, p.down):
 		if len(p.workers) > 0 {
 			p.cursor = min(p.cursor+1, len(p.workers)-1)
@@ -225,19 +253,13 @@ func (p WorkersPanel) View(width, height int, focused bool) string {
 		titleColor = theme.ColorPrimary
 	}
 
-	innerWidth := width - 2
-	innerHeight := height - 2
-	if innerWidth < 0 {
-		innerWidth = 0
-	}
-	if innerHeight < 0 {
-		innerHeight = 0
-	}
+	innerWidth, innerHeight := layout.Inner(width, height)
 
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(titleColor).
-		Render(" Workers ")
+		Render(countTitle("Workers", len(p.workers), p.loading)) +
+		sortChip(workersSort, p.order)
 
 	content := p.renderList(innerWidth, innerHeight-1)
 
@@ -253,11 +275,7 @@ const workerColProcsWidth = 8
 const workerTableOverhead = 2 + colStatusWidth + 2 + 2 + workerColProcsWidth + 4
 
 func workerConnWidth(maxWidth int) int {
-	w := maxWidth - workerTableOverhead
-	if w < 10 {
-		w = 10
-	}
-	return w
+	return layout.Columns(maxWidth, workerTableOverhead, 10)
 }
 
 func (p WorkersPanel) renderList(width, height int) string {
@@ -270,25 +288,18 @@ func (p WorkersPanel) renderList(width, height int) string {
 	} else {
 		lines = append(lines, p.renderWorkerHeader(width))
 
-		visibleHeight := height - 2
-		if visibleHeight < 1 {
-			visibleHeight = 1
-		}
-		startIdx := 0
-		if p.cursor >= visibleHeight {
-			startIdx = p.cursor - visibleHeight + 1
-		}
+		visibleHeight := max(height-2, 1)
+		startIdx := layout.ScrollStart(p.cursor, visibleHeight)
 
-		for i := startIdx; i < len(p.workers) && len(lines)-1 < visibleHeight; i++ {
-			w := p.workers[i]
+		shown := p.shown()
+		for i := startIdx; i < len(shown) && len(lines)-1 < visibleHeight; i++ {
+			w := shown[i]
 			line := p.renderWorkerLine(w, i, width)
 			lines = append(lines, line)
 		}
 	}
 
-	for len(lines) < height {
-		lines = append(lines, "")
-	}
+	lines = layout.Pad(lines, height)
 
 	return strings.Join(lines, "\n")
 }
@@ -350,6 +361,7 @@ func (p WorkersPanel) HelpBindings() []HelpBinding {
 		{Key: "c", Desc: "create"},
 		{Key: "r", Desc: "restart"},
 		{Key: "x", Desc: "delete"},
+		{Key: "s", Desc: "sort"},
 		{Key: "g/G", Desc: "top/bottom"},
 		{Key: "esc", Desc: "back"},
 		{Key: "tab", Desc: "switch panel"},
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// maxUploadLimit caps the max upload size that can be set, in megabytes.
+const maxUploadLimit = 10240
+
+// handleServerInfoKey handles keys on the server info tab: u sets PHP's max
+// upload size and o toggles OPcache.
+func (m App) handleServerInfoKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("u"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInput("max-upload", fmt.Sprintf("Max upload size on %s (MB):", m.selectedSrv.Name), "64").Remember())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("o"))):
+		action := "Enable"
+		if m.selectedSrv.OPcacheEnabled() {
+			action = "Disable"
+		}
+		m.dialogs = m.dialogs.Confirm("toggle-opcache", fmt.Sprintf("%s OPcache on %s?", action, m.selectedSrv.Name))
+		return m, nil
+	}
+	return m, nil
+}
+
+// setMaxUpload sets the selected server's max upload size to value,
+// given in megabytes.
+func (m App) setMaxUpload(value string) (tea.Model, tea.Cmd) {
+	if m.selectedSrv == nil {
+		return m, nil
+	}
+	mb, err := strconv.Atoi(strings.TrimSuffix(strings.ToUpper(strings.TrimSpace(value)), "M"))
+	if err != nil || mb < 1 || mb > maxUploadLimit {
+		m.toast = fmt.Sprintf("Max upload size must be 1-%d MB", maxUploadLimit)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	client := m.forge
+	srv := *m.selectedSrv
+	m.toast = fmt.Sprintf("Setting max upload size on %s...", srv.Name)
+	m.toastIsErr = false
+	return m, func() tea.Msg {
+		err := client.Servers.SetMaxUploadSize(context.Background(), srv.ID, mb)
+		return phpSettingMsg{server: srv, what: fmt.Sprintf("max upload size %d MB", mb), err: err}
+	}
+}
+
+// toggleOPcache turns OPcache on the selected server off when it is
+// enabled and on otherwise.
+func (m App) toggleOPcache() (tea.Model, tea.Cmd) {
+	if m.selectedSrv == nil {
+		return m, nil
+	}
+	client := m.forge
+	srv := *m.selectedSrv
+	enable := !srv.OPcacheEnabled()
+	return m, func() tea.Msg {
+		var err error
+		if enable {
+			err = client.Servers.EnableOPcache(context.Background(), srv.ID)
+			srv.OPcacheStatus = "enabled"
+		} else {
+			err = client.Servers.DisableOPcache(context.Background(), srv.ID)
+			srv.OPcacheStatus = "disabled"
+		}
+		what := "OPcache " + srv.OPcacheStatus
+		return phpSettingMsg{server: srv, what: what, err: err}
+	}
+}
+
+// handlePHPSetting reports a PHP settings change and shows the server as
+// it is now.
+func (m App) handlePHPSetting(msg phpSettingMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("PHP settings on %s: %v", msg.server.Name, msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	if m.selectedSrv != nil && m.selectedSrv.ID == msg.server.ID {
+		srv := msg.server
+		m.selectedSrv = &srv
+		m.detail.serverInfo = m.detail.serverInfo.SetServer(&srv)
+	}
+	m.toast = fmt.Sprintf("%s: %s", msg.server.Name, msg.what)
+	m.toastIsErr = false
+	return m, m.clearToastAfter(3 * time.Second)
+}
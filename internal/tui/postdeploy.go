@@ -0,0 +1,307 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/health"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// deployWatchInterval is how often a deployment is polled while waiting to
+// run the post-deploy steps after it.
+const deployWatchInterval = 5 * time.Second
+
+// deployWatchMaxPolls bounds how long a deployment is watched (30 minutes).
+const deployWatchMaxPolls = 360
+
+// deployWatch tracks a TUI-triggered deployment until it finishes, to save
+// its output and run the post-deploy steps after it.
+type deployWatch struct {
+	serverID  int64
+	siteID    int64
+	prevID    int64 // newest deployment before the one being watched
+	siteName  string
+	daemons   []string
+	healthURL string
+	polls     int
+
+	// rollback redeploys the previous successful commit through
+	// triggerURL when the health check fails.
+	rollback   bool
+	triggerURL string
+	deployID   int64 // the watched deployment, once it has appeared
+}
+
+// healthCheckedMsg carries the result of a post-deploy health probe.
+type healthCheckedMsg struct {
+	watch  deployWatch
+	result health.Result
+}
+
+// rollbackDoneMsg reports the outcome of an automatic rollback.
+type rollbackDoneMsg struct {
+	siteName string
+	target   *forge.Deployment // the deployment whose commit was redeployed
+	err      error
+}
+
+// deployWatchTickMsg asks for the watched deployment to be checked again.
+type deployWatchTickMsg struct {
+	watch deployWatch
+}
+
+// deployWatchStatusMsg carries the status and ID of the watched
+// deployment. status is empty while the new deployment has not appeared
+// yet.
+type deployWatchStatusMsg struct {
+	watch  deployWatch
+	status string
+	id     int64
+	err    error
+}
+
+// daemonsRestartedMsg reports the outcome of each post-deploy restart.
+type daemonsRestartedMsg struct {
+	siteName string
+	results  []string
+	failed   int
+}
+
+// restartDaemonsFor returns the daemons to restart after siteName is
+// deployed: those in the user config plus, when siteName is the .phorge
+// project's site, its restart_daemons directive.
+func (m App) restartDaemonsFor(siteName string) []string {
+	daemons := append([]string(nil), m.config.DaemonsToRestart(siteName)...)
+
+	if m.project.IsProjectSite(siteName) {
+		for _, d := range m.project.RestartDaemons {
+			if !containsFold(daemons, d) {
+				daemons = append(daemons, d)
+			}
+		}
+	}
+	return daemons
+}
+
+// healthURLFor returns the health-check URL to probe after siteName is
+// deployed: the user config's entry for the site, or else the .phorge
+// health_check when siteName is the project's site.
+func (m App) healthURLFor(siteName string) string {
+	if url := m.config.HealthCheckURL(siteName); url != "" {
+		return url
+	}
+	if m.project.IsProjectSite(siteName) {
+		return m.project.HealthCheck
+	}
+	return ""
+}
+
+// containsFold reports whether list contains s, ignoring case.
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// watchDeployTick schedules the next check of a watched deployment.
+func watchDeployTick(w deployWatch) tea.Cmd {
+	return tea.Tick(deployWatchInterval, func(time.Time) tea.Msg {
+		return deployWatchTickMsg{watch: w}
+	})
+}
+
+// checkDeployWatch returns a command that looks up the status of the first
+// deployment newer than the watch's baseline.
+func (m App) checkDeployWatch(w deployWatch) tea.Cmd {
+	client := m.forge
+	return func() tea.Msg {
+		deployments, err := client.Deployments.List(context.Background(), w.serverID, w.siteID)
+		if err != nil {
+			return deployWatchStatusMsg{watch: w, err: err}
+		}
+		for _, d := range deployments {
+			if d.ID > w.prevID {
+				return deployWatchStatusMsg{watch: w, status: d.Status, id: d.ID}
+			}
+		}
+		return deployWatchStatusMsg{watch: w}
+	}
+}
+
+// handleDeployWatchStatus restarts the watched site's daemons and probes
+// its health check once its deployment has finished, or keeps polling
+// while it is still running.
+func (m App) handleDeployWatchStatus(msg deployWatchStatusMsg) (tea.Model, tea.Cmd) {
+	w := msg.watch
+	w.deployID = msg.id
+	switch {
+	case msg.status == "finished":
+		cmds := []tea.Cmd{m.fireDeployFinished(w, msg.status), m.saveDeployOutput(w)}
+		switch {
+		case len(w.daemons) > 0:
+			m.toast = fmt.Sprintf("Deploy finished — restarting %d daemon(s)...", len(w.daemons))
+			cmds = append(cmds, m.restartSiteDaemons(w))
+		case w.healthURL != "":
+			m.toast = "Deploy finished — checking health..."
+		default:
+			m.toast = fmt.Sprintf("Deploy of %s finished", w.siteName)
+			cmds = append(cmds, m.clearToastAfter(5*time.Second))
+		}
+		m.toastIsErr = false
+		if w.healthURL != "" {
+			cmds = append(cmds, probeHealth(w))
+		}
+		return m, tea.Batch(cmds...)
+
+	case msg.status == "failed" || msg.status == "error":
+		m.toast = fmt.Sprintf("Deploy of %s failed", w.siteName)
+		if len(w.daemons) > 0 {
+			m.toast += " — daemons not restarted"
+		}
+		m.toastIsErr = true
+		return m, tea.Batch(m.clearToastAfter(5*time.Second), m.fireDeployFinished(w, "failed"), m.saveDeployOutput(w))
+	}
+
+	// Still queued or deploying (or a transient API error): try again
+	// unless the deployment has been watched for too long.
+	w.polls++
+	if w.polls >= deployWatchMaxPolls {
+		m.toast = fmt.Sprintf("Stopped waiting for %s to deploy", w.siteName)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	return m, watchDeployTick(w)
+}
+
+// probeHealth returns a command that requests the watched site's
+// health-check URL.
+func probeHealth(w deployWatch) tea.Cmd {
+	return func() tea.Msg {
+		return healthCheckedMsg{watch: w, result: health.Probe(context.Background(), w.healthURL)}
+	}
+}
+
+// handleHealthChecked reports a post-deploy health probe and, when it
+// failed and the site opted in, rolls the site back.
+func (m App) handleHealthChecked(msg healthCheckedMsg) (tea.Model, tea.Cmd) {
+	w := msg.watch
+	if msg.result.OK() {
+		m.toast = fmt.Sprintf("%s health check: %s", w.siteName, msg.result)
+		m.toastIsErr = false
+		return m, m.clearToastAfter(8 * time.Second)
+	}
+
+	m.toast = fmt.Sprintf("%s health check failed: %s", w.siteName, msg.result)
+	m.toastIsErr = true
+	if !w.rollback {
+		return m, m.clearToastAfter(8 * time.Second)
+	}
+	m.toast += " — rolling back..."
+	return m, m.rollbackDeploy(w)
+}
+
+// rollbackDeploy returns a command that redeploys the commit of the last
+// successful deployment before the watched one.
+func (m App) rollbackDeploy(w deployWatch) tea.Cmd {
+	client := m.forge
+	return func() tea.Msg {
+		target, err := client.Deployments.Rollback(context.Background(), w.serverID, w.siteID, w.triggerURL, w.deployID)
+		return rollbackDoneMsg{siteName: w.siteName, target: target, err: err}
+	}
+}
+
+// handleRollbackDone reports the outcome of an automatic rollback.
+func (m App) handleRollbackDone(msg rollbackDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Rollback of %s failed: %v", msg.siteName, msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(10 * time.Second)
+	}
+	m.toast = fmt.Sprintf("Health check failed — rolling %s back to %s (deployment #%d)",
+		msg.siteName, shortCommit(msg.target.CommitHash), msg.target.ID)
+	m.toastIsErr = true
+	cmds := []tea.Cmd{m.clearToastAfter(10 * time.Second)}
+	if m.detail.activeTab == 1 {
+		cmds = append(cmds, m.detail.deploymentsPanel.LoadDeployments())
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// shortCommit abbreviates a commit hash to 7 characters.
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// autoRollbackFor reports whether siteName rolls back when its post-deploy
+// health check fails: the user config's entry for the site, or else the
+// .phorge auto_rollback when siteName is the project's site.
+func (m App) autoRollbackFor(siteName string) bool {
+	if m.config.AutoRollbackEnabled(siteName) {
+		return true
+	}
+	return m.project.AutoRollback && m.project.IsProjectSite(siteName)
+}
+
+// restartSiteDaemons returns a command that restarts every daemon on the
+// watched server matching the configured entries.
+func (m App) restartSiteDaemons(w deployWatch) tea.Cmd {
+	client := m.forge
+	return func() tea.Msg {
+		ctx := context.Background()
+		result := daemonsRestartedMsg{siteName: w.siteName}
+
+		daemons, err := client.Daemons.List(ctx, w.serverID)
+		if err != nil {
+			result.results = []string{fmt.Sprintf("listing daemons: %v", err)}
+			result.failed = len(w.daemons)
+			return result
+		}
+
+		for _, entry := range w.daemons {
+			d := matchDaemon(daemons, entry)
+			if d == nil {
+				result.results = append(result.results, entry+" "+theme.GlyphFail+" not found")
+				result.failed++
+				continue
+			}
+			if err := client.Daemons.Restart(ctx, w.serverID, d.ID); err != nil {
+				result.results = append(result.results, fmt.Sprintf("%s %s %v", entry, theme.GlyphFail, err))
+				result.failed++
+				continue
+			}
+			result.results = append(result.results, entry+" "+theme.GlyphOK)
+		}
+		return result
+	}
+}
+
+// matchDaemon finds the daemon identified by entry: an exact daemon ID,
+// or otherwise the first daemon whose command contains entry.
+func matchDaemon(daemons []forge.Daemon, entry string) *forge.Daemon {
+	if id, err := strconv.ParseInt(entry, 10, 64); err == nil {
+		for i := range daemons {
+			if daemons[i].ID == id {
+				return &daemons[i]
+			}
+		}
+	}
+	for i := range daemons {
+		if strings.Contains(daemons[i].Command, entry) {
+			return &daemons[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// ProvidersModal is a floating overlay listing the cloud provider
+// credentials linked to the Forge account, so their IDs can be looked up
+// and copied instead of guessed. Enter lists the regions and sizes of the
+// selected credential's provider.
+type ProvidersModal struct {
+	client  *forge.Client
+	active  bool
+	loading bool
+	creds   []forge.Credential
+	err     error
+	cursor  int
+	status  string
+
+	// regions is set while the regions of a provider are listed, with
+	// regionCursor the selected one.
+	regions      *providerRegions
+	regionCursor int
+}
+
+// providerRegions is the regions view of one provider.
+type providerRegions struct {
+	provider string
+	loading  bool
+	regions  []forge.Region
+	err      error
+}
+
+// providersLoadedMsg carries the account's provider credentials.
+type providersLoadedMsg struct {
+	creds []forge.Credential
+	err   error
+}
+
+// providerRegionsMsg carries the region catalogue for the regions view.
+type providerRegionsMsg struct {
+	cat *config.Catalogue
+	err error
+}
+
+// NewProvidersModal creates a new (inactive) providers modal.
+func NewProvidersModal() ProvidersModal {
+	return ProvidersModal{}
+}
+
+// Open activates the modal and fetches the credentials using client.
+func (p ProvidersModal) Open(client *forge.Client) (ProvidersModal, tea.Cmd) {
+	p = ProvidersModal{client: client, active: true, loading: true}
+	return p, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		creds, err := client.Credentials.List(ctx)
+		return providersLoadedMsg{creds: creds, err: err}
+	}
+}
+
+// Active returns whether the providers modal is currently visible.
+func (p ProvidersModal) Active() bool {
+	return p.active
+}
+
+// Update handles key events and the credentials and regions fetch
+// results. j/k move, y copies the selected credential's ID and enter
+// lists its provider's regions.
+func (p ProvidersModal) Update(msg tea.Msg) (ProvidersModal, tea.Cmd) {
+	switch msg := msg.(type) {
+	case providersLoadedMsg:
+		p.loading = false
+		p.creds = msg.creds
+		p.err = msg.err
+	case providerRegionsMsg:
+		if p.regions == nil {
+			return p, nil
+		}
+		p.regions.loading = false
+		p.regions.err = msg.err
+		if msg.cat != nil {
+			p.regions.regions = msg.cat.Regions[p.regions.provider]
+		}
+	case tea.KeyPressMsg:
+		if p.regions != nil {
+			return p.updateRegions(msg), nil
+		}
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "q", "P"))):
+			p.active = false
+		case key.Matches(msg, key.NewBinding(key.WithKeys("j", "down"))):
+			p.cursor = min(p.cursor+1, max(len(p.creds)-1, 0))
+			p.status = ""
+		case key.Matches(msg, key.NewBinding(key.WithKeys("k", "up"))):
+			p.cursor = max(p.cursor-1, 0)
+			p.status = ""
+		case key.Matches(msg, key.NewBinding(key.WithKeys("y", "c"))):
+			if p.cursor >= len(p.creds) {
+				return p, nil
+			}
+			c := p.creds[p.cursor]
+			p.status = fmt.Sprintf("ID of %s copied", c.Name)
+			return p, tea.SetClipboard(strconv.FormatInt(c.ID, 10))
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter", "l"))):
+			if p.cursor >= len(p.creds) {
+				return p, nil
+			}
+			p.regions = &providerRegions{provider: p.creds[p.cursor].Type, loading: true}
+			p.regionCursor = 0
+			p.status = ""
+			client := p.client
+			return p, func() tea.Msg {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				cat, err := config.CachedCatalogue(ctx, client, false)
+				return providerRegionsMsg{cat: cat, err: err}
+			}
+		}
+	}
+	return p, nil
+}
+
+// updateRegions handles keys in the regions view: j/k move, esc goes back
+// to the credentials.
+func (p ProvidersModal) updateRegions(msg tea.KeyPressMsg) ProvidersModal {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "h", "backspace"))):
+		p.regions = nil
+	case key.Matches(msg, key.NewBinding(key.WithKeys("q", "P"))):
+		p.regions = nil
+		p.active = false
+	case key.Matches(msg, key.NewBinding(key.WithKeys("j", "down"))):
+		p.regionCursor = min(p.regionCursor+1, max(len(p.regions.regions)-1, 0))
+	case key.Matches(msg, key.NewBinding(key.WithKeys("k", "up"))):
+		p.regionCursor = max(p.regionCursor-1, 0)
+	}
+	return p
+}
+
+// View renders the providers modal as a box suitable for overlay.
+func (p ProvidersModal) View(width, height int) string {
+	if !p.active {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.ColorPrimary).
+		Align(lipgloss.Center)
+
+	subtleStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorSubtle)
+
+	errStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorError)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorMuted).
+		Align(lipgloss.Center)
+
+	contentWidth := layout.Clamp(width-6, 30, 64)
+
+	if p.regions != nil {
+		return p.viewRegions(width, height)
+	}
+
+	lines := []string{
+		titleStyle.Width(contentWidth).Render("Provider credentials"),
+		"",
+	}
+	switch {
+	case p.loading:
+		lines = append(lines, theme.LoadingStyle.Render("Loading credentials..."))
+	case p.err != nil:
+		lines = append(lines, errStyle.Render(theme.GlyphFail+" "+p.err.Error()))
+	case len(p.creds) == 0:
+		lines = append(lines, "No provider credentials are linked to this account.")
+	default:
+		idWidth := 0
+		for _, c := range p.creds {
+			idWidth = max(idWidth, len(strconv.FormatInt(c.ID, 10)))
+		}
+		visible := max(height-12, 1)
+		start := layout.ScrollStart(p.cursor, visible)
+		for i := start; i < len(p.creds) && i < start+visible; i++ {
+			c := p.creds[i]
+			provider := fmt.Sprintf("%-16s", theme.Truncate(c.Provider(), 16))
+			id := fmt.Sprintf("%*d", idWidth, c.ID)
+			name := theme.Truncate(c.Name, max(contentWidth-2-16-2-idWidth-2, 4))
+			prefix := "  "
+			if i == p.cursor {
+				prefix = theme.CursorStyle.Render("> ")
+				name = theme.SelectedItemStyle.Render(name)
+			}
+			lines = append(lines, prefix+subtleStyle.Render(id)+"  "+provider+"  "+name)
+		}
+	}
+
+	lines = append(lines, "")
+	if p.status != "" {
+		lines = append(lines, hintStyle.Width(contentWidth).Render(p.status))
+	}
+	lines = append(lines, hintStyle.Width(contentWidth).Render("j/k move  enter regions  y copy ID  esc close"))
+
+	return lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(theme.ColorPrimary).
+		Padding(1, 2).
+		Background(theme.ColorBg).
+		Width(contentWidth + 4).
+		Render(strings.Join(lines, "\n"))
+}
+
+// viewRegions renders the regions of a provider, the selected one with
+// the sizes it offers.
+func (p ProvidersModal) viewRegions(width, height int) string {
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.ColorPrimary).
+		Align(lipgloss.Center)
+
+	subtleStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorSubtle)
+
+	errStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorError)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorMuted).
+		Align(lipgloss.Center)
+
+	contentWidth := layout.Clamp(width-6, 30, 64)
+	r := p.regions
+	name := forge.Credential{Type: r.provider}.Provider()
+
+	lines := []string{
+		titleStyle.Width(contentWidth).Render("Regions · " + name),
+		"",
+	}
+	switch {
+	case r.loading:
+		lines = append(lines, theme.LoadingStyle.Render("Loading regions..."))
+	case r.err != nil:
+		lines = append(lines, errStyle.Render(theme.GlyphFail+" "+r.err.Error()))
+	case len(r.regions) == 0:
+		lines = append(lines, "Forge lists no regions for "+name+".")
+	default:
+		// The selected region's sizes are listed below the regions, so
+		// the regions get whatever they leave.
+		sizes := r.regions[min(p.regionCursor, len(r.regions)-1)].Sizes
+		sizeRows := min(len(sizes), max((height-12)/2, 1))
+		visible := max(height-12-sizeRows-2, 1)
+		start := layout.ScrollStart(p.regionCursor, visible)
+		for i := start; i < len(r.regions) && i < start+visible; i++ {
+			region := r.regions[i]
+			label := fmt.Sprintf("%-12s %s", theme.Truncate(region.ID, 12), region.Name)
+			label = theme.Truncate(label, contentWidth-2)
+			prefix := "  "
+			if i == p.regionCursor {
+				prefix = theme.CursorStyle.Render("> ")
+				label = theme.SelectedItemStyle.Render(label)
+			}
+			lines = append(lines, prefix+label)
+		}
+		lines = append(lines, "", subtleStyle.Render(fmt.Sprintf("Sizes (%d)", len(sizes))))
+		for _, s := range sizes[:sizeRows] {
+			lines = append(lines, "  "+theme.Truncate(fmt.Sprintf("%-16s %s", s.Size, s.Name), contentWidth-2))
+		}
+		if sizeRows < len(sizes) {
+			lines = append(lines, subtleStyle.Render(fmt.Sprintf("  ... %d more (phorge regions %s %s)", len(sizes)-sizeRows, r.provider, r.regions[p.regionCursor].ID)))
+		}
+	}
+
+	lines = append(lines, "", hintStyle.Width(contentWidth).Render("j/k move  esc back"))
+
+	return lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(theme.ColorPrimary).
+		Padding(1, 2).
+		Background(theme.ColorBg).
+		Width(contentWidth + 4).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// quickDeployMsg is sent after quick deploy is turned on or off for a
+// site. site is the site as it is once the change is made.
+type quickDeployMsg struct {
+	site forge.Site
+	err  error
+}
+
+// confirmQuickDeploy asks before turning quick deploy on the selected site
+// off when it is enabled and on otherwise.
+func (m App) confirmQuickDeploy() (tea.Model, tea.Cmd) {
+	if m.selectedSrv == nil || m.selectedSite == nil {
+		return m, nil
+	}
+	q := fmt.Sprintf("Enable quick deploy on %s?\nEvery push to %s will deploy the site.",
+		m.selectedSite.Name, branchOrDefault(m.selectedSite.RepositoryBranch))
+	if m.selectedSite.QuickDeploy {
+		q = fmt.Sprintf("Disable quick deploy on %s?", m.selectedSite.Name)
+	}
+	m.dialogs = m.dialogs.Confirm("toggle-quick-deploy", q)
+	return m, nil
+}
+
+// toggleQuickDeploy turns quick deploy on the selected site off when it is
+// enabled and on otherwise.
+func (m App) toggleQuickDeploy() (tea.Model, tea.Cmd) {
+	if m.selectedSrv == nil || m.selectedSite == nil {
+		return m, nil
+	}
+	client := m.forge
+	serverID := m.selectedSrv.ID
+	site := *m.selectedSite
+	return m, func() tea.Msg {
+		var err error
+		if site.QuickDeploy {
+			err = client.Deployments.DisableQuickDeploy(context.Background(), serverID, site.ID)
+		} else {
+			err = client.Deployments.EnableQuickDeploy(context.Background(), serverID, site.ID)
+		}
+		if err == nil {
+			site.QuickDeploy = !site.QuickDeploy
+		}
+		return quickDeployMsg{site: site, err: err}
+	}
+}
+
+// handleQuickDeploy reports a quick deploy change and shows the site as it
+// is now.
+func (m App) handleQuickDeploy(msg quickDeployMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Quick deploy on %s: %v", msg.site.Name, msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	site := msg.site
+	m.treePanel = m.treePanel.UpdateSite(site)
+	if m.selectedSite != nil && m.selectedSite.ID == site.ID {
+		m.selectedSite = &site
+		m.detail.siteInfo = m.detail.siteInfo.SetSite(&site)
+		m.detail.gitPanel = m.detail.gitPanel.SetSite(&site)
+		m.detail.deploymentsPanel = m.detail.deploymentsPanel.SetSite(&site)
+	}
+	state := "off"
+	if site.QuickDeploy {
+		state = "on"
+	}
+	m.toast = fmt.Sprintf("Quick deploy %s for %s", state, site.Name)
+	m.toastIsErr = false
+	return m, m.clearToastAfter(3 * time.Second)
+}
+
+// branchOrDefault returns branch, or "the deployed branch" when the site
+// has none recorded.
+func branchOrDefault(branch string) string {
+	if branch == "" {
+		return "the deployed branch"
+	}
+	return branch
+}
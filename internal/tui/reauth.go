@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// authExpiredMsg reports that the API rejected the configured key.
+type authExpiredMsg struct{}
+
+// newForgeClient returns an API client for cfg's keys that signals on
+// expired whenever the primary key is rejected.
+func newForgeClient(cfg *config.Config, expired chan struct{}) *forge.Client {
+	c := forge.NewClient(cfg.Forge.APIKey).WithFallbackToken(cfg.Forge.FallbackAPIKey).WithSchemaLog(cfg.Forge.SchemaLog)
+	c.OnAuthError = func(error) {
+		select {
+		case expired <- struct{}{}:
+		default: // already signalled
+		}
+	}
+	return c
+}
+
+// waitAuthExpired waits for the API to reject the key.
+func waitAuthExpired(expired chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		<-expired
+		return authExpiredMsg{}
+	}
+}
+
+// handleAuthExpired swaps the error toasts of a rejected key for a prompt
+// to enter a new one. Once the prompt has been dismissed, later rejections
+// are left to show as errors.
+func (m App) handleAuthExpired() (tea.Model, tea.Cmd) {
+	wait := waitAuthExpired(m.authExpired)
+	if m.reauth != nil || m.reauthDismissed {
+		return m, wait
+	}
+	s := NewReauth(m.config)
+	model, _ := s.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	s = model.(Setup)
+	m.reauth = &s
+	return m, tea.Batch(wait, s.Init())
+}
+
+// updateReauth routes a message to the key prompt.
+func (m App) updateReauth(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.reauth.Update(msg)
+	s := model.(Setup)
+	m.reauth = &s
+	return m, cmd
+}
+
+// handleReauthDone closes the key prompt. With a new key, the API client
+// is replaced and the servers and the tab on screen are reloaded, leaving
+// the selection as it was.
+func (m App) handleReauthDone(msg reauthDoneMsg) (tea.Model, tea.Cmd) {
+	m.reauth = nil
+	if msg.apiKey == "" {
+		m.reauthDismissed = true
+		m.toast = "API key not updated; requests will keep failing"
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+
+	m.forge = newForgeClient(m.config, m.authExpired)
+	m.detail = m.detail.ForgetPanels()
+	m.toast = "API key updated"
+	m.toastIsErr = false
+	cmds := []tea.Cmd{m.fetchServers(), m.clearToastAfter(3 * time.Second)}
+	if m.selectedSrv != nil {
+		siteID := int64(0)
+		if m.selectedSite != nil {
+			siteID = m.selectedSite.ID
+		}
+		model, tabCmd := m.initTabPanel(m.detail.activeTab, m.selectedSrv.ID, siteID)
+		m = model.(App)
+		cmds = append(cmds, tabCmd)
+	}
+	return m, tea.Batch(cmds...)
+}
@@ -0,0 +1,266 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+)
+
+// Forge runs recipes in the background and doesn't return their output,
+// so it is read from the server event each run adds: polled every
+// recipePollInterval for up to recipeOutputTimeout.
+const (
+	recipePollInterval  = 5 * time.Second
+	recipeOutputTimeout = 5 * time.Minute
+)
+
+// recipeRun is a run of a recipe being set up: the recipe and, once
+// picked, the servers it runs on.
+type recipeRun struct {
+	recipe  forge.Recipe
+	servers []forge.Server
+}
+
+// recipeRanMsg carries the output of a recipe run on each of its servers,
+// "" for a server whose output didn't arrive in time.
+type recipeRanMsg struct {
+	recipe  string
+	servers []forge.Server
+	outputs []string
+	err     error
+}
+
+// recipeEditedMsg carries the script of a new recipe once the editor
+// exits.
+type recipeEditedMsg struct {
+	script string
+	err    error
+}
+
+// handleRecipesKey handles keys specific to the recipes panel tab.
+func (m App) handleRecipesKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		r := m.detail.recipesPanel.SelectedRecipe()
+		if r == nil {
+			return m, nil
+		}
+		m.pendingRecipeRun = &recipeRun{recipe: *r}
+		servers := m.treePanel.Servers()
+		options := make([]components.PickerOption, len(servers))
+		for i, srv := range servers {
+			options[i] = components.PickerOption{Label: srv.Name, Detail: srv.IPAddress, Value: strconv.Itoa(i)}
+		}
+		m.dialogs = m.dialogs.Pick(components.NewMultiPicker("recipe-servers", fmt.Sprintf("Run %s on:", r.Name), options))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.pendingRecipe = nil
+		m.dialogs = m.dialogs.Prompt(components.NewInputWide("create-recipe-name", "Recipe name:", "Install ImageMagick"))
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// pickedRecipeServers asks to confirm running the recipe on the picked
+// servers.
+func (m App) pickedRecipeServers(values []string) (tea.Model, tea.Cmd) {
+	run := m.pendingRecipeRun
+	if run == nil {
+		return m, nil
+	}
+	servers := m.treePanel.Servers()
+	run.servers = nil
+	var names []string
+	for _, v := range values {
+		if i, err := strconv.Atoi(v); err == nil && i < len(servers) {
+			run.servers = append(run.servers, servers[i])
+			names = append(names, servers[i].Name)
+		}
+	}
+	if len(run.servers) == 0 {
+		m.pendingRecipeRun = nil
+		return m, nil
+	}
+	user := run.recipe.User
+	if user == "" {
+		user = "root"
+	}
+	m.dialogs = m.dialogs.Confirm("run-recipe", fmt.Sprintf("Run %s as %s on %d server(s)?\n%s",
+		run.recipe.Name, user, len(names), truncateStr(strings.Join(names, ", "), 60)))
+	return m, nil
+}
+
+// runRecipe starts the confirmed run and reports its output once it is in.
+func (m App) runRecipe() (tea.Model, tea.Cmd) {
+	run := m.pendingRecipeRun
+	m.pendingRecipeRun = nil
+	if run == nil {
+		return m, nil
+	}
+	m.toast = fmt.Sprintf("Running %s on %d server(s); the output shows when it finishes", run.recipe.Name, len(run.servers))
+	m.toastIsErr = false
+	return m, tea.Batch(m.clearToastAfter(5*time.Second), runRecipeCmd(m.forge, *run))
+}
+
+// runRecipeCmd returns a command that runs a recipe and waits for the
+// output of each server's run.
+func runRecipeCmd(client *forge.Client, run recipeRun) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), recipeOutputTimeout)
+		defer cancel()
+		msg := recipeRanMsg{recipe: run.recipe.Name, servers: run.servers, outputs: make([]string, len(run.servers))}
+
+		// Note each server's existing events, to tell the run's apart.
+		seen := make([]map[int64]bool, len(run.servers))
+		ids := make([]int64, len(run.servers))
+		for i, srv := range run.servers {
+			ids[i] = srv.ID
+			events, err := client.Events.List(ctx, srv.ID)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+			seen[i] = make(map[int64]bool, len(events))
+			for _, e := range events {
+				seen[i][e.ID] = true
+			}
+		}
+		if err := client.Recipes.Run(ctx, run.recipe.ID, ids, false); err != nil {
+			msg.err = err
+			return msg
+		}
+
+		for waiting := len(run.servers); waiting > 0; {
+			select {
+			case <-ctx.Done():
+				return msg
+			case <-time.After(recipePollInterval):
+			}
+			for i, srv := range run.servers {
+				if msg.outputs[i] != "" {
+					continue
+				}
+				events, err := client.Events.List(ctx, srv.ID)
+				if err != nil {
+					continue
+				}
+				e := recipeEvent(events, seen[i], run.recipe.Name)
+				if e == nil {
+					continue
+				}
+				if out, err := client.Events.GetOutput(ctx, srv.ID, e.ID); err == nil && out != "" {
+					msg.outputs[i] = out
+					waiting--
+				}
+			}
+		}
+		return msg
+	}
+}
+
+// recipeEvent returns the event a run of the named recipe added: the first
+// one not in seen that mentions it, or nil.
+func recipeEvent(events []forge.Event, seen map[int64]bool, recipe string) *forge.Event {
+	for i, e := range events {
+		if !seen[e.ID] && strings.Contains(strings.ToLower(e.Description), strings.ToLower(recipe)) {
+			return &events[i]
+		}
+	}
+	return nil
+}
+
+// handleRecipeRan shows a recipe run's output on each server in the output
+// panel.
+func (m App) handleRecipeRan(msg recipeRanMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Running %s failed: %v", msg.recipe, msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	var b strings.Builder
+	for i, srv := range msg.servers {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "=== %s ===\n", srv.Name)
+		out := strings.TrimRight(msg.outputs[i], "\n")
+		if out == "" {
+			out = fmt.Sprintf("(no output after %s; it may still be running, see the server's Events tab)", recipeOutputTimeout)
+		}
+		b.WriteString(out + "\n")
+	}
+	m.outputPanel = m.outputPanel.SetContent("Recipe: "+msg.recipe, b.String())
+	m.nav = m.nav.Push(ScreenOutput)
+	return m, nil
+}
+
+// createRecipeName is the first step of creating a recipe: it keeps the
+// name and asks who runs it.
+func (m App) createRecipeName(name string) (tea.Model, tea.Cmd) {
+	m.pendingRecipe = &forge.RecipeCreateOpts{Name: name}
+	m.dialogs = m.dialogs.Pick(components.NewPicker("create-recipe-user", "Run as user:", []components.PickerOption{
+		{Label: "root", Value: "root"},
+		{Label: "forge", Value: "forge"},
+	}))
+	return m, nil
+}
+
+// createRecipeUser keeps the recipe's user and opens the editor on its
+// script.
+func (m App) createRecipeUser(user string) (tea.Model, tea.Cmd) {
+	if m.pendingRecipe == nil {
+		return m, nil
+	}
+	m.pendingRecipe.User = user
+
+	tmpFile, err := os.CreateTemp("", "phorge-recipe-*.sh")
+	if err != nil {
+		return m, func() tea.Msg { return recipeEditedMsg{err: err} }
+	}
+	tmpFile.Close()
+	path := tmpFile.Name()
+
+	editor := m.config.Editor.Command
+	if editor == "" {
+		editor = "vim"
+	}
+	return m, tea.ExecProcess(exec.Command(editor, path), func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return recipeEditedMsg{err: err}
+		}
+		data, err := os.ReadFile(path)
+		return recipeEditedMsg{script: string(data), err: err}
+	})
+}
+
+// handleRecipeEdited creates the recipe with the edited script, unless it
+// was left empty.
+func (m App) handleRecipeEdited(msg recipeEditedMsg) (tea.Model, tea.Cmd) {
+	opts := m.pendingRecipe
+	m.pendingRecipe = nil
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Editing the script failed: %v", msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	if opts == nil || strings.TrimSpace(msg.script) == "" {
+		m.toast = "Recipe not created: the script is empty"
+		m.toastIsErr = false
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	opts.Script = msg.script
+	return m, m.detail.recipesPanel.CreateRecipe(*opts)
+}
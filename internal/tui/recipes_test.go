@@ -0,0 +1,22 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+func TestRecipeEvent(t *testing.T) {
+	events := []forge.Event{
+		{ID: 12, Description: "Running recipe: Install ImageMagick"},
+		{ID: 11, Description: "Running recipe: Clear OPcache"},
+		{ID: 10, Description: "Running recipe: Install ImageMagick"},
+	}
+	seen := map[int64]bool{10: true}
+	if e := recipeEvent(events, seen, "install imagemagick"); e == nil || e.ID != 12 {
+		t.Errorf("recipeEvent = %+v, want event 12", e)
+	}
+	if e := recipeEvent(events[2:], seen, "Install ImageMagick"); e != nil {
+		t.Errorf("recipeEvent with only a seen event = %+v, want nil", e)
+	}
+}
@@ -0,0 +1,342 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/tui/layout"
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// scheduledAction is a one-off action to run at a set local time. Only
+// deploys can be scheduled so far.
+type scheduledAction struct {
+	id       int
+	at       time.Time
+	serverID int64
+	siteID   int64
+	siteName string
+}
+
+// String describes the action, e.g. "Deploy example.com".
+func (a scheduledAction) String() string {
+	return "Deploy " + a.siteName
+}
+
+// scheduledDueMsg carries an action whose time has come.
+type scheduledDueMsg struct {
+	action scheduledAction
+}
+
+// scheduler runs scheduled actions for as long as the TUI is open. Its
+// goroutine sleeps until the earliest action is due and then hands it to
+// the app through the due channel. Nothing is persisted, so quitting drops
+// whatever is still pending.
+type scheduler struct {
+	mu      sync.Mutex
+	pending []scheduledAction // sorted by at
+	nextID  int
+
+	wake chan struct{}
+	due  chan scheduledAction
+	now  func() time.Time
+}
+
+// newScheduler starts a scheduler's goroutine.
+func newScheduler() *scheduler {
+	s := &scheduler{
+		wake: make(chan struct{}, 1),
+		due:  make(chan scheduledAction),
+		now:  time.Now,
+	}
+	go s.run()
+	return s
+}
+
+// Add schedules a and returns it with its ID set.
+func (s *scheduler) Add(a scheduledAction) scheduledAction {
+	s.mu.Lock()
+	s.nextID++
+	a.id = s.nextID
+	s.pending = append(s.pending, a)
+	slices.SortStableFunc(s.pending, func(x, y scheduledAction) int { return x.at.Compare(y.at) })
+	s.mu.Unlock()
+	s.poke()
+	return a
+}
+
+// Cancel drops the pending action with the given ID, reporting whether
+// it was still pending.
+func (s *scheduler) Cancel(id int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.IndexFunc(s.pending, func(a scheduledAction) bool { return a.id == id })
+	if i < 0 {
+		return false
+	}
+	s.pending = slices.Delete(s.pending, i, i+1)
+	s.poke()
+	return true
+}
+
+// Pending returns the actions not yet due, earliest first.
+func (s *scheduler) Pending() []scheduledAction {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.pending)
+}
+
+// poke makes the goroutine look at the pending actions again.
+func (s *scheduler) poke() {
+	select {
+	case s.wake <- struct{}{}:
+	default: // already woken
+	}
+}
+
+// run sleeps until the earliest pending action is due and sends it on
+// due, for as long as the process runs.
+func (s *scheduler) run() {
+	for {
+		s.mu.Lock()
+		var wait <-chan time.Time
+		var timer *time.Timer
+		if len(s.pending) > 0 {
+			timer = time.NewTimer(s.pending[0].at.Sub(s.now()))
+			wait = timer.C
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.wake:
+			if timer != nil {
+				timer.Stop()
+			}
+			continue
+		case <-wait:
+		}
+
+		s.mu.Lock()
+		if len(s.pending) == 0 || s.pending[0].at.After(s.now()) {
+			s.mu.Unlock()
+			continue
+		}
+		a := s.pending[0]
+		s.pending = s.pending[1:]
+		s.mu.Unlock()
+		s.due <- a
+	}
+}
+
+// waitScheduled waits for the next scheduled action to fall due.
+func waitScheduled(s *scheduler) tea.Cmd {
+	return func() tea.Msg {
+		return scheduledDueMsg{action: <-s.due}
+	}
+}
+
+// parseClock returns the next time after now that the local clock reads
+// text, given as "HH:MM" (e.g. "02:00" or "14:30"). A time already past
+// today means tomorrow.
+func parseClock(text string, now time.Time) (time.Time, error) {
+	hh, mm, ok := strings.Cut(strings.TrimSpace(text), ":")
+	h, herr := strconv.Atoi(hh)
+	m, merr := strconv.Atoi(mm)
+	if !ok || herr != nil || merr != nil || h < 0 || h > 23 || m < 0 || m > 59 || len(mm) != 2 {
+		return time.Time{}, fmt.Errorf("expected a time like 02:00, got %q", text)
+	}
+	at := time.Date(now.Year(), now.Month(), now.Day(), h, m, 0, 0, now.Location())
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, nil
+}
+
+// scheduleDeploy schedules a deploy of the selected site at the local time
+// the user typed.
+func (m App) scheduleDeploy(value string) (tea.Model, tea.Cmd) {
+	if m.selectedSite == nil || m.selectedSrv == nil {
+		return m, nil
+	}
+	at, err := parseClock(value, time.Now())
+	if err != nil {
+		m.toast = err.Error()
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	if m, cmd, held := m.guardMaintenanceWindow("schedule-deploy", at.Format(time.RFC3339), at); held {
+		return m, cmd
+	}
+	return m.addScheduledDeploy(at)
+}
+
+// addScheduledDeploy schedules a deploy of the selected site at at.
+func (m App) addScheduledDeploy(at time.Time) (tea.Model, tea.Cmd) {
+	if m.selectedSite == nil || m.selectedSrv == nil {
+		return m, nil
+	}
+	a := m.scheduler.Add(scheduledAction{
+		at:       at,
+		serverID: m.selectedSrv.ID,
+		siteID:   m.selectedSite.ID,
+		siteName: m.selectedSite.Name,
+	})
+	m.toast = fmt.Sprintf("%s scheduled for %s (T lists scheduled actions)", a, formatScheduled(a.at, time.Now()))
+	m.toastIsErr = false
+	return m, m.clearToastAfter(5 * time.Second)
+}
+
+// handleScheduledDue runs an action whose time has come and waits for the
+// next one.
+func (m App) handleScheduledDue(msg scheduledDueMsg) (tea.Model, tea.Cmd) {
+	a := msg.action
+	m.toast = fmt.Sprintf("Running scheduled action: %s...", a)
+	m.toastIsErr = false
+	return m, tea.Batch(
+		waitScheduled(m.scheduler),
+		startAutoDeploy(m.forge, a.serverID, a.siteID, "Scheduled deploy of "+a.siteName),
+	)
+}
+
+// formatScheduled formats at as a clock time, with the date when it isn't
+// today.
+func formatScheduled(at, now time.Time) string {
+	y, mo, d := at.Date()
+	ny, nmo, nd := now.Date()
+	if y == ny && mo == nmo && d == nd {
+		return at.Format("15:04")
+	}
+	return at.Format("Mon 15:04")
+}
+
+// scheduledBadge renders the footer badge counting scheduled actions, or
+// "".
+func (m App) scheduledBadge() string {
+	n := len(m.scheduler.Pending())
+	if n == 0 {
+		return ""
+	}
+	text := fmt.Sprintf("%d scheduled", n)
+	return lipgloss.NewStyle().Bold(true).Foreground(theme.ColorHighlight).Render(text)
+}
+
+// ScheduledModal is a floating overlay listing the pending scheduled
+// actions, where they can be cancelled.
+type ScheduledModal struct {
+	sched  *scheduler
+	active bool
+	cursor int
+	status string
+}
+
+// NewScheduledModal creates a new (inactive) scheduled actions modal.
+func NewScheduledModal(s *scheduler) ScheduledModal {
+	return ScheduledModal{sched: s}
+}
+
+// Open activates the modal.
+func (s ScheduledModal) Open() ScheduledModal {
+	s.active = true
+	s.cursor = 0
+	s.status = ""
+	return s
+}
+
+// Active returns whether the scheduled actions modal is currently visible.
+func (s ScheduledModal) Active() bool {
+	return s.active
+}
+
+// Update handles key events: j/k move, x cancels the selected action and
+// esc, q or T close.
+func (s ScheduledModal) Update(msg tea.Msg) (ScheduledModal, tea.Cmd) {
+	k, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		return s, nil
+	}
+	pending := s.sched.Pending()
+	switch {
+	case key.Matches(k, key.NewBinding(key.WithKeys("esc", "q", "T"))):
+		s.active = false
+	case key.Matches(k, key.NewBinding(key.WithKeys("j", "down"))):
+		s.cursor = min(s.cursor+1, max(len(pending)-1, 0))
+		s.status = ""
+	case key.Matches(k, key.NewBinding(key.WithKeys("k", "up"))):
+		s.cursor = max(s.cursor-1, 0)
+		s.status = ""
+	case key.Matches(k, key.NewBinding(key.WithKeys("x"))):
+		if s.cursor >= len(pending) {
+			return s, nil
+		}
+		a := pending[s.cursor]
+		if s.sched.Cancel(a.id) {
+			s.status = fmt.Sprintf("Cancelled: %s at %s", a, formatScheduled(a.at, time.Now()))
+		}
+		s.cursor = min(s.cursor, max(len(pending)-2, 0))
+	}
+	return s, nil
+}
+
+// View renders the scheduled actions modal as a box suitable for overlay.
+func (s ScheduledModal) View(width, height int) string {
+	if !s.active {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.ColorPrimary).
+		Align(lipgloss.Center)
+	subtleStyle := lipgloss.NewStyle().Foreground(theme.ColorSubtle)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorMuted).
+		Align(lipgloss.Center)
+
+	contentWidth := layout.Clamp(width-6, 30, 64)
+	lines := []string{titleStyle.Width(contentWidth).Render("Scheduled actions"), ""}
+
+	now := time.Now()
+	pending := s.sched.Pending()
+	if len(pending) == 0 {
+		lines = append(lines, "Nothing scheduled. Press @ in a site's Deployments tab to schedule a deploy.")
+	}
+	visible := max(height-12, 1)
+	start := layout.ScrollStart(s.cursor, visible)
+	for i := start; i < len(pending) && i < start+visible; i++ {
+		a := pending[i]
+		when := fmt.Sprintf("%-9s", formatScheduled(a.at, now))
+		in := "in <1m"
+		if d := a.at.Sub(now).Round(time.Minute); d >= time.Minute {
+			in = "in " + strings.TrimSuffix(d.String(), "0s")
+		}
+		text := theme.Truncate(a.String(), max(contentWidth-2-9-2-len(in)-2, 4))
+		prefix := "  "
+		if i == s.cursor {
+			prefix = theme.CursorStyle.Render("> ")
+			text = theme.SelectedItemStyle.Render(text)
+		}
+		lines = append(lines, prefix+when+"  "+text+"  "+subtleStyle.Render(in))
+	}
+
+	lines = append(lines, "")
+	if s.status != "" {
+		lines = append(lines, hintStyle.Width(contentWidth).Render(s.status))
+	}
+	lines = append(lines, hintStyle.Width(contentWidth).Render("j/k move  x cancel  esc close"))
+
+	return lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(theme.ColorPrimary).
+		Padding(1, 2).
+		Background(theme.ColorBg).
+		Width(contentWidth + 4).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	now := time.Date(2026, 3, 14, 15, 30, 0, 0, time.Local)
+	tests := []struct {
+		text    string
+		want    time.Time
+		wantErr bool
+	}{
+		{"16:00", time.Date(2026, 3, 14, 16, 0, 0, 0, time.Local), false},
+		{"02:00", time.Date(2026, 3, 15, 2, 0, 0, 0, time.Local), false},
+		{" 2:05 ", time.Date(2026, 3, 15, 2, 5, 0, 0, time.Local), false},
+		{"15:30", time.Date(2026, 3, 15, 15, 30, 0, 0, time.Local), false}, // now is not after now
+		{"24:00", time.Time{}, true},
+		{"12:60", time.Time{}, true},
+		{"12:5", time.Time{}, true},
+		{"noon", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseClock(tt.text, now)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseClock(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("parseClock(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSchedulerRunsDueActions(t *testing.T) {
+	s := newScheduler()
+	later := s.Add(scheduledAction{at: time.Now().Add(time.Hour), siteName: "later.example.com"})
+	s.Add(scheduledAction{at: time.Now().Add(20 * time.Millisecond), siteName: "soon.example.com"})
+	cancelled := s.Add(scheduledAction{at: time.Now().Add(10 * time.Millisecond), siteName: "cancelled.example.com"})
+	if !s.Cancel(cancelled.id) {
+		t.Fatal("Cancel of a pending action = false")
+	}
+
+	select {
+	case a := <-s.due:
+		if a.siteName != "soon.example.com" {
+			t.Errorf("due action = %s, want soon.example.com", a.siteName)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no action fell due")
+	}
+
+	pending := s.Pending()
+	if len(pending) != 1 || pending[0].id != later.id {
+		t.Errorf("pending = %v, want only the later action", pending)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	lipgloss "charm.land/lipgloss/v2"
 
 	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/tui/layout"
 	"github.com/hinkers/Phorge/internal/tui/theme"
 )
 
@@ -209,13 +210,7 @@ func (s SettingsModal) View(width, height int) string {
 		Foreground(theme.ColorMuted).
 		Align(lipgloss.Center)
 
-	contentWidth := 54
-	if width < contentWidth+6 {
-		contentWidth = width - 6
-	}
-	if contentWidth < 30 {
-		contentWidth = 30
-	}
+	contentWidth := layout.Clamp(width-6, 30, 54)
 
 	var lines []string
 	lines = append(lines, titleStyle.Width(contentWidth).Render("Settings"))
@@ -249,7 +244,7 @@ func (s SettingsModal) View(width, height int) string {
 	inner := strings.Join(lines, "\n")
 
 	return lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.Border()).
 		BorderForeground(theme.ColorPrimary).
 		Padding(1, 2).
 		Background(theme.ColorBg).
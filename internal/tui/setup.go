@@ -2,6 +2,8 @@ package tui
 
 import (
 	"context"
+	"fmt"
+	"os/exec"
 	"strings"
 
 	tea "charm.land/bubbletea/v2"
@@ -20,15 +22,36 @@ type setupValidateMsg struct {
 	err  error
 }
 
+// readinessCheck is one line of the post-setup readiness checklist.
+type readinessCheck struct {
+	label  string
+	ok     bool
+	detail string
+}
+
+// setupChecksMsg carries the results of the readiness checks.
+type setupChecksMsg struct {
+	checks []readinessCheck
+}
+
+// reauthDoneMsg ends a Setup started by NewReauth. apiKey is the new,
+// validated token, or "" if the user backed out.
+type reauthDoneMsg struct {
+	apiKey string
+}
+
 // Setup is a standalone bubbletea model for the first-run API key setup.
-// It runs before the main App when no API key is configured.
+// It runs before the main App when no API key is configured, and inside it
+// (see NewReauth) when the configured key stops working.
 type Setup struct {
 	config     *config.Config
+	resume     bool // re-entering a rejected key mid-session
 	input      textinput.Model
 	err        error
 	validating bool
 	done       bool
 	userName   string
+	checks     []readinessCheck // nil while the checks are running
 	width      int
 	height     int
 }
@@ -47,6 +70,15 @@ func NewSetup(cfg *config.Config) Setup {
 	}
 }
 
+// NewReauth creates a Setup that asks for a new API key after the current
+// one was rejected. Instead of quitting, it ends with a reauthDoneMsg so
+// the App can carry on where the user was.
+func NewReauth(cfg *config.Config) Setup {
+	s := NewSetup(cfg)
+	s.resume = true
+	return s
+}
+
 // Init returns no initial command.
 func (s Setup) Init() tea.Cmd {
 	return textinput.Blink
@@ -61,9 +93,18 @@ func (s Setup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return s, nil
 
 	case tea.KeyPressMsg:
-		// If setup is done (success screen), Enter exits.
+		// If setup is done (success screen), Enter continues with the
+		// onboarding tour and s continues without it.
 		if s.done {
-			if key.Matches(msg, key.NewBinding(key.WithKeys("enter"))) {
+			switch {
+			case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+				return s, tea.Quit
+			case key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
+				s.config.UI.TourSeen = true
+				if err := s.config.Save(); err != nil {
+					s.err = err
+					return s, nil
+				}
 				return s, tea.Quit
 			}
 			return s, nil
@@ -86,6 +127,9 @@ func (s Setup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return s, s.validateKey(apiKey)
 
 		case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "ctrl+c"))):
+			if s.resume {
+				return s, func() tea.Msg { return reauthDoneMsg{} }
+			}
 			return s, tea.Quit
 		}
 
@@ -107,9 +151,17 @@ func (s Setup) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			s.err = err
 			return s, nil
 		}
+		if s.resume {
+			apiKey := s.config.Forge.APIKey
+			return s, func() tea.Msg { return reauthDoneMsg{apiKey: apiKey} }
+		}
 
 		s.userName = msg.user.Name
 		s.done = true
+		return s, s.runChecks(s.config.Forge.APIKey)
+
+	case setupChecksMsg:
+		s.checks = msg.checks
 		return s, nil
 
 	default:
@@ -157,13 +209,23 @@ func (s Setup) viewInput() string {
 
 	var lines []string
 	lines = append(lines, "")
-	lines = append(lines, titleStyle.Render("  Welcome to Phorge"))
-	lines = append(lines, "")
-	lines = append(lines, subtitleStyle.Render("  Laravel Forge TUI"))
+	if s.resume {
+		lines = append(lines, titleStyle.Render("  API key rejected"))
+		lines = append(lines, "")
+		lines = append(lines, subtitleStyle.Render("  Forge no longer accepts your key;"))
+		lines = append(lines, subtitleStyle.Render("  it may have expired or been revoked."))
+	} else {
+		lines = append(lines, titleStyle.Render("  Welcome to Phorge"))
+		lines = append(lines, "")
+		lines = append(lines, subtitleStyle.Render("  Laravel Forge TUI"))
+	}
 	lines = append(lines, "")
 
 	if s.validating {
 		lines = append(lines, hintStyle.Render("  Validating API key..."))
+	} else if s.resume {
+		lines = append(lines, subtitleStyle.Render("  Enter a new Forge API key:"))
+		lines = append(lines, "  "+s.input.View())
 	} else {
 		lines = append(lines, subtitleStyle.Render("  Enter your Forge API key:"))
 		lines = append(lines, "  "+s.input.View())
@@ -179,11 +241,20 @@ func (s Setup) viewInput() string {
 	lines = append(lines, hintStyle.Render("  Get your key from:"))
 	lines = append(lines, hintStyle.Render("  forge.laravel.com/user/profile"))
 	lines = append(lines, "")
+	if s.resume {
+		if s.config.Forge.APIKeyCmd != "" {
+			lines = append(lines, hintStyle.Render("  api_key_cmd is set, so the new"))
+			lines = append(lines, hintStyle.Render("  key is kept for this session only."))
+			lines = append(lines, "")
+		}
+		lines = append(lines, hintStyle.Render("  esc back to Phorge"))
+		lines = append(lines, "")
+	}
 
 	inner := strings.Join(lines, "\n")
 
 	box := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.Border()).
 		BorderForeground(theme.ColorPrimary).
 		Padding(0, 2).
 		Width(40).
@@ -213,16 +284,44 @@ func (s Setup) viewSuccess() string {
 	lines = append(lines, subtitleStyle.Render("  Config saved to:"))
 	lines = append(lines, hintStyle.Render("  "+configPath))
 	lines = append(lines, "")
-	lines = append(lines, hintStyle.Render("  Press Enter to continue..."))
+
+	okStyle := lipgloss.NewStyle().Foreground(theme.ColorSecondary)
+	failStyle := lipgloss.NewStyle().Foreground(theme.ColorHighlight)
+
+	lines = append(lines, subtitleStyle.Render("  Readiness checks:"))
+	if s.checks == nil {
+		lines = append(lines, hintStyle.Render("  Running checks..."))
+	}
+	for _, c := range s.checks {
+		if c.ok {
+			lines = append(lines, okStyle.Render("  "+theme.GlyphOK+" ")+subtitleStyle.Render(c.label))
+		} else {
+			lines = append(lines, failStyle.Render("  "+theme.GlyphFail+" ")+subtitleStyle.Render(c.label))
+		}
+		if c.detail != "" {
+			lines = append(lines, hintStyle.Render("    "+c.detail))
+		}
+	}
+	lines = append(lines, "")
+	lines = append(lines, subtitleStyle.Render("  Take a quick tour of the basics?"))
+	lines = append(lines, hintStyle.Render("  enter start tour  s skip"))
 	lines = append(lines, "")
 
+	if s.err != nil {
+		errorStyle := lipgloss.NewStyle().
+			Foreground(theme.ColorError).
+			Bold(true)
+		lines = append(lines, errorStyle.Render("  "+s.err.Error()))
+		lines = append(lines, "")
+	}
+
 	inner := strings.Join(lines, "\n")
 
 	box := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
+		Border(theme.Border()).
 		BorderForeground(theme.ColorSecondary).
 		Padding(0, 2).
-		Width(40).
+		Width(48).
 		Render(inner)
 
 	return s.center(box)
@@ -258,6 +357,59 @@ func (s Setup) center(box string) string {
 	return out.String()
 }
 
+// runChecks creates a command that runs the optional readiness checks:
+// the token can list servers, ssh is on PATH and an editor is configured.
+// Failures are reported, not fatal — the app still works without them.
+func (s Setup) runChecks(apiKey string) tea.Cmd {
+	editor := s.config.Editor.Command
+	return func() tea.Msg {
+		var checks []readinessCheck
+
+		client := forge.NewClient(apiKey)
+		servers, err := client.Servers.List(context.Background())
+		if err != nil {
+			checks = append(checks, readinessCheck{
+				label:  "List servers",
+				detail: fmt.Sprintf("token may lack read scope: %v", err),
+			})
+		} else {
+			checks = append(checks, readinessCheck{
+				label: fmt.Sprintf("List servers (%d found)", len(servers)),
+				ok:    true,
+			})
+		}
+
+		if _, err := exec.LookPath("ssh"); err != nil {
+			checks = append(checks, readinessCheck{
+				label:  "ssh in PATH",
+				detail: "install OpenSSH to use ctrl+s / ctrl+d",
+			})
+		} else {
+			checks = append(checks, readinessCheck{label: "ssh in PATH", ok: true})
+		}
+
+		fields := strings.Fields(editor)
+		switch {
+		case len(fields) == 0:
+			checks = append(checks, readinessCheck{
+				label:  "Editor configured",
+				detail: "set one in settings (ctrl+o)",
+			})
+		default:
+			if _, err := exec.LookPath(fields[0]); err != nil {
+				checks = append(checks, readinessCheck{
+					label:  fmt.Sprintf("Editor %q", fields[0]),
+					detail: "not found in PATH; change it in settings (ctrl+o)",
+				})
+			} else {
+				checks = append(checks, readinessCheck{label: fmt.Sprintf("Editor %q", fields[0]), ok: true})
+			}
+		}
+
+		return setupChecksMsg{checks: checks}
+	}
+}
+
 // validateKey creates a command that validates the API key by calling the Forge API.
 func (s Setup) validateKey(apiKey string) tea.Cmd {
 	return func() tea.Msg {
@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/hooks"
+	"github.com/hinkers/Phorge/internal/socket"
+)
+
+// eventSelectionChanged is published on the event socket when the selected
+// server or site changes.
+const eventSelectionChanged = "selection_changed"
+
+// socketCommandMsg carries a command from an event socket client.
+type socketCommandMsg struct {
+	cmd socket.Command
+}
+
+// projectSiteMsg carries the server and site resolved for a socket
+// client's open command.
+type projectSiteMsg struct {
+	cmd    socket.Command
+	server string
+	site   string
+	err    error
+}
+
+// openSocket opens the event socket when the config enables it.
+func openSocket(cfg *config.Config) (*socket.Server, error) {
+	if !cfg.Socket.Enabled {
+		return nil, nil
+	}
+	return socket.Listen(cfg.Socket.SocketPath())
+}
+
+// socketErrToast returns a command that reports an event socket that
+// couldn't be opened, or nil.
+func socketErrToast(err error) tea.Cmd {
+	if err == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return toastMsg{message: fmt.Sprintf("Event socket: %v", err), isError: true}
+	}
+}
+
+// waitSocket returns a command that waits for the next command from a
+// socket client.
+func waitSocket(s *socket.Server) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return socketCommandMsg{cmd: <-s.Commands()}
+	}
+}
+
+// handleSocketCommand carries out a socket client's command and replies
+// to it.
+func (m App) handleSocketCommand(msg socketCommandMsg) (tea.Model, tea.Cmd) {
+	cmds := []tea.Cmd{waitSocket(m.socket)}
+	c := msg.cmd
+	switch c.Name {
+	case socket.CommandSelect:
+		srv := m.treePanel.FindServerByName(c.Server)
+		if srv == nil {
+			m.socket.Reply(c, fmt.Errorf("unknown server %q", c.Server))
+			break
+		}
+		var cmd tea.Cmd
+		m, cmd = m.jumpTo(srv, c.Site)
+		cmds = append(cmds, cmd)
+		if c.Site != "" && m.pendingJump == nil && m.selectedSite == nil {
+			m.socket.Reply(c, fmt.Errorf("site %q not found on %s", c.Site, srv.Name))
+			break
+		}
+		m.socket.Reply(c, nil)
+
+	case socket.CommandDeploy:
+		cmd, err := m.deployByName(c.Server, c.Site, "Deploy of "+c.Site+" (socket)")
+		m.socket.Reply(c, err)
+		cmds = append(cmds, cmd)
+
+	case socket.CommandOpen:
+		cmds = append(cmds, m.resolveProjectSite(c))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// resolveProjectSite returns a command that finds the site of the project
+// checked out in the open command's directory: the one its .phorge names,
+// or else the first site on any server deploying its git origin.
+func (m App) resolveProjectSite(c socket.Command) tea.Cmd {
+	client := m.forge
+	return func() tea.Msg {
+		msg := projectSiteMsg{cmd: c}
+		project := config.LoadProjectConfigFrom(config.FindProjectConfig(c.Dir))
+		if project.Server != "" {
+			msg.server, msg.site = project.Server, project.Site
+			return msg
+		}
+
+		var match func(forge.Site) bool
+		what := project.Site
+		if what != "" {
+			match = func(site forge.Site) bool { return strings.EqualFold(site.Name, project.Site) }
+		} else {
+			repo := gitOriginRepo(c.Dir)
+			if repo == "" {
+				msg.err = fmt.Errorf("%s has no .phorge and no git origin", c.Dir)
+				return msg
+			}
+			what = repo
+			match = func(site forge.Site) bool {
+				return site.Repository != "" && normalizeRepo(site.Repository) == repo
+			}
+		}
+		srv, site, ok := findSite(context.Background(), client, match)
+		if !ok {
+			msg.err = fmt.Errorf("no site found for %s", what)
+			return msg
+		}
+		msg.server, msg.site = srv.Name, site.Name
+		return msg
+	}
+}
+
+// handleProjectSite selects the site resolved for an open command and
+// replies to it.
+func (m App) handleProjectSite(msg projectSiteMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.socket.Reply(msg.cmd, msg.err)
+		return m, nil
+	}
+	srv := m.treePanel.FindServerByName(msg.server)
+	if srv == nil {
+		m.socket.Reply(msg.cmd, fmt.Errorf("server %q is not in the tree (is it hidden?)", msg.server))
+		return m, nil
+	}
+	m, cmd := m.jumpTo(srv, msg.site)
+	m.socket.Reply(msg.cmd, nil)
+	m.toast = "Opened " + srv.Name
+	if msg.site != "" {
+		m.toast = fmt.Sprintf("Opened %s on %s", msg.site, srv.Name)
+	}
+	m.toastIsErr = false
+	return m, tea.Batch(cmd, m.clearToastAfter(3*time.Second))
+}
+
+// updateAndPublish runs update and, when the event socket is open and the
+// message changed the selected server or site, publishes the selection.
+func (m App) updateAndPublish(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	if m.socket == nil {
+		return model, cmd
+	}
+	after, ok := model.(App)
+	if !ok || selectionIDs(after) == selectionIDs(m) {
+		return model, cmd
+	}
+	ev := hooks.Event{Name: eventSelectionChanged, Time: time.Now()}
+	if after.selectedSrv != nil {
+		ev.Server, ev.ServerID = after.selectedSrv.Name, after.selectedSrv.ID
+	}
+	if after.selectedSite != nil {
+		ev.Site, ev.SiteID = after.selectedSite.Name, after.selectedSite.ID
+	}
+	after.socket.SetState(ev)
+	return after, cmd
+}
+
+// selectionIDs returns the IDs of the selected server and site, 0 for
+// none.
+func selectionIDs(m App) [2]int64 {
+	var ids [2]int64
+	if m.selectedSrv != nil {
+		ids[0] = m.selectedSrv.ID
+	}
+	if m.selectedSite != nil {
+		ids[1] = m.selectedSite.ID
+	}
+	return ids
+}
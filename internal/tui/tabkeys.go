@@ -0,0 +1,638 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+)
+
+// updateTab passes a key the app doesn't act on to the active tab's panel,
+// through the detail controller.
+func (m App) updateTab(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.detail, cmd, _ = m.detail.Update(tabKeyMsg{key: msg, srv: m.selectedSrv, site: m.selectedSite, screen: m.nav.Top()})
+	return m, cmd
+}
+
+// handleDetailKey processes keys when the detail panel is focused.
+func (m App) handleDetailKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	// If the deploy script sub-view is active, route keys to it.
+	if m.nav.Top() == ScreenDeployScript {
+		if key.Matches(msg, m.navKeys.Back) {
+			m.nav = m.nav.Pop()
+			return m, nil
+		}
+		return m.updateTab(msg)
+	}
+
+	// If the DB users sub-view is active, route keys to it.
+	if m.nav.Top() == ScreenDBUsers {
+		if key.Matches(msg, m.navKeys.Back) {
+			m.nav = m.nav.Pop()
+			return m, nil
+		}
+		return m.handleDBUsersKey(msg)
+	}
+
+	// If the redirect rules sub-view is active, route keys to it.
+	if m.nav.Top() == ScreenRedirects {
+		if key.Matches(msg, m.navKeys.Back) {
+			m.nav = m.nav.Pop()
+			return m, nil
+		}
+		return m.handleRedirectsKey(msg)
+	}
+
+	// If the security rules sub-view is active, route keys to it.
+	if m.nav.Top() == ScreenSecurity {
+		if key.Matches(msg, m.navKeys.Back) {
+			m.nav = m.nav.Pop()
+			return m, nil
+		}
+		return m.handleSecurityKey(msg)
+	}
+
+	// If the commands panel is showing detail and user presses Esc,
+	// go back to the commands list (not up to tree panel).
+	if m.detail.activeTab == 6 && m.selectedSite != nil && m.detail.commandsPanel.ShowingDetail() {
+		if key.Matches(msg, m.navKeys.Back) {
+			return m.updateTab(msg)
+		}
+	}
+
+	// Esc clears the Logs tab's grep filter before leaving the tab.
+	if m.logsShowing() && m.detail.logsPanel.Filtered() && key.Matches(msg, m.navKeys.Back) {
+		return m.handleLogsKey(msg)
+	}
+
+	switch {
+	case key.Matches(msg, m.navKeys.Back):
+		m.nav = m.nav.Pop()
+		return m, nil
+
+	// Section tab switching (0-9, b, R). Backups (b) and Recipes (R) are
+	// server-level only.
+	case key.Matches(msg, m.sectionKeys.Info) && m.selectedSite == nil:
+		return m.switchToServerTab(0)
+	case key.Matches(msg, m.sectionKeys.Info):
+		return m.switchToTab(0)
+	case key.Matches(msg, m.sectionKeys.Backups) && m.selectedSite == nil:
+		return m.switchToServerTab(backupsTab)
+	case key.Matches(msg, m.sectionKeys.Recipes) && m.selectedSite == nil:
+		return m.switchToServerTab(recipesTab)
+	case key.Matches(msg, m.sectionKeys.Deployments):
+		return m.switchToTab(1)
+	case key.Matches(msg, m.sectionKeys.Environment):
+		return m.switchToTab(2)
+	case key.Matches(msg, m.sectionKeys.Databases):
+		return m.switchToTab(3)
+	case key.Matches(msg, m.sectionKeys.SSL):
+		return m.switchToTab(4)
+	case key.Matches(msg, m.sectionKeys.Workers):
+		return m.switchToTab(5)
+	case key.Matches(msg, m.sectionKeys.Daemons):
+		return m.switchToTab(6)
+	case key.Matches(msg, m.sectionKeys.Firewall):
+		return m.switchToTab(7)
+	case key.Matches(msg, m.sectionKeys.Jobs):
+		return m.switchToTab(8)
+	case key.Matches(msg, m.sectionKeys.Domains):
+		return m.switchToTab(9)
+	}
+
+	// The site info view: tab 0, or a tab a site has no panel for.
+	if m.selectedSite != nil {
+		if _, ok := m.detail.ActivePanel(m.selectedSrv, m.selectedSite, m.nav.Top()).(panels.SiteInfo); ok {
+			return m.handleSiteInfoKey(msg)
+		}
+	}
+
+	// A disabled tab carried over from the other context shows the info
+	// view.
+	if !m.detail.TabEnabled(m.detail.activeTab, m.selectedSite != nil) {
+		if m.selectedSite == nil && m.selectedSrv != nil {
+			return m.handleServerInfoKey(msg)
+		}
+		return m, nil
+	}
+
+	// Tab 0: server info.
+	if m.detail.activeTab == 0 && m.selectedSite == nil && m.selectedSrv != nil {
+		return m.handleServerInfoKey(msg)
+	}
+
+	// Tab 1: Deploy (site) or Events (server).
+	if m.detail.activeTab == 1 {
+		if m.selectedSite != nil {
+			return m.handleDeploymentsKey(msg)
+		}
+		if m.selectedSrv != nil {
+			return m.handleEventsKey(msg)
+		}
+	}
+
+	// Tab 2: Env (site) or Nginx templates (server).
+	if m.detail.activeTab == 2 {
+		if m.selectedSite != nil {
+			return m.handleEnvironmentKey(msg)
+		}
+		if m.selectedSrv != nil {
+			return m.handleNginxKey(msg)
+		}
+	}
+
+	// Databases (tab 3) - server-level.
+	if m.detail.activeTab == 3 && m.selectedSrv != nil {
+		return m.handleDatabasesKey(msg)
+	}
+
+	// SSL (tab 4) - site certificates, or the overview in server context.
+	if m.detail.activeTab == 4 {
+		if m.selectedSite != nil {
+			return m.handleSSLKey(msg)
+		}
+		if m.selectedSrv != nil {
+			return m.updateTab(msg)
+		}
+	}
+
+	// Tab 5: Workers (site) or Circles (server).
+	if m.detail.activeTab == 5 {
+		if m.selectedSite != nil {
+			return m.handleWorkersKey(msg)
+		}
+		if m.selectedSrv != nil {
+			return m.handleCirclesKey(msg)
+		}
+	}
+
+	// Tab 6: Commands (site) or Daemons (server).
+	if m.detail.activeTab == 6 {
+		if m.selectedSite != nil {
+			return m.handleCommandsKey(msg)
+		}
+		if m.selectedSrv != nil {
+			return m.handleDaemonsKey(msg)
+		}
+	}
+
+	// Tab 7: Logs (site) or Firewall (server).
+	if m.detail.activeTab == 7 {
+		if m.selectedSite != nil {
+			return m.handleLogsKey(msg)
+		}
+		if m.selectedSrv != nil {
+			return m.handleFirewallKey(msg)
+		}
+	}
+
+	// Tab 8: Git (site) or Jobs (server).
+	if m.detail.activeTab == 8 {
+		if m.selectedSite != nil {
+			return m.handleGitKey(msg)
+		}
+		if m.selectedSrv != nil {
+			return m.handleJobsKey(msg)
+		}
+	}
+
+	// Tab 9: Domains (site) or SSH Keys (server).
+	if m.detail.activeTab == 9 {
+		if m.selectedSite != nil {
+			return m.handleDomainsKey(msg)
+		}
+		if m.selectedSrv != nil {
+			return m.handleSSHKeysKey(msg)
+		}
+	}
+
+	// Backups - server-level.
+	if m.detail.activeTab == backupsTab && m.selectedSite == nil && m.selectedSrv != nil {
+		return m.handleBackupsKey(msg)
+	}
+
+	// Recipes - account-level, shown from a server.
+	if m.detail.activeTab == recipesTab && m.selectedSite == nil && m.selectedSrv != nil {
+		return m.handleRecipesKey(msg)
+	}
+
+	return m, nil
+}
+
+// handleDeploymentsKey handles keys specific to the deployments panel tab.
+func (m App) handleDeploymentsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	// Check for action keys before delegating to the panel.
+	switch {
+	case key.Matches(msg, m.siteActKeys.Deploy):
+		if running := m.detail.deploymentsPanel.Running(); running != nil {
+			m.dialogs = m.dialogs.Confirm("queue-deploy",
+				fmt.Sprintf("Deployment #%d is still running. Queue a deploy to run after it?", running.ID))
+			return m, nil
+		}
+		m.dialogs = m.dialogs.Confirm("deploy", "Deploy site now?")
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("@"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInput("schedule-deploy", "Deploy at (local time, HH:MM):", "02:00").Remember())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		m.dialogs = m.dialogs.Confirm("reset-deploy", "Reset deployment status?")
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("L"))):
+		return m.browseDeployLogs()
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("Q"))):
+		return m.confirmQuickDeploy()
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("y"))):
+		return m.copyTriggerURL()
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("U"))):
+		if m.selectedSite != nil {
+			m.dialogs = m.dialogs.Confirm("reset-trigger-url", fmt.Sprintf("Regenerate the deployment trigger URL of %s?\nAnything still calling the current URL will stop deploying the site.", m.selectedSite.Name))
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("S"))):
+		// Open the deploy script sub-view.
+		if m.selectedSrv != nil && m.selectedSite != nil {
+			m.nav = m.nav.Push(ScreenDeployScript)
+			m.detail.deployScriptPanel = panels.NewDeployScriptPanel(
+				m.forge, m.selectedSrv.ID, m.selectedSite.ID, m.config.Editor.Command,
+			)
+			return m, m.detail.deployScriptPanel.LoadScript()
+		}
+		return m, nil
+	}
+
+	// Delegate navigation and other keys to the deployments panel.
+	return m.updateTab(msg)
+}
+
+// handleEventsKey handles keys specific to the events panel tab.
+func (m App) handleEventsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	return m.updateTab(msg)
+}
+
+// handleEnvironmentKey handles keys specific to the environment panel tab.
+func (m App) handleEnvironmentKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, key.NewBinding(key.WithKeys("C"))) {
+		return m.startEnvCopy()
+	}
+	// Delegate all other keys to the environment panel.
+	return m.updateTab(msg)
+}
+
+// handleDatabasesKey handles keys specific to the databases panel tab.
+func (m App) handleDatabasesKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInput("create-db", "Database name:", "my_database"))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if db := m.detail.databasesPanel.SelectedDatabase(); db != nil {
+			m.dialogs = m.dialogs.Confirm("delete-db", fmt.Sprintf("Delete database %q?", db.Name))
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("v"))):
+		if m.selectedSrv == nil || m.selectedSite == nil {
+			m.toast = "Select a site to view its database credentials"
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		var cmd tea.Cmd
+		m.credsModal, cmd = m.credsModal.Open(m.forge, m.selectedSrv.ID, *m.selectedSite)
+		return m, cmd
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("u"))):
+		if m.selectedSrv != nil {
+			m.nav = m.nav.Push(ScreenDBUsers)
+			m.detail.dbUsersPanel = panels.NewDBUsersPanel(m.forge, m.selectedSrv.ID)
+			return m, m.detail.dbUsersPanel.LoadUsers()
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleDBUsersKey handles keys specific to the database users sub-view.
+func (m App) handleDBUsersKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.dialogs = m.dialogs.Form(dbUserForm())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if u := m.detail.dbUsersPanel.SelectedUser(); u != nil {
+			m.dialogs = m.dialogs.Confirm("delete-dbuser", fmt.Sprintf("Delete user %q?", u.Name))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleSSLKey handles keys specific to the SSL panel tab.
+func (m App) handleSSLKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInput("create-cert", "Domain(s) (comma-separated):", "example.com").Remember())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("i"))):
+		m.pendingInputValue = ""
+		m.dialogs = m.dialogs.Compose(components.NewTextArea("install-cert", "Certificate (PEM, with any intermediates):", "-----BEGIN CERTIFICATE-----"))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
+		if cert := m.detail.sslPanel.SelectedCert(); cert != nil {
+			m.dialogs = m.dialogs.Confirm("activate-cert", fmt.Sprintf("Activate certificate for %q?", cert.Domain))
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		cert := m.detail.sslPanel.SelectedCert()
+		if cert == nil {
+			return m, nil
+		}
+		if cert.Type != "letsencrypt" {
+			m.toast = "Only Let's Encrypt certificates can be renewed"
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		question := fmt.Sprintf("Renew certificate for %q?", cert.Domain)
+		if d, ok := m.detail.sslPanel.ExpiresIn(cert.ID); ok {
+			question = fmt.Sprintf("Renew certificate for %q (expires in %d days)?", cert.Domain, int(d.Hours())/24)
+		}
+		m.dialogs = m.dialogs.Confirm("renew-cert", question)
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if cert := m.detail.sslPanel.SelectedCert(); cert != nil {
+			m.dialogs = m.dialogs.Confirm("delete-cert", fmt.Sprintf("Delete certificate for %q?", cert.Domain))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleWorkersKey handles keys specific to the workers panel tab.
+func (m App) handleWorkersKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		php := ""
+		if m.selectedSite != nil {
+			php = m.selectedSite.PHPVersion
+		}
+		m.dialogs = m.dialogs.Form(workerForm(php))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		if w := m.detail.workersPanel.SelectedWorker(); w != nil {
+			m.dialogs = m.dialogs.Confirm("restart-worker", fmt.Sprintf("Restart worker %s:%s?", w.Connection, w.Queue))
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if w := m.detail.workersPanel.SelectedWorker(); w != nil {
+			m.dialogs = m.dialogs.Confirm("delete-worker", fmt.Sprintf("Delete worker %s:%s?", w.Connection, w.Queue))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleDaemonsKey handles keys specific to the daemons panel tab.
+func (m App) handleDaemonsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.dialogs = m.dialogs.Form(daemonForm())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		if d := m.detail.daemonsPanel.SelectedDaemon(); d != nil {
+			m.dialogs = m.dialogs.Confirm("restart-daemon", fmt.Sprintf("Restart daemon %q?", truncateStr(d.Command, 30)))
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if d := m.detail.daemonsPanel.SelectedDaemon(); d != nil {
+			m.dialogs = m.dialogs.Confirm("delete-daemon", fmt.Sprintf("Delete daemon %q?", truncateStr(d.Command, 30)))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleFirewallKey handles keys specific to the firewall panel tab.
+func (m App) handleFirewallKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.dialogs = m.dialogs.Form(firewallRuleForm())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if r := m.detail.firewallPanel.SelectedRule(); r != nil {
+			m.dialogs = m.dialogs.Confirm("delete-firewall", fmt.Sprintf("Delete rule %q?", r.Name))
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("y"))):
+		if r := m.detail.firewallPanel.SelectedRule(); r != nil {
+			m.pendingRules = []forge.FirewallRule{*r}
+			m.dialogs = m.dialogs.Pick(components.NewPicker("copy-firewall",
+				fmt.Sprintf("Copy rule %q to:", r.Name), serverOptions(m.otherServers())))
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("Y"))):
+		if rules := m.detail.firewallPanel.Rules(); len(rules) > 0 {
+			m.pendingRules = append([]forge.FirewallRule(nil), rules...)
+			m.dialogs = m.dialogs.Pick(components.NewPicker("copy-firewall",
+				fmt.Sprintf("Copy all %d rules to:", len(rules)), serverOptions(m.otherServers())))
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
+		names := m.config.FirewallSetNames()
+		if len(names) == 0 {
+			m.toast = "No firewall_sets defined in config"
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		options := make([]components.PickerOption, 0, len(names))
+		for _, name := range names {
+			ports, _ := m.config.FirewallSet(name)
+			options = append(options, components.PickerOption{Label: name, Detail: joinPorts(ports)})
+		}
+		m.dialogs = m.dialogs.Pick(components.NewPicker("apply-firewall-set", "Rule set to apply:", options))
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleCommandsKey handles keys specific to the commands panel tab.
+func (m App) handleCommandsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInput("run-command", "Command to execute:", "php artisan migrate").Remember())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("C"))):
+		m.dialogs = m.dialogs.Compose(components.NewTextArea("run-script", "Script to execute:", "php artisan down\nphp artisan migrate --force\nphp artisan up"))
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleDomainsKey handles keys specific to the domains panel tab.
+func (m App) handleDomainsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInput("add-domain", "Domain alias:", "example.com").Remember())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("p"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInputWide("bulk-domains", "Paste domain aliases (comma or newline separated):", "a.example.com, b.example.com"))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("w"))):
+		from, to, err := m.detail.domainsPanel.WWWRedirect()
+		if err != nil {
+			m.toast = err.Error()
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		m.dialogs = m.dialogs.Confirm("www-redirect", fmt.Sprintf("Permanently redirect %s to %s?", from, to))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		if m.selectedSrv != nil && m.selectedSite != nil {
+			m.nav = m.nav.Push(ScreenRedirects)
+			m.detail.redirectsPanel = panels.NewRedirectsPanel(m.forge, m.selectedSrv.ID, m.selectedSite.ID)
+			return m, m.detail.redirectsPanel.LoadRedirects()
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
+		if m.selectedSrv != nil && m.selectedSite != nil {
+			m.nav = m.nav.Push(ScreenSecurity)
+			m.detail.securityPanel = panels.NewSecurityRulesPanel(m.forge, m.selectedSrv.ID, m.selectedSite.ID)
+			return m, m.detail.securityPanel.LoadRules()
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if m.detail.domainsPanel.OnPrimary() {
+			m.toast = "The primary domain can't be removed"
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		if alias := m.detail.domainsPanel.SelectedAlias(); alias != "" {
+			m.dialogs = m.dialogs.Confirm("remove-domain", fmt.Sprintf("Remove alias %q?", alias))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleSSHKeysKey handles keys specific to the SSH keys panel tab.
+func (m App) handleSSHKeysKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("i"))):
+		// Install default SSH key from config.
+		name, keyContent, err := m.defaultSSHKey()
+		if err != nil {
+			m.toast = err.Error()
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		return m, m.detail.sshKeysPanel.CreateKey(name, keyContent, "forge")
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInputWide("create-sshkey-path", "Path to public key (or paste key directly):", "~/.ssh/id_rsa.pub").Remember())
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("p"))):
+		m.dialogs = m.dialogs.Compose(components.NewTextArea("paste-sshkey", "Public key:", "ssh-ed25519 AAAA... you@example.com"))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if k := m.detail.sshKeysPanel.SelectedKey(); k != nil {
+			m.dialogs = m.dialogs.Confirm("delete-sshkey", fmt.Sprintf("Delete SSH key %q?", k.Name))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleBackupsKey handles keys specific to the backups panel tab.
+func (m App) handleBackupsKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("n"))):
+		if c := m.detail.backupsPanel.SelectedConfig(); c != nil {
+			m.dialogs = m.dialogs.Confirm("run-backup", fmt.Sprintf("Back up now with %s?", panels.BackupConfigName(*c)))
+		}
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+		b := m.detail.backupsPanel.SelectedBackup()
+		if b == nil {
+			m.toast = "Select a backup to restore"
+			m.toastIsErr = true
+			return m, m.clearToastAfter(3 * time.Second)
+		}
+		date := b.Date
+		if date == "" {
+			date = fmt.Sprintf("#%d", b.ID)
+		}
+		m.dialogs = m.dialogs.Confirm("restore-backup",
+			fmt.Sprintf("Restore the backup from %s? Its databases are overwritten.", date))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+		if c := m.detail.backupsPanel.SelectedConfig(); c != nil {
+			m.dialogs = m.dialogs.Confirm("delete-backup-config",
+				fmt.Sprintf("Delete backup configuration %s and its backups?", panels.BackupConfigName(*c)))
+		}
+		return m, nil
+	}
+
+	return m.updateTab(msg)
+}
+
+// handleSiteInfoKey handles keys on the site info tab: R renames the site
+// and W changes its web directory.
+func (m App) handleSiteInfoKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.selectedSrv == nil || m.selectedSite == nil {
+		return m, nil
+	}
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("R"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInputWide("rename-site", "New domain for "+m.selectedSite.Name+":", m.selectedSite.Name))
+		return m, nil
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("W"))):
+		m.dialogs = m.dialogs.Prompt(components.NewInputWide("site-web-dir", "Web directory (relative to site root):", "/public").Remember())
+		return m, nil
+	}
+	return m, nil
+}
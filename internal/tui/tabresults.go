@@ -0,0 +1,290 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/tui/panels"
+)
+
+// updateResult handles the result of an action taken in a tab panel:
+// it reloads the panel the action changed and reports the outcome in a
+// toast. Results that need more than the detail area, such as output to
+// show, are passed on to the app as messages. It reports false for
+// messages that aren't tab panel results.
+func (d DetailController) updateResult(msg tea.Msg) (DetailController, tea.Cmd, bool) {
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	// Deployments panel.
+	case panels.DeploymentsLoadedMsg:
+		d.deploymentsPanel, cmd = updatePanel(d.deploymentsPanel, msg)
+		// Keep the tree badge in step with the freshest history.
+		if len(msg.Deployments) > 0 && msg.Deployments[0].SiteID != 0 {
+			latest := msg.Deployments[0]
+			cmd = tea.Batch(cmd, func() tea.Msg {
+				return treeDeployStatusMsg{statuses: map[int64]string{latest.SiteID: latest.Status}}
+			})
+		}
+		return d, cmd, true
+
+	case panels.DeployOutputMsg:
+		return d, showOutput("Deploy Output", msg.Output), true
+
+	case panels.DeployResetMsg:
+		if msg.Err != nil {
+			return d, tea.Batch(toast(fmt.Sprintf("Reset failed: %v", msg.Err), true), d.deploymentsPanel.LoadDeployments()), true
+		}
+		return d, tea.Batch(toast("Deployment status reset", false), d.deploymentsPanel.LoadDeployments()), true
+
+	// Deploy script panel.
+	case panels.ScriptEditorDoneMsg:
+		d.deployScriptPanel, cmd = updatePanel(d.deployScriptPanel, msg)
+		if msg.Changed {
+			cmd = tea.Batch(cmd, saving("Saving deploy script..."))
+		}
+		return d, cmd, true
+
+	case panels.ScriptSavedMsg:
+		if msg.Err != nil {
+			return d, toast(fmt.Sprintf("Script save failed: %v", msg.Err), true), true
+		}
+		return d, toast("Deploy script updated", false), true
+
+	// Environment panel.
+	case panels.EnvEditorDoneMsg:
+		d.environmentPanel, cmd = updatePanel(d.environmentPanel, msg)
+		if msg.Changed {
+			cmd = tea.Batch(cmd, saving("Saving environment..."))
+		}
+		return d, cmd, true
+
+	case panels.EnvSavedMsg:
+		if msg.Err != nil {
+			return d, toast(fmt.Sprintf("Environment save failed: %v", msg.Err), true), true
+		}
+		return d, toast("Environment updated", false), true
+
+	// Nginx templates panel.
+	case panels.NginxEditorDoneMsg:
+		d.nginxPanel, cmd = updatePanel(d.nginxPanel, msg)
+		if msg.Changed {
+			cmd = tea.Batch(cmd, saving("Saving nginx template..."))
+		}
+		return d, cmd, true
+
+	case panels.NginxTemplateSavedMsg:
+		d.nginxPanel, _ = updatePanel(d.nginxPanel, msg)
+		if msg.Err != nil {
+			return d, toastFor(fmt.Sprintf("Template save failed: %v", msg.Err), true, 5*time.Second), true
+		}
+		text := fmt.Sprintf("Nginx template %q saved", msg.Name)
+		if msg.Created {
+			text = fmt.Sprintf("Nginx template %q created", msg.Name)
+		}
+		return d, tea.Batch(toast(text, false), d.nginxPanel.LoadTemplates()), true
+
+	case panels.NginxTemplateDeletedMsg:
+		return d, tea.Batch(toast(fmt.Sprintf("Nginx template %q deleted", msg.Name), false), d.nginxPanel.LoadTemplates()), true
+
+	case panels.NginxTemplateAppliedMsg:
+		return d, toast(fmt.Sprintf("Applied %s to %s", msg.Template, msg.Site), false), true
+
+	// Databases and database users panels.
+	case panels.DatabaseCreatedMsg:
+		return d, tea.Batch(toast("Database created", false), d.databasesPanel.LoadDatabases()), true
+
+	case panels.DatabaseDeletedMsg:
+		return d, tea.Batch(toast("Database deleted", false), d.databasesPanel.LoadDatabases()), true
+
+	case panels.DBUserCreatedMsg:
+		cmd = tea.Batch(toast("Database user created", false), d.dbUsersPanel.LoadUsers())
+		if msg.Password != "" {
+			name := ""
+			if msg.User != nil {
+				name = msg.User.Name
+			}
+			cmd = tea.Batch(cmd, func() tea.Msg {
+				return generatedPasswordMsg{user: name, password: msg.Password}
+			})
+		}
+		return d, cmd, true
+
+	case panels.DBUserDeletedMsg:
+		return d, tea.Batch(toast("Database user deleted", false), d.dbUsersPanel.LoadUsers()), true
+
+	// SSL panel.
+	case panels.CertRenewedMsg:
+		return d, tea.Batch(toast("Certificate renewal requested", false), d.sslPanel.LoadCerts()), true
+
+	case panels.CertCreatedMsg:
+		return d, tea.Batch(toast("Certificate created", false), d.sslPanel.LoadCerts()), true
+
+	case panels.CertActivatedMsg:
+		return d, tea.Batch(toast("Certificate activated", false), d.sslPanel.LoadCerts()), true
+
+	case panels.CertDeletedMsg:
+		return d, tea.Batch(toast("Certificate deleted", false), d.sslPanel.LoadCerts()), true
+
+	// Workers panel.
+	case panels.WorkerCreatedMsg:
+		return d, tea.Batch(toast("Worker created", false), d.workersPanel.LoadWorkers()), true
+
+	case panels.WorkerRestartedMsg:
+		return d, tea.Batch(toast("Worker restarted", false), d.workersPanel.LoadWorkers()), true
+
+	case panels.WorkerDeletedMsg:
+		return d, tea.Batch(toast("Worker deleted", false), d.workersPanel.LoadWorkers()), true
+
+	// Daemons panel.
+	case panels.DaemonCreatedMsg:
+		return d, tea.Batch(toast("Daemon created", false), d.daemonsPanel.LoadDaemons()), true
+
+	case panels.DaemonRestartedMsg:
+		return d, tea.Batch(toast("Daemon restarted", false), d.daemonsPanel.LoadDaemons()), true
+
+	case panels.DaemonDeletedMsg:
+		return d, tea.Batch(toast("Daemon deleted", false), d.daemonsPanel.LoadDaemons()), true
+
+	// Redirects and security rules panels.
+	case panels.RedirectCreatedMsg:
+		return d, tea.Batch(toast("Redirect rule created", false), d.redirectsPanel.LoadRedirects()), true
+
+	case panels.RedirectDeletedMsg:
+		return d, tea.Batch(toast("Redirect rule deleted", false), d.redirectsPanel.LoadRedirects()), true
+
+	case panels.SecurityRuleCreatedMsg:
+		return d, tea.Batch(toast("Security rule created", false), d.securityPanel.LoadRules()), true
+
+	case panels.SecurityRuleDeletedMsg:
+		return d, tea.Batch(toast("Security rule deleted", false), d.securityPanel.LoadRules()), true
+
+	// Jobs panel.
+	case panels.JobCreatedMsg:
+		return d, tea.Batch(toast("Scheduled job created", false), d.jobsPanel.LoadJobs()), true
+
+	case panels.JobDeletedMsg:
+		return d, tea.Batch(toast("Scheduled job deleted", false), d.jobsPanel.LoadJobs()), true
+
+	case panels.JobOutputMsg:
+		output := msg.Output
+		if strings.TrimSpace(output) == "" {
+			output = "(no output yet)"
+		}
+		return d, showOutput("Job Output: "+truncateStr(msg.Command, 40), output), true
+
+	// Recipes panel.
+	case panels.RecipeCreatedMsg:
+		return d, tea.Batch(toast(fmt.Sprintf("Recipe %s created", msg.Recipe.Name), false), d.recipesPanel.LoadRecipes()), true
+
+	case panels.RecipeScriptMsg:
+		user := msg.User
+		if user == "" {
+			user = "root"
+		}
+		return d, showOutput(fmt.Sprintf("Recipe: %s (as %s)", msg.Name, user), msg.Script), true
+
+	// Firewall panel.
+	case panels.FirewallCreatedMsg:
+		return d, tea.Batch(toast("Firewall rule created", false), d.firewallPanel.LoadRules()), true
+
+	case panels.FirewallDeletedMsg:
+		return d, tea.Batch(toast("Firewall rule deleted", false), d.firewallPanel.LoadRules()), true
+
+	case panels.FirewallCopiedMsg:
+		return d, d.firewallCopied(msg), true
+
+	// SSH keys panel.
+	case panels.SSHKeyCreatedMsg:
+		return d, tea.Batch(toast("SSH key created", false), d.sshKeysPanel.LoadKeys()), true
+
+	case panels.SSHKeyDeletedMsg:
+		return d, tea.Batch(toast("SSH key deleted", false), d.sshKeysPanel.LoadKeys()), true
+
+	// Backups panel.
+	case panels.BackupRunMsg:
+		return d, tea.Batch(toast(fmt.Sprintf("Backup started (%s)", msg.Config), false), d.backupsPanel.LoadBackups()), true
+
+	case panels.BackupRestoredMsg:
+		return d, toastFor(fmt.Sprintf("Restoring backup from %s", msg.Date), false, 5*time.Second), true
+
+	case panels.BackupConfigDeletedMsg:
+		return d, tea.Batch(toast("Backup configuration deleted", false), d.backupsPanel.LoadBackups()), true
+
+	// Circles panel.
+	case panels.CircleInvitedMsg:
+		return d, tea.Batch(toast(fmt.Sprintf("Invited %s to %s", msg.Email, msg.Circle), false), d.circlesPanel.LoadCircles()), true
+
+	case panels.CircleMemberRemovedMsg:
+		return d, tea.Batch(toast(fmt.Sprintf("Removed %s from %s", msg.Member, msg.Circle), false), d.circlesPanel.LoadCircles()), true
+
+	// Commands panel.
+	case panels.CommandCreatedMsg:
+		return d, tea.Batch(toast("Command executed", false), d.commandsPanel.LoadCommands()), true
+
+	// Domains panel.
+	case panels.DomainsRedirectMsg:
+		return d, toast(fmt.Sprintf("%s now redirects to %s", msg.From, msg.To), false), true
+
+	case panels.DomainsSavedMsg:
+		text, isErr := "Domains updated", false
+		if msg.Err != nil {
+			text, isErr = fmt.Sprintf("Domain update failed: %v", msg.Err), true
+		}
+		return d, tea.Batch(toast(text, isErr), d.domainsPanel.RefreshAliases()), true
+	}
+	return d, nil, false
+}
+
+// firewallCopied reports rules copied to another server or a rule set
+// applied to this one, reloading the rules when they were added here.
+func (d DetailController) firewallCopied(msg panels.FirewallCopiedMsg) tea.Cmd {
+	verb := "Copy to " + msg.Target
+	if msg.Set != "" {
+		verb = fmt.Sprintf("Applying set %q", msg.Set)
+	}
+	if msg.Err != nil {
+		return toast(fmt.Sprintf("%s failed after %d rule(s): %v", verb, msg.Created, msg.Err), true)
+	}
+	text := fmt.Sprintf("Copied %d rule(s) to %s", msg.Created, msg.Target)
+	if msg.Set != "" {
+		text = fmt.Sprintf("Applied set %q: %d rule(s) created", msg.Set, msg.Created)
+	}
+	if msg.Skipped > 0 {
+		text += fmt.Sprintf(" (%d already present)", msg.Skipped)
+	}
+	if msg.Set != "" {
+		// Sets are applied to the current server, so show the new rules.
+		return tea.Batch(toast(text, false), d.firewallPanel.LoadRules())
+	}
+	return toast(text, false)
+}
+
+// toast returns a command showing message in a toast for the usual time.
+func toast(message string, isError bool) tea.Cmd {
+	return toastFor(message, isError, 0)
+}
+
+// toastFor returns a command showing message in a toast for d.
+func toastFor(message string, isError bool, d time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		return toastMsg{message: message, isError: isError, duration: d}
+	}
+}
+
+// saving returns a command showing message until the save it announces
+// reports back.
+func saving(message string) tea.Cmd {
+	return func() tea.Msg {
+		return toastMsg{message: message, sticky: true}
+	}
+}
+
+// showOutput returns a command opening the output screen with content.
+func showOutput(title, content string) tea.Cmd {
+	return func() tea.Msg {
+		return showOutputMsg{title: title, content: content}
+	}
+}
@@ -3,20 +3,132 @@
 // the root tui package and its sub-packages (panels, components, etc.).
 package theme
 
-import lipgloss "charm.land/lipgloss/v2"
+import (
+	"image/color"
 
-// Colour palette — loosely inspired by the lazygit theme.
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Palette is a set of theme colours.
+type Palette struct {
+	Primary, Secondary, Subtle, Highlight, Error, Fg, Muted, Bg, HelpBarBg color.Color
+}
+
+// DefaultPalette is loosely inspired by the lazygit theme.
+var DefaultPalette = Palette{
+	Primary:   lipgloss.Color("#7aa2f7"), // blue
+	Secondary: lipgloss.Color("#9ece6a"), // green
+	Subtle:    lipgloss.Color("#565f89"), // grey
+	Highlight: lipgloss.Color("#e0af68"), // amber
+	Error:     lipgloss.Color("#f7768e"), // red
+	Fg:        lipgloss.Color("#c0caf5"), // light fg
+	Muted:     lipgloss.Color("#545c7e"), // muted fg
+	Bg:        lipgloss.Color("#1a1b26"), // dark bg
+	HelpBarBg: lipgloss.Color("#24283b"), // slightly lighter than bg
+}
+
+// HighContrastPalette uses bright, saturated colours on black, with no
+// low-contrast greys.
+var HighContrastPalette = Palette{
+	Primary:   lipgloss.Color("#00d7ff"), // cyan
+	Secondary: lipgloss.Color("#00ff5f"), // green
+	Subtle:    lipgloss.Color("#d0d0d0"), // light grey
+	Highlight: lipgloss.Color("#ffff00"), // yellow
+	Error:     lipgloss.Color("#ff5f5f"), // red
+	Fg:        lipgloss.Color("#ffffff"), // white
+	Muted:     lipgloss.Color("#e4e4e4"), // near white
+	Bg:        lipgloss.Color("#000000"), // black
+	HelpBarBg: lipgloss.Color("#000000"), // black
+}
+
+// palette is the palette in use; see UsePalette.
+var palette = DefaultPalette
+
+// paletteColor is a colour looked up in the palette in use each time it
+// is rendered, so styles built before UsePalette still follow it.
+type paletteColor int
+
+const (
+	primary paletteColor = iota
+	secondary
+	subtle
+	highlight
+	errorColor
+	fg
+	muted
+	bg
+	helpBarBg
+)
+
+// RGBA implements color.Color.
+func (c paletteColor) RGBA() (r, g, b, a uint32) {
+	cols := [...]color.Color{
+		palette.Primary, palette.Secondary, palette.Subtle, palette.Highlight,
+		palette.Error, palette.Fg, palette.Muted, palette.Bg, palette.HelpBarBg,
+	}
+	return cols[c].RGBA()
+}
+
+// UsePalette switches every theme colour to p. Call it before the UI is
+// first drawn.
+func UsePalette(p Palette) {
+	palette = p
+}
+
+// Theme colours, in the palette in use.
+var (
+	ColorPrimary   color.Color = primary
+	ColorSecondary color.Color = secondary
+	ColorSubtle    color.Color = subtle
+	ColorHighlight color.Color = highlight
+	ColorError     color.Color = errorColor
+	ColorFg        color.Color = fg
+	ColorMuted     color.Color = muted
+	ColorBg        color.Color = bg
+)
+
+// Glyphs drawn in the tree and next to statuses. UseASCII replaces them
+// with plain ASCII for screen readers and limited terminals.
 var (
-	ColorPrimary   = lipgloss.Color("#7aa2f7") // blue
-	ColorSecondary = lipgloss.Color("#9ece6a") // green
-	ColorSubtle    = lipgloss.Color("#565f89") // grey
-	ColorHighlight = lipgloss.Color("#e0af68") // amber
-	ColorError     = lipgloss.Color("#f7768e") // red
-	ColorFg        = lipgloss.Color("#c0caf5") // light fg
-	ColorMuted     = lipgloss.Color("#545c7e") // muted fg
-	ColorBg        = lipgloss.Color("#1a1b26") // dark bg
+	GlyphCollapsed  = "▶"
+	GlyphExpanded   = "▼"
+	GlyphBranch     = "├"
+	GlyphLastBranch = "└"
+	GlyphOK         = "✓"
+	GlyphFail       = "✗"
+	GlyphDot        = "●"
+	GlyphStar       = "★"
+	GlyphSeparator  = "│"
+	GlyphRule       = "─"
+	GlyphAsc        = "↑"
+	GlyphDesc       = "↓"
+	SpinnerFrames   = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 )
 
+// border is the border drawn around panels and overlays; see Border.
+var border = lipgloss.RoundedBorder()
+
+// Border returns the border to draw around panels and overlays.
+func Border() lipgloss.Border {
+	return border
+}
+
+// UseASCII switches glyphs and borders to plain ASCII. Call it before the
+// UI is first drawn.
+func UseASCII() {
+	GlyphCollapsed, GlyphExpanded = ">", "v"
+	GlyphBranch, GlyphLastBranch = "|", "`"
+	GlyphOK, GlyphFail, GlyphDot, GlyphStar = "+", "x", "*", "*"
+	GlyphSeparator, GlyphRule = "|", "-"
+	GlyphAsc, GlyphDesc = "^", "v"
+	SpinnerFrames = []string{"|", "/", "-", "\\"}
+
+	border = lipgloss.ASCIIBorder()
+	ActiveBorderStyle = ActiveBorderStyle.Border(border)
+	InactiveBorderStyle = InactiveBorderStyle.Border(border)
+}
+
 // Panel border styles.
 var (
 	ActiveBorderStyle = lipgloss.NewStyle().
@@ -36,7 +148,7 @@ var TitleStyle = lipgloss.NewStyle().
 
 // Help bar styles.
 var (
-	HelpBarBg = lipgloss.Color("#24283b") // slightly lighter than bg
+	HelpBarBg color.Color = helpBarBg
 
 	HelpBarStyle = lipgloss.NewStyle().
 			Foreground(ColorMuted).
@@ -108,23 +220,17 @@ var (
 )
 
 // Truncate shortens a string to fit within the given width, accounting for
-// ANSI escape sequences by using lipgloss.Width for measurement.
+// ANSI escape sequences: they are kept intact so styles are still reset
+// after the cut.
 func Truncate(s string, maxWidth int) string {
 	if maxWidth <= 0 {
 		return ""
 	}
-	w := lipgloss.Width(s)
-	if w <= maxWidth {
+	if lipgloss.Width(s) <= maxWidth {
 		return s
 	}
-	// Brute-force truncation: trim runes until we fit.
-	runes := []rune(s)
-	for len(runes) > 0 {
-		runes = runes[:len(runes)-1]
-		candidate := string(runes) + "..."
-		if lipgloss.Width(candidate) <= maxWidth {
-			return candidate
-		}
+	if maxWidth <= 3 {
+		return ansi.Truncate(s, maxWidth, "")
 	}
-	return ""
+	return ansi.Truncate(s, maxWidth, "...")
 }
@@ -0,0 +1,37 @@
+package theme
+
+import (
+	"testing"
+
+	lipgloss "charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"hello", 10, "hello"},
+		{"hello world", 8, "hello..."},
+		{"hello", 2, "he"},
+		{"hello", 0, ""},
+	}
+	for _, tt := range tests {
+		if got := Truncate(tt.s, tt.width); got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateStyled(t *testing.T) {
+	s := lipgloss.NewStyle().Bold(true).Render("hello") + " " + lipgloss.NewStyle().Italic(true).Render("world")
+	got := Truncate(s, 8)
+	if plain := ansi.Strip(got); plain != "hello..." {
+		t.Errorf("Truncate styled = %q, want hello...", plain)
+	}
+	if w := lipgloss.Width(got); w > 8 {
+		t.Errorf("Truncate styled is %d wide, want at most 8", w)
+	}
+}
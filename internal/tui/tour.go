@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+	lipgloss "charm.land/lipgloss/v2"
+
+	"github.com/hinkers/Phorge/internal/tui/theme"
+)
+
+// tourTarget identifies the part of the layout a tour step points at.
+// The targeted panel is drawn focused and the tour box is placed beside it.
+type tourTarget int
+
+const (
+	tourCenter tourTarget = iota
+	tourTree
+	tourDetail
+	tourOutput
+)
+
+// tourStep is a single page of the onboarding tour.
+type tourStep struct {
+	title  string
+	body   string
+	target tourTarget
+}
+
+// tourSteps returns the onboarding tour pages in order.
+func tourSteps() []tourStep {
+	return []tourStep{
+		{
+			title:  "Welcome to Phorge",
+			body:   "This short tour walks through the basics.\nIt is only shown once — reopen it any time\nfrom the help screen (?) with t.",
+			target: tourCenter,
+		},
+		{
+			title:  "Servers & sites",
+			body:   "← Your servers and their sites live here.\nj/k move, l or enter expands a server,\nand / filters by name. D pins a default\nserver/site for the current directory.",
+			target: tourTree,
+		},
+		{
+			title:  "Tabs",
+			body:   "→ The detail panel shows the selected item.\nPress 1–9 to switch tabs: Deploy, Env, DB,\nSSL, Workers… Tabs 6–9 change between\nsite and server context.",
+			target: tourDetail,
+		},
+		{
+			title:  "Deploying",
+			body:   "Select a site, open tab 1 and press d to\ndeploy. enter on a deployment streams its\noutput into the panel below.",
+			target: tourOutput,
+		},
+		{
+			title:  "SSH & keys",
+			body:   "ctrl+s opens SSH to the selected server,\nctrl+f SFTP. Set a default public key in\nsettings (ctrl+o), then press i on a\nserver's SSH Keys tab (9) to install it.",
+			target: tourDetail,
+		},
+		{
+			title:  "You're all set",
+			body:   "Press ? for every keybinding and A for\nversion and API status. Happy shipping!",
+			target: tourCenter,
+		},
+	}
+}
+
+// tourStartMsg asks the app to (re)start the onboarding tour.
+type tourStartMsg struct{}
+
+// tourDoneMsg is sent when the tour is finished or skipped.
+type tourDoneMsg struct{}
+
+// Tour is the first-run onboarding overlay.
+type Tour struct {
+	active bool
+	step   int
+	steps  []tourStep
+}
+
+// NewTour creates a new (inactive) tour.
+func NewTour() Tour {
+	return Tour{steps: tourSteps()}
+}
+
+// Start activates the tour from the first step.
+func (t Tour) Start() Tour {
+	t.active = true
+	t.step = 0
+	return t
+}
+
+// Active returns whether the tour is currently visible.
+func (t Tour) Active() bool {
+	return t.active
+}
+
+// Target returns the layout region the current step points at.
+func (t Tour) Target() tourTarget {
+	if !t.active {
+		return tourCenter
+	}
+	return t.steps[t.step].target
+}
+
+// Update handles key events while the tour is active.
+// enter/l advance, h/backspace go back, esc/q skip the rest.
+func (t Tour) Update(msg tea.Msg) (Tour, tea.Cmd) {
+	if !t.active {
+		return t, nil
+	}
+
+	if msg, ok := msg.(tea.KeyPressMsg); ok {
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter", "l", "right", " "))):
+			if t.step < len(t.steps)-1 {
+				t.step++
+				return t, nil
+			}
+			t.active = false
+			return t, func() tea.Msg { return tourDoneMsg{} }
+		case key.Matches(msg, key.NewBinding(key.WithKeys("h", "left", "backspace"))):
+			if t.step > 0 {
+				t.step--
+			}
+			return t, nil
+		case key.Matches(msg, key.NewBinding(key.WithKeys("esc", "q"))):
+			t.active = false
+			return t, func() tea.Msg { return tourDoneMsg{} }
+		}
+	}
+
+	return t, nil
+}
+
+// View renders the current tour step as a box suitable for overlay.
+func (t Tour) View(width int) string {
+	if !t.active {
+		return ""
+	}
+	step := t.steps[t.step]
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(theme.ColorHighlight)
+
+	bodyStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorFg)
+
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.ColorMuted)
+
+	next := "enter next"
+	if t.step == len(t.steps)-1 {
+		next = "enter finish"
+	}
+
+	lines := []string{
+		titleStyle.Render(step.title) + hintStyle.Render(fmt.Sprintf("  %d/%d", t.step+1, len(t.steps))),
+		"",
+		bodyStyle.Render(step.body),
+		"",
+		hintStyle.Render(next + "  h back  esc skip"),
+	}
+
+	boxWidth := 48
+	if boxWidth > width-4 {
+		boxWidth = width - 4
+	}
+
+	return lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(theme.ColorHighlight).
+		Padding(0, 1).
+		Background(theme.ColorBg).
+		Width(boxWidth).
+		Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/forge"
+)
+
+// triggerURLMsg is sent after a site's deployment trigger URL has been
+// regenerated. site is the site fetched afterwards, with the new URL.
+type triggerURLMsg struct {
+	site *forge.Site
+	err  error
+}
+
+// copyTriggerURL copies the selected site's deployment trigger URL, for
+// wiring up a CI system, to the clipboard.
+func (m App) copyTriggerURL() (tea.Model, tea.Cmd) {
+	url := m.detail.deploymentsPanel.TriggerURL()
+	if url == "" {
+		m.toast = "This site has no deployment trigger URL"
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	m.toast = "Deployment trigger URL copied to clipboard"
+	m.toastIsErr = false
+	return m, tea.Batch(tea.SetClipboard(url), m.clearToastAfter(3*time.Second))
+}
+
+// resetTriggerURL returns a command that regenerates the selected site's
+// deployment trigger URL and then fetches the site, to show the new one.
+func (m App) resetTriggerURL() tea.Cmd {
+	if m.selectedSrv == nil || m.selectedSite == nil {
+		return nil
+	}
+	client := m.forge
+	serverID, siteID := m.selectedSrv.ID, m.selectedSite.ID
+	return func() tea.Msg {
+		ctx := context.Background()
+		if err := client.Deployments.ResetTriggerURL(ctx, serverID, siteID); err != nil {
+			return triggerURLMsg{err: err}
+		}
+		site, err := client.Sites.Get(ctx, serverID, siteID)
+		return triggerURLMsg{site: site, err: err}
+	}
+}
+
+// handleTriggerURL shows a site's regenerated deployment trigger URL.
+func (m App) handleTriggerURL(msg triggerURLMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.toast = fmt.Sprintf("Regenerating the trigger URL failed: %v", msg.err)
+		m.toastIsErr = true
+		return m, m.clearToastAfter(5 * time.Second)
+	}
+	site := msg.site
+	m.treePanel = m.treePanel.UpdateSite(*site)
+	if m.selectedSite != nil && m.selectedSite.ID == site.ID {
+		m.selectedSite = site
+		m.detail.siteInfo = m.detail.siteInfo.SetSite(site)
+		m.detail.gitPanel = m.detail.gitPanel.SetSite(site)
+		m.detail.deploymentsPanel = m.detail.deploymentsPanel.SetSite(site)
+	}
+	m.toast = fmt.Sprintf("Regenerated the trigger URL of %s; press y to copy it", site.Name)
+	m.toastIsErr = false
+	return m, m.clearToastAfter(5 * time.Second)
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// deleteGrace is how long a confirmed delete waits before it is sent to
+// the API. Pressing z in that time cancels it.
+const deleteGrace = 5 * time.Second
+
+// pendingDelete is a confirmed delete waiting out its grace period.
+type pendingDelete struct {
+	seq       int    // tells successive deletes' ticks apart
+	what      string // e.g. `database "shop"`
+	run       tea.Cmd
+	remaining time.Duration
+}
+
+// deleteTickMsg counts down the grace period of pending delete seq.
+type deleteTickMsg struct {
+	seq int
+}
+
+// deferDelete holds back run, which deletes what, for deleteGrace and
+// shows an undo toast meanwhile. A delete already pending is carried out
+// straight away: only the latest one can be undone.
+func (m App) deferDelete(what string, run tea.Cmd) (App, tea.Cmd) {
+	if run == nil {
+		return m, nil
+	}
+	var flush tea.Cmd
+	if m.pendingDelete != nil {
+		flush = m.pendingDelete.run
+	}
+	m.deleteSeq++
+	m.pendingDelete = &pendingDelete{seq: m.deleteSeq, what: what, run: run, remaining: deleteGrace}
+	m = m.showPendingDelete()
+	return m, tea.Batch(flush, deleteTick(m.deleteSeq))
+}
+
+// deleteTick schedules the next countdown step of pending delete seq.
+func deleteTick(seq int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return deleteTickMsg{seq: seq}
+	})
+}
+
+// handleDeleteTick counts the pending delete down, running it once its
+// grace period is over. Ticks of undone or superseded deletes are ignored.
+func (m App) handleDeleteTick(msg deleteTickMsg) (tea.Model, tea.Cmd) {
+	if m.pendingDelete == nil || m.pendingDelete.seq != msg.seq {
+		return m, nil
+	}
+	p := *m.pendingDelete
+	p.remaining -= time.Second
+	if p.remaining > 0 {
+		m.pendingDelete = &p
+		return m.showPendingDelete(), deleteTick(p.seq)
+	}
+
+	m.pendingDelete = nil
+	m.toast = fmt.Sprintf("Deleting %s...", p.what)
+	m.toastIsErr = false
+	return m, tea.Batch(p.run, m.clearToastAfter(3*time.Second))
+}
+
+// undoDelete cancels the pending delete.
+func (m App) undoDelete() (tea.Model, tea.Cmd) {
+	what := m.pendingDelete.what
+	m.pendingDelete = nil
+	m.toast = fmt.Sprintf("Kept %s", what)
+	m.toastIsErr = false
+	return m, m.clearToastAfter(3 * time.Second)
+}
+
+// showPendingDelete shows the undo toast for the pending delete.
+func (m App) showPendingDelete() App {
+	p := m.pendingDelete
+	m.toast = fmt.Sprintf("Deleting %s · z: undo (%ds)", p.what, int(p.remaining/time.Second))
+	m.toastIsErr = true
+	return m
+}
@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+)
+
+// fakeAliases is a Forge API holding the aliases of site 10 on server 1.
+type fakeAliases struct {
+	mu      sync.Mutex
+	aliases []string
+}
+
+func (f *fakeAliases) client(t *testing.T) *forge.Client {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/servers/1/sites/10":
+		case r.Method == http.MethodPut && r.URL.Path == "/servers/1/sites/10/aliases":
+			var body struct {
+				Aliases []string `json:"aliases"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			f.aliases = body.Aliases
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"site": forge.Site{ID: 10, Name: "example.com", Aliases: f.aliases},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	c := forge.NewClient("test-token")
+	c.BaseURL = srv.URL
+	return c
+}
+
+func (f *fakeAliases) get() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.aliases)
+}
+
+// TestDeferredAliasDeleteKeepsNewAliases checks an alias added while an
+// alias delete waits out its grace period survives the delete.
+func TestDeferredAliasDeleteKeepsNewAliases(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	api := &fakeAliases{aliases: []string{"a.example.com", "b.example.com"}}
+	client := api.client(t)
+
+	cfg := config.Default()
+	cfg.UI.TourSeen = true
+	m := NewApp(cfg, "", LaunchNone)
+	m.detail.domainsPanel = panels.NewDomainsPanel(client, 1, 10, "example.com", api.get())
+
+	// Select b.example.com (below the primary domain and a.example.com)
+	// and confirm removing it.
+	for range 2 {
+		p, _ := m.detail.domainsPanel.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+		m.detail.domainsPanel = p.(panels.DomainsPanel)
+	}
+	if got := m.detail.domainsPanel.SelectedAlias(); got != "b.example.com" {
+		t.Fatalf("selected %q, want b.example.com", got)
+	}
+	model, _ := m.handleConfirmResult(components.ConfirmResult{ID: "remove-domain", Confirmed: true})
+	m = model.(App)
+	if m.pendingDelete == nil {
+		t.Fatal("removing an alias didn't wait out the grace period")
+	}
+
+	// Another alias is added during the grace period.
+	if msg := m.detail.domainsPanel.AddAlias("c.example.com")(); msg != (panels.DomainsSavedMsg{}) {
+		t.Fatalf("adding an alias: %v", msg)
+	}
+
+	if msg := m.pendingDelete.run(); msg != (panels.DomainsSavedMsg{}) {
+		t.Fatalf("removing the alias: %v", msg)
+	}
+	if got, want := api.get(), []string{"a.example.com", "c.example.com"}; !slices.Equal(got, want) {
+		t.Errorf("aliases = %q, want %q", got, want)
+	}
+}
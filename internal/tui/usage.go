@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/bubbles/v2/key"
+)
+
+// mostUsedCount is how many actions the help modal's Most Used section
+// lists.
+const mostUsedCount = 5
+
+// usageSkipped lists footer keys that move around rather than act; they
+// aren't counted as uses.
+var usageSkipped = map[string]bool{
+	"j/k": true, "h/l": true, "g/G": true, "enter": true, "space": true,
+	"esc": true, "tab": true, "q": true,
+}
+
+// recordUsage counts the action msg runs, if any: a global action or one
+// listed in the focused panel's footer. Panel actions are named after the
+// panel, e.g. "Deploy: deploy" or "Tree: reboot". The counts are part of
+// the session state and never leave this machine.
+func (m App) recordUsage(msg tea.KeyPressMsg) {
+	g := m.globalKeys
+	for _, b := range []key.Binding{g.Refresh, g.SSH, g.SFTP, g.Database, g.Settings, g.About, g.Env} {
+		if key.Matches(msg, b) {
+			m.state.RecordUse(b.Help().Desc, b.Help().Key)
+			return
+		}
+	}
+
+	pressed := msg.String()
+	for _, b := range m.panelBindings() {
+		if usageSkipped[b.Key] || !slices.Contains(strings.Split(b.Key, "/"), pressed) {
+			continue
+		}
+		m.state.RecordUse(m.usagePrefix()+b.Desc, pressed)
+		return
+	}
+}
+
+// usagePrefix names the focused panel for recordUsage.
+func (m App) usagePrefix() string {
+	switch m.nav.Focus() {
+	case FocusTree:
+		return "Tree: "
+	case FocusOutput:
+		return "Output: "
+	}
+	return m.detail.tabName(m.selectedSite != nil) + ": "
+}
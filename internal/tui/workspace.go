@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/hinkers/Phorge/internal/config"
+	"github.com/hinkers/Phorge/internal/forge"
+	"github.com/hinkers/Phorge/internal/tui/components"
+	"github.com/hinkers/Phorge/internal/tui/panels"
+)
+
+// workspaceScope returns the name and tree predicate for the workspace
+// called name in cfg, or an empty name and nil when it isn't declared.
+func workspaceScope(cfg *config.Config, name string) (string, func(forge.Server, *forge.Site) bool) {
+	targets, ok := cfg.Workspace(name)
+	if name == "" || !ok {
+		return "", nil
+	}
+	return name, func(srv forge.Server, site *forge.Site) bool {
+		siteName := ""
+		if site != nil {
+			siteName = site.Name
+		}
+		return config.Includes(targets, srv.Name, siteName)
+	}
+}
+
+// pickWorkspace asks which workspace to narrow the tree to, or leaves the
+// selected one.
+func (m App) pickWorkspace() (tea.Model, tea.Cmd) {
+	if ws := m.treePanel.Workspace(); ws != "" {
+		m, cmd := m.selectWorkspace("")
+		m.toast = fmt.Sprintf("Left workspace %q", ws)
+		m.toastIsErr = false
+		return m, tea.Batch(cmd, m.clearToastAfter(3*time.Second))
+	}
+
+	names := m.config.WorkspaceNames()
+	if len(names) == 0 {
+		m.toast = "No workspaces configured (add [workspaces] to config.toml)"
+		m.toastIsErr = true
+		return m, m.clearToastAfter(3 * time.Second)
+	}
+	options := make([]components.PickerOption, 0, len(names))
+	for _, name := range names {
+		targets, _ := m.config.Workspace(name)
+		entries := make([]string, len(targets))
+		for i, t := range targets {
+			entries[i] = t.Server
+			if t.Site != "" {
+				entries[i] += "/" + t.Site
+			}
+		}
+		options = append(options, components.PickerOption{Label: name, Detail: strings.Join(entries, ", ")})
+	}
+	m.dialogs = m.dialogs.Pick(components.NewPicker("workspace", "Workspace:", options))
+	return m, nil
+}
+
+// selectWorkspace narrows the tree to the named workspace, expanding its
+// servers so the sites in it show, or shows everything when name is
+// empty.
+func (m App) selectWorkspace(name string) (App, tea.Cmd) {
+	m.treePanel = m.treePanel.SetWorkspace(workspaceScope(m.config, name))
+	var cmd tea.Cmd
+	if name != "" {
+		m.treePanel, cmd = m.treePanel.ExpandAll()
+	}
+
+	// The cursor may have moved to a different node.
+	sel, site := m.treePanel.Selected()
+	return m, tea.Batch(cmd, func() tea.Msg {
+		return panels.TreeNodeSelectedMsg{Server: sel, Site: site}
+	})
+}
@@ -0,0 +1,324 @@
+// Package update implements self-updating of the phorge binary from the
+// project's GitHub releases.
+//
+// Release archives are produced by goreleaser (see .goreleaser.yml) and are
+// named phorge_<version>_<os>_<arch>.tar.gz (.zip on Windows), alongside a
+// phorge_<version>_checksums.txt file listing SHA-256 sums for each archive.
+package update
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultBaseURL = "https://api.github.com"
+	repository     = "hinkers/Phorge"
+	binaryName     = "phorge"
+)
+
+// ErrNoAsset is returned when a release has no archive for the running platform.
+var ErrNoAsset = errors.New("no release archive for this platform")
+
+// Release is a GitHub release as returned by the releases API.
+type Release struct {
+	TagName string  `json:"tag_name"`
+	HTMLURL string  `json:"html_url"`
+	Assets  []Asset `json:"assets"`
+}
+
+// Asset is a downloadable file attached to a release.
+type Asset struct {
+	Name string `json:"name"`
+	URL  string `json:"browser_download_url"`
+}
+
+// Version returns the release tag without its leading "v".
+func (r *Release) Version() string {
+	return strings.TrimPrefix(r.TagName, "v")
+}
+
+// asset returns the asset with the given name, or nil if not found.
+func (r *Release) asset(name string) *Asset {
+	for i := range r.Assets {
+		if r.Assets[i].Name == name {
+			return &r.Assets[i]
+		}
+	}
+	return nil
+}
+
+// Updater checks for and installs new phorge releases.
+type Updater struct {
+	BaseURL string
+	GOOS    string
+	GOARCH  string
+	http    *http.Client
+}
+
+// NewUpdater creates an Updater for the running platform.
+func NewUpdater() *Updater {
+	return &Updater{
+		BaseURL: defaultBaseURL,
+		GOOS:    runtime.GOOS,
+		GOARCH:  runtime.GOARCH,
+		http:    &http.Client{},
+	}
+}
+
+// Latest returns the most recent published release.
+func (u *Updater) Latest(ctx context.Context) (*Release, error) {
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", u.BaseURL, repository)
+	data, err := u.get(ctx, url, "application/vnd.github+json")
+	if err != nil {
+		return nil, err
+	}
+
+	var rel Release
+	if err := json.Unmarshal(data, &rel); err != nil {
+		return nil, fmt.Errorf("decoding release: %w", err)
+	}
+	return &rel, nil
+}
+
+// Apply downloads the archive for the running platform from rel, verifies
+// it against the release checksums and atomically replaces the executable
+// at exePath with the binary it contains.
+func (u *Updater) Apply(ctx context.Context, rel *Release, exePath string) error {
+	archiveName := ArchiveName(rel.Version(), u.GOOS, u.GOARCH)
+	archive := rel.asset(archiveName)
+	if archive == nil {
+		return fmt.Errorf("%w: %s", ErrNoAsset, archiveName)
+	}
+	checksums := rel.asset(ChecksumsName(rel.Version()))
+	if checksums == nil {
+		return fmt.Errorf("release %s has no checksums file", rel.TagName)
+	}
+
+	sums, err := u.get(ctx, checksums.URL, "application/octet-stream")
+	if err != nil {
+		return fmt.Errorf("downloading checksums: %w", err)
+	}
+	want, err := findChecksum(sums, archiveName)
+	if err != nil {
+		return err
+	}
+
+	data, err := u.get(ctx, archive.URL, "application/octet-stream")
+	if err != nil {
+		return fmt.Errorf("downloading %s: %w", archiveName, err)
+	}
+	sum := sha256.Sum256(data)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("checksum mismatch for %s: got %s, want %s", archiveName, got, want)
+	}
+
+	exeName := binaryName
+	if u.GOOS == "windows" {
+		exeName += ".exe"
+	}
+
+	var bin []byte
+	if strings.HasSuffix(archiveName, ".zip") {
+		bin, err = extractZip(data, exeName)
+	} else {
+		bin, err = extractTarGz(data, exeName)
+	}
+	if err != nil {
+		return err
+	}
+
+	return replaceExecutable(exePath, bin, u.GOOS)
+}
+
+// get performs a GET request and returns the response body.
+func (u *Updater) get(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+
+	resp, err := u.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status %s from %s", resp.Status, url)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	return data, nil
+}
+
+// ArchiveName returns the goreleaser archive name for a version and platform.
+func ArchiveName(version, goos, goarch string) string {
+	ext := "tar.gz"
+	if goos == "windows" {
+		ext = "zip"
+	}
+	return fmt.Sprintf("%s_%s_%s_%s.%s", binaryName, version, goos, goarch, ext)
+}
+
+// ChecksumsName returns the goreleaser checksums file name for a version.
+func ChecksumsName(version string) string {
+	return fmt.Sprintf("%s_%s_checksums.txt", binaryName, version)
+}
+
+// IsNewer reports whether latest is a higher semantic version than current.
+// Versions may carry a leading "v". A current version that cannot be parsed
+// (e.g. "dev") is always considered older.
+func IsNewer(current, latest string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return true
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+// parseVersion parses "v1.2.3" (pre-release suffixes are ignored) into
+// its numeric components.
+func parseVersion(s string) ([3]int, bool) {
+	var v [3]int
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		s = s[:i]
+	}
+	parts := strings.Split(s, ".")
+	if len(parts) == 0 || len(parts) > 3 {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+// findChecksum returns the hex SHA-256 for name from a checksums.txt body
+// in the "<sum>  <file>" format written by goreleaser.
+func findChecksum(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[1] == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum listed for %s", name)
+}
+
+// extractTarGz returns the contents of the file called name from a .tar.gz archive.
+func extractTarGz(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+		if hdr.Typeflag == tar.TypeReg && path.Base(hdr.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+	return nil, fmt.Errorf("%s not found in archive", name)
+}
+
+// extractZip returns the contents of the file called name from a .zip archive.
+func extractZip(data []byte, name string) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("opening archive: %w", err)
+	}
+	for _, f := range zr.File {
+		if path.Base(f.Name) != name {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("reading archive: %w", err)
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("%s not found in archive", name)
+}
+
+// replaceExecutable writes bin next to exePath and renames it into place so
+// the swap is atomic. Windows cannot overwrite a running executable, so the
+// old binary is moved aside to <exe>.old first.
+func replaceExecutable(exePath string, bin []byte, goos string) error {
+	dir := filepath.Dir(exePath)
+	tmp, err := os.CreateTemp(dir, ".phorge-update-*")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once renamed
+
+	if _, err := tmp.Write(bin); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0o755); err != nil {
+		return err
+	}
+
+	if goos == "windows" {
+		old := exePath + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(exePath, old); err != nil {
+			return fmt.Errorf("moving old binary aside: %w", err)
+		}
+	}
+
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		return fmt.Errorf("replacing binary: %w", err)
+	}
+	return nil
+}
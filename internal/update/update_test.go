@@ -0,0 +1,177 @@
+package update
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildTarGz returns a .tar.gz archive containing a single file.
+func buildTarGz(t *testing.T, name string, content []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+		t.Fatalf("WriteHeader: %v", err)
+	}
+	if _, err := tw.Write(content); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("tar Close: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("gzip Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// newReleaseServer serves a latest-release response plus its assets.
+func newReleaseServer(t *testing.T, archive []byte, checksum string) *httptest.Server {
+	t.Helper()
+	archiveName := ArchiveName("1.2.0", "linux", "amd64")
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/hinkers/Phorge/releases/latest":
+			fmt.Fprintf(w, `{
+				"tag_name": "v1.2.0",
+				"assets": [
+					{"name": %q, "browser_download_url": "%s/dl/archive"},
+					{"name": %q, "browser_download_url": "%s/dl/checksums"}
+				]
+			}`, archiveName, srv.URL, ChecksumsName("1.2.0"), srv.URL)
+		case "/dl/archive":
+			_, _ = w.Write(archive)
+		case "/dl/checksums":
+			fmt.Fprintf(w, "%s  %s\n", checksum, archiveName)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return srv
+}
+
+func newTestUpdater(srv *httptest.Server) *Updater {
+	u := NewUpdater()
+	u.BaseURL = srv.URL
+	u.GOOS = "linux"
+	u.GOARCH = "amd64"
+	return u
+}
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"1.0.0", "v1.0.1", true},
+		{"v1.2.0", "v1.10.0", true},
+		{"1.2.0", "1.2.0", false},
+		{"2.0.0", "1.9.9", false},
+		{"1.2.0-rc.1", "1.2.0", false},
+		{"dev", "0.1.0", true},
+		{"1.0.0", "garbage", false},
+	}
+	for _, tt := range tests {
+		if got := IsNewer(tt.current, tt.latest); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestArchiveName(t *testing.T) {
+	if got := ArchiveName("1.2.0", "darwin", "arm64"); got != "phorge_1.2.0_darwin_arm64.tar.gz" {
+		t.Errorf("ArchiveName = %q", got)
+	}
+	if got := ArchiveName("1.2.0", "windows", "amd64"); got != "phorge_1.2.0_windows_amd64.zip" {
+		t.Errorf("ArchiveName = %q", got)
+	}
+}
+
+func TestLatest(t *testing.T) {
+	srv := newReleaseServer(t, nil, "")
+	defer srv.Close()
+
+	rel, err := newTestUpdater(srv).Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest: %v", err)
+	}
+	if rel.TagName != "v1.2.0" {
+		t.Errorf("TagName = %q, want %q", rel.TagName, "v1.2.0")
+	}
+	if rel.Version() != "1.2.0" {
+		t.Errorf("Version() = %q, want %q", rel.Version(), "1.2.0")
+	}
+	if len(rel.Assets) != 2 {
+		t.Errorf("got %d assets, want 2", len(rel.Assets))
+	}
+}
+
+func TestApply(t *testing.T) {
+	newBinary := []byte("new phorge binary")
+	archive := buildTarGz(t, "phorge", newBinary)
+	sum := sha256.Sum256(archive)
+
+	srv := newReleaseServer(t, archive, hex.EncodeToString(sum[:]))
+	defer srv.Close()
+
+	exe := filepath.Join(t.TempDir(), "phorge")
+	if err := os.WriteFile(exe, []byte("old phorge binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	u := newTestUpdater(srv)
+	rel, err := u.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest: %v", err)
+	}
+	if err := u.Apply(context.Background(), rel, exe); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	got, err := os.ReadFile(exe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, newBinary) {
+		t.Errorf("binary = %q, want %q", got, newBinary)
+	}
+}
+
+func TestApplyChecksumMismatch(t *testing.T) {
+	archive := buildTarGz(t, "phorge", []byte("tampered"))
+	srv := newReleaseServer(t, archive, strings.Repeat("0", 64))
+	defer srv.Close()
+
+	exe := filepath.Join(t.TempDir(), "phorge")
+	if err := os.WriteFile(exe, []byte("old phorge binary"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	u := newTestUpdater(srv)
+	rel, err := u.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest: %v", err)
+	}
+	err = u.Apply(context.Background(), rel, exe)
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("Apply error = %v, want checksum mismatch", err)
+	}
+
+	got, _ := os.ReadFile(exe)
+	if string(got) != "old phorge binary" {
+		t.Errorf("binary was modified after failed verification: %q", got)
+	}
+}